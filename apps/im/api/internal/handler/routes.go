@@ -29,6 +29,36 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 				Path:    "/chatlog",
 				Handler: getChatLogHandler(serverCtx),
 			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/thread/replies",
+				Handler: getThreadRepliesHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/msg/pin",
+				Handler: pinMsgHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/msg/pins",
+				Handler: getPinnedMsgsHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/msg/star",
+				Handler: starMsgHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/msg/stars",
+				Handler: getStarredMsgsHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/msg/search",
+				Handler: searchMsgsHandler(serverCtx),
+			},
 			{
 				Method:  http.MethodPost,
 				Path:    "/setup/conversation",
@@ -39,11 +69,41 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 				Path:    "/conversation",
 				Handler: getConversationsHandler(serverCtx),
 			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/conversation/list",
+				Handler: listConversationsHandler(serverCtx),
+			},
 			{
 				Method:  http.MethodPut,
 				Path:    "/conversation",
 				Handler: putConversationsHandler(serverCtx),
 			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/conversation/pin",
+				Handler: pinConversationHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/conversation/mute",
+				Handler: muteConversationHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/conversation/archive",
+				Handler: archiveConversationHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/conversation/delete",
+				Handler: deleteConversationHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/conversation/clear",
+				Handler: clearConversationHistoryHandler(serverCtx),
+			},
 		},
 		rest.WithJwt(serverCtx.Config.JwtAuth.AccessSecret),
 		rest.WithPrefix("/v1/im"),
@@ -0,0 +1,39 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/rpc/imclient"
+	"easy-chat/pkg/ctxdata"
+
+	"easy-chat/apps/im/api/internal/svc"
+	"easy-chat/apps/im/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ArchiveConversationLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewArchiveConversationLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ArchiveConversationLogic {
+	return &ArchiveConversationLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// ArchiveConversation 归档或取消归档会话
+func (l *ArchiveConversationLogic) ArchiveConversation(req *types.ArchiveConversationReq) (resp *types.ArchiveConversationResp, err error) {
+	_, err = l.svcCtx.ArchiveConversation(l.ctx, &imclient.ArchiveConversationReq{
+		UserId:         ctxdata.GetUid(l.ctx),
+		ConversationId: req.ConversationId,
+		Unarchive:      req.Unarchive,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.ArchiveConversationResp{}, nil
+}
@@ -0,0 +1,38 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/rpc/imclient"
+	"easy-chat/pkg/ctxdata"
+
+	"easy-chat/apps/im/api/internal/svc"
+	"easy-chat/apps/im/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ClearConversationHistoryLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewClearConversationHistoryLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ClearConversationHistoryLogic {
+	return &ClearConversationHistoryLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// ClearConversationHistory 清空用户在会话中的聊天记录
+func (l *ClearConversationHistoryLogic) ClearConversationHistory(req *types.ClearConversationHistoryReq) (resp *types.ClearConversationHistoryResp, err error) {
+	_, err = l.svcCtx.ClearConversationHistory(l.ctx, &imclient.ClearConversationHistoryReq{
+		UserId:         ctxdata.GetUid(l.ctx),
+		ConversationId: req.ConversationId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.ClearConversationHistoryResp{}, nil
+}
@@ -0,0 +1,39 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/rpc/imclient"
+	"easy-chat/pkg/ctxdata"
+
+	"easy-chat/apps/im/api/internal/svc"
+	"easy-chat/apps/im/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DeleteConversationLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewDeleteConversationLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeleteConversationLogic {
+	return &DeleteConversationLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// DeleteConversation 从会话列表中删除会话，可以同时清空聊天记录
+func (l *DeleteConversationLogic) DeleteConversation(req *types.DeleteConversationReq) (resp *types.DeleteConversationResp, err error) {
+	_, err = l.svcCtx.DeleteConversation(l.ctx, &imclient.DeleteConversationReq{
+		UserId:         ctxdata.GetUid(l.ctx),
+		ConversationId: req.ConversationId,
+		ClearHistory:   req.ClearHistory,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.DeleteConversationResp{}, nil
+}
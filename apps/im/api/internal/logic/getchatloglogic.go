@@ -3,6 +3,7 @@ package logic
 import (
 	"context"
 	"easy-chat/apps/im/rpc/imclient"
+	"easy-chat/pkg/ctxdata"
 	"github.com/jinzhu/copier"
 
 	"easy-chat/apps/im/api/internal/svc"
@@ -29,6 +30,7 @@ func NewGetChatLogLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetCha
 //
 // 该方法调用服务上下文中的 GetChatLog 方法来从数据源中获取聊天记录。
 // 根据请求中的参数，方法会查询特定会话的聊天记录，并将结果返回给调用方。
+// 不指定发送时间时按游标翻页，响应中的游标用于继续向前或向后翻页。
 //
 // 参数:
 //   - req: 请求对象，包含查询聊天记录所需的所有信息。
@@ -43,6 +45,10 @@ func (l *GetChatLogLogic) GetChatLog(req *types.ChatLogReq) (resp *types.ChatLog
 		StartSendTime:  req.StartSendTime,
 		EndSendTime:    req.EndSendTime,
 		Count:          req.Count,
+		UserId:         ctxdata.GetUid(l.ctx),
+		Cursor:         req.Cursor,
+		AnchorMsgId:    req.AnchorMsgId,
+		Direction:      req.Direction,
 	})
 	if err != nil {
 		// 如果获取聊天记录时发生错误，返回 nil 和错误信息
@@ -0,0 +1,41 @@
+package logic
+
+import (
+	"easy-chat/apps/im/api/internal/types"
+	"testing"
+)
+
+func TestGetChatLogLogic_GetChatLog(t *testing.T) {
+	svcCtx := newTestServiceContext()
+
+	tests := []struct {
+		name           string
+		uid            string
+		conversationId string
+		wantErr        bool
+	}{
+		{"single participant", "u2", "u1_u2", false},
+		{"single outsider", "u3", "u1_u2", true},
+		{"group member", "u3", "g1", false},
+		{"group outsider", "u2", "g1", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := NewGetChatLogLogic(withUid(tt.uid), svcCtx).GetChatLog(&types.ChatLogReq{
+				ConversationId: tt.conversationId,
+			})
+			if tt.wantErr {
+				if !isNoPermission(err) {
+					t.Fatalf("GetChatLog() err = %v, want permission error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetChatLog() err = %v", err)
+			}
+			if len(resp.List) != 1 {
+				t.Errorf("GetChatLog() got %d chat logs, want 1", len(resp.List))
+			}
+		})
+	}
+}
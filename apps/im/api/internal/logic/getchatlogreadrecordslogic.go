@@ -2,11 +2,14 @@ package logic
 
 import (
 	"context"
+	"easy-chat/apps/im/authz"
 	"easy-chat/apps/im/rpc/im"
 	"easy-chat/apps/social/rpc/socialclient"
 	"easy-chat/apps/user/rpc/user"
-	"easy-chat/pkg/bitmap"
 	"easy-chat/pkg/constants"
+	"easy-chat/pkg/ctxdata"
+	"github.com/pkg/errors"
+	"slices"
 
 	"easy-chat/apps/im/api/internal/svc"
 	"easy-chat/apps/im/api/internal/types"
@@ -29,8 +32,10 @@ func NewGetChatLogReadRecordsLogic(ctx context.Context, svcCtx *svc.ServiceConte
 }
 
 func (l *GetChatLogReadRecordsLogic) GetChatLogReadRecords(req *types.GetChatLogReadRecordsReq) (resp *types.GetChatLogReadRecordsResp, err error) {
+	uid := ctxdata.GetUid(l.ctx)
 	chatLogs, err := l.svcCtx.Im.GetChatLog(l.ctx, &im.GetChatLogReq{
-		MsgId: req.MsgId,
+		MsgId:  req.MsgId,
+		UserId: uid,
 	})
 	if err != nil || len(chatLogs.List) == 0 {
 		return nil, err
@@ -45,11 +50,6 @@ func (l *GetChatLogReadRecordsLogic) GetChatLogReadRecords(req *types.GetChatLog
 	// 分别设置已读未读
 	switch constants.ChatType(chatlog.ChatType) {
 	case constants.SingleChatType:
-		if len(chatlog.ReadRecords) == 0 || chatlog.ReadRecords[0] == 0 {
-			unreads = []string{chatlog.RecvId}
-		} else {
-			reads = append(reads, chatlog.RecvId)
-		}
 		ids = []string{chatlog.RecvId, chatlog.SendId}
 	case constants.GroupChatType:
 		groupUsers, err := l.svcCtx.Social.GroupUsers(l.ctx, &socialclient.GroupUsersReq{
@@ -58,18 +58,32 @@ func (l *GetChatLogReadRecordsLogic) GetChatLogReadRecords(req *types.GetChatLog
 		if err != nil {
 			return nil, err
 		}
-
-		aBitmap := bitmap.Load(chatlog.ReadRecords)
 		for _, member := range groupUsers.List {
 			ids = append(ids, member.UserId)
-			if member.UserId == chatlog.SendId {
-				continue
-			}
-			if aBitmap.IsSet(member.UserId) {
-				reads = append(reads, member.UserId)
-			} else {
-				unreads = append(unreads, member.UserId)
-			}
+		}
+	}
+
+	// 只有会话的成员可以查看已读记录
+	if !slices.Contains(ids, uid) {
+		return nil, errors.WithStack(authz.ErrNotMember)
+	}
+
+	// 用户的已读水位不小于消息序号即为已读
+	readSeqs, err := l.svcCtx.Im.GetReadSeqs(l.ctx, &im.GetReadSeqsReq{
+		ConversationId: chatlog.ConversationId,
+		UserIds:        ids,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		if id == chatlog.SendId {
+			continue
+		}
+		if readSeqs.ReadSeqs[id] >= chatlog.Seq {
+			reads = append(reads, id)
+		} else {
+			unreads = append(unreads, id)
 		}
 	}
 
@@ -0,0 +1,42 @@
+package logic
+
+import (
+	"easy-chat/apps/im/api/internal/types"
+	"testing"
+)
+
+func TestGetChatLogReadRecordsLogic_GetChatLogReadRecords(t *testing.T) {
+	svcCtx := newTestServiceContext()
+
+	tests := []struct {
+		name        string
+		uid         string
+		msgId       string
+		wantErr     bool
+		wantUnreads []string
+	}{
+		{"single participant", "u2", "m1", false, []string{"phone-u2"}},
+		{"single outsider", "u3", "m1", true, nil},
+		{"group member", "u3", "m2", false, []string{"phone-u3"}},
+		{"group outsider", "u2", "m2", true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := NewGetChatLogReadRecordsLogic(withUid(tt.uid), svcCtx).GetChatLogReadRecords(&types.GetChatLogReadRecordsReq{
+				MsgId: tt.msgId,
+			})
+			if tt.wantErr {
+				if !isNoPermission(err) {
+					t.Fatalf("GetChatLogReadRecords() err = %v, want permission error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetChatLogReadRecords() err = %v", err)
+			}
+			if len(resp.UnReads) != len(tt.wantUnreads) || resp.UnReads[0] != tt.wantUnreads[0] {
+				t.Errorf("GetChatLogReadRecords() unreads = %v, want %v", resp.UnReads, tt.wantUnreads)
+			}
+		})
+	}
+}
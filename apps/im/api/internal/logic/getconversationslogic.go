@@ -37,6 +37,12 @@ func (l *GetConversationsLogic) GetConversations(req *types.GetConversationsReq)
 
 	var res types.GetConversationsResp
 	copier.Copy(&res, &data)
+	// 未读数在 rpc 中名为 toRead
+	for id, conversation := range res.ConversationList {
+		if v := data.ConversationList[id]; v != nil {
+			conversation.Unread = v.ToRead
+		}
+	}
 
 	return &res, err
 }
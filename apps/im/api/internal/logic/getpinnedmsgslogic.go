@@ -0,0 +1,42 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/rpc/imclient"
+	"easy-chat/pkg/ctxdata"
+	"github.com/jinzhu/copier"
+
+	"easy-chat/apps/im/api/internal/svc"
+	"easy-chat/apps/im/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetPinnedMsgsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetPinnedMsgsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetPinnedMsgsLogic {
+	return &GetPinnedMsgsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// GetPinnedMsgs 获取会话中置顶的消息
+func (l *GetPinnedMsgsLogic) GetPinnedMsgs(req *types.GetPinnedMsgsReq) (resp *types.GetPinnedMsgsResp, err error) {
+	data, err := l.svcCtx.GetPinnedMsgs(l.ctx, &imclient.GetPinnedMsgsReq{
+		UserId:         ctxdata.GetUid(l.ctx),
+		ConversationId: req.ConversationId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var res types.GetPinnedMsgsResp
+	copier.Copy(&res, &data)
+	return &res, nil
+}
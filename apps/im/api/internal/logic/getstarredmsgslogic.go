@@ -0,0 +1,43 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/rpc/imclient"
+	"easy-chat/pkg/ctxdata"
+	"github.com/jinzhu/copier"
+
+	"easy-chat/apps/im/api/internal/svc"
+	"easy-chat/apps/im/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetStarredMsgsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetStarredMsgsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetStarredMsgsLogic {
+	return &GetStarredMsgsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// GetStarredMsgs 按收藏时间倒序分页获取收藏的消息
+func (l *GetStarredMsgsLogic) GetStarredMsgs(req *types.GetStarredMsgsReq) (resp *types.GetStarredMsgsResp, err error) {
+	data, err := l.svcCtx.GetStarredMsgs(l.ctx, &imclient.GetStarredMsgsReq{
+		UserId:     ctxdata.GetUid(l.ctx),
+		BeforeTime: req.BeforeTime,
+		Count:      req.Count,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var res types.GetStarredMsgsResp
+	copier.Copy(&res, &data)
+	return &res, nil
+}
@@ -0,0 +1,44 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/rpc/imclient"
+	"easy-chat/pkg/ctxdata"
+	"github.com/jinzhu/copier"
+
+	"easy-chat/apps/im/api/internal/svc"
+	"easy-chat/apps/im/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetThreadRepliesLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetThreadRepliesLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetThreadRepliesLogic {
+	return &GetThreadRepliesLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// GetThreadReplies 分页获取话题中的回复，同时返回话题的第一条消息
+func (l *GetThreadRepliesLogic) GetThreadReplies(req *types.ThreadRepliesReq) (resp *types.ThreadRepliesResp, err error) {
+	data, err := l.svcCtx.GetThreadReplies(l.ctx, &imclient.GetThreadRepliesReq{
+		UserId:   ctxdata.GetUid(l.ctx),
+		ThreadId: req.ThreadId,
+		AfterSeq: req.AfterSeq,
+		Count:    req.Count,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var res types.ThreadRepliesResp
+	copier.Copy(&res, &data)
+	return &res, nil
+}
@@ -0,0 +1,114 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/rpc/imclient"
+	"easy-chat/apps/social/rpc/socialclient"
+	"easy-chat/apps/user/rpc/userclient"
+	"easy-chat/pkg/constants"
+	"easy-chat/pkg/ctxdata"
+	"github.com/jinzhu/copier"
+
+	"easy-chat/apps/im/api/internal/svc"
+	"easy-chat/apps/im/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListConversationsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListConversationsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListConversationsLogic {
+	return &ListConversationsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// ListConversations 分页获取会话列表
+//
+// 会话按最后一条消息的时间倒序排列，置顶的会话在前；私聊会话展示对方的昵称与头像，群聊会话展示群名称与群头像。
+func (l *ListConversationsLogic) ListConversations(req *types.ListConversationsReq) (resp *types.ListConversationsResp, err error) {
+	uid := ctxdata.GetUid(l.ctx)
+	data, err := l.svcCtx.ListConversations(l.ctx, &imclient.ListConversationsReq{
+		UserId:   uid,
+		Cursor:   req.Cursor,
+		Count:    req.Count,
+		Archived: req.Archived,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var res types.ListConversationsResp
+	copier.Copy(&res, &data)
+	if res.List == nil {
+		res.List = []*types.Conversation{}
+	}
+	// 未读数在 rpc 中名为 toRead
+	for i, conversation := range res.List {
+		conversation.Unread = data.List[i].ToRead
+	}
+
+	if err := l.fillProfiles(uid, res.List); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// fillProfiles 补充会话的名称与头像
+func (l *ListConversationsLogic) fillProfiles(uid string, conversations []*types.Conversation) error {
+	var (
+		uids      []string
+		hasGroups bool
+	)
+	for _, conversation := range conversations {
+		switch constants.ChatType(conversation.ChatType) {
+		case constants.SingleChatType:
+			if conversation.TargetId != "" {
+				uids = append(uids, conversation.TargetId)
+			}
+		case constants.GroupChatType:
+			hasGroups = true
+		}
+	}
+
+	users := make(map[string]*userclient.UserEntity, len(uids))
+	if len(uids) > 0 {
+		userResp, err := l.svcCtx.FindUser(l.ctx, &userclient.FindUserReq{Ids: uids})
+		if err != nil {
+			return err
+		}
+		for _, user := range userResp.User {
+			users[user.Id] = user
+		}
+	}
+	groups := make(map[string]*socialclient.Groups)
+	if hasGroups {
+		groupResp, err := l.svcCtx.GroupList(l.ctx, &socialclient.GroupListReq{UserId: uid})
+		if err != nil {
+			return err
+		}
+		for _, group := range groupResp.List {
+			groups[group.Id] = group
+		}
+	}
+
+	for _, conversation := range conversations {
+		switch constants.ChatType(conversation.ChatType) {
+		case constants.SingleChatType:
+			if user, ok := users[conversation.TargetId]; ok {
+				conversation.Name, conversation.Avatar = user.Nickname, user.Avatar
+			}
+		case constants.GroupChatType:
+			if group, ok := groups[conversation.TargetId]; ok {
+				conversation.Name, conversation.Avatar = group.Name, group.Icon
+			}
+		}
+	}
+	return nil
+}
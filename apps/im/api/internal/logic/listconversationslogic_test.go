@@ -0,0 +1,40 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/api/internal/types"
+	"easy-chat/apps/im/rpc/imclient"
+	"easy-chat/pkg/constants"
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+func (f *fakeIm) ListConversations(ctx context.Context, in *imclient.ListConversationsReq, opts ...grpc.CallOption) (*imclient.ListConversationsResp, error) {
+	return &imclient.ListConversationsResp{
+		List: []*imclient.Conversation{
+			{ConversationId: "g1", ChatType: int32(constants.GroupChatType), TargetId: "g1", ToRead: 3, Msg: fakeChatLogs["m2"]},
+			{ConversationId: "u1_u2", ChatType: int32(constants.SingleChatType), TargetId: "u2", Msg: fakeChatLogs["m1"]},
+		},
+		NextCursor: "next",
+		HasMore:    true,
+	}, nil
+}
+
+func TestListConversationsLogic_ListConversations(t *testing.T) {
+	resp, err := NewListConversationsLogic(withUid("u1"), newTestServiceContext()).ListConversations(&types.ListConversationsReq{})
+	if err != nil {
+		t.Fatalf("ListConversations() err = %v", err)
+	}
+	if len(resp.List) != 2 || resp.NextCursor != "next" || !resp.HasMore {
+		t.Fatalf("ListConversations() = %+v, want two conversations with next cursor", resp)
+	}
+
+	group, single := resp.List[0], resp.List[1]
+	if group.Name != "name-g1" || group.Unread != 3 || group.Msg == nil || group.Msg.Id != "m2" {
+		t.Errorf("ListConversations() group = %+v, want name-g1 with 3 unread and preview m2", group)
+	}
+	if single.Name != "nickname-u2" || single.Msg == nil || single.Msg.Id != "m1" {
+		t.Errorf("ListConversations() single = %+v, want nickname-u2 with preview m1", single)
+	}
+}
@@ -0,0 +1,106 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/api/internal/svc"
+	"easy-chat/apps/im/authz"
+	"easy-chat/apps/im/rpc/imclient"
+	"easy-chat/apps/social/rpc/socialclient"
+	"easy-chat/apps/user/rpc/userclient"
+	"easy-chat/pkg/constants"
+	"easy-chat/pkg/ctxdata"
+	"easy-chat/pkg/xerr"
+	"slices"
+
+	"github.com/pkg/errors"
+	zerr "github.com/zeromicro/x/errors"
+	"google.golang.org/grpc"
+)
+
+// fakeIm 模拟 im rpc：私聊消息 m1 在 u1 与 u2 之间，群消息 m2 在群 g1（成员 u1、u3）中
+type fakeIm struct {
+	imclient.Im
+	social *fakeSocial
+}
+
+var fakeChatLogs = map[string]*imclient.ChatLog{
+	"m1": {Id: "m1", ConversationId: "u1_u2", ChatType: int32(constants.SingleChatType), SendId: "u1", RecvId: "u2", Seq: 1},
+	"m2": {Id: "m2", ConversationId: "g1", ChatType: int32(constants.GroupChatType), SendId: "u1", RecvId: "g1", Seq: 1},
+}
+
+func (f *fakeIm) GetChatLog(ctx context.Context, in *imclient.GetChatLogReq, opts ...grpc.CallOption) (*imclient.GetChatLogResp, error) {
+	var list []*imclient.ChatLog
+	for _, chatLog := range fakeChatLogs {
+		if chatLog.Id == in.MsgId || chatLog.ConversationId == in.ConversationId {
+			list = append(list, chatLog)
+		}
+	}
+	// 与 im rpc 一致，以请求中的用户校验会话成员
+	for _, chatLog := range list {
+		ok := chatLog.SendId == in.UserId || chatLog.RecvId == in.UserId
+		if chatLog.ChatType == int32(constants.GroupChatType) {
+			ok = slices.Contains(f.social.groups[chatLog.RecvId], in.UserId)
+		}
+		if !ok {
+			return nil, errors.WithStack(authz.ErrNotMember)
+		}
+	}
+	return &imclient.GetChatLogResp{List: list}, nil
+}
+
+func (f *fakeIm) GetReadSeqs(ctx context.Context, in *imclient.GetReadSeqsReq, opts ...grpc.CallOption) (*imclient.GetReadSeqsResp, error) {
+	return &imclient.GetReadSeqsResp{ReadSeqs: map[string]int64{}}, nil
+}
+
+type fakeUser struct {
+	userclient.User
+}
+
+func (f *fakeUser) FindUser(ctx context.Context, in *userclient.FindUserReq, opts ...grpc.CallOption) (*userclient.FindUserResp, error) {
+	var users []*userclient.UserEntity
+	for _, id := range in.Ids {
+		users = append(users, &userclient.UserEntity{Id: id, Phone: "phone-" + id, Nickname: "nickname-" + id})
+	}
+	return &userclient.FindUserResp{User: users}, nil
+}
+
+type fakeSocial struct {
+	socialclient.Social
+	groups map[string][]string
+}
+
+func (f *fakeSocial) GroupUsers(ctx context.Context, in *socialclient.GroupUsersReq, opts ...grpc.CallOption) (*socialclient.GroupUsersResp, error) {
+	var list []*socialclient.GroupMembers
+	for _, uid := range f.groups[in.GroupId] {
+		list = append(list, &socialclient.GroupMembers{GroupId: in.GroupId, UserId: uid})
+	}
+	return &socialclient.GroupUsersResp{List: list}, nil
+}
+
+func (f *fakeSocial) GroupList(ctx context.Context, in *socialclient.GroupListReq, opts ...grpc.CallOption) (*socialclient.GroupListResp, error) {
+	var list []*socialclient.Groups
+	for id, uids := range f.groups {
+		if slices.Contains(uids, in.UserId) {
+			list = append(list, &socialclient.Groups{Id: id, Name: "name-" + id})
+		}
+	}
+	return &socialclient.GroupListResp{List: list}, nil
+}
+
+func newTestServiceContext() *svc.ServiceContext {
+	social := &fakeSocial{groups: map[string][]string{"g1": {"u1", "u3"}}}
+	return &svc.ServiceContext{
+		Im:     &fakeIm{social: social},
+		User:   &fakeUser{},
+		Social: social,
+	}
+}
+
+func withUid(uid string) context.Context {
+	return context.WithValue(context.Background(), ctxdata.Identify, uid)
+}
+
+func isNoPermission(err error) bool {
+	e, ok := errors.Cause(err).(*zerr.CodeMsg)
+	return ok && e.Code == xerr.NO_PERMISSION_ERROR
+}
@@ -0,0 +1,39 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/rpc/imclient"
+	"easy-chat/pkg/ctxdata"
+
+	"easy-chat/apps/im/api/internal/svc"
+	"easy-chat/apps/im/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type MuteConversationLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewMuteConversationLogic(ctx context.Context, svcCtx *svc.ServiceContext) *MuteConversationLogic {
+	return &MuteConversationLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// MuteConversation 设置会话免打扰，muteUntil 为 0 时取消，为 -1 时一直免打扰
+func (l *MuteConversationLogic) MuteConversation(req *types.MuteConversationReq) (resp *types.MuteConversationResp, err error) {
+	_, err = l.svcCtx.MuteConversation(l.ctx, &imclient.MuteConversationReq{
+		UserId:         ctxdata.GetUid(l.ctx),
+		ConversationId: req.ConversationId,
+		MuteUntil:      req.MuteUntil,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.MuteConversationResp{}, nil
+}
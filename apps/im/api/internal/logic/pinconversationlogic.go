@@ -0,0 +1,39 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/rpc/imclient"
+	"easy-chat/pkg/ctxdata"
+
+	"easy-chat/apps/im/api/internal/svc"
+	"easy-chat/apps/im/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type PinConversationLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewPinConversationLogic(ctx context.Context, svcCtx *svc.ServiceContext) *PinConversationLogic {
+	return &PinConversationLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// PinConversation 置顶或取消置顶会话
+func (l *PinConversationLogic) PinConversation(req *types.PinConversationReq) (resp *types.PinConversationResp, err error) {
+	_, err = l.svcCtx.PinConversation(l.ctx, &imclient.PinConversationReq{
+		UserId:         ctxdata.GetUid(l.ctx),
+		ConversationId: req.ConversationId,
+		Unpin:          req.Unpin,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.PinConversationResp{}, nil
+}
@@ -0,0 +1,39 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/rpc/imclient"
+	"easy-chat/pkg/ctxdata"
+
+	"easy-chat/apps/im/api/internal/svc"
+	"easy-chat/apps/im/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type PinMsgLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewPinMsgLogic(ctx context.Context, svcCtx *svc.ServiceContext) *PinMsgLogic {
+	return &PinMsgLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// PinMsg 置顶或取消置顶消息，群聊中只有群主与管理员可以置顶
+func (l *PinMsgLogic) PinMsg(req *types.PinMsgReq) (resp *types.PinMsgResp, err error) {
+	_, err = l.svcCtx.PinMsg(l.ctx, &imclient.PinMsgReq{
+		UserId: ctxdata.GetUid(l.ctx),
+		MsgId:  req.MsgId,
+		Unpin:  req.Unpin,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.PinMsgResp{}, nil
+}
@@ -0,0 +1,48 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/rpc/imclient"
+	"easy-chat/pkg/ctxdata"
+	"github.com/jinzhu/copier"
+
+	"easy-chat/apps/im/api/internal/svc"
+	"easy-chat/apps/im/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type SearchMsgsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewSearchMsgsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SearchMsgsLogic {
+	return &SearchMsgsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// SearchMsgs 在所在的会话中检索聊天记录，摘要中的关键词以 <em> 标记
+func (l *SearchMsgsLogic) SearchMsgs(req *types.SearchMsgsReq) (resp *types.SearchMsgsResp, err error) {
+	data, err := l.svcCtx.SearchMsgs(l.ctx, &imclient.SearchMsgsReq{
+		UserId:         ctxdata.GetUid(l.ctx),
+		Keyword:        req.Keyword,
+		ConversationId: req.ConversationId,
+		SendId:         req.SendId,
+		StartTime:      req.StartTime,
+		EndTime:        req.EndTime,
+		Offset:         req.Offset,
+		Count:          req.Count,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var res types.SearchMsgsResp
+	copier.Copy(&res, &data)
+	return &res, nil
+}
@@ -0,0 +1,39 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/rpc/imclient"
+	"easy-chat/pkg/ctxdata"
+
+	"easy-chat/apps/im/api/internal/svc"
+	"easy-chat/apps/im/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type StarMsgLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewStarMsgLogic(ctx context.Context, svcCtx *svc.ServiceContext) *StarMsgLogic {
+	return &StarMsgLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// StarMsg 收藏或取消收藏消息，收藏仅自己可见
+func (l *StarMsgLogic) StarMsg(req *types.StarMsgReq) (resp *types.StarMsgResp, err error) {
+	_, err = l.svcCtx.StarMsg(l.ctx, &imclient.StarMsgReq{
+		UserId: ctxdata.GetUid(l.ctx),
+		MsgId:  req.MsgId,
+		Unstar: req.Unstar,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.StarMsgResp{}, nil
+}
@@ -2,25 +2,124 @@
 package types
 
 type ChatLog struct {
-	Id             string `json:"id,omitempty"`
-	ConversationId string `json:"conversationId,omitempty"`
-	SendId         string `json:"sendId,omitempty"`
-	RecvId         string `json:"recvId,omitempty"`
-	MsgType        int32  `json:"msgType,omitempty"`
-	MsgContent     string `json:"msgContent,omitempty"`
-	ChatType       int32  `json:"chatType,omitempty"`
-	SendTime       int64  `json:"SendTime,omitempty"`
+	Id             string      `json:"id,omitempty"`
+	ConversationId string      `json:"conversationId,omitempty"`
+	SendId         string      `json:"sendId,omitempty"`
+	RecvId         string      `json:"recvId,omitempty"`
+	MsgType        int32       `json:"msgType,omitempty"`
+	MsgContent     string      `json:"msgContent,omitempty"`
+	ChatType       int32       `json:"chatType,omitempty"`
+	SendTime       int64       `json:"SendTime,omitempty"`
+	Seq            int64       `json:"seq,omitempty"`
+	Status         int32       `json:"status,omitempty"`
+	Version        int32       `json:"version,omitempty"`
+	Body           *MsgBody    `json:"body,omitempty"`
+	ReplyTo        *Quote      `json:"replyTo,omitempty"`
+	Mention        *Mention    `json:"mention,omitempty"`
+	Reactions      []*Reaction `json:"reactions,omitempty"`
+	ThreadId       string      `json:"threadId,omitempty"`
+	Thread         *Thread     `json:"thread,omitempty"`
+}
+
+type Image struct {
+	Url          string `json:"url"`
+	Thumbnail    string `json:"thumbnail,omitempty"`
+	Width        int32  `json:"width"`
+	Height       int32  `json:"height"`
+	AttachmentId string `json:"attachmentId,omitempty"`
+}
+
+type File struct {
+	Url          string `json:"url"`
+	Name         string `json:"name"`
+	Size         int64  `json:"size"`
+	Mime         string `json:"mime"`
+	AttachmentId string `json:"attachmentId,omitempty"`
+}
+
+type Voice struct {
+	Url          string `json:"url"`
+	Duration     int32  `json:"duration"`
+	AttachmentId string `json:"attachmentId,omitempty"`
+}
+
+type Location struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Name      string  `json:"name,omitempty"`
+	Address   string  `json:"address,omitempty"`
+}
+
+type Card struct {
+	CardType int32  `json:"cardType"`
+	TargetId string `json:"targetId"`
+	Name     string `json:"name,omitempty"`
+	Avatar   string `json:"avatar,omitempty"`
+}
+
+type MsgBody struct {
+	Image    *Image    `json:"image,omitempty"`
+	File     *File     `json:"file,omitempty"`
+	Voice    *Voice    `json:"voice,omitempty"`
+	Location *Location `json:"location,omitempty"`
+	Card     *Card     `json:"card,omitempty"`
+}
+
+type Mention struct {
+	UserIds []string `json:"userIds,omitempty"`
+	All     bool     `json:"all,omitempty"`
+}
+
+type Reaction struct {
+	Emoji   string `json:"emoji"`
+	Count   int64  `json:"count"`
+	Reacted bool   `json:"reacted,omitempty"`
+}
+
+type Thread struct {
+	ReplyCount    int64  `json:"replyCount"`
+	LastReply     *Quote `json:"lastReply,omitempty"`
+	LastReplyTime int64  `json:"lastReplyTime,omitempty"`
+}
+
+type Quote struct {
+	MsgId   string `json:"msgId"`
+	SendId  string `json:"sendId"`
+	MsgType int32  `json:"msgType"`
+	Snippet string `json:"snippet"`
+	Status  int32  `json:"status,omitempty"`
 }
 
 type Conversation struct {
-	ConversationId string `json:"conversationId,omitempty"`
-	ChatType       int32  `json:"ChatType,omitempty"`
-	TargetId       string `json:"targetId,omitempty"`
-	IsShow         bool   `json:"isShow,omitempty"`
-	Seq            int64  `json:"seq,omitempty"`
-	Read           int32  `json:"read,omitempty"`
-	Total          int32  `json:"total,omitempty"`
-	Unread         int32  `json:"unread,omitempty"`
+	ConversationId string   `json:"conversationId,omitempty"`
+	ChatType       int32    `json:"ChatType,omitempty"`
+	TargetId       string   `json:"targetId,omitempty"`
+	IsShow         bool     `json:"isShow,omitempty"`
+	Seq            int64    `json:"seq,omitempty"`
+	Read           int32    `json:"read,omitempty"`
+	Total          int32    `json:"total,omitempty"`
+	Unread         int32    `json:"unread,omitempty"`
+	ReadSeq        int64    `json:"readSeq,omitempty"`
+	MentionSeq     int64    `json:"mentionSeq,omitempty"`
+	Mentioned      bool     `json:"mentioned,omitempty"`
+	Pins           []*Pin   `json:"pins,omitempty"`
+	PinTime        int64    `json:"pinTime,omitempty"`
+	MuteUntil      int64    `json:"muteUntil,omitempty"`
+	Archived       bool     `json:"archived,omitempty"`
+	ClearSeq       int64    `json:"clearSeq,omitempty"`
+	LastMsgTime    int64    `json:"lastMsgTime,omitempty"`
+	Msg            *ChatLog `json:"msg,omitempty"`
+	Name           string   `json:"name,omitempty"`
+	Avatar         string   `json:"avatar,omitempty"`
+	Draft          string   `json:"draft,omitempty"`
+	DraftTime      int64    `json:"draftTime,omitempty"`
+}
+
+type Pin struct {
+	MsgId    string `json:"msgId"`
+	Seq      int64  `json:"seq"`
+	PinnedBy string `json:"pinnedBy"`
+	PinTime  int64  `json:"pinTime"`
 }
 
 type GetChatLogReadRecordsReq struct {
@@ -37,18 +136,113 @@ type ChatLogReq struct {
 	StartSendTime  int64  `json:"startSendTime,omitempty"`
 	EndSendTime    int64  `json:"endSendTime,omitempty"`
 	Count          int64  `json:"count,omitempty"`
+	Cursor         string `json:"cursor,omitempty"`
+	AnchorMsgId    string `json:"anchorMsgId,omitempty"`
+	Direction      int32  `json:"direction,omitempty"`
 }
 
 type ChatLogResp struct {
+	List        []*ChatLog `json:"list"`
+	HasMore     bool       `json:"hasMore,omitempty"`
+	OlderCursor string     `json:"olderCursor,omitempty"`
+	NewerCursor string     `json:"newerCursor,omitempty"`
+}
+
+type ThreadRepliesReq struct {
+	ThreadId string `json:"threadId"`
+	AfterSeq int64  `json:"afterSeq,omitempty"`
+	Count    int64  `json:"count,omitempty"`
+}
+
+type ThreadRepliesResp struct {
+	Root *ChatLog   `json:"root"`
 	List []*ChatLog `json:"list"`
 }
 
+type PinMsgReq struct {
+	MsgId string `json:"msgId"`
+	Unpin bool   `json:"unpin,omitempty"`
+}
+
+type PinMsgResp struct {
+}
+
+type GetPinnedMsgsReq struct {
+	ConversationId string `json:"conversationId"`
+}
+
+type PinnedMsg struct {
+	Msg      *ChatLog `json:"msg"`
+	PinnedBy string   `json:"pinnedBy"`
+	PinTime  int64    `json:"pinTime"`
+}
+
+type GetPinnedMsgsResp struct {
+	List []*PinnedMsg `json:"list"`
+}
+
+type StarMsgReq struct {
+	MsgId  string `json:"msgId"`
+	Unstar bool   `json:"unstar,omitempty"`
+}
+
+type StarMsgResp struct {
+}
+
+type GetStarredMsgsReq struct {
+	BeforeTime int64 `json:"beforeTime,omitempty"`
+	Count      int64 `json:"count,omitempty"`
+}
+
+type StarredMsg struct {
+	Msg      *ChatLog `json:"msg"`
+	StarTime int64    `json:"starTime"`
+}
+
+type GetStarredMsgsResp struct {
+	List []*StarredMsg `json:"list"`
+}
+
+type SearchMsgsReq struct {
+	Keyword        string `json:"keyword"`
+	ConversationId string `json:"conversationId,omitempty"`
+	SendId         string `json:"sendId,omitempty"`
+	StartTime      int64  `json:"startTime,omitempty"`
+	EndTime        int64  `json:"endTime,omitempty"`
+	Offset         int64  `json:"offset,omitempty"`
+	Count          int64  `json:"count,omitempty"`
+}
+
+type SearchHit struct {
+	Msg     *ChatLog `json:"msg"`
+	Snippet string   `json:"snippet"`
+}
+
+type SearchMsgsResp struct {
+	List  []*SearchHit `json:"list"`
+	Total int64        `json:"total"`
+}
+
 type GetConversationsReq struct {
 }
 
 type GetConversationsResp struct {
 	UserId           string                   `json:"userId"`
 	ConversationList map[string]*Conversation `json:"conversationList"`
+	ConversationIds  []string                 `json:"conversationIds"`
+	UnreadTotal      int64                    `json:"unreadTotal"`
+}
+
+type ListConversationsReq struct {
+	Cursor   string `json:"cursor,omitempty"`
+	Count    int64  `json:"count,omitempty"`
+	Archived bool   `json:"archived,omitempty"`
+}
+
+type ListConversationsResp struct {
+	List       []*Conversation `json:"list"`
+	NextCursor string          `json:"nextCursor,omitempty"`
+	HasMore    bool            `json:"hasMore,omitempty"`
 }
 
 type PutConversationsReq struct {
@@ -58,6 +252,45 @@ type PutConversationsReq struct {
 type PutConversationsResp struct {
 }
 
+type PinConversationReq struct {
+	ConversationId string `json:"conversationId"`
+	Unpin          bool   `json:"unpin,omitempty"`
+}
+
+type PinConversationResp struct {
+}
+
+type MuteConversationReq struct {
+	ConversationId string `json:"conversationId"`
+	MuteUntil      int64  `json:"muteUntil"`
+}
+
+type MuteConversationResp struct {
+}
+
+type ArchiveConversationReq struct {
+	ConversationId string `json:"conversationId"`
+	Unarchive      bool   `json:"unarchive,omitempty"`
+}
+
+type ArchiveConversationResp struct {
+}
+
+type DeleteConversationReq struct {
+	ConversationId string `json:"conversationId"`
+	ClearHistory   bool   `json:"clearHistory,omitempty"`
+}
+
+type DeleteConversationResp struct {
+}
+
+type ClearConversationHistoryReq struct {
+	ConversationId string `json:"conversationId"`
+}
+
+type ClearConversationHistoryResp struct {
+}
+
 type SetUpUserConversationReq struct {
 	SendId   string `json:"sendId,omitempty"`
 	RecvId   string `json:"recvId,omitempty"`
@@ -0,0 +1,49 @@
+package authz
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/media/rpc/mediaclient"
+	"easy-chat/pkg/xerr"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrAttachmentNotFound = xerr.New(xerr.REQUEST_PARAM_ERROR, "附件不存在或还未上传")
+	ErrAttachmentOwner    = xerr.New(xerr.NO_PERMISSION_ERROR, "只能发送自己上传的附件")
+)
+
+// AttachmentClient 查询附件，mediaclient.Media 实现了该接口
+type AttachmentClient interface {
+	GetAttachment(ctx context.Context, in *mediaclient.GetAttachmentReq, opts ...grpc.CallOption) (*mediaclient.GetAttachmentResp, error)
+}
+
+// CheckAttachment 消息引用的附件需已上传且由发送者上传，没有引用附件时不校验
+func CheckAttachment(ctx context.Context, media AttachmentClient, uid string, body *immodels.MsgBody) error {
+	id := body.AttachmentId()
+	if id == "" {
+		return nil
+	}
+
+	resp, err := media.GetAttachment(ctx, &mediaclient.GetAttachmentReq{
+		AttachmentId: id,
+		UserId:       uid,
+	})
+	if err != nil {
+		// media rpc 以错误码作为 grpc 的状态码返回业务错误
+		switch status.Code(err) {
+		case codes.Code(xerr.REQUEST_PARAM_ERROR):
+			return errors.WithStack(ErrAttachmentNotFound)
+		case codes.Code(xerr.NO_PERMISSION_ERROR):
+			return errors.WithStack(ErrAttachmentOwner)
+		}
+		return errors.Wrapf(xerr.NewInternalErr(), "media.GetAttachment err %v, id %v", err, id)
+	}
+	if resp.Attachment.GetUserId() != uid {
+		return errors.WithStack(ErrAttachmentOwner)
+	}
+	return nil
+}
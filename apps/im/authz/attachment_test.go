@@ -0,0 +1,55 @@
+package authz
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/media/rpc/mediaclient"
+	"easy-chat/pkg/xerr"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeMedia 附件 a1 由 u1 上传，u2 可以访问；其他附件不存在
+type fakeMedia struct{}
+
+func (f *fakeMedia) GetAttachment(ctx context.Context, in *mediaclient.GetAttachmentReq, opts ...grpc.CallOption) (*mediaclient.GetAttachmentResp, error) {
+	if in.AttachmentId != "a1" {
+		return nil, status.Error(codes.Code(xerr.REQUEST_PARAM_ERROR), "附件不存在")
+	}
+	switch in.UserId {
+	case "u1", "u2":
+		return &mediaclient.GetAttachmentResp{Attachment: &mediaclient.Attachment{Id: "a1", UserId: "u1"}}, nil
+	}
+	return nil, status.Error(codes.Code(xerr.NO_PERMISSION_ERROR), "没有权限操作该附件")
+}
+
+func TestCheckAttachment(t *testing.T) {
+	image := func(id string) *immodels.MsgBody {
+		return &immodels.MsgBody{Image: &immodels.Image{AttachmentId: id, Width: 1, Height: 1}}
+	}
+	tests := []struct {
+		name    string
+		uid     string
+		body    *immodels.MsgBody
+		wantErr error
+	}{
+		{name: "text", uid: "u3"},
+		{name: "url only", uid: "u3", body: &immodels.MsgBody{File: &immodels.File{Url: "http://a"}}},
+		{name: "uploader", uid: "u1", body: image("a1")},
+		{name: "forwarded", uid: "u2", body: image("a1"), wantErr: ErrAttachmentOwner},
+		{name: "stranger", uid: "u3", body: image("a1"), wantErr: ErrAttachmentOwner},
+		{name: "not found", uid: "u1", body: image("a2"), wantErr: ErrAttachmentNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckAttachment(context.Background(), &fakeMedia{}, tt.uid, tt.body)
+			if pkgerrors.Cause(err) != tt.wantErr {
+				t.Errorf("CheckAttachment() err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package authz
+
+import (
+	"context"
+	"easy-chat/apps/social/rpc/socialclient"
+	"easy-chat/pkg/constants"
+	"easy-chat/pkg/wuid"
+	"easy-chat/pkg/xerr"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+var (
+	ErrNotMember = xerr.New(xerr.NO_PERMISSION_ERROR, "不是会话成员，没有权限访问")
+	ErrNotFriend = xerr.New(xerr.NO_PERMISSION_ERROR, "对方不是好友，不能发送消息")
+)
+
+// GroupUsersClient 查询群成员，socialclient.Social 实现了该接口
+type GroupUsersClient interface {
+	GroupUsers(ctx context.Context, in *socialclient.GroupUsersReq, opts ...grpc.CallOption) (*socialclient.GroupUsersResp, error)
+}
+
+// SocialClient 查询群成员与好友，socialclient.Social 实现了该接口
+type SocialClient interface {
+	GroupUsersClient
+	FriendList(ctx context.Context, in *socialclient.FriendListReq, opts ...grpc.CallOption) (*socialclient.FriendListResp, error)
+}
+
+// Authorizer 校验用户是否为会话的成员
+//
+// 私聊的成员是会话的双方，群聊的成员由 social rpc 查询。im api 与 im rpc 共用，
+// 需要鉴权的接口以 JWT 中的用户进行校验。
+type Authorizer struct {
+	social SocialClient
+}
+
+func NewAuthorizer(social SocialClient) *Authorizer {
+	return &Authorizer{social: social}
+}
+
+// GroupRole 查询用户在群中的角色，不是群成员时返回 false
+func (a *Authorizer) GroupRole(ctx context.Context, groupId, uid string) (constants.GroupRoleLevel, bool, error) {
+	users, err := a.social.GroupUsers(ctx, &socialclient.GroupUsersReq{
+		GroupId: groupId,
+	})
+	if err != nil {
+		return 0, false, errors.Wrapf(xerr.NewInternalErr(), "social.GroupUsers err %v, groupId %v", err, groupId)
+	}
+	for _, member := range users.List {
+		if member.UserId == uid {
+			return constants.GroupRoleLevel(member.RoleLevel), true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// IsConversationMember 用户是否为会话的成员，私聊的会话ID由双方的ID组成，群聊的会话ID为群ID
+func (a *Authorizer) IsConversationMember(ctx context.Context, uid string, chatType constants.ChatType, conversationId string) (bool, error) {
+	if uid == "" {
+		return false, nil
+	}
+	switch chatType {
+	case constants.SingleChatType:
+		aid, bid, ok := wuid.SplitId(conversationId)
+		return ok && (uid == aid || uid == bid), nil
+	case constants.GroupChatType:
+		_, ok, err := a.GroupRole(ctx, conversationId, uid)
+		return ok, err
+	}
+	return false, nil
+}
+
+// IsChatLogMember 用户是否为消息所在会话的成员：私聊的发送者与接收者或群成员
+func (a *Authorizer) IsChatLogMember(ctx context.Context, uid string, chatType constants.ChatType, sendId, recvId string) (bool, error) {
+	if uid == "" {
+		return false, nil
+	}
+	switch chatType {
+	case constants.SingleChatType:
+		return uid == sendId || uid == recvId, nil
+	case constants.GroupChatType:
+		_, ok, err := a.GroupRole(ctx, recvId, uid)
+		return ok, err
+	}
+	return false, nil
+}
+
+// CheckConversation 用户不是会话的成员时返回 ErrNotMember
+func (a *Authorizer) CheckConversation(ctx context.Context, uid string, chatType constants.ChatType, conversationId string) error {
+	ok, err := a.IsConversationMember(ctx, uid, chatType, conversationId)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.WithStack(ErrNotMember)
+	}
+	return nil
+}
+
+// CheckChatLog 用户不是消息所在会话的成员时返回 ErrNotMember
+func (a *Authorizer) CheckChatLog(ctx context.Context, uid string, chatType constants.ChatType, sendId, recvId string) error {
+	ok, err := a.IsChatLogMember(ctx, uid, chatType, sendId, recvId)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.WithStack(ErrNotMember)
+	}
+	return nil
+}
+
+// IsFriend friendUid 是否为 uid 的好友
+func (a *Authorizer) IsFriend(ctx context.Context, uid, friendUid string) (bool, error) {
+	friends, err := a.social.FriendList(ctx, &socialclient.FriendListReq{
+		UserId: uid,
+	})
+	if err != nil {
+		return false, errors.Wrapf(xerr.NewInternalErr(), "social.FriendList err %v, uid %v", err, uid)
+	}
+	for _, friend := range friends.List {
+		if friend.FriendUid == friendUid {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// CheckSend 用户是否可以向接收者发送消息：私聊需要是好友，群聊需要是群成员，recvId 为对方的用户ID或群ID
+func (a *Authorizer) CheckSend(ctx context.Context, uid string, chatType constants.ChatType, recvId string) error {
+	switch chatType {
+	case constants.SingleChatType:
+		ok, err := a.IsFriend(ctx, uid, recvId)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.WithStack(ErrNotFriend)
+		}
+		return nil
+	case constants.GroupChatType:
+		_, ok, err := a.GroupRole(ctx, recvId, uid)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.WithStack(ErrNotMember)
+		}
+		return nil
+	}
+	return errors.WithStack(ErrNotMember)
+}
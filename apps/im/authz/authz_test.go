@@ -0,0 +1,153 @@
+package authz
+
+import (
+	"context"
+	"easy-chat/apps/social/rpc/socialclient"
+	"easy-chat/pkg/constants"
+	"easy-chat/pkg/xerr"
+	"errors"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+	zerr "github.com/zeromicro/x/errors"
+	"google.golang.org/grpc"
+)
+
+// fakeSocial 以群ID到成员角色的映射与用户的好友模拟 social rpc
+type fakeSocial struct {
+	groups  map[string]map[string]constants.GroupRoleLevel
+	friends map[string][]string
+	err     error
+}
+
+func (f *fakeSocial) FriendList(ctx context.Context, in *socialclient.FriendListReq, opts ...grpc.CallOption) (*socialclient.FriendListResp, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	var list []*socialclient.Friends
+	for _, uid := range f.friends[in.UserId] {
+		list = append(list, &socialclient.Friends{UserId: in.UserId, FriendUid: uid})
+	}
+	return &socialclient.FriendListResp{List: list}, nil
+}
+
+func (f *fakeSocial) GroupUsers(ctx context.Context, in *socialclient.GroupUsersReq, opts ...grpc.CallOption) (*socialclient.GroupUsersResp, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	var list []*socialclient.GroupMembers
+	for uid, role := range f.groups[in.GroupId] {
+		list = append(list, &socialclient.GroupMembers{GroupId: in.GroupId, UserId: uid, RoleLevel: int32(role)})
+	}
+	return &socialclient.GroupUsersResp{List: list}, nil
+}
+
+func newTestAuthorizer() *Authorizer {
+	return NewAuthorizer(&fakeSocial{
+		groups: map[string]map[string]constants.GroupRoleLevel{
+			"g1": {"u1": constants.CreatorGroupRoleLevel, "u2": constants.AtLargeGroupRoleLevel},
+		},
+		friends: map[string][]string{"u1": {"u2"}},
+	})
+}
+
+func isNoPermission(err error) bool {
+	e, ok := pkgerrors.Cause(err).(*zerr.CodeMsg)
+	return ok && e.Code == xerr.NO_PERMISSION_ERROR
+}
+
+func TestAuthorizer_CheckConversation(t *testing.T) {
+	a := newTestAuthorizer()
+
+	tests := []struct {
+		name           string
+		uid            string
+		chatType       constants.ChatType
+		conversationId string
+		wantErr        bool
+	}{
+		{"single participant", "u1", constants.SingleChatType, "u1_u3", false},
+		{"single other participant", "u3", constants.SingleChatType, "u1_u3", false},
+		{"single outsider", "u2", constants.SingleChatType, "u1_u3", true},
+		{"single malformed id", "u1", constants.SingleChatType, "u1", true},
+		{"group member", "u2", constants.GroupChatType, "g1", false},
+		{"group outsider", "u3", constants.GroupChatType, "g1", true},
+		{"unknown group", "u1", constants.GroupChatType, "g2", true},
+		{"empty uid", "", constants.SingleChatType, "u1_u3", true},
+		{"unknown chat type", "u1", 0, "u1_u3", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := a.CheckConversation(context.Background(), tt.uid, tt.chatType, tt.conversationId)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckConversation() err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !isNoPermission(err) {
+				t.Errorf("CheckConversation() err = %v, want permission error", err)
+			}
+		})
+	}
+}
+
+func TestAuthorizer_CheckChatLog(t *testing.T) {
+	a := newTestAuthorizer()
+
+	tests := []struct {
+		name     string
+		uid      string
+		chatType constants.ChatType
+		wantErr  bool
+	}{
+		{"single sender", "u1", constants.SingleChatType, false},
+		{"single receiver", "g1", constants.SingleChatType, false},
+		{"single outsider", "u2", constants.SingleChatType, true},
+		{"group member", "u2", constants.GroupChatType, false},
+		{"group outsider", "u3", constants.GroupChatType, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := a.CheckChatLog(context.Background(), tt.uid, tt.chatType, "u1", "g1")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckChatLog() err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !isNoPermission(err) {
+				t.Errorf("CheckChatLog() err = %v, want permission error", err)
+			}
+		})
+	}
+}
+
+func TestAuthorizer_CheckSend(t *testing.T) {
+	a := newTestAuthorizer()
+
+	tests := []struct {
+		name     string
+		uid      string
+		chatType constants.ChatType
+		recvId   string
+		wantErr  error
+	}{
+		{"friend", "u1", constants.SingleChatType, "u2", nil},
+		{"not friend", "u1", constants.SingleChatType, "u3", ErrNotFriend},
+		{"group member", "u2", constants.GroupChatType, "g1", nil},
+		{"group outsider", "u3", constants.GroupChatType, "g1", ErrNotMember},
+		{"unknown chat type", "u1", 0, "u2", ErrNotMember},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := a.CheckSend(context.Background(), tt.uid, tt.chatType, tt.recvId)
+			if pkgerrors.Cause(err) != tt.wantErr {
+				t.Errorf("CheckSend() err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthorizer_GroupRoleErr(t *testing.T) {
+	a := NewAuthorizer(&fakeSocial{err: errors.New("unavailable")})
+
+	err := a.CheckConversation(context.Background(), "u1", constants.GroupChatType, "g1")
+	if err == nil || isNoPermission(err) {
+		t.Errorf("CheckConversation() err = %v, want internal error", err)
+	}
+}
@@ -19,6 +19,7 @@ type (
 // NewChatLogModel returns a model for the mongo.
 func NewChatLogModel(url, db, collection string) ChatLogModel {
 	conn := mon.MustNewModel(url, db, collection)
+	mustCreateIndexes(conn, chatLogIndexes)
 	return &customChatLogModel{
 		defaultChatLogModel: newDefaultChatLogModel(conn),
 	}
@@ -3,6 +3,7 @@ package immodels
 
 import (
 	"context"
+	"easy-chat/pkg/constants"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"time"
 
@@ -14,12 +15,26 @@ import (
 
 type chatLogModel interface {
 	Insert(ctx context.Context, data *ChatLog) error
+	InsertMany(ctx context.Context, data []*ChatLog) error
 	FindOne(ctx context.Context, id string) (*ChatLog, error)
-	ListBySendTime(ctx context.Context, conversationId string, startSendTime, endSendTime, limit int64) ([]*ChatLog, error)
-	ListByMsgIds(ctx context.Context,msgIds []string)([]*ChatLog,error)
+	ListBySendTime(ctx context.Context, conversationId string, clearSeq, startSendTime, endSendTime, limit int64) ([]*ChatLog, error)
+	ListByMsgIds(ctx context.Context, msgIds []string) ([]*ChatLog, error)
 	Update(ctx context.Context, data *ChatLog) (*mongo.UpdateResult, error)
-	UpdateMarkRead(ctx context.Context,id primitive.ObjectID,readRecords []byte)error
 	Delete(ctx context.Context, id string) (int64, error)
+	ListLegacyConversationIds(ctx context.Context) ([]string, error)
+	ListLegacyByConversationId(ctx context.Context, conversationId string, legacySeq int64) ([]*ChatLog, error)
+	NegateSeqs(ctx context.Context, conversationId string) error
+	ShiftNegatedSeqs(ctx context.Context, conversationId string, shift int64) error
+	MigrateSeqs(ctx context.Context, legacy []*ChatLog) error
+	Recall(ctx context.Context, id primitive.ObjectID) (bool, error)
+	Edit(ctx context.Context, data *ChatLog, content string, editTime int64) (bool, error)
+	UpdateQuotes(ctx context.Context, quote *Quote) (int64, error)
+	ListByThreadId(ctx context.Context, threadId string, afterSeq, limit int64) ([]*ChatLog, error)
+	UpdateThread(ctx context.Context, reply *ChatLog) (*ChatLog, error)
+	ListAfterId(ctx context.Context, afterId string, limit int64) ([]*ChatLog, error)
+	ListByCursor(ctx context.Context, conversationId string, clearSeq int64, anchor *ChatLogCursor, direction constants.PageDirection, limit int64) ([]*ChatLog, bool, error)
+	CountUnread(ctx context.Context, conversationId, uid string, readSeq int64) (int64, error)
+	ListByAttachmentId(ctx context.Context, attachmentId string, limit int64) ([]*ChatLog, error)
 }
 
 type defaultChatLogModel struct {
@@ -41,6 +56,33 @@ func (m *defaultChatLogModel) Insert(ctx context.Context, data *ChatLog) error {
 	return err
 }
 
+// 批量写入聊天记录，无序写入时一条失败不影响其他记录
+//
+// 已经写入过的记录（主键重复）视为写入成功，写入失败后可以用相同的记录重试。
+func (m *defaultChatLogModel) InsertMany(ctx context.Context, data []*ChatLog) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	docs := make([]any, 0, len(data))
+	for _, chatLog := range data {
+		if chatLog.ID.IsZero() {
+			chatLog.ID = primitive.NewObjectID()
+		}
+		if chatLog.CreateAt.IsZero() {
+			chatLog.CreateAt = time.Now()
+			chatLog.UpdateAt = time.Now()
+		}
+		docs = append(docs, chatLog)
+	}
+
+	_, err := m.conn.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	if err != nil && !onlyDuplicateKey(err) {
+		return err
+	}
+	return nil
+}
+
 func (m *defaultChatLogModel) FindOne(ctx context.Context, id string) (*ChatLog, error) {
 	oid, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
@@ -61,7 +103,8 @@ func (m *defaultChatLogModel) FindOne(ctx context.Context, id string) (*ChatLog,
 }
 
 // 查询聊天记录
-func (m *defaultChatLogModel) ListBySendTime(ctx context.Context, conversationId string, startSendTime, endSendTime, limit int64) ([]*ChatLog, error) {
+// ListBySendTime 按发送时间倒序查询会话的聊天记录，只包含序号大于 clearSeq 的消息
+func (m *defaultChatLogModel) ListBySendTime(ctx context.Context, conversationId string, clearSeq, startSendTime, endSendTime, limit int64) ([]*ChatLog, error) {
 	var data []*ChatLog
 
 	opt := options.FindOptions{
@@ -74,8 +117,13 @@ func (m *defaultChatLogModel) ListBySendTime(ctx context.Context, conversationId
 		opt.Limit = &limit
 	}
 
+	// 话题中的回复不在会话中展示
 	filter := bson.M{
 		"conversationId": conversationId,
+		"threadId":       bson.M{"$exists": false},
+	}
+	if clearSeq > 0 {
+		filter["seq"] = bson.M{"$gt": clearSeq}
 	}
 
 	if endSendTime > 0 {
@@ -99,19 +147,19 @@ func (m *defaultChatLogModel) ListBySendTime(ctx context.Context, conversationId
 	}
 }
 
-func (m *defaultChatLogModel) ListByMsgIds(ctx context.Context,msgIds []string)([]*ChatLog,error){
+func (m *defaultChatLogModel) ListByMsgIds(ctx context.Context, msgIds []string) ([]*ChatLog, error) {
 	var data []*ChatLog
-	ids:=make([]primitive.ObjectID,0,len(msgIds))
-	for _,id:=range msgIds{
-		oid,_:=primitive.ObjectIDFromHex(id)
-		ids = append(ids,oid)
+	ids := make([]primitive.ObjectID, 0, len(msgIds))
+	for _, id := range msgIds {
+		oid, _ := primitive.ObjectIDFromHex(id)
+		ids = append(ids, oid)
 	}
-	filter:=bson.M{
+	filter := bson.M{
 		"_id": bson.M{
-			"$in":ids,
+			"$in": ids,
 		},
 	}
-	err:=m.conn.Find(ctx,&data,filter)
+	err := m.conn.Find(ctx, &data, filter)
 	switch err {
 	case nil:
 		return data, nil
@@ -122,14 +170,6 @@ func (m *defaultChatLogModel) ListByMsgIds(ctx context.Context,msgIds []string)(
 	}
 }
 
-//修改聊天记录中的已读记录
-func (m *defaultChatLogModel)UpdateMarkRead(ctx context.Context,id primitive.ObjectID,readRecords []byte)error{
-	_, err := m.conn.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{
-		"readRecords": readRecords,
-	}})
-	return err
-}
-
 func (m *defaultChatLogModel) Update(ctx context.Context, data *ChatLog) (*mongo.UpdateResult, error) {
 	data.UpdateAt = time.Now()
 
@@ -146,3 +186,339 @@ func (m *defaultChatLogModel) Delete(ctx context.Context, id string) (int64, err
 	res, err := m.conn.DeleteOne(ctx, bson.M{"_id": oid})
 	return res, err
 }
+
+// legacyFilter 没有消息序号的旧版本聊天记录
+func legacyFilter() bson.M {
+	return bson.M{"seq": bson.M{"$in": bson.A{nil, 0}}}
+}
+
+// ListLegacyConversationIds 查询存在旧版本聊天记录的会话
+func (m *defaultChatLogModel) ListLegacyConversationIds(ctx context.Context) ([]string, error) {
+	values, err := m.conn.Distinct(ctx, "conversationId", legacyFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(values))
+	for _, v := range values {
+		if id, ok := v.(string); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// ListLegacyByConversationId 按发送顺序查询会话中旧版本的聊天记录
+//
+// 迁移中已经写入序号的旧消息占用 1..legacySeq，同样被查出，重复执行迁移时得到相同的列表。
+func (m *defaultChatLogModel) ListLegacyByConversationId(ctx context.Context, conversationId string, legacySeq int64) ([]*ChatLog, error) {
+	var data []*ChatLog
+
+	filter := legacyFilter()
+	if legacySeq > 0 {
+		filter = bson.M{"$or": bson.A{
+			legacyFilter(),
+			bson.M{"seq": bson.M{"$gt": 0, "$lte": legacySeq}},
+		}}
+	}
+	filter["conversationId"] = conversationId
+	err := m.conn.Find(ctx, &data, filter, options.Find().SetSort(bson.D{
+		{Key: "sendTime", Value: 1},
+		{Key: "_id", Value: 1},
+	}))
+	switch err {
+	case nil, mon.ErrNotFound:
+		return data, nil
+	default:
+		return nil, err
+	}
+}
+
+// NegateSeqs 将会话中已有的消息序号取反
+//
+// 取反之后的序号与后移之后的序号不会重叠，后移中断时能区分哪些消息还没有后移。
+func (m *defaultChatLogModel) NegateSeqs(ctx context.Context, conversationId string) error {
+	_, err := m.conn.UpdateMany(ctx,
+		bson.M{"conversationId": conversationId, "seq": bson.M{"$gt": 0}},
+		bson.M{"$mul": bson.M{"seq": -1}},
+	)
+	return err
+}
+
+// ShiftNegatedSeqs 将取反的消息序号恢复并后移 shift 位，给排在它们之前的旧消息让出序号
+func (m *defaultChatLogModel) ShiftNegatedSeqs(ctx context.Context, conversationId string, shift int64) error {
+	_, err := m.conn.UpdateMany(ctx,
+		bson.M{"conversationId": conversationId, "seq": bson.M{"$lt": 0}},
+		bson.A{bson.M{"$set": bson.M{"seq": bson.M{"$subtract": bson.A{shift, "$seq"}}}}},
+	)
+	return err
+}
+
+// MigrateSeqs 为旧版本的聊天记录写入消息序号并清除已读位图
+func (m *defaultChatLogModel) MigrateSeqs(ctx context.Context, legacy []*ChatLog) error {
+	if len(legacy) == 0 {
+		return nil
+	}
+
+	models := make([]mongo.WriteModel, 0, len(legacy))
+	for _, chatLog := range legacy {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": chatLog.ID}).
+			SetUpdate(bson.M{
+				"$set":   bson.M{"seq": chatLog.Seq},
+				"$unset": bson.M{"readRecords": ""},
+			}))
+	}
+
+	_, err := m.conn.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// Recall 撤回消息并清除消息内容，返回 false 表示消息已经被撤回
+func (m *defaultChatLogModel) Recall(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	res, err := m.conn.UpdateOne(ctx,
+		bson.M{
+			"_id":    id,
+			"status": bson.M{"$ne": constants.RecallMsgStatus},
+		},
+		bson.M{
+			"$set": bson.M{
+				"status":     constants.RecallMsgStatus,
+				"msgContent": "",
+				"updateAt":   time.Now(),
+			},
+			// 撤回后不再保留消息的任何内容，包括编辑前的历史版本
+			"$unset": bson.M{
+				"body":    "",
+				"replyTo": "",
+				"mention": "",
+				"edits":   "",
+			},
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+// Edit 修改消息内容，修改前的内容追加到历史版本中
+//
+// 以 data 的版本作为乐观锁，版本已变化、消息已撤回或不是文本消息时返回 false；成功后 data 更新为新的内容与版本。
+func (m *defaultChatLogModel) Edit(ctx context.Context, data *ChatLog, content string, editTime int64) (bool, error) {
+	res, err := m.conn.UpdateOne(ctx,
+		bson.M{
+			"_id":     data.ID,
+			"version": data.Version,
+			"msgType": constants.TextMtype,
+			"status":  bson.M{"$ne": constants.RecallMsgStatus},
+		},
+		bson.M{
+			"$set": bson.M{
+				"msgContent": content,
+				"updateAt":   time.Now(),
+			},
+			"$inc": bson.M{"version": 1},
+			"$push": bson.M{"edits": &ChatLogEdit{
+				Version:    data.Version,
+				MsgContent: data.MsgContent,
+				EditTime:   editTime,
+			}},
+		},
+	)
+	if err != nil || res.ModifiedCount == 0 {
+		return false, err
+	}
+
+	data.Edits = append(data.Edits, &ChatLogEdit{
+		Version:    data.Version,
+		MsgContent: data.MsgContent,
+		EditTime:   editTime,
+	})
+	data.MsgContent = content
+	data.Version++
+	return true, nil
+}
+
+// UpdateQuotes 原消息撤回或编辑后，同步更新所有回复中的引用，以及话题中最后一条回复的摘要
+func (m *defaultChatLogModel) UpdateQuotes(ctx context.Context, quote *Quote) (int64, error) {
+	res, err := m.conn.UpdateMany(ctx,
+		bson.M{"replyTo.msgId": quote.MsgId},
+		bson.M{"$set": bson.M{
+			"replyTo":  quote,
+			"updateAt": time.Now(),
+		}},
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	thread, err := m.conn.UpdateOne(ctx,
+		bson.M{"thread.lastReply.msgId": quote.MsgId},
+		bson.M{"$set": bson.M{
+			"thread.lastReply": quote,
+			"updateAt":         time.Now(),
+		}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount + thread.ModifiedCount, nil
+}
+
+// ListByThreadId 按序号升序查询话题中 afterSeq 之后的回复
+func (m *defaultChatLogModel) ListByThreadId(ctx context.Context, threadId string, afterSeq, limit int64) ([]*ChatLog, error) {
+	var data []*ChatLog
+
+	opt := options.Find().SetSort(bson.M{"seq": 1}).SetLimit(DefaultChatLogLimit)
+	if limit > 0 {
+		opt.SetLimit(limit)
+	}
+	err := m.conn.Find(ctx, &data, bson.M{
+		"threadId": threadId,
+		"seq":      bson.M{"$gt": afterSeq},
+	}, opt)
+	if err != nil && err != mon.ErrNotFound {
+		return nil, err
+	}
+	return data, nil
+}
+
+// UpdateThread 话题有新的回复，增加回复数并记录最后一条回复，返回更新后的话题第一条消息
+func (m *defaultChatLogModel) UpdateThread(ctx context.Context, reply *ChatLog) (*ChatLog, error) {
+	oid, err := primitive.ObjectIDFromHex(reply.ThreadId)
+	if err != nil {
+		return nil, ErrInvalidObjectId
+	}
+
+	var data ChatLog
+	err = m.conn.FindOneAndUpdate(ctx, &data,
+		bson.M{"_id": oid},
+		bson.M{
+			"$inc": bson.M{"thread.replyCount": 1},
+			"$set": bson.M{
+				"thread.lastReply":     NewQuote(reply),
+				"thread.lastReplyTime": reply.SendTime,
+			},
+		},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	)
+	switch err {
+	case nil:
+		return &data, nil
+	case mon.ErrNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, err
+	}
+}
+
+// ListAfterId 按ID升序查询 afterId 之后的聊天记录，afterId 为空时从头开始，用于遍历全部记录
+func (m *defaultChatLogModel) ListAfterId(ctx context.Context, afterId string, limit int64) ([]*ChatLog, error) {
+	var data []*ChatLog
+
+	filter := bson.M{}
+	if afterId != "" {
+		oid, err := primitive.ObjectIDFromHex(afterId)
+		if err != nil {
+			return nil, ErrInvalidObjectId
+		}
+		filter["_id"] = bson.M{"$gt": oid}
+	}
+	opt := options.Find().SetSort(bson.M{"_id": 1}).SetLimit(DefaultChatLogLimit)
+	if limit > 0 {
+		opt.SetLimit(limit)
+	}
+	err := m.conn.Find(ctx, &data, filter, opt)
+	if err != nil && err != mon.ErrNotFound {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ListByCursor 从 anchor 开始（不包含）按方向翻页查询会话中的消息，返回按序号升序排列的消息，以及该方向上是否还有更多消息
+//
+// anchor 为空时向前翻页从最新的消息开始，向后翻页从最早的消息开始；话题中的回复不在会话中展示。
+// 只包含序号大于 clearSeq 的消息，即用户清空聊天记录之后的消息。
+func (m *defaultChatLogModel) ListByCursor(ctx context.Context, conversationId string, clearSeq int64, anchor *ChatLogCursor, direction constants.PageDirection, limit int64) ([]*ChatLog, bool, error) {
+	var (
+		data  []*ChatLog
+		op    = "$lt"
+		order = -1
+	)
+	if direction == constants.NewerPageDirection {
+		op, order = "$gt", 1
+	}
+
+	filter := bson.M{
+		"conversationId": conversationId,
+		"threadId":       bson.M{"$exists": false},
+	}
+	if clearSeq > 0 {
+		filter["seq"] = bson.M{"$gt": clearSeq}
+	}
+	if anchor != nil {
+		filter["$or"] = bson.A{
+			bson.M{"seq": bson.M{op: anchor.Seq}},
+			bson.M{"seq": anchor.Seq, "_id": bson.M{op: anchor.Id}},
+		}
+	}
+	// 多查询一条判断是否还有更多消息
+	opt := options.Find().
+		SetSort(bson.D{{Key: "seq", Value: order}, {Key: "_id", Value: order}}).
+		SetLimit(limit + 1)
+	err := m.conn.Find(ctx, &data, filter, opt)
+	if err != nil && err != mon.ErrNotFound {
+		return nil, false, err
+	}
+
+	hasMore := int64(len(data)) > limit
+	if hasMore {
+		data = data[:limit]
+	}
+	if order < 0 {
+		for i, j := 0, len(data)-1; i < j; i, j = i+1, j-1 {
+			data[i], data[j] = data[j], data[i]
+		}
+	}
+	return data, hasMore, nil
+}
+
+// CountUnread 统计会话中序号在已读水位 readSeq 之后、不是 uid 自己发送的消息数
+//
+// 话题中的回复与撤回的消息不计入未读数。
+func (m *defaultChatLogModel) CountUnread(ctx context.Context, conversationId, uid string, readSeq int64) (int64, error) {
+	return m.conn.CountDocuments(ctx, unreadFilter(conversationId, uid, readSeq))
+}
+
+// unreadFilter 计入 uid 未读数的消息
+func unreadFilter(conversationId, uid string, readSeq int64) bson.M {
+	return bson.M{
+		"conversationId": conversationId,
+		"seq":            bson.M{"$gt": readSeq},
+		"sendId":         bson.M{"$ne": uid},
+		"threadId":       bson.M{"$in": bson.A{nil, ""}},
+		"status":         bson.M{"$ne": constants.RecallMsgStatus},
+	}
+}
+
+// ListByAttachmentId 查询引用了 media 服务附件的消息，按发送时间降序，撤回的消息不再引用附件
+func (m *defaultChatLogModel) ListByAttachmentId(ctx context.Context, attachmentId string, limit int64) ([]*ChatLog, error) {
+	var data []*ChatLog
+
+	filter := bson.M{"$or": []bson.M{
+		{"body.image.attachmentId": attachmentId},
+		{"body.file.attachmentId": attachmentId},
+		{"body.voice.attachmentId": attachmentId},
+	}}
+	opt := options.Find().SetSort(bson.M{"sendTime": -1}).SetLimit(limit)
+	err := m.conn.Find(ctx, &data, filter, opt)
+	switch err {
+	case nil:
+		return data, nil
+	case mon.ErrNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, err
+	}
+}
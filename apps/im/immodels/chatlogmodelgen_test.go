@@ -0,0 +1,101 @@
+package immodels
+
+import (
+	"easy-chat/pkg/constants"
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// toM 按存储的格式转换为 bson.M，使过滤条件与文档中的值类型一致
+func toM(t *testing.T, v interface{}) bson.M {
+	t.Helper()
+	data, err := bson.Marshal(v)
+	if err != nil {
+		t.Fatalf("bson.Marshal() err = %v", err)
+	}
+	var m bson.M
+	if err := bson.Unmarshal(data, &m); err != nil {
+		t.Fatalf("bson.Unmarshal() err = %v", err)
+	}
+	return m
+}
+
+// match 判断文档是否满足过滤条件，只支持 unreadFilter 用到的操作符
+func match(t *testing.T, filter, doc bson.M) bool {
+	t.Helper()
+	for field, cond := range filter {
+		v := doc[field]
+		ops, ok := cond.(bson.M)
+		if !ok {
+			if !reflect.DeepEqual(v, cond) {
+				return false
+			}
+			continue
+		}
+		for op, want := range ops {
+			switch op {
+			case "$gt":
+				if n, ok := v.(int64); !ok || n <= want.(int64) {
+					return false
+				}
+			case "$ne":
+				if reflect.DeepEqual(v, want) {
+					return false
+				}
+			case "$in":
+				in := false
+				for _, w := range want.(bson.A) {
+					in = in || reflect.DeepEqual(v, w)
+				}
+				if !in {
+					return false
+				}
+			default:
+				t.Fatalf("unsupported operator %v", op)
+			}
+		}
+	}
+	return true
+}
+
+func TestUnreadFilter(t *testing.T) {
+	newChatLog := func(sendId string, seq int64) *ChatLog {
+		return &ChatLog{ID: primitive.NewObjectID(), ConversationId: "g1", ChatType: constants.GroupChatType,
+			SendId: sendId, RecvId: "g1", Seq: seq, Status: constants.NormalMsgStatus}
+	}
+	tests := []struct {
+		name    string
+		chatLog *ChatLog
+		want    bool
+	}{
+		{"unread", newChatLog("u2", 6), true},
+		{"read", newChatLog("u2", 5), false},
+		{"own", newChatLog("u1", 6), false},
+		{"other conversation", func() *ChatLog {
+			chatLog := newChatLog("u2", 6)
+			chatLog.ConversationId = "g2"
+			return chatLog
+		}(), false},
+		{"thread reply", func() *ChatLog {
+			chatLog := newChatLog("u2", 6)
+			chatLog.ThreadId = primitive.NewObjectID().Hex()
+			return chatLog
+		}(), false},
+		{"recalled", func() *ChatLog {
+			chatLog := newChatLog("u2", 6)
+			chatLog.Status = constants.RecallMsgStatus
+			return chatLog
+		}(), false},
+	}
+	filter := toM(t, unreadFilter("g1", "u1", 5))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := match(t, filter, toM(t, tt.chatLog)); got != tt.want {
+				t.Errorf("unreadFilter() match = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
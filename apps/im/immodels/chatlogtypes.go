@@ -11,18 +11,33 @@ var DefaultChatLogLimit int64 = 100
 type ChatLog struct {
 	ID primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
 
-	ConversationId string             `bson:"conversationId"`
-	SendId         string             `bson:"sendId"`
-	RecvId         string             `bson:"recvId"`
-	MsgFrom        int                `bson:"msgFrom"`
-	ChatType       constants.ChatType `bson:"chatType"`
-	MsgType        constants.MType    `bson:"msgType"`
-	MsgContent     string             `bson:"msgContent"`
-	SendTime       int64              `bson:"sendTime"`
-	Status         int                `bson:"status"`
-	ReadRecords    []byte             `bson:"readRecords"` // 记录该消息的已读信息
+	ConversationId string              `bson:"conversationId"`
+	SendId         string              `bson:"sendId"`
+	RecvId         string              `bson:"recvId"`
+	MsgFrom        int                 `bson:"msgFrom"`
+	ChatType       constants.ChatType  `bson:"chatType"`
+	MsgType        constants.MType     `bson:"msgType"`
+	MsgContent     string              `bson:"msgContent"`
+	Body           *MsgBody            `bson:"body,omitempty"`     // 非文本消息的结构化内容
+	ReplyTo        *Quote              `bson:"replyTo,omitempty"`  // 回复时引用的消息
+	Mention        *Mention            `bson:"mention,omitempty"`  // 提及的群成员
+	ThreadId       string              `bson:"threadId,omitempty"` // 所属话题的第一条消息ID，仅话题中的回复
+	Thread         *Thread             `bson:"thread,omitempty"`   // 话题的统计，仅话题的第一条消息
+	SendTime       int64               `bson:"sendTime"`
+	Seq            int64               `bson:"seq"` // 会话内的消息序号
+	Status         constants.MsgStatus `bson:"status"`
+	Version        int                 `bson:"version"`               // 编辑版本，每次编辑加一
+	Edits          []*ChatLogEdit      `bson:"edits,omitempty"`       // 历史版本，按编辑顺序排列
+	ReadRecords    []byte              `bson:"readRecords,omitempty"` // 旧版本的已读位图，迁移后清除
 
 	// TODO: Fill your own fields
 	UpdateAt time.Time `bson:"updateAt,omitempty" json:"updateAt,omitempty"`
 	CreateAt time.Time `bson:"createAt,omitempty" json:"createAt,omitempty"`
 }
+
+// ChatLogEdit 消息被编辑前的内容
+type ChatLogEdit struct {
+	Version    int    `bson:"version"`
+	MsgContent string `bson:"msgContent"`
+	EditTime   int64  `bson:"editTime"` // 编辑的时间戳
+}
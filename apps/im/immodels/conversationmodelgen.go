@@ -3,12 +3,15 @@ package immodels
 
 import (
 	"context"
+	"easy-chat/pkg/constants"
+	"strconv"
 	"time"
 
 	"github.com/zeromicro/go-zero/core/stores/mon"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type conversationModel interface {
@@ -16,7 +19,18 @@ type conversationModel interface {
 	FindOne(ctx context.Context, id string) (*Conversation, error)
 	ListByConversationIds(ctx context.Context, ids []string) ([]*Conversation, error)
 	Update(ctx context.Context, data *Conversation) (*mongo.UpdateResult, error)
-	UpdateMsg(ctx context.Context, chatLog *ChatLog)error
+	UpdateMsg(ctx context.Context, chatLog *ChatLog) error
+	UpdateMsgs(ctx context.Context, chatLogs []*ChatLog) error
+	FindByConversationId(ctx context.Context, conversationId string) (*Conversation, error)
+	IncrSeq(ctx context.Context, conversationId string, chatType constants.ChatType, n int64) (int64, error)
+	StartSeqMigration(ctx context.Context, conversationId string, seq int64, migration *SeqMigration) (bool, error)
+	SetSeqMigrationStep(ctx context.Context, conversationId string, step SeqMigrationStep) error
+	ListSeqMigrating(ctx context.Context) ([]string, error)
+	SetMsgSeq(ctx context.Context, conversationId string, msgId primitive.ObjectID, seq int64) error
+	RecallMsg(ctx context.Context, chatLog *ChatLog) error
+	EditMsg(ctx context.Context, chatLog *ChatLog) error
+	Pin(ctx context.Context, conversationId string, pin *Pin, max int) (bool, error)
+	Unpin(ctx context.Context, conversationId, msgId string) (bool, error)
 	Delete(ctx context.Context, id string) (int64, error)
 }
 
@@ -93,6 +107,20 @@ func (m *defaultConversationModel) ListByConversationIds(ctx context.Context, id
 	}
 }
 
+func (m *defaultConversationModel) FindByConversationId(ctx context.Context, conversationId string) (*Conversation, error) {
+	var data Conversation
+
+	err := m.conn.FindOne(ctx, &data, bson.M{"conversationId": conversationId})
+	switch err {
+	case nil:
+		return &data, nil
+	case mon.ErrNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, err
+	}
+}
+
 func (m *defaultConversationModel) UpdateMsg(ctx context.Context, chatLog *ChatLog) error {
 	_, err := m.conn.UpdateOne(ctx,
 		bson.M{"conversationId": chatLog.ConversationId},
@@ -103,4 +131,187 @@ func (m *defaultConversationModel) UpdateMsg(ctx context.Context, chatLog *ChatL
 		},
 	)
 	return err
-}
\ No newline at end of file
+}
+
+// UpdateMsgs 批量更新会话的最后一条消息与总消息数
+//
+// 同一会话的多条消息聚合为一次 $inc，最后一条消息以切片中的顺序为准。
+func (m *defaultConversationModel) UpdateMsgs(ctx context.Context, chatLogs []*ChatLog) error {
+	if len(chatLogs) == 0 {
+		return nil
+	}
+
+	var (
+		counts = make(map[string]int, len(chatLogs))
+		lasts  = make(map[string]*ChatLog, len(chatLogs))
+		ids    = make([]string, 0, len(chatLogs))
+	)
+	for _, chatLog := range chatLogs {
+		if _, ok := counts[chatLog.ConversationId]; !ok {
+			ids = append(ids, chatLog.ConversationId)
+		}
+		counts[chatLog.ConversationId]++
+		lasts[chatLog.ConversationId] = chatLog
+	}
+
+	models := make([]mongo.WriteModel, 0, len(ids))
+	for _, id := range ids {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"conversationId": id}).
+			SetUpdate(bson.M{
+				"$inc": bson.M{"total": counts[id]},
+				"$set": bson.M{"msg": lasts[id]},
+			}))
+	}
+
+	_, err := m.conn.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// IncrSeq 为会话分配 n 个连续的消息序号，返回分配后的最大序号
+func (m *defaultConversationModel) IncrSeq(ctx context.Context, conversationId string, chatType constants.ChatType, n int64) (int64, error) {
+	var data Conversation
+
+	err := m.conn.FindOneAndUpdate(ctx, &data,
+		bson.M{"conversationId": conversationId},
+		bson.M{
+			"$inc":         bson.M{"seq": n},
+			"$setOnInsert": bson.M{"chatType": chatType},
+		},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	)
+	if err != nil {
+		return 0, err
+	}
+	return data.Seq, nil
+}
+
+// StartSeqMigration 记录序号迁移的计划并为旧消息预留序号
+//
+// 会话的序号仍为 seq 且没有开始过迁移时才会记录，否则返回 false。
+func (m *defaultConversationModel) StartSeqMigration(ctx context.Context, conversationId string, seq int64, migration *SeqMigration) (bool, error) {
+	filter := bson.M{
+		"conversationId": conversationId,
+		"seqMigration":   bson.M{"$exists": false},
+		"seq":            seq,
+	}
+	if seq == 0 {
+		filter["seq"] = bson.M{"$in": bson.A{nil, 0}}
+	}
+
+	res, err := m.conn.UpdateOne(ctx, filter, bson.M{
+		"$inc": bson.M{"seq": migration.Legacy},
+		"$set": bson.M{"seqMigration": migration},
+	})
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+// SetSeqMigrationStep 记录序号迁移完成的步骤
+func (m *defaultConversationModel) SetSeqMigrationStep(ctx context.Context, conversationId string, step SeqMigrationStep) error {
+	_, err := m.conn.UpdateOne(ctx,
+		bson.M{"conversationId": conversationId},
+		bson.M{"$set": bson.M{"seqMigration.step": step}},
+	)
+	return err
+}
+
+// ListSeqMigrating 查询已经开始但还没有完成序号迁移的会话
+func (m *defaultConversationModel) ListSeqMigrating(ctx context.Context) ([]string, error) {
+	var data []*Conversation
+
+	err := m.conn.Find(ctx, &data,
+		bson.M{"seqMigration.step": bson.M{"$lt": DoneSeqMigration}},
+		options.Find().SetProjection(bson.M{"conversationId": 1}),
+	)
+	switch err {
+	case nil, mon.ErrNotFound:
+	default:
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(data))
+	for _, conversation := range data {
+		ids = append(ids, conversation.ConversationId)
+	}
+	return ids, nil
+}
+
+// SetMsgSeq 会话的最后一条消息是 msgId 时，更新其中的消息序号
+func (m *defaultConversationModel) SetMsgSeq(ctx context.Context, conversationId string, msgId primitive.ObjectID, seq int64) error {
+	_, err := m.conn.UpdateOne(ctx,
+		bson.M{"conversationId": conversationId, "msg._id": msgId},
+		bson.M{"$set": bson.M{"msg.seq": seq}},
+	)
+	return err
+}
+
+// RecallMsg 撤回的消息是会话的最后一条消息时，同步更新会话中的消息
+func (m *defaultConversationModel) RecallMsg(ctx context.Context, chatLog *ChatLog) error {
+	_, err := m.conn.UpdateOne(ctx,
+		bson.M{
+			"conversationId": chatLog.ConversationId,
+			"msg._id":        chatLog.ID,
+		},
+		bson.M{
+			"$set": bson.M{
+				"msg.status":     constants.RecallMsgStatus,
+				"msg.msgContent": "",
+			},
+			"$unset": bson.M{
+				"msg.body":    "",
+				"msg.replyTo": "",
+				"msg.mention": "",
+				"msg.edits":   "",
+			},
+		},
+	)
+	return err
+}
+
+// EditMsg 编辑的消息是会话的最后一条消息时，同步更新会话中的消息
+func (m *defaultConversationModel) EditMsg(ctx context.Context, chatLog *ChatLog) error {
+	_, err := m.conn.UpdateOne(ctx,
+		bson.M{
+			"conversationId": chatLog.ConversationId,
+			"msg._id":        chatLog.ID,
+		},
+		bson.M{"$set": bson.M{
+			"msg.msgContent": chatLog.MsgContent,
+			"msg.version":    chatLog.Version,
+		}},
+	)
+	return err
+}
+
+// Pin 置顶消息，消息已经置顶或置顶数达到 max 时返回 false
+func (m *defaultConversationModel) Pin(ctx context.Context, conversationId string, pin *Pin, max int) (bool, error) {
+	filter := bson.M{
+		"conversationId": conversationId,
+		"pins.msgId":     bson.M{"$ne": pin.MsgId},
+	}
+	if max > 0 {
+		// 第 max 个置顶不存在时才能追加
+		filter["pins."+strconv.Itoa(max-1)] = bson.M{"$exists": false}
+	}
+
+	res, err := m.conn.UpdateOne(ctx, filter, bson.M{"$push": bson.M{"pins": pin}})
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+// Unpin 取消置顶，消息没有置顶时返回 false
+func (m *defaultConversationModel) Unpin(ctx context.Context, conversationId, msgId string) (bool, error) {
+	res, err := m.conn.UpdateOne(ctx,
+		bson.M{"conversationId": conversationId},
+		bson.M{"$pull": bson.M{"pins": bson.M{"msgId": msgId}}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
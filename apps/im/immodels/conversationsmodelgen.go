@@ -18,7 +18,7 @@ type conversationsModel interface {
 	FindByUserId(ctx context.Context, uid string) (*Conversations, error)
 	Update(ctx context.Context, data *Conversations) (*mongo.UpdateResult, error)
 	Delete(ctx context.Context, id string) (int64, error)
-
+	ListAfterId(ctx context.Context, afterId string, limit int64) ([]*Conversations, error)
 }
 
 type defaultConversationsModel struct {
@@ -59,14 +59,14 @@ func (m *defaultConversationsModel) FindOne(ctx context.Context, id string) (*Co
 	}
 }
 
-//func (m *defaultConversationsModel) Update(ctx context.Context, data *Conversations) (*mongo.UpdateResult, error) {
-//	data.UpdateAt = time.Now()
+//	func (m *defaultConversationsModel) Update(ctx context.Context, data *Conversations) (*mongo.UpdateResult, error) {
+//		data.UpdateAt = time.Now()
 //
-//	res, err := m.conn.UpdateOne(ctx, bson.M{"_id": data.ID}, bson.M{
-//		"$set": data,
-//	},options.Update().SetUpsert(true))
-//	return res, err
-//}
+//		res, err := m.conn.UpdateOne(ctx, bson.M{"_id": data.ID}, bson.M{
+//			"$set": data,
+//		},options.Update().SetUpsert(true))
+//		return res, err
+//	}
 func (m *defaultConversationsModel) Update(ctx context.Context, data *Conversations) (*mongo.UpdateResult, error) {
 	data.UpdateAt = time.Now()
 
@@ -85,7 +85,7 @@ func (m *defaultConversationsModel) Delete(ctx context.Context, id string) (int6
 	return res, err
 }
 
-//根据用户Id查询会话内容
+// 根据用户Id查询会话内容
 func (m *defaultConversationsModel) FindByUserId(ctx context.Context, uid string) (*Conversations, error) {
 	var data Conversations
 
@@ -98,4 +98,23 @@ func (m *defaultConversationsModel) FindByUserId(ctx context.Context, uid string
 	default:
 		return nil, err
 	}
-}
\ No newline at end of file
+}
+
+// ListAfterId 按ID升序查询 afterId 之后的用户会话列表，afterId 为空时从头开始，用于迁移时遍历全部记录
+func (m *defaultConversationsModel) ListAfterId(ctx context.Context, afterId string, limit int64) ([]*Conversations, error) {
+	var data []*Conversations
+
+	filter := bson.M{}
+	if afterId != "" {
+		oid, err := primitive.ObjectIDFromHex(afterId)
+		if err != nil {
+			return nil, ErrInvalidObjectId
+		}
+		filter["_id"] = bson.M{"$gt": oid}
+	}
+	err := m.conn.Find(ctx, &data, filter, options.Find().SetSort(bson.M{"_id": 1}).SetLimit(limit))
+	if err != nil && err != mon.ErrNotFound {
+		return nil, err
+	}
+	return data, nil
+}
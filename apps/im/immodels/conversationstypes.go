@@ -6,6 +6,9 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// Conversations 旧版本的用户会话列表，一个用户的所有会话保存在一条记录中
+//
+// 已由 UserConversation 取代，仅用于迁移。
 type Conversations struct {
 	ID primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
 
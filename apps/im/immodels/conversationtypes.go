@@ -17,7 +17,38 @@ type Conversation struct {
 	Total  int      `bson:"total,omitempty"`
 	Seq    int64    `bson:"seq"`
 	Msg    *ChatLog `bson:"msg,omitempty"`
+	// 用户已读到的消息序号，仅用于用户的会话列表
+	ReadSeq int64 `bson:"readSeq,omitempty"`
+	// 最近一条提及用户的消息序号，大于已读水位时表示用户被提及，仅用于用户的会话列表
+	MentionSeq int64 `bson:"mentionSeq,omitempty"`
+	// 置顶的消息，按置顶的先后排列
+	Pins []*Pin `bson:"pins,omitempty"`
+	// 为旧版本消息分配序号的迁移进度
+	SeqMigration *SeqMigration `bson:"seqMigration,omitempty"`
 
 	UpdateAt time.Time `bson:"updateAt,omitempty" json:"updateAt,omitempty"`
 	CreateAt time.Time `bson:"createAt,omitempty" json:"createAt,omitempty"`
 }
+
+// SeqMigrationStep 旧版本消息序号迁移完成的步骤
+type SeqMigrationStep int
+
+const (
+	// PlannedSeqMigration 已记录旧消息的数量并为其预留序号
+	PlannedSeqMigration SeqMigrationStep = iota + 1
+	// NegatedSeqMigration 已有序号的消息暂时取反，与后移之后的序号区分
+	NegatedSeqMigration
+	// ShiftedSeqMigration 已有序号的消息与用户的水位已经后移
+	ShiftedSeqMigration
+	// DoneSeqMigration 旧消息已经写入序号
+	DoneSeqMigration
+)
+
+// SeqMigration 会话的序号迁移进度，迁移中断后重新执行时跳过已经完成的步骤
+type SeqMigration struct {
+	// 旧消息的数量，旧消息占用 1..Legacy 的序号
+	Legacy int64 `bson:"legacy"`
+	// 已有序号后移的位数，会话中没有已分配序号的消息时为 0
+	Shift int64            `bson:"shift"`
+	Step  SeqMigrationStep `bson:"step"`
+}
@@ -0,0 +1,102 @@
+package immodels
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	// DefaultChatLogPageSize 按游标翻页时未指定的每页消息数
+	DefaultChatLogPageSize int64 = 20
+	// MaxChatLogPageSize 按游标翻页时每页最多的消息数
+	MaxChatLogPageSize int64 = 100
+	// DefaultConversationPageSize 分页查询会话列表时未指定的每页会话数
+	DefaultConversationPageSize int64 = 20
+	// MaxConversationPageSize 分页查询会话列表时每页最多的会话数
+	MaxConversationPageSize int64 = 100
+
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+// ChatLogCursor 聊天记录在会话中的位置
+//
+// 按消息序号排序，序号相同时（迁移前的旧消息）以ID区分，保证翻页的顺序稳定。
+type ChatLogCursor struct {
+	Seq int64
+	Id  primitive.ObjectID
+}
+
+func NewChatLogCursor(chatLog *ChatLog) *ChatLogCursor {
+	return &ChatLogCursor{Seq: chatLog.Seq, Id: chatLog.ID}
+}
+
+// Encode 编码为客户端不需要解析的字符串
+func (c *ChatLogCursor) Encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(c.Seq, 10) + ":" + c.Id.Hex()))
+}
+
+// DecodeChatLogCursor 解析 Encode 编码的游标
+func DecodeChatLogCursor(s string) (*ChatLogCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	seq, id, ok := strings.Cut(string(b), ":")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+
+	var c ChatLogCursor
+	if c.Seq, err = strconv.ParseInt(seq, 10, 64); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if c.Id, err = primitive.ObjectIDFromHex(id); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// ConversationCursor 会话在用户会话列表中的位置
+//
+// 按置顶时间与最后一条消息的时间倒序排列，时间相同时以ID区分，保证翻页的顺序稳定。
+type ConversationCursor struct {
+	PinTime     int64
+	LastMsgTime int64
+	Id          string
+}
+
+func NewConversationCursor(conversation *UserConversation) *ConversationCursor {
+	return &ConversationCursor{PinTime: conversation.PinTime, LastMsgTime: conversation.LastMsgTime, Id: conversation.ID}
+}
+
+// Encode 编码为客户端不需要解析的字符串
+func (c *ConversationCursor) Encode() string {
+	s := strconv.FormatInt(c.PinTime, 10) + ":" + strconv.FormatInt(c.LastMsgTime, 10) + ":" + c.Id
+	return base64.RawURLEncoding.EncodeToString([]byte(s))
+}
+
+// DecodeConversationCursor 解析 Encode 编码的游标
+func DecodeConversationCursor(s string) (*ConversationCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	// 会话的ID由用户与会话组成，本身包含分隔符，只切分前两段
+	parts := strings.SplitN(string(b), ":", 3)
+	if len(parts) != 3 || parts[2] == "" {
+		return nil, ErrInvalidCursor
+	}
+
+	c := ConversationCursor{Id: parts[2]}
+	if c.PinTime, err = strconv.ParseInt(parts[0], 10, 64); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if c.LastMsgTime, err = strconv.ParseInt(parts[1], 10, 64); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
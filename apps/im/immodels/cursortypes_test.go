@@ -0,0 +1,43 @@
+package immodels
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestChatLogCursor(t *testing.T) {
+	c := &ChatLogCursor{Seq: 42, Id: primitive.NewObjectID()}
+
+	got, err := DecodeChatLogCursor(c.Encode())
+	if err != nil {
+		t.Fatalf("DecodeChatLogCursor() err = %v", err)
+	}
+	if *got != *c {
+		t.Errorf("DecodeChatLogCursor() = %v, want %v", got, c)
+	}
+
+	for _, s := range []string{"", "!!!", "NDI", "YTpi", "NDI6eHl6"} {
+		if _, err := DecodeChatLogCursor(s); err != ErrInvalidCursor {
+			t.Errorf("DecodeChatLogCursor(%q) err = %v, want ErrInvalidCursor", s, err)
+		}
+	}
+}
+
+func TestConversationCursor(t *testing.T) {
+	c := &ConversationCursor{PinTime: 0, LastMsgTime: 1700000000000, Id: "u1:u1_u2"}
+
+	got, err := DecodeConversationCursor(c.Encode())
+	if err != nil {
+		t.Fatalf("DecodeConversationCursor() err = %v", err)
+	}
+	if *got != *c {
+		t.Errorf("DecodeConversationCursor() = %v, want %v", got, c)
+	}
+
+	for _, s := range []string{"", "!!!", "MDox", "MDoxOg", "YToxOnU", "MDpiOnU"} {
+		if _, err := DecodeConversationCursor(s); err != ErrInvalidCursor {
+			t.Errorf("DecodeConversationCursor(%q) err = %v, want ErrInvalidCursor", s, err)
+		}
+	}
+}
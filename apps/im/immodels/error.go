@@ -4,9 +4,24 @@ import (
 	"errors"
 
 	"github.com/zeromicro/go-zero/core/stores/mon"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 var (
 	ErrNotFound        = mon.ErrNotFound
 	ErrInvalidObjectId = errors.New("invalid objectId")
 )
+
+// onlyDuplicateKey 批量写入的错误是否全部为重复的主键，即文档都已经写入过
+func onlyDuplicateKey(err error) bool {
+	var bwe mongo.BulkWriteException
+	if !errors.As(err, &bwe) || bwe.WriteConcernError != nil || len(bwe.WriteErrors) == 0 {
+		return false
+	}
+	for _, e := range bwe.WriteErrors {
+		if !mongo.IsDuplicateKeyError(e) {
+			return false
+		}
+	}
+	return true
+}
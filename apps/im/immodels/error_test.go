@@ -0,0 +1,44 @@
+package immodels
+
+import (
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestOnlyDuplicateKey(t *testing.T) {
+	writeErr := func(code int) mongo.BulkWriteError {
+		return mongo.BulkWriteError{WriteError: mongo.WriteError{Code: code}}
+	}
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "other error", err: errors.New("timeout")},
+		{
+			name: "all duplicate",
+			err:  mongo.BulkWriteException{WriteErrors: []mongo.BulkWriteError{writeErr(11000), writeErr(11000)}},
+			want: true,
+		},
+		{
+			name: "partly duplicate",
+			err:  mongo.BulkWriteException{WriteErrors: []mongo.BulkWriteError{writeErr(11000), writeErr(121)}},
+		},
+		{
+			name: "write concern",
+			err: mongo.BulkWriteException{
+				WriteErrors:       []mongo.BulkWriteError{writeErr(11000)},
+				WriteConcernError: &mongo.WriteConcernError{Code: 64},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := onlyDuplicateKey(tt.err); got != tt.want {
+				t.Errorf("onlyDuplicateKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package immodels
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/mon"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// indexTimeout 建立索引的超时时间
+const indexTimeout = time.Minute
+
+var (
+	// userConversationIndexes 用户的会话唯一，按会话更新所有成员的记录，按置顶与最后一条消息的时间分页用户的会话列表
+	userConversationIndexes = []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "userId", Value: 1}, {Key: "conversationId", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "conversationId", Value: 1}}},
+		{Keys: bson.D{
+			{Key: "userId", Value: 1}, {Key: "isShow", Value: 1}, {Key: "archived", Value: 1},
+			{Key: "pinTime", Value: -1}, {Key: "lastMsgTime", Value: -1}, {Key: "_id", Value: 1},
+		}},
+	}
+
+	// chatLogIndexes 按序号分页、统计未读，按序号分页话题中的回复，按附件查询引用的消息，按原消息更新回复与话题中的引用
+	chatLogIndexes = []mongo.IndexModel{
+		{Keys: bson.D{{Key: "conversationId", Value: 1}, {Key: "seq", Value: 1}}},
+		{Keys: bson.D{{Key: "threadId", Value: 1}, {Key: "seq", Value: 1}}},
+		{Keys: bson.D{{Key: "replyTo.msgId", Value: 1}}, Options: options.Index().SetSparse(true)},
+		{Keys: bson.D{{Key: "thread.lastReply.msgId", Value: 1}}, Options: options.Index().SetSparse(true)},
+		{Keys: bson.D{{Key: "body.image.attachmentId", Value: 1}}, Options: options.Index().SetSparse(true)},
+		{Keys: bson.D{{Key: "body.file.attachmentId", Value: 1}}, Options: options.Index().SetSparse(true)},
+		{Keys: bson.D{{Key: "body.voice.attachmentId", Value: 1}}, Options: options.Index().SetSparse(true)},
+	}
+
+	// reactionIndexes 用户对消息的同一表情只有一个回应，按消息与表情统计回应数
+	reactionIndexes = []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "msgId", Value: 1}, {Key: "userId", Value: 1}, {Key: "emoji", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "msgId", Value: 1}, {Key: "emoji", Value: 1}}},
+	}
+
+	// starIndexes 按收藏时间倒序分页用户的收藏，收藏的唯一性由 _id 保证
+	starIndexes = []mongo.IndexModel{
+		{Keys: bson.D{{Key: "userId", Value: 1}, {Key: "createAt", Value: -1}}},
+	}
+)
+
+// mustCreateIndexes 建立集合的索引，已经存在的同名索引不做修改，建立失败时退出
+func mustCreateIndexes(conn *mon.Model, indexes []mongo.IndexModel) {
+	ctx, cancel := context.WithTimeout(context.Background(), indexTimeout)
+	defer cancel()
+
+	_, err := conn.Indexes().CreateMany(ctx, indexes)
+	logx.Must(err)
+}
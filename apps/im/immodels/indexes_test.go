@@ -0,0 +1,59 @@
+package immodels
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// findIndex 按键的顺序查找索引
+func findIndex(indexes []mongo.IndexModel, keys ...string) (mongo.IndexModel, bool) {
+	for _, index := range indexes {
+		d := index.Keys.(bson.D)
+		if len(d) != len(keys) {
+			continue
+		}
+		match := true
+		for i, e := range d {
+			if e.Key != keys[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return index, true
+		}
+	}
+	return mongo.IndexModel{}, false
+}
+
+func TestIndexes(t *testing.T) {
+	tests := []struct {
+		name       string
+		indexes    []mongo.IndexModel
+		keys       []string
+		wantUnique bool
+	}{
+		{"user conversation unique", userConversationIndexes, []string{"userId", "conversationId"}, true},
+		{"user conversation members", userConversationIndexes, []string{"conversationId"}, false},
+		{"user conversation page", userConversationIndexes, []string{"userId", "isShow", "archived", "pinTime", "lastMsgTime", "_id"}, false},
+		{"chat log seq", chatLogIndexes, []string{"conversationId", "seq"}, false},
+		{"chat log thread", chatLogIndexes, []string{"threadId", "seq"}, false},
+		{"reaction unique", reactionIndexes, []string{"msgId", "userId", "emoji"}, true},
+		{"reaction count", reactionIndexes, []string{"msgId", "emoji"}, false},
+		{"star list", starIndexes, []string{"userId", "createAt"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index, ok := findIndex(tt.indexes, tt.keys...)
+			if !ok {
+				t.Fatalf("index %v not found", tt.keys)
+			}
+			unique := index.Options != nil && index.Options.Unique != nil && *index.Options.Unique
+			if unique != tt.wantUnique {
+				t.Errorf("index %v unique = %v, want %v", tt.keys, unique, tt.wantUnique)
+			}
+		})
+	}
+}
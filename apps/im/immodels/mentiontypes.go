@@ -0,0 +1,12 @@
+package immodels
+
+// Mention 群消息中提及的成员
+type Mention struct {
+	UserIds []string `bson:"userIds,omitempty" json:"userIds,omitempty" mapstructure:"userIds"` // 提及的成员
+	All     bool     `bson:"all,omitempty" json:"all,omitempty" mapstructure:"all"`             // 提及所有人，仅群主与管理员可用
+}
+
+// IsEmpty 是否没有提及任何成员
+func (m *Mention) IsEmpty() bool {
+	return m == nil || (!m.All && len(m.UserIds) == 0)
+}
@@ -0,0 +1,197 @@
+package immodels
+
+import (
+	"easy-chat/pkg/constants"
+	"errors"
+)
+
+var (
+	ErrMsgContentEmpty = errors.New("消息内容不能为空")
+	ErrMsgBodyMismatch = errors.New("消息内容与消息类型不匹配")
+	ErrMsgTypeUnknown  = errors.New("不支持的消息类型")
+)
+
+// MsgBody 非文本消息的结构化内容，按消息类型只设置对应的一项
+type MsgBody struct {
+	Image    *Image    `bson:"image,omitempty" json:"image,omitempty" mapstructure:"image"`
+	File     *File     `bson:"file,omitempty" json:"file,omitempty" mapstructure:"file"`
+	Voice    *Voice    `bson:"voice,omitempty" json:"voice,omitempty" mapstructure:"voice"`
+	Location *Location `bson:"location,omitempty" json:"location,omitempty" mapstructure:"location"`
+	Card     *Card     `bson:"card,omitempty" json:"card,omitempty" mapstructure:"card"`
+}
+
+// Image 图片消息，通过地址或 media 服务的附件ID引用图片
+type Image struct {
+	AttachmentId string `bson:"attachmentId,omitempty" json:"attachmentId,omitempty" mapstructure:"attachmentId"` // media 服务中的附件ID
+	Url          string `bson:"url" json:"url" mapstructure:"url"`
+	Thumbnail    string `bson:"thumbnail,omitempty" json:"thumbnail,omitempty" mapstructure:"thumbnail"` // 缩略图地址
+	Width        int32  `bson:"width" json:"width" mapstructure:"width"`
+	Height       int32  `bson:"height" json:"height" mapstructure:"height"`
+}
+
+// File 文件消息
+type File struct {
+	AttachmentId string `bson:"attachmentId,omitempty" json:"attachmentId,omitempty" mapstructure:"attachmentId"` // media 服务中的附件ID
+	Url          string `bson:"url" json:"url" mapstructure:"url"`
+	Name         string `bson:"name" json:"name" mapstructure:"name"`
+	Size         int64  `bson:"size" json:"size" mapstructure:"size"` // 文件大小：单位为字节
+	Mime         string `bson:"mime" json:"mime" mapstructure:"mime"`
+}
+
+// Voice 语音消息
+type Voice struct {
+	AttachmentId string `bson:"attachmentId,omitempty" json:"attachmentId,omitempty" mapstructure:"attachmentId"` // media 服务中的附件ID
+	Url          string `bson:"url" json:"url" mapstructure:"url"`
+	Duration     int32  `bson:"duration" json:"duration" mapstructure:"duration"` // 时长：单位为秒
+}
+
+// Location 位置消息
+type Location struct {
+	Latitude  float64 `bson:"latitude" json:"latitude" mapstructure:"latitude"`
+	Longitude float64 `bson:"longitude" json:"longitude" mapstructure:"longitude"`
+	Name      string  `bson:"name,omitempty" json:"name,omitempty" mapstructure:"name"`
+	Address   string  `bson:"address,omitempty" json:"address,omitempty" mapstructure:"address"`
+}
+
+// Card 名片消息，分享用户或群
+type Card struct {
+	CardType constants.CardType `bson:"cardType" json:"cardType" mapstructure:"cardType"`
+	TargetId string             `bson:"targetId" json:"targetId" mapstructure:"targetId"` // 用户ID或群ID
+	Name     string             `bson:"name,omitempty" json:"name,omitempty" mapstructure:"name"`
+	Avatar   string             `bson:"avatar,omitempty" json:"avatar,omitempty" mapstructure:"avatar"`
+}
+
+// ValidateMsg 校验消息类型与消息内容
+//
+// 文本消息使用 content，其他类型的消息使用 body 中与类型对应的一项，且不能设置其他项。
+func ValidateMsg(mType constants.MType, content string, body *MsgBody) error {
+	if mType == constants.TextMtype {
+		if content == "" {
+			return ErrMsgContentEmpty
+		}
+		if body != nil && body.count() > 0 {
+			return ErrMsgBodyMismatch
+		}
+		return nil
+	}
+
+	if body == nil || body.count() != 1 {
+		return ErrMsgBodyMismatch
+	}
+	switch mType {
+	case constants.ImageMtype:
+		if body.Image == nil {
+			return ErrMsgBodyMismatch
+		}
+		return body.Image.validate()
+	case constants.FileMtype:
+		if body.File == nil {
+			return ErrMsgBodyMismatch
+		}
+		return body.File.validate()
+	case constants.VoiceMtype:
+		if body.Voice == nil {
+			return ErrMsgBodyMismatch
+		}
+		return body.Voice.validate()
+	case constants.LocationMtype:
+		if body.Location == nil {
+			return ErrMsgBodyMismatch
+		}
+		return body.Location.validate()
+	case constants.CardMtype:
+		if body.Card == nil {
+			return ErrMsgBodyMismatch
+		}
+		return body.Card.validate()
+	}
+	return ErrMsgTypeUnknown
+}
+
+// AttachmentId 消息引用的 media 服务附件ID，没有引用附件时为空
+func (b *MsgBody) AttachmentId() string {
+	switch {
+	case b == nil:
+		return ""
+	case b.Image != nil:
+		return b.Image.AttachmentId
+	case b.File != nil:
+		return b.File.AttachmentId
+	case b.Voice != nil:
+		return b.Voice.AttachmentId
+	}
+	return ""
+}
+
+// count 已设置的内容项数
+func (b *MsgBody) count() int {
+	n := 0
+	if b.Image != nil {
+		n++
+	}
+	if b.File != nil {
+		n++
+	}
+	if b.Voice != nil {
+		n++
+	}
+	if b.Location != nil {
+		n++
+	}
+	if b.Card != nil {
+		n++
+	}
+	return n
+}
+
+func (i *Image) validate() error {
+	if i.Url == "" && i.AttachmentId == "" {
+		return errors.New("图片地址不能为空")
+	}
+	if i.Width <= 0 || i.Height <= 0 {
+		return errors.New("图片尺寸有误")
+	}
+	return nil
+}
+
+func (f *File) validate() error {
+	if (f.Url == "" && f.AttachmentId == "") || f.Name == "" {
+		return errors.New("文件地址与文件名不能为空")
+	}
+	if f.Size <= 0 {
+		return errors.New("文件大小有误")
+	}
+	if f.Mime == "" {
+		return errors.New("文件类型不能为空")
+	}
+	return nil
+}
+
+func (v *Voice) validate() error {
+	if v.Url == "" && v.AttachmentId == "" {
+		return errors.New("语音地址不能为空")
+	}
+	if v.Duration <= 0 {
+		return errors.New("语音时长有误")
+	}
+	return nil
+}
+
+func (l *Location) validate() error {
+	if l.Latitude < -90 || l.Latitude > 90 || l.Longitude < -180 || l.Longitude > 180 {
+		return errors.New("经纬度有误")
+	}
+	return nil
+}
+
+func (c *Card) validate() error {
+	switch c.CardType {
+	case constants.UserCardType, constants.GroupCardType:
+	default:
+		return errors.New("名片类型有误")
+	}
+	if c.TargetId == "" {
+		return errors.New("名片对象不能为空")
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package immodels
+
+import (
+	"easy-chat/pkg/constants"
+	"testing"
+)
+
+func TestValidateMsg(t *testing.T) {
+	tests := []struct {
+		name    string
+		mType   constants.MType
+		content string
+		body    *MsgBody
+		wantErr bool
+	}{
+		{"text", constants.TextMtype, "hello", nil, false},
+		{"empty text", constants.TextMtype, "", nil, true},
+		{"text with body", constants.TextMtype, "hello", &MsgBody{Voice: &Voice{Url: "v", Duration: 1}}, true},
+		{"image", constants.ImageMtype, "", &MsgBody{Image: &Image{Url: "i", Width: 10, Height: 20}}, false},
+		{"image without size", constants.ImageMtype, "", &MsgBody{Image: &Image{Url: "i"}}, true},
+		{"image by attachment", constants.ImageMtype, "", &MsgBody{Image: &Image{AttachmentId: "a", Width: 10, Height: 20}}, false},
+		{"image without url", constants.ImageMtype, "", &MsgBody{Image: &Image{Width: 10, Height: 20}}, true},
+		{"image with file body", constants.ImageMtype, "", &MsgBody{File: &File{Url: "f", Name: "a.txt", Size: 1, Mime: "text/plain"}}, true},
+		{"file", constants.FileMtype, "", &MsgBody{File: &File{Url: "f", Name: "a.txt", Size: 1, Mime: "text/plain"}}, false},
+		{"file without size", constants.FileMtype, "", &MsgBody{File: &File{Url: "f", Name: "a.txt", Mime: "text/plain"}}, true},
+		{"voice", constants.VoiceMtype, "", &MsgBody{Voice: &Voice{Url: "v", Duration: 3}}, false},
+		{"location", constants.LocationMtype, "", &MsgBody{Location: &Location{Latitude: 30.5, Longitude: 114.3}}, false},
+		{"location out of range", constants.LocationMtype, "", &MsgBody{Location: &Location{Latitude: 91}}, true},
+		{"user card", constants.CardMtype, "", &MsgBody{Card: &Card{CardType: constants.UserCardType, TargetId: "u1"}}, false},
+		{"unknown card", constants.CardMtype, "", &MsgBody{Card: &Card{TargetId: "u1"}}, true},
+		{"two bodies", constants.VoiceMtype, "", &MsgBody{
+			Voice: &Voice{Url: "v", Duration: 3},
+			Card:  &Card{CardType: constants.UserCardType, TargetId: "u1"},
+		}, true},
+		{"missing body", constants.ImageMtype, "", nil, true},
+		{"unknown type", constants.MType(99), "", &MsgBody{Voice: &Voice{Url: "v", Duration: 3}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateMsg(tt.mType, tt.content, tt.body); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateMsg() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
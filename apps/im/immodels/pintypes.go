@@ -0,0 +1,12 @@
+package immodels
+
+// DefaultMaxPins 未配置时每个会话最多置顶的消息数
+var DefaultMaxPins = 20
+
+// Pin 会话中置顶的消息，保存在会话上，会话成员都可以看到
+type Pin struct {
+	MsgId    string `bson:"msgId" json:"msgId" mapstructure:"msgId"`
+	Seq      int64  `bson:"seq" json:"seq" mapstructure:"seq"`
+	PinnedBy string `bson:"pinnedBy" json:"pinnedBy" mapstructure:"pinnedBy"` // 置顶的用户
+	PinTime  int64  `bson:"pinTime" json:"pinTime" mapstructure:"pinTime"`    // 置顶的时间戳
+}
@@ -0,0 +1,70 @@
+package immodels
+
+import (
+	"easy-chat/pkg/constants"
+)
+
+// QuoteSnippetLen 引用摘要的最大字符数
+var QuoteSnippetLen = 50
+
+// Quote 回复消息时引用的原消息
+//
+// 摘要冗余保存在回复中，查询聊天记录时无需再查询原消息；原消息撤回或编辑后同步更新。
+type Quote struct {
+	MsgId   string              `bson:"msgId" json:"msgId" mapstructure:"msgId"`
+	SendId  string              `bson:"sendId" json:"sendId" mapstructure:"sendId"`
+	MsgType constants.MType     `bson:"msgType" json:"msgType" mapstructure:"msgType"`
+	Snippet string              `bson:"snippet" json:"snippet" mapstructure:"snippet"` // 原消息的摘要
+	Status  constants.MsgStatus `bson:"status" json:"status" mapstructure:"status"`    // 原消息的状态
+}
+
+// NewQuote 根据原消息生成引用
+func NewQuote(chatLog *ChatLog) *Quote {
+	return &Quote{
+		MsgId:   chatLog.ID.Hex(),
+		SendId:  chatLog.SendId,
+		MsgType: chatLog.MsgType,
+		Snippet: Snippet(chatLog),
+		Status:  chatLog.Status,
+	}
+}
+
+// Snippet 消息的摘要，文本消息截取前 QuoteSnippetLen 个字符，其他消息使用类型的占位文字
+func Snippet(chatLog *ChatLog) string {
+	if chatLog.Status == constants.RecallMsgStatus {
+		return ""
+	}
+
+	body := chatLog.Body
+	if body == nil {
+		body = &MsgBody{}
+	}
+	switch chatLog.MsgType {
+	case constants.TextMtype:
+		content := []rune(chatLog.MsgContent)
+		if len(content) > QuoteSnippetLen {
+			return string(content[:QuoteSnippetLen]) + "..."
+		}
+		return string(content)
+	case constants.ImageMtype:
+		return "[图片]"
+	case constants.FileMtype:
+		if body.File != nil {
+			return "[文件] " + body.File.Name
+		}
+		return "[文件]"
+	case constants.VoiceMtype:
+		return "[语音]"
+	case constants.LocationMtype:
+		if body.Location != nil && body.Location.Name != "" {
+			return "[位置] " + body.Location.Name
+		}
+		return "[位置]"
+	case constants.CardMtype:
+		if body.Card != nil && body.Card.Name != "" {
+			return "[名片] " + body.Card.Name
+		}
+		return "[名片]"
+	}
+	return ""
+}
@@ -0,0 +1,30 @@
+package immodels
+
+import (
+	"easy-chat/pkg/constants"
+	"strings"
+	"testing"
+)
+
+func TestSnippet(t *testing.T) {
+	long := strings.Repeat("消", QuoteSnippetLen+1)
+	tests := []struct {
+		name    string
+		chatLog *ChatLog
+		want    string
+	}{
+		{"text", &ChatLog{MsgType: constants.TextMtype, MsgContent: "hello"}, "hello"},
+		{"long text", &ChatLog{MsgType: constants.TextMtype, MsgContent: long}, long[:len(long)-len("消")] + "..."},
+		{"image", &ChatLog{MsgType: constants.ImageMtype, Body: &MsgBody{Image: &Image{Url: "i"}}}, "[图片]"},
+		{"file", &ChatLog{MsgType: constants.FileMtype, Body: &MsgBody{File: &File{Name: "a.txt"}}}, "[文件] a.txt"},
+		{"location without body", &ChatLog{MsgType: constants.LocationMtype}, "[位置]"},
+		{"recalled", &ChatLog{MsgType: constants.TextMtype, MsgContent: "hello", Status: constants.RecallMsgStatus}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Snippet(tt.chatLog); got != tt.want {
+				t.Errorf("Snippet() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
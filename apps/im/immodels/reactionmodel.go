@@ -0,0 +1,30 @@
+package immodels
+
+import "github.com/zeromicro/go-zero/core/stores/mon"
+
+var _ ReactionModel = (*customReactionModel)(nil)
+
+type (
+	// ReactionModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customReactionModel.
+	ReactionModel interface {
+		reactionModel
+	}
+
+	customReactionModel struct {
+		*defaultReactionModel
+	}
+)
+
+// NewReactionModel returns a model for the mongo.
+func NewReactionModel(url, db, collection string) ReactionModel {
+	conn := mon.MustNewModel(url, db, collection)
+	mustCreateIndexes(conn, reactionIndexes)
+	return &customReactionModel{
+		defaultReactionModel: newDefaultReactionModel(conn),
+	}
+}
+
+func MustReactionModel(url, db string) ReactionModel {
+	return NewReactionModel(url, db, "reaction")
+}
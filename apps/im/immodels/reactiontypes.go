@@ -0,0 +1,30 @@
+package immodels
+
+import (
+	"time"
+)
+
+// Reaction 用户对消息的一个表情回应
+//
+// 每个回应单独保存，ID 由消息、用户与表情组成，并发的添加与取消互不覆盖。
+type Reaction struct {
+	ID string `bson:"_id,omitempty" json:"id,omitempty"`
+
+	MsgId          string `bson:"msgId"`
+	ConversationId string `bson:"conversationId"`
+	UserId         string `bson:"userId"`
+	Emoji          string `bson:"emoji"`
+
+	CreateAt time.Time `bson:"createAt,omitempty" json:"createAt,omitempty"`
+}
+
+// ReactionCount 消息上一个表情的统计
+type ReactionCount struct {
+	Emoji   string `bson:"emoji"`
+	Count   int64  `bson:"count"`
+	Reacted bool   `bson:"reacted"` // 当前用户是否回应过
+}
+
+func reactionId(msgId, uid, emoji string) string {
+	return msgId + ":" + uid + ":" + emoji
+}
@@ -0,0 +1,29 @@
+package immodels
+
+import "github.com/zeromicro/go-zero/core/stores/mon"
+
+var _ ScheduledMsgModel = (*customScheduledMsgModel)(nil)
+
+type (
+	// ScheduledMsgModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customScheduledMsgModel.
+	ScheduledMsgModel interface {
+		scheduledMsgModel
+	}
+
+	customScheduledMsgModel struct {
+		*defaultScheduledMsgModel
+	}
+)
+
+// NewScheduledMsgModel returns a model for the mongo.
+func NewScheduledMsgModel(url, db, collection string) ScheduledMsgModel {
+	conn := mon.MustNewModel(url, db, collection)
+	return &customScheduledMsgModel{
+		defaultScheduledMsgModel: newDefaultScheduledMsgModel(conn),
+	}
+}
+
+func MustScheduledMsgModel(url, db string) ScheduledMsgModel {
+	return NewScheduledMsgModel(url, db, "scheduled_msg")
+}
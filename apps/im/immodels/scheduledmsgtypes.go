@@ -0,0 +1,42 @@
+package immodels
+
+import (
+	"easy-chat/pkg/constants"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// MaxPendingScheduledMsgs 用户等待发送的定时消息数上限
+	MaxPendingScheduledMsgs = 100
+	// MaxScheduleAhead 定时消息最多提前设置的时间
+	MaxScheduleAhead = 30 * 24 * time.Hour
+)
+
+// ScheduledMsg 用户设置的定时消息，到期后由 task 服务以用户的身份发送
+//
+// 状态的流转：等待发送 -> 发送中 -> 已发送或发送失败；等待发送与发送失败的消息可以取消。
+// 发送中的消息在领取超时后可以被重新领取，ChatLogId 在创建时预先分配，重复投递的消息由消费者去重；
+// 投递之前记录 PushTime，记录之后消息可能已经发出，重新领取时直接重新投递，不会再变为发送失败。
+type ScheduledMsg struct {
+	ID primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+
+	SendId             string `bson:"sendId"`
+	ConversationId     string `bson:"conversationId"`
+	RecvId             string `bson:"recvId"`
+	constants.ChatType `bson:"chatType"`
+	MsgType            constants.MType `bson:"msgType"`
+	MsgContent         string          `bson:"msgContent"`
+	Body               *MsgBody        `bson:"body,omitempty"`
+	SendTime           int64           `bson:"sendTime"` // 计划发送的毫秒时间戳
+
+	Status    constants.ScheduledMsgStatus `bson:"status"`
+	ChatLogId string                       `bson:"chatLogId"`           // 发送后的聊天记录ID
+	ClaimTime int64                        `bson:"claimTime,omitempty"` // 最近一次被领取发送的毫秒时间戳
+	PushTime  int64                        `bson:"pushTime,omitempty"`  // 开始投递到消息队列的毫秒时间戳
+	Reason    string                       `bson:"reason,omitempty"`    // 发送失败的原因
+
+	UpdateAt time.Time `bson:"updateAt,omitempty" json:"updateAt,omitempty"`
+	CreateAt time.Time `bson:"createAt,omitempty" json:"createAt,omitempty"`
+}
@@ -0,0 +1,30 @@
+package immodels
+
+import "github.com/zeromicro/go-zero/core/stores/mon"
+
+var _ StarModel = (*customStarModel)(nil)
+
+type (
+	// StarModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customStarModel.
+	StarModel interface {
+		starModel
+	}
+
+	customStarModel struct {
+		*defaultStarModel
+	}
+)
+
+// NewStarModel returns a model for the mongo.
+func NewStarModel(url, db, collection string) StarModel {
+	conn := mon.MustNewModel(url, db, collection)
+	mustCreateIndexes(conn, starIndexes)
+	return &customStarModel{
+		defaultStarModel: newDefaultStarModel(conn),
+	}
+}
+
+func MustStarModel(url, db string) StarModel {
+	return NewStarModel(url, db, "star")
+}
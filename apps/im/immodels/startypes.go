@@ -0,0 +1,22 @@
+package immodels
+
+import (
+	"time"
+)
+
+// Star 用户收藏的消息，仅收藏者本人可见
+//
+// ID 由用户与消息组成，重复收藏不会产生多条记录。
+type Star struct {
+	ID string `bson:"_id,omitempty" json:"id,omitempty"`
+
+	UserId         string `bson:"userId"`
+	MsgId          string `bson:"msgId"`
+	ConversationId string `bson:"conversationId"`
+
+	CreateAt time.Time `bson:"createAt,omitempty" json:"createAt,omitempty"`
+}
+
+func starId(uid, msgId string) string {
+	return uid + ":" + msgId
+}
@@ -0,0 +1,8 @@
+package immodels
+
+// Thread 话题的统计，保存在话题的第一条消息上
+type Thread struct {
+	ReplyCount    int64  `bson:"replyCount" json:"replyCount" mapstructure:"replyCount"`                  // 回复数
+	LastReply     *Quote `bson:"lastReply,omitempty" json:"lastReply,omitempty" mapstructure:"lastReply"` // 最后一条回复的摘要
+	LastReplyTime int64  `bson:"lastReplyTime" json:"lastReplyTime" mapstructure:"lastReplyTime"`         // 最后一条回复的时间戳
+}
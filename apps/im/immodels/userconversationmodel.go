@@ -0,0 +1,30 @@
+package immodels
+
+import "github.com/zeromicro/go-zero/core/stores/mon"
+
+var _ UserConversationModel = (*customUserConversationModel)(nil)
+
+type (
+	// UserConversationModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customUserConversationModel.
+	UserConversationModel interface {
+		userConversationModel
+	}
+
+	customUserConversationModel struct {
+		*defaultUserConversationModel
+	}
+)
+
+// NewUserConversationModel returns a model for the mongo.
+func NewUserConversationModel(url, db, collection string) UserConversationModel {
+	conn := mon.MustNewModel(url, db, collection)
+	mustCreateIndexes(conn, userConversationIndexes)
+	return &customUserConversationModel{
+		defaultUserConversationModel: newDefaultUserConversationModel(conn),
+	}
+}
+
+func MustUserConversationModel(url, db string) UserConversationModel {
+	return NewUserConversationModel(url, db, "user_conversation")
+}
@@ -0,0 +1,66 @@
+package immodels
+
+import (
+	"easy-chat/pkg/constants"
+	"time"
+)
+
+// UserConversation 用户在一个会话中的状态，每个用户与会话一条记录
+//
+// ID 由用户与会话组成，各字段以原子操作单独更新，不再整体读写用户的会话列表。
+type UserConversation struct {
+	ID string `bson:"_id,omitempty" json:"id,omitempty"`
+
+	UserId         string             `bson:"userId"`
+	ConversationId string             `bson:"conversationId"`
+	ChatType       constants.ChatType `bson:"chatType"`
+	// 是否在会话列表中展示
+	IsShow bool `bson:"isShow"`
+	// 用户已读的消息数
+	Total int `bson:"total"`
+	// 用户已读到的消息序号
+	ReadSeq int64 `bson:"readSeq"`
+	// 最近一条提及用户的消息序号，大于已读水位时表示用户被提及
+	MentionSeq int64 `bson:"mentionSeq"`
+	// 未读消息数，不包含用户自己发送的消息
+	Unread int64 `bson:"unread"`
+	// 置顶会话的时间，为 0 表示未置顶，多个置顶的会话按置顶时间倒序排列
+	PinTime int64 `bson:"pinTime"`
+	// 免打扰的截止时间，为 0 表示未设置，为 MuteForever 表示一直免打扰
+	MuteUntil int64 `bson:"muteUntil"`
+	// 是否归档
+	Archived bool `bson:"archived"`
+	// 清空聊天记录时会话的最新序号，用户只能看到序号大于该值的消息
+	ClearSeq int64 `bson:"clearSeq"`
+	// 最后一条消息的发送时间，会话列表按该时间倒序排列
+	LastMsgTime int64 `bson:"lastMsgTime"`
+	// 未发送的草稿，在用户的多个设备间同步
+	Draft string `bson:"draft,omitempty"`
+	// 保存草稿的时间，用户在该时间之后发送消息时清除草稿
+	DraftTime int64 `bson:"draftTime,omitempty"`
+	// 迁移旧版本消息时水位后移的位数，避免重复执行迁移时再次后移
+	SeqShift int64 `bson:"seqShift,omitempty"`
+
+	UpdateAt time.Time `bson:"updateAt,omitempty" json:"updateAt,omitempty"`
+	CreateAt time.Time `bson:"createAt,omitempty" json:"createAt,omitempty"`
+}
+
+// MuteForever 一直免打扰
+const MuteForever int64 = -1
+
+// MaxDraftLength 草稿的最大长度，按字符计算
+const MaxDraftLength = 4096
+
+func userConversationId(uid, conversationId string) string {
+	return uid + ":" + conversationId
+}
+
+// Mentioned 已读水位是否还未到达最近一次提及
+func (c *UserConversation) Mentioned() bool {
+	return c.MentionSeq > c.ReadSeq
+}
+
+// Muted 在 now 时是否处于免打扰
+func (c *UserConversation) Muted(now time.Time) bool {
+	return c.MuteUntil == MuteForever || c.MuteUntil > now.UnixMilli()
+}
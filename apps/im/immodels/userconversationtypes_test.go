@@ -0,0 +1,27 @@
+package immodels
+
+import (
+	"testing"
+)
+
+func TestUserConversation_Mentioned(t *testing.T) {
+	tests := []struct {
+		name                string
+		mentionSeq, readSeq int64
+		want                bool
+	}{
+		{"never mentioned", 0, 0, false},
+		{"unread mention", 5, 3, true},
+		// 已读水位到达或越过提及的消息后不再提示
+		{"read to mention", 5, 5, false},
+		{"read past mention", 5, 8, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &UserConversation{MentionSeq: tt.mentionSeq, ReadSeq: tt.readSeq}
+			if got := c.Mentioned(); got != tt.want {
+				t.Errorf("Mentioned() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"easy-chat/apps/im/rpc/internal/indexer"
 	"easy-chat/pkg/intercepter/rpcserver"
 	"flag"
 	"fmt"
@@ -35,8 +37,22 @@ func main() {
 	})
 	//增加拦截器
 	s.AddUnaryInterceptors(rpcserver.LogInterceptor)
-	defer s.Stop()
+
+	//建立全文检索的索引
+	idx := indexer.NewIndexer(ctx)
+	if c.Search.Rebuild {
+		if err := idx.Rebuild(context.Background()); err != nil {
+			panic(err)
+		}
+	}
+
+	serviceGroup := service.NewServiceGroup()
+	defer serviceGroup.Stop()
+	serviceGroup.Add(s)
+	for _, srv := range idx.Services() {
+		serviceGroup.Add(srv)
+	}
 
 	fmt.Printf("Starting rpc server at %s...\n", c.ListenOn)
-	s.Start()
+	serviceGroup.Start()
 }
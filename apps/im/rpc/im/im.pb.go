@@ -20,24 +20,20 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-type ChatLog struct {
+type Image struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id             string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	ConversationId string `protobuf:"bytes,2,opt,name=conversationId,proto3" json:"conversationId,omitempty"`
-	SendId         string `protobuf:"bytes,3,opt,name=sendId,proto3" json:"sendId,omitempty"`
-	RecvId         string `protobuf:"bytes,4,opt,name=recvId,proto3" json:"recvId,omitempty"`
-	MsgType        int32  `protobuf:"varint,5,opt,name=msgType,proto3" json:"msgType,omitempty"`
-	MsgContent     string `protobuf:"bytes,6,opt,name=msgContent,proto3" json:"msgContent,omitempty"`
-	ChatType       int32  `protobuf:"varint,7,opt,name=chatType,proto3" json:"chatType,omitempty"`
-	SendTime       int64  `protobuf:"varint,8,opt,name=SendTime,proto3" json:"SendTime,omitempty"`
-	ReadRecords    []byte `protobuf:"bytes,9,opt,name=readRecords,proto3" json:"readRecords,omitempty"`
+	Url          string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Thumbnail    string `protobuf:"bytes,2,opt,name=thumbnail,proto3" json:"thumbnail,omitempty"`
+	Width        int32  `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`
+	Height       int32  `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	AttachmentId string `protobuf:"bytes,5,opt,name=attachmentId,proto3" json:"attachmentId,omitempty"`
 }
 
-func (x *ChatLog) Reset() {
-	*x = ChatLog{}
+func (x *Image) Reset() {
+	*x = Image{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_apps_im_rpc_im_proto_msgTypes[0]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -45,13 +41,13 @@ func (x *ChatLog) Reset() {
 	}
 }
 
-func (x *ChatLog) String() string {
+func (x *Image) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChatLog) ProtoMessage() {}
+func (*Image) ProtoMessage() {}
 
-func (x *ChatLog) ProtoReflect() protoreflect.Message {
+func (x *Image) ProtoReflect() protoreflect.Message {
 	mi := &file_apps_im_rpc_im_proto_msgTypes[0]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -63,95 +59,60 @@ func (x *ChatLog) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChatLog.ProtoReflect.Descriptor instead.
-func (*ChatLog) Descriptor() ([]byte, []int) {
+// Deprecated: Use Image.ProtoReflect.Descriptor instead.
+func (*Image) Descriptor() ([]byte, []int) {
 	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *ChatLog) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *ChatLog) GetConversationId() string {
-	if x != nil {
-		return x.ConversationId
-	}
-	return ""
-}
-
-func (x *ChatLog) GetSendId() string {
-	if x != nil {
-		return x.SendId
-	}
-	return ""
-}
-
-func (x *ChatLog) GetRecvId() string {
+func (x *Image) GetUrl() string {
 	if x != nil {
-		return x.RecvId
+		return x.Url
 	}
 	return ""
 }
 
-func (x *ChatLog) GetMsgType() int32 {
-	if x != nil {
-		return x.MsgType
-	}
-	return 0
-}
-
-func (x *ChatLog) GetMsgContent() string {
+func (x *Image) GetThumbnail() string {
 	if x != nil {
-		return x.MsgContent
+		return x.Thumbnail
 	}
 	return ""
 }
 
-func (x *ChatLog) GetChatType() int32 {
+func (x *Image) GetWidth() int32 {
 	if x != nil {
-		return x.ChatType
+		return x.Width
 	}
 	return 0
 }
 
-func (x *ChatLog) GetSendTime() int64 {
+func (x *Image) GetHeight() int32 {
 	if x != nil {
-		return x.SendTime
+		return x.Height
 	}
 	return 0
 }
 
-func (x *ChatLog) GetReadRecords() []byte {
+func (x *Image) GetAttachmentId() string {
 	if x != nil {
-		return x.ReadRecords
+		return x.AttachmentId
 	}
-	return nil
+	return ""
 }
 
-type Conversation struct {
+type File struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ConversationId string `protobuf:"bytes,1,opt,name=conversationId,proto3" json:"conversationId,omitempty"`
-	ChatType       int32  `protobuf:"varint,2,opt,name=chatType,proto3" json:"chatType,omitempty"`
-	TargetId       string `protobuf:"bytes,3,opt,name=targetId,proto3" json:"targetId,omitempty"`
-	IsShow         bool   `protobuf:"varint,4,opt,name=isShow,proto3" json:"isShow,omitempty"`
-	Seq            int64  `protobuf:"varint,5,opt,name=seq,proto3" json:"seq,omitempty"`
-	// 总消息数
-	Total int32 `protobuf:"varint,6,opt,name=total,proto3" json:"total,omitempty"`
-	// 未读消息数
-	ToRead int32 `protobuf:"varint,7,opt,name=toRead,proto3" json:"toRead,omitempty"`
-	// 已读消息
-	Read int32    `protobuf:"varint,9,opt,name=Read,proto3" json:"Read,omitempty"`
-	Msg  *ChatLog `protobuf:"bytes,8,opt,name=msg,proto3" json:"msg,omitempty"`
+	Url          string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Name         string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Size         int64  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Mime         string `protobuf:"bytes,4,opt,name=mime,proto3" json:"mime,omitempty"`
+	AttachmentId string `protobuf:"bytes,5,opt,name=attachmentId,proto3" json:"attachmentId,omitempty"`
 }
 
-func (x *Conversation) Reset() {
-	*x = Conversation{}
+func (x *File) Reset() {
+	*x = File{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_apps_im_rpc_im_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -159,13 +120,13 @@ func (x *Conversation) Reset() {
 	}
 }
 
-func (x *Conversation) String() string {
+func (x *File) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Conversation) ProtoMessage() {}
+func (*File) ProtoMessage() {}
 
-func (x *Conversation) ProtoReflect() protoreflect.Message {
+func (x *File) ProtoReflect() protoreflect.Message {
 	mi := &file_apps_im_rpc_im_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -177,84 +138,59 @@ func (x *Conversation) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Conversation.ProtoReflect.Descriptor instead.
-func (*Conversation) Descriptor() ([]byte, []int) {
+// Deprecated: Use File.ProtoReflect.Descriptor instead.
+func (*File) Descriptor() ([]byte, []int) {
 	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *Conversation) GetConversationId() string {
+func (x *File) GetUrl() string {
 	if x != nil {
-		return x.ConversationId
+		return x.Url
 	}
 	return ""
 }
 
-func (x *Conversation) GetChatType() int32 {
-	if x != nil {
-		return x.ChatType
-	}
-	return 0
-}
-
-func (x *Conversation) GetTargetId() string {
+func (x *File) GetName() string {
 	if x != nil {
-		return x.TargetId
+		return x.Name
 	}
 	return ""
 }
 
-func (x *Conversation) GetIsShow() bool {
-	if x != nil {
-		return x.IsShow
-	}
-	return false
-}
-
-func (x *Conversation) GetSeq() int64 {
-	if x != nil {
-		return x.Seq
-	}
-	return 0
-}
-
-func (x *Conversation) GetTotal() int32 {
-	if x != nil {
-		return x.Total
-	}
-	return 0
-}
-
-func (x *Conversation) GetToRead() int32 {
+func (x *File) GetSize() int64 {
 	if x != nil {
-		return x.ToRead
+		return x.Size
 	}
 	return 0
 }
 
-func (x *Conversation) GetRead() int32 {
+func (x *File) GetMime() string {
 	if x != nil {
-		return x.Read
+		return x.Mime
 	}
-	return 0
+	return ""
 }
 
-func (x *Conversation) GetMsg() *ChatLog {
+func (x *File) GetAttachmentId() string {
 	if x != nil {
-		return x.Msg
+		return x.AttachmentId
 	}
-	return nil
+	return ""
 }
 
-type GetConversationsReq struct {
+type Voice struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserId string `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// 时长：单位为秒
+	Duration     int32  `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"`
+	AttachmentId string `protobuf:"bytes,3,opt,name=attachmentId,proto3" json:"attachmentId,omitempty"`
 }
 
-func (x *GetConversationsReq) Reset() {
-	*x = GetConversationsReq{}
+func (x *Voice) Reset() {
+	*x = Voice{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_apps_im_rpc_im_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -262,13 +198,13 @@ func (x *GetConversationsReq) Reset() {
 	}
 }
 
-func (x *GetConversationsReq) String() string {
+func (x *Voice) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetConversationsReq) ProtoMessage() {}
+func (*Voice) ProtoMessage() {}
 
-func (x *GetConversationsReq) ProtoReflect() protoreflect.Message {
+func (x *Voice) ProtoReflect() protoreflect.Message {
 	mi := &file_apps_im_rpc_im_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -280,28 +216,45 @@ func (x *GetConversationsReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetConversationsReq.ProtoReflect.Descriptor instead.
-func (*GetConversationsReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use Voice.ProtoReflect.Descriptor instead.
+func (*Voice) Descriptor() ([]byte, []int) {
 	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *GetConversationsReq) GetUserId() string {
+func (x *Voice) GetUrl() string {
 	if x != nil {
-		return x.UserId
+		return x.Url
 	}
 	return ""
 }
 
-type GetConversationsResp struct {
+func (x *Voice) GetDuration() int32 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *Voice) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+type Location struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ConversationList map[string]*Conversation `protobuf:"bytes,2,rep,name=conversationList,proto3" json:"conversationList,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Latitude  float64 `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude float64 `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Name      string  `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Address   string  `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
 }
 
-func (x *GetConversationsResp) Reset() {
-	*x = GetConversationsResp{}
+func (x *Location) Reset() {
+	*x = Location{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_apps_im_rpc_im_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -309,13 +262,13 @@ func (x *GetConversationsResp) Reset() {
 	}
 }
 
-func (x *GetConversationsResp) String() string {
+func (x *Location) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetConversationsResp) ProtoMessage() {}
+func (*Location) ProtoMessage() {}
 
-func (x *GetConversationsResp) ProtoReflect() protoreflect.Message {
+func (x *Location) ProtoReflect() protoreflect.Message {
 	mi := &file_apps_im_rpc_im_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -327,30 +280,53 @@ func (x *GetConversationsResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetConversationsResp.ProtoReflect.Descriptor instead.
-func (*GetConversationsResp) Descriptor() ([]byte, []int) {
+// Deprecated: Use Location.ProtoReflect.Descriptor instead.
+func (*Location) Descriptor() ([]byte, []int) {
 	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GetConversationsResp) GetConversationList() map[string]*Conversation {
+func (x *Location) GetLatitude() float64 {
 	if x != nil {
-		return x.ConversationList
+		return x.Latitude
 	}
-	return nil
+	return 0
 }
 
-type PutConversationsReq struct {
+func (x *Location) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *Location) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Location) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type Card struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id               string                   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId           string                   `protobuf:"bytes,2,opt,name=userId,proto3" json:"userId,omitempty"`
-	ConversationList map[string]*Conversation `protobuf:"bytes,3,rep,name=conversationList,proto3" json:"conversationList,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// 名片类型 1. 用户 2. 群
+	CardType int32  `protobuf:"varint,1,opt,name=cardType,proto3" json:"cardType,omitempty"`
+	TargetId string `protobuf:"bytes,2,opt,name=targetId,proto3" json:"targetId,omitempty"`
+	Name     string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Avatar   string `protobuf:"bytes,4,opt,name=avatar,proto3" json:"avatar,omitempty"`
 }
 
-func (x *PutConversationsReq) Reset() {
-	*x = PutConversationsReq{}
+func (x *Card) Reset() {
+	*x = Card{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_apps_im_rpc_im_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -358,13 +334,13 @@ func (x *PutConversationsReq) Reset() {
 	}
 }
 
-func (x *PutConversationsReq) String() string {
+func (x *Card) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PutConversationsReq) ProtoMessage() {}
+func (*Card) ProtoMessage() {}
 
-func (x *PutConversationsReq) ProtoReflect() protoreflect.Message {
+func (x *Card) ProtoReflect() protoreflect.Message {
 	mi := &file_apps_im_rpc_im_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -376,40 +352,54 @@ func (x *PutConversationsReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PutConversationsReq.ProtoReflect.Descriptor instead.
-func (*PutConversationsReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use Card.ProtoReflect.Descriptor instead.
+func (*Card) Descriptor() ([]byte, []int) {
 	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *PutConversationsReq) GetId() string {
+func (x *Card) GetCardType() int32 {
 	if x != nil {
-		return x.Id
+		return x.CardType
+	}
+	return 0
+}
+
+func (x *Card) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
 	}
 	return ""
 }
 
-func (x *PutConversationsReq) GetUserId() string {
+func (x *Card) GetName() string {
 	if x != nil {
-		return x.UserId
+		return x.Name
 	}
 	return ""
 }
 
-func (x *PutConversationsReq) GetConversationList() map[string]*Conversation {
+func (x *Card) GetAvatar() string {
 	if x != nil {
-		return x.ConversationList
+		return x.Avatar
 	}
-	return nil
+	return ""
 }
 
-type PutConversationsResp struct {
+// 非文本消息的结构化内容，按消息类型只设置对应的一项
+type MsgBody struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Image    *Image    `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	File     *File     `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`
+	Voice    *Voice    `protobuf:"bytes,3,opt,name=voice,proto3" json:"voice,omitempty"`
+	Location *Location `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	Card     *Card     `protobuf:"bytes,5,opt,name=card,proto3" json:"card,omitempty"`
 }
 
-func (x *PutConversationsResp) Reset() {
-	*x = PutConversationsResp{}
+func (x *MsgBody) Reset() {
+	*x = MsgBody{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_apps_im_rpc_im_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -417,13 +407,13 @@ func (x *PutConversationsResp) Reset() {
 	}
 }
 
-func (x *PutConversationsResp) String() string {
+func (x *MsgBody) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PutConversationsResp) ProtoMessage() {}
+func (*MsgBody) ProtoMessage() {}
 
-func (x *PutConversationsResp) ProtoReflect() protoreflect.Message {
+func (x *MsgBody) ProtoReflect() protoreflect.Message {
 	mi := &file_apps_im_rpc_im_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -435,25 +425,59 @@ func (x *PutConversationsResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PutConversationsResp.ProtoReflect.Descriptor instead.
-func (*PutConversationsResp) Descriptor() ([]byte, []int) {
+// Deprecated: Use MsgBody.ProtoReflect.Descriptor instead.
+func (*MsgBody) Descriptor() ([]byte, []int) {
 	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{5}
 }
 
-type GetChatLogReq struct {
+func (x *MsgBody) GetImage() *Image {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+func (x *MsgBody) GetFile() *File {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+func (x *MsgBody) GetVoice() *Voice {
+	if x != nil {
+		return x.Voice
+	}
+	return nil
+}
+
+func (x *MsgBody) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *MsgBody) GetCard() *Card {
+	if x != nil {
+		return x.Card
+	}
+	return nil
+}
+
+// 群消息中提及的成员
+type Mention struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ConversationId string `protobuf:"bytes,1,opt,name=conversationId,proto3" json:"conversationId,omitempty"`
-	StartSendTime  int64  `protobuf:"varint,2,opt,name=startSendTime,proto3" json:"startSendTime,omitempty"`
-	EndSendTime    int64  `protobuf:"varint,3,opt,name=endSendTime,proto3" json:"endSendTime,omitempty"`
-	Count          int64  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
-	MsgId          string `protobuf:"bytes,5,opt,name=msgId,proto3" json:"msgId,omitempty"`
+	UserIds []string `protobuf:"bytes,1,rep,name=userIds,proto3" json:"userIds,omitempty"`
+	// 提及所有人
+	All bool `protobuf:"varint,2,opt,name=all,proto3" json:"all,omitempty"`
 }
 
-func (x *GetChatLogReq) Reset() {
-	*x = GetChatLogReq{}
+func (x *Mention) Reset() {
+	*x = Mention{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_apps_im_rpc_im_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -461,13 +485,13 @@ func (x *GetChatLogReq) Reset() {
 	}
 }
 
-func (x *GetChatLogReq) String() string {
+func (x *Mention) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetChatLogReq) ProtoMessage() {}
+func (*Mention) ProtoMessage() {}
 
-func (x *GetChatLogReq) ProtoReflect() protoreflect.Message {
+func (x *Mention) ProtoReflect() protoreflect.Message {
 	mi := &file_apps_im_rpc_im_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -479,56 +503,39 @@ func (x *GetChatLogReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetChatLogReq.ProtoReflect.Descriptor instead.
-func (*GetChatLogReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use Mention.ProtoReflect.Descriptor instead.
+func (*Mention) Descriptor() ([]byte, []int) {
 	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GetChatLogReq) GetConversationId() string {
-	if x != nil {
-		return x.ConversationId
-	}
-	return ""
-}
-
-func (x *GetChatLogReq) GetStartSendTime() int64 {
+func (x *Mention) GetUserIds() []string {
 	if x != nil {
-		return x.StartSendTime
+		return x.UserIds
 	}
-	return 0
+	return nil
 }
 
-func (x *GetChatLogReq) GetEndSendTime() int64 {
+func (x *Mention) GetAll() bool {
 	if x != nil {
-		return x.EndSendTime
+		return x.All
 	}
-	return 0
+	return false
 }
 
-func (x *GetChatLogReq) GetCount() int64 {
-	if x != nil {
-		return x.Count
-	}
-	return 0
-}
-
-func (x *GetChatLogReq) GetMsgId() string {
-	if x != nil {
-		return x.MsgId
-	}
-	return ""
-}
-
-type GetChatLogResp struct {
+// 消息上一个表情的回应统计
+type Reaction struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	List []*ChatLog `protobuf:"bytes,1,rep,name=List,proto3" json:"List,omitempty"`
+	Emoji string `protobuf:"bytes,1,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	Count int64  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// 当前用户是否回应过
+	Reacted bool `protobuf:"varint,3,opt,name=reacted,proto3" json:"reacted,omitempty"`
 }
 
-func (x *GetChatLogResp) Reset() {
-	*x = GetChatLogResp{}
+func (x *Reaction) Reset() {
+	*x = Reaction{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_apps_im_rpc_im_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -536,13 +543,13 @@ func (x *GetChatLogResp) Reset() {
 	}
 }
 
-func (x *GetChatLogResp) String() string {
+func (x *Reaction) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetChatLogResp) ProtoMessage() {}
+func (*Reaction) ProtoMessage() {}
 
-func (x *GetChatLogResp) ProtoReflect() protoreflect.Message {
+func (x *Reaction) ProtoReflect() protoreflect.Message {
 	mi := &file_apps_im_rpc_im_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -554,30 +561,46 @@ func (x *GetChatLogResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetChatLogResp.ProtoReflect.Descriptor instead.
-func (*GetChatLogResp) Descriptor() ([]byte, []int) {
+// Deprecated: Use Reaction.ProtoReflect.Descriptor instead.
+func (*Reaction) Descriptor() ([]byte, []int) {
 	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *GetChatLogResp) GetList() []*ChatLog {
+func (x *Reaction) GetEmoji() string {
 	if x != nil {
-		return x.List
+		return x.Emoji
 	}
-	return nil
+	return ""
 }
 
-type SetUpUserConversationReq struct {
+func (x *Reaction) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *Reaction) GetReacted() bool {
+	if x != nil {
+		return x.Reacted
+	}
+	return false
+}
+
+// 话题的统计，保存在话题的第一条消息上
+type Thread struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SendId   string `protobuf:"bytes,1,opt,name=SendId,proto3" json:"SendId,omitempty"`
-	RecvId   string `protobuf:"bytes,2,opt,name=recvId,proto3" json:"recvId,omitempty"`
-	ChatType int32  `protobuf:"varint,3,opt,name=chatType,proto3" json:"chatType,omitempty"`
+	ReplyCount int64 `protobuf:"varint,1,opt,name=replyCount,proto3" json:"replyCount,omitempty"`
+	// 最后一条回复的摘要
+	LastReply     *Quote `protobuf:"bytes,2,opt,name=lastReply,proto3" json:"lastReply,omitempty"`
+	LastReplyTime int64  `protobuf:"varint,3,opt,name=lastReplyTime,proto3" json:"lastReplyTime,omitempty"`
 }
 
-func (x *SetUpUserConversationReq) Reset() {
-	*x = SetUpUserConversationReq{}
+func (x *Thread) Reset() {
+	*x = Thread{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_apps_im_rpc_im_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -585,13 +608,13 @@ func (x *SetUpUserConversationReq) Reset() {
 	}
 }
 
-func (x *SetUpUserConversationReq) String() string {
+func (x *Thread) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetUpUserConversationReq) ProtoMessage() {}
+func (*Thread) ProtoMessage() {}
 
-func (x *SetUpUserConversationReq) ProtoReflect() protoreflect.Message {
+func (x *Thread) ProtoReflect() protoreflect.Message {
 	mi := &file_apps_im_rpc_im_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -603,40 +626,49 @@ func (x *SetUpUserConversationReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetUpUserConversationReq.ProtoReflect.Descriptor instead.
-func (*SetUpUserConversationReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use Thread.ProtoReflect.Descriptor instead.
+func (*Thread) Descriptor() ([]byte, []int) {
 	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *SetUpUserConversationReq) GetSendId() string {
+func (x *Thread) GetReplyCount() int64 {
 	if x != nil {
-		return x.SendId
+		return x.ReplyCount
 	}
-	return ""
+	return 0
 }
 
-func (x *SetUpUserConversationReq) GetRecvId() string {
+func (x *Thread) GetLastReply() *Quote {
 	if x != nil {
-		return x.RecvId
+		return x.LastReply
 	}
-	return ""
+	return nil
 }
 
-func (x *SetUpUserConversationReq) GetChatType() int32 {
+func (x *Thread) GetLastReplyTime() int64 {
 	if x != nil {
-		return x.ChatType
+		return x.LastReplyTime
 	}
 	return 0
 }
 
-type SetUpUserConversationResp struct {
+// 回复时引用的消息
+type Quote struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	MsgId   string `protobuf:"bytes,1,opt,name=msgId,proto3" json:"msgId,omitempty"`
+	SendId  string `protobuf:"bytes,2,opt,name=sendId,proto3" json:"sendId,omitempty"`
+	MsgType int32  `protobuf:"varint,3,opt,name=msgType,proto3" json:"msgType,omitempty"`
+	// 原消息的摘要
+	Snippet string `protobuf:"bytes,4,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	// 原消息的状态 0. 正常 1. 已撤回
+	Status int32 `protobuf:"varint,5,opt,name=status,proto3" json:"status,omitempty"`
 }
 
-func (x *SetUpUserConversationResp) Reset() {
-	*x = SetUpUserConversationResp{}
+func (x *Quote) Reset() {
+	*x = Quote{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_apps_im_rpc_im_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -644,13 +676,13 @@ func (x *SetUpUserConversationResp) Reset() {
 	}
 }
 
-func (x *SetUpUserConversationResp) String() string {
+func (x *Quote) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetUpUserConversationResp) ProtoMessage() {}
+func (*Quote) ProtoMessage() {}
 
-func (x *SetUpUserConversationResp) ProtoReflect() protoreflect.Message {
+func (x *Quote) ProtoReflect() protoreflect.Message {
 	mi := &file_apps_im_rpc_im_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -662,22 +694,78 @@ func (x *SetUpUserConversationResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetUpUserConversationResp.ProtoReflect.Descriptor instead.
-func (*SetUpUserConversationResp) Descriptor() ([]byte, []int) {
+// Deprecated: Use Quote.ProtoReflect.Descriptor instead.
+func (*Quote) Descriptor() ([]byte, []int) {
 	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{9}
 }
 
-type CreateGroupConversationReq struct {
+func (x *Quote) GetMsgId() string {
+	if x != nil {
+		return x.MsgId
+	}
+	return ""
+}
+
+func (x *Quote) GetSendId() string {
+	if x != nil {
+		return x.SendId
+	}
+	return ""
+}
+
+func (x *Quote) GetMsgType() int32 {
+	if x != nil {
+		return x.MsgType
+	}
+	return 0
+}
+
+func (x *Quote) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+func (x *Quote) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+type ChatLog struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	GroupId  string `protobuf:"bytes,1,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
-	CreateId string `protobuf:"bytes,2,opt,name=CreateId,proto3" json:"CreateId,omitempty"`
+	Id             string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ConversationId string `protobuf:"bytes,2,opt,name=conversationId,proto3" json:"conversationId,omitempty"`
+	SendId         string `protobuf:"bytes,3,opt,name=sendId,proto3" json:"sendId,omitempty"`
+	RecvId         string `protobuf:"bytes,4,opt,name=recvId,proto3" json:"recvId,omitempty"`
+	MsgType        int32  `protobuf:"varint,5,opt,name=msgType,proto3" json:"msgType,omitempty"`
+	MsgContent     string `protobuf:"bytes,6,opt,name=msgContent,proto3" json:"msgContent,omitempty"`
+	ChatType       int32  `protobuf:"varint,7,opt,name=chatType,proto3" json:"chatType,omitempty"`
+	SendTime       int64  `protobuf:"varint,8,opt,name=SendTime,proto3" json:"SendTime,omitempty"`
+	ReadRecords    []byte `protobuf:"bytes,9,opt,name=readRecords,proto3" json:"readRecords,omitempty"`
+	// 会话内的消息序号
+	Seq int64 `protobuf:"varint,10,opt,name=seq,proto3" json:"seq,omitempty"`
+	// 消息状态 0. 正常 1. 已撤回
+	Status int32 `protobuf:"varint,11,opt,name=status,proto3" json:"status,omitempty"`
+	// 编辑版本，大于 0 表示消息被编辑过
+	Version   int32       `protobuf:"varint,12,opt,name=version,proto3" json:"version,omitempty"`
+	Body      *MsgBody    `protobuf:"bytes,13,opt,name=body,proto3" json:"body,omitempty"`
+	ReplyTo   *Quote      `protobuf:"bytes,14,opt,name=replyTo,proto3" json:"replyTo,omitempty"`
+	Mention   *Mention    `protobuf:"bytes,15,opt,name=mention,proto3" json:"mention,omitempty"`
+	Reactions []*Reaction `protobuf:"bytes,16,rep,name=reactions,proto3" json:"reactions,omitempty"`
+	// 所属话题的第一条消息ID，仅话题中的回复
+	ThreadId string `protobuf:"bytes,17,opt,name=threadId,proto3" json:"threadId,omitempty"`
+	// 话题的统计，仅话题的第一条消息
+	Thread *Thread `protobuf:"bytes,18,opt,name=thread,proto3" json:"thread,omitempty"`
 }
 
-func (x *CreateGroupConversationReq) Reset() {
-	*x = CreateGroupConversationReq{}
+func (x *ChatLog) Reset() {
+	*x = ChatLog{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_apps_im_rpc_im_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -685,13 +773,13 @@ func (x *CreateGroupConversationReq) Reset() {
 	}
 }
 
-func (x *CreateGroupConversationReq) String() string {
+func (x *ChatLog) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateGroupConversationReq) ProtoMessage() {}
+func (*ChatLog) ProtoMessage() {}
 
-func (x *CreateGroupConversationReq) ProtoReflect() protoreflect.Message {
+func (x *ChatLog) ProtoReflect() protoreflect.Message {
 	mi := &file_apps_im_rpc_im_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -703,33 +791,178 @@ func (x *CreateGroupConversationReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateGroupConversationReq.ProtoReflect.Descriptor instead.
-func (*CreateGroupConversationReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use ChatLog.ProtoReflect.Descriptor instead.
+func (*ChatLog) Descriptor() ([]byte, []int) {
 	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *CreateGroupConversationReq) GetGroupId() string {
+func (x *ChatLog) GetId() string {
 	if x != nil {
-		return x.GroupId
+		return x.Id
 	}
 	return ""
 }
 
-func (x *CreateGroupConversationReq) GetCreateId() string {
+func (x *ChatLog) GetConversationId() string {
 	if x != nil {
-		return x.CreateId
+		return x.ConversationId
 	}
 	return ""
 }
 
-type CreateGroupConversationResp struct {
+func (x *ChatLog) GetSendId() string {
+	if x != nil {
+		return x.SendId
+	}
+	return ""
+}
+
+func (x *ChatLog) GetRecvId() string {
+	if x != nil {
+		return x.RecvId
+	}
+	return ""
+}
+
+func (x *ChatLog) GetMsgType() int32 {
+	if x != nil {
+		return x.MsgType
+	}
+	return 0
+}
+
+func (x *ChatLog) GetMsgContent() string {
+	if x != nil {
+		return x.MsgContent
+	}
+	return ""
+}
+
+func (x *ChatLog) GetChatType() int32 {
+	if x != nil {
+		return x.ChatType
+	}
+	return 0
+}
+
+func (x *ChatLog) GetSendTime() int64 {
+	if x != nil {
+		return x.SendTime
+	}
+	return 0
+}
+
+func (x *ChatLog) GetReadRecords() []byte {
+	if x != nil {
+		return x.ReadRecords
+	}
+	return nil
+}
+
+func (x *ChatLog) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *ChatLog) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *ChatLog) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ChatLog) GetBody() *MsgBody {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+func (x *ChatLog) GetReplyTo() *Quote {
+	if x != nil {
+		return x.ReplyTo
+	}
+	return nil
+}
+
+func (x *ChatLog) GetMention() *Mention {
+	if x != nil {
+		return x.Mention
+	}
+	return nil
+}
+
+func (x *ChatLog) GetReactions() []*Reaction {
+	if x != nil {
+		return x.Reactions
+	}
+	return nil
+}
+
+func (x *ChatLog) GetThreadId() string {
+	if x != nil {
+		return x.ThreadId
+	}
+	return ""
+}
+
+func (x *ChatLog) GetThread() *Thread {
+	if x != nil {
+		return x.Thread
+	}
+	return nil
+}
+
+type Conversation struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	ConversationId string `protobuf:"bytes,1,opt,name=conversationId,proto3" json:"conversationId,omitempty"`
+	ChatType       int32  `protobuf:"varint,2,opt,name=chatType,proto3" json:"chatType,omitempty"`
+	TargetId       string `protobuf:"bytes,3,opt,name=targetId,proto3" json:"targetId,omitempty"`
+	IsShow         bool   `protobuf:"varint,4,opt,name=isShow,proto3" json:"isShow,omitempty"`
+	Seq            int64  `protobuf:"varint,5,opt,name=seq,proto3" json:"seq,omitempty"`
+	// 总消息数
+	Total int32 `protobuf:"varint,6,opt,name=total,proto3" json:"total,omitempty"`
+	// 未读消息数
+	ToRead int32 `protobuf:"varint,7,opt,name=toRead,proto3" json:"toRead,omitempty"`
+	// 已读消息
+	Read int32    `protobuf:"varint,9,opt,name=Read,proto3" json:"Read,omitempty"`
+	Msg  *ChatLog `protobuf:"bytes,8,opt,name=msg,proto3" json:"msg,omitempty"`
+	// 用户已读到的消息序号
+	ReadSeq int64 `protobuf:"varint,10,opt,name=readSeq,proto3" json:"readSeq,omitempty"`
+	// 最近一条提及用户的消息序号
+	MentionSeq int64 `protobuf:"varint,11,opt,name=mentionSeq,proto3" json:"mentionSeq,omitempty"`
+	// 是否有未读的提及
+	Mentioned bool `protobuf:"varint,12,opt,name=mentioned,proto3" json:"mentioned,omitempty"`
+	// 置顶的消息
+	Pins []*Pin `protobuf:"bytes,13,rep,name=pins,proto3" json:"pins,omitempty"`
+	// 置顶会话的时间，为 0 表示未置顶
+	PinTime int64 `protobuf:"varint,14,opt,name=pinTime,proto3" json:"pinTime,omitempty"`
+	// 免打扰的截止时间，为 0 表示未设置，为 -1 表示一直免打扰
+	MuteUntil int64 `protobuf:"varint,15,opt,name=muteUntil,proto3" json:"muteUntil,omitempty"`
+	Archived  bool  `protobuf:"varint,16,opt,name=archived,proto3" json:"archived,omitempty"`
+	// 清空聊天记录时会话的序号，只能看到之后的消息
+	ClearSeq int64 `protobuf:"varint,17,opt,name=clearSeq,proto3" json:"clearSeq,omitempty"`
+	// 最后一条消息的发送时间
+	LastMsgTime int64 `protobuf:"varint,18,opt,name=lastMsgTime,proto3" json:"lastMsgTime,omitempty"`
+	// 未发送的草稿
+	Draft     string `protobuf:"bytes,19,opt,name=draft,proto3" json:"draft,omitempty"`
+	DraftTime int64  `protobuf:"varint,20,opt,name=draftTime,proto3" json:"draftTime,omitempty"`
 }
 
-func (x *CreateGroupConversationResp) Reset() {
-	*x = CreateGroupConversationResp{}
+func (x *Conversation) Reset() {
+	*x = Conversation{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_apps_im_rpc_im_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -737,13 +970,13 @@ func (x *CreateGroupConversationResp) Reset() {
 	}
 }
 
-func (x *CreateGroupConversationResp) String() string {
+func (x *Conversation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateGroupConversationResp) ProtoMessage() {}
+func (*Conversation) ProtoMessage() {}
 
-func (x *CreateGroupConversationResp) ProtoReflect() protoreflect.Message {
+func (x *Conversation) ProtoReflect() protoreflect.Message {
 	mi := &file_apps_im_rpc_im_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -755,198 +988,4485 @@ func (x *CreateGroupConversationResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateGroupConversationResp.ProtoReflect.Descriptor instead.
-func (*CreateGroupConversationResp) Descriptor() ([]byte, []int) {
+// Deprecated: Use Conversation.ProtoReflect.Descriptor instead.
+func (*Conversation) Descriptor() ([]byte, []int) {
 	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{11}
 }
 
-var File_apps_im_rpc_im_proto protoreflect.FileDescriptor
-
-var file_apps_im_rpc_im_proto_rawDesc = []byte{
-	0x0a, 0x14, 0x61, 0x70, 0x70, 0x73, 0x2f, 0x69, 0x6d, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x69, 0x6d,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x69, 0x6d, 0x22, 0x85, 0x02, 0x0a, 0x07, 0x43,
-	0x68, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72,
-	0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
-	0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x16,
-	0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x73, 0x65, 0x6e, 0x64, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x63, 0x76, 0x49, 0x64,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x63, 0x76, 0x49, 0x64, 0x12, 0x18,
-	0x0a, 0x07, 0x6d, 0x73, 0x67, 0x54, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x07, 0x6d, 0x73, 0x67, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x73, 0x67, 0x43,
-	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x73,
-	0x67, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x74,
-	0x54, 0x79, 0x70, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x63, 0x68, 0x61, 0x74,
-	0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x53, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65,
-	0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x53, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65,
-	0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18,
-	0x09, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72,
-	0x64, 0x73, 0x22, 0xf9, 0x01, 0x0a, 0x0c, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e,
-	0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x63,
-	0x68, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x63,
-	0x68, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x61, 0x72, 0x67, 0x65,
-	0x74, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x61, 0x72, 0x67, 0x65,
-	0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x73, 0x53, 0x68, 0x6f, 0x77, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x06, 0x69, 0x73, 0x53, 0x68, 0x6f, 0x77, 0x12, 0x10, 0x0a, 0x03, 0x73,
-	0x65, 0x71, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x14, 0x0a,
-	0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x74, 0x6f,
-	0x74, 0x61, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f, 0x52, 0x65, 0x61, 0x64, 0x18, 0x07, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x06, 0x74, 0x6f, 0x52, 0x65, 0x61, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x52,
-	0x65, 0x61, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x52, 0x65, 0x61, 0x64, 0x12,
-	0x1d, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x69,
-	0x6d, 0x2e, 0x43, 0x68, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x2d,
-	0x0a, 0x13, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0xc9, 0x01,
-	0x0a, 0x14, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x5a, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72,
-	0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x2e, 0x2e, 0x69, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x2e, 0x43, 0x6f, 0x6e, 0x76, 0x65,
-	0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x52, 0x10, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69,
-	0x73, 0x74, 0x1a, 0x55, 0x0a, 0x15, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
-	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x26, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x69,
-	0x6d, 0x2e, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xef, 0x01, 0x0a, 0x13, 0x50, 0x75,
-	0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65,
-	0x71, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
-	0x64, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x59, 0x0a, 0x10, 0x63, 0x6f, 0x6e,
-	0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x03, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x69, 0x6d, 0x2e, 0x50, 0x75, 0x74, 0x43, 0x6f, 0x6e, 0x76,
-	0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x2e, 0x43, 0x6f, 0x6e,
-	0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x52, 0x10, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x4c, 0x69, 0x73, 0x74, 0x1a, 0x55, 0x0a, 0x15, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x26, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
-	0x2e, 0x69, 0x6d, 0x2e, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x16, 0x0a, 0x14, 0x50,
-	0x75, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x22, 0xab, 0x01, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x74, 0x4c,
-	0x6f, 0x67, 0x52, 0x65, 0x71, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63,
-	0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x24, 0x0a,
-	0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x6e, 0x64, 0x54,
-	0x69, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x65, 0x6e, 0x64, 0x53, 0x65, 0x6e, 0x64, 0x54, 0x69,
-	0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x65, 0x6e, 0x64, 0x53, 0x65, 0x6e,
-	0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6d,
-	0x73, 0x67, 0x49, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x73, 0x67, 0x49,
-	0x64, 0x22, 0x31, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x52,
-	0x65, 0x73, 0x70, 0x12, 0x1f, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x0b, 0x2e, 0x69, 0x6d, 0x2e, 0x43, 0x68, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x04,
-	0x4c, 0x69, 0x73, 0x74, 0x22, 0x66, 0x0a, 0x18, 0x53, 0x65, 0x74, 0x55, 0x70, 0x55, 0x73, 0x65,
-	0x72, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
-	0x12, 0x16, 0x0a, 0x06, 0x53, 0x65, 0x6e, 0x64, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x53, 0x65, 0x6e, 0x64, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x63, 0x76,
-	0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x63, 0x76, 0x49, 0x64,
-	0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x08, 0x63, 0x68, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x22, 0x1b, 0x0a, 0x19,
-	0x53, 0x65, 0x74, 0x55, 0x70, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x22, 0x52, 0x0a, 0x1a, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70,
-	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49,
-	0x64, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x64, 0x22, 0x1d, 0x0a,
-	0x1b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x43, 0x6f, 0x6e, 0x76,
-	0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x32, 0xf9, 0x02, 0x0a,
-	0x02, 0x49, 0x6d, 0x12, 0x33, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x74, 0x4c, 0x6f,
-	0x67, 0x12, 0x11, 0x2e, 0x69, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61, 0x74, 0x4c, 0x6f,
-	0x67, 0x52, 0x65, 0x71, 0x1a, 0x12, 0x2e, 0x69, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61,
-	0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x12, 0x54, 0x0a, 0x15, 0x53, 0x65, 0x74, 0x55,
-	0x70, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x1c, 0x2e, 0x69, 0x6d, 0x2e, 0x53, 0x65, 0x74, 0x55, 0x70, 0x55, 0x73, 0x65, 0x72,
-	0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x1a,
-	0x1d, 0x2e, 0x69, 0x6d, 0x2e, 0x53, 0x65, 0x74, 0x55, 0x70, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f,
-	0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x12, 0x45,
-	0x0a, 0x10, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x12, 0x17, 0x2e, 0x69, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65,
-	0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x18, 0x2e, 0x69, 0x6d,
-	0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x45, 0x0a, 0x10, 0x50, 0x75, 0x74, 0x43, 0x6f, 0x6e, 0x76,
-	0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x17, 0x2e, 0x69, 0x6d, 0x2e, 0x50,
-	0x75, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52,
-	0x65, 0x71, 0x1a, 0x18, 0x2e, 0x69, 0x6d, 0x2e, 0x50, 0x75, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65,
-	0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x5a, 0x0a, 0x17,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x43, 0x6f, 0x6e, 0x76, 0x65,
-	0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x2e, 0x69, 0x6d, 0x2e, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x1a, 0x1f, 0x2e, 0x69, 0x6d, 0x2e, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x42, 0x06, 0x5a, 0x04, 0x2e, 0x2f, 0x69, 0x6d,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *Conversation) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
 }
 
-var (
-	file_apps_im_rpc_im_proto_rawDescOnce sync.Once
-	file_apps_im_rpc_im_proto_rawDescData = file_apps_im_rpc_im_proto_rawDesc
-)
+func (x *Conversation) GetChatType() int32 {
+	if x != nil {
+		return x.ChatType
+	}
+	return 0
+}
 
-func file_apps_im_rpc_im_proto_rawDescGZIP() []byte {
-	file_apps_im_rpc_im_proto_rawDescOnce.Do(func() {
-		file_apps_im_rpc_im_proto_rawDescData = protoimpl.X.CompressGZIP(file_apps_im_rpc_im_proto_rawDescData)
-	})
-	return file_apps_im_rpc_im_proto_rawDescData
+func (x *Conversation) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
 }
 
-var file_apps_im_rpc_im_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
-var file_apps_im_rpc_im_proto_goTypes = []any{
-	(*ChatLog)(nil),                     // 0: im.ChatLog
-	(*Conversation)(nil),                // 1: im.Conversation
-	(*GetConversationsReq)(nil),         // 2: im.GetConversationsReq
-	(*GetConversationsResp)(nil),        // 3: im.GetConversationsResp
-	(*PutConversationsReq)(nil),         // 4: im.PutConversationsReq
-	(*PutConversationsResp)(nil),        // 5: im.PutConversationsResp
-	(*GetChatLogReq)(nil),               // 6: im.GetChatLogReq
-	(*GetChatLogResp)(nil),              // 7: im.GetChatLogResp
-	(*SetUpUserConversationReq)(nil),    // 8: im.SetUpUserConversationReq
-	(*SetUpUserConversationResp)(nil),   // 9: im.SetUpUserConversationResp
-	(*CreateGroupConversationReq)(nil),  // 10: im.CreateGroupConversationReq
-	(*CreateGroupConversationResp)(nil), // 11: im.CreateGroupConversationResp
-	nil,                                 // 12: im.GetConversationsResp.ConversationListEntry
-	nil,                                 // 13: im.PutConversationsReq.ConversationListEntry
+func (x *Conversation) GetIsShow() bool {
+	if x != nil {
+		return x.IsShow
+	}
+	return false
 }
-var file_apps_im_rpc_im_proto_depIdxs = []int32{
-	0,  // 0: im.Conversation.msg:type_name -> im.ChatLog
-	12, // 1: im.GetConversationsResp.conversationList:type_name -> im.GetConversationsResp.ConversationListEntry
-	13, // 2: im.PutConversationsReq.conversationList:type_name -> im.PutConversationsReq.ConversationListEntry
-	0,  // 3: im.GetChatLogResp.List:type_name -> im.ChatLog
-	1,  // 4: im.GetConversationsResp.ConversationListEntry.value:type_name -> im.Conversation
-	1,  // 5: im.PutConversationsReq.ConversationListEntry.value:type_name -> im.Conversation
-	6,  // 6: im.Im.GetChatLog:input_type -> im.GetChatLogReq
-	8,  // 7: im.Im.SetUpUserConversation:input_type -> im.SetUpUserConversationReq
-	2,  // 8: im.Im.GetConversations:input_type -> im.GetConversationsReq
-	4,  // 9: im.Im.PutConversations:input_type -> im.PutConversationsReq
-	10, // 10: im.Im.CreateGroupConversation:input_type -> im.CreateGroupConversationReq
-	7,  // 11: im.Im.GetChatLog:output_type -> im.GetChatLogResp
-	9,  // 12: im.Im.SetUpUserConversation:output_type -> im.SetUpUserConversationResp
-	3,  // 13: im.Im.GetConversations:output_type -> im.GetConversationsResp
-	5,  // 14: im.Im.PutConversations:output_type -> im.PutConversationsResp
-	11, // 15: im.Im.CreateGroupConversation:output_type -> im.CreateGroupConversationResp
-	11, // [11:16] is the sub-list for method output_type
-	6,  // [6:11] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+
+func (x *Conversation) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
 }
 
-func init() { file_apps_im_rpc_im_proto_init() }
-func file_apps_im_rpc_im_proto_init() {
-	if File_apps_im_rpc_im_proto != nil {
-		return
+func (x *Conversation) GetTotal() int32 {
+	if x != nil {
+		return x.Total
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_apps_im_rpc_im_proto_msgTypes[0].Exporter = func(v any, i int) any {
-			switch v := v.(*ChatLog); i {
+	return 0
+}
+
+func (x *Conversation) GetToRead() int32 {
+	if x != nil {
+		return x.ToRead
+	}
+	return 0
+}
+
+func (x *Conversation) GetRead() int32 {
+	if x != nil {
+		return x.Read
+	}
+	return 0
+}
+
+func (x *Conversation) GetMsg() *ChatLog {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+func (x *Conversation) GetReadSeq() int64 {
+	if x != nil {
+		return x.ReadSeq
+	}
+	return 0
+}
+
+func (x *Conversation) GetMentionSeq() int64 {
+	if x != nil {
+		return x.MentionSeq
+	}
+	return 0
+}
+
+func (x *Conversation) GetMentioned() bool {
+	if x != nil {
+		return x.Mentioned
+	}
+	return false
+}
+
+func (x *Conversation) GetPins() []*Pin {
+	if x != nil {
+		return x.Pins
+	}
+	return nil
+}
+
+func (x *Conversation) GetPinTime() int64 {
+	if x != nil {
+		return x.PinTime
+	}
+	return 0
+}
+
+func (x *Conversation) GetMuteUntil() int64 {
+	if x != nil {
+		return x.MuteUntil
+	}
+	return 0
+}
+
+func (x *Conversation) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+func (x *Conversation) GetClearSeq() int64 {
+	if x != nil {
+		return x.ClearSeq
+	}
+	return 0
+}
+
+func (x *Conversation) GetLastMsgTime() int64 {
+	if x != nil {
+		return x.LastMsgTime
+	}
+	return 0
+}
+
+func (x *Conversation) GetDraft() string {
+	if x != nil {
+		return x.Draft
+	}
+	return ""
+}
+
+func (x *Conversation) GetDraftTime() int64 {
+	if x != nil {
+		return x.DraftTime
+	}
+	return 0
+}
+
+// 会话中置顶的消息
+type Pin struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MsgId string `protobuf:"bytes,1,opt,name=msgId,proto3" json:"msgId,omitempty"`
+	Seq   int64  `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	// 置顶的用户
+	PinnedBy string `protobuf:"bytes,3,opt,name=pinnedBy,proto3" json:"pinnedBy,omitempty"`
+	PinTime  int64  `protobuf:"varint,4,opt,name=pinTime,proto3" json:"pinTime,omitempty"`
+}
+
+func (x *Pin) Reset() {
+	*x = Pin{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Pin) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pin) ProtoMessage() {}
+
+func (x *Pin) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pin.ProtoReflect.Descriptor instead.
+func (*Pin) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Pin) GetMsgId() string {
+	if x != nil {
+		return x.MsgId
+	}
+	return ""
+}
+
+func (x *Pin) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *Pin) GetPinnedBy() string {
+	if x != nil {
+		return x.PinnedBy
+	}
+	return ""
+}
+
+func (x *Pin) GetPinTime() int64 {
+	if x != nil {
+		return x.PinTime
+	}
+	return 0
+}
+
+type GetConversationsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+}
+
+func (x *GetConversationsReq) Reset() {
+	*x = GetConversationsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetConversationsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConversationsReq) ProtoMessage() {}
+
+func (x *GetConversationsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConversationsReq.ProtoReflect.Descriptor instead.
+func (*GetConversationsReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetConversationsReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetConversationsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConversationList map[string]*Conversation `protobuf:"bytes,2,rep,name=conversationList,proto3" json:"conversationList,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// 展示的会话按顺序排列：置顶的会话按置顶时间倒序在前，其他会话按最后一条消息的时间倒序，归档的会话在最后
+	ConversationIds []string `protobuf:"bytes,3,rep,name=conversationIds,proto3" json:"conversationIds,omitempty"`
+	// 未归档且未免打扰的会话的未读数之和
+	UnreadTotal int64 `protobuf:"varint,4,opt,name=unreadTotal,proto3" json:"unreadTotal,omitempty"`
+}
+
+func (x *GetConversationsResp) Reset() {
+	*x = GetConversationsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetConversationsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConversationsResp) ProtoMessage() {}
+
+func (x *GetConversationsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConversationsResp.ProtoReflect.Descriptor instead.
+func (*GetConversationsResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetConversationsResp) GetConversationList() map[string]*Conversation {
+	if x != nil {
+		return x.ConversationList
+	}
+	return nil
+}
+
+func (x *GetConversationsResp) GetConversationIds() []string {
+	if x != nil {
+		return x.ConversationIds
+	}
+	return nil
+}
+
+func (x *GetConversationsResp) GetUnreadTotal() int64 {
+	if x != nil {
+		return x.UnreadTotal
+	}
+	return 0
+}
+
+type PutConversationsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id               string                   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId           string                   `protobuf:"bytes,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	ConversationList map[string]*Conversation `protobuf:"bytes,3,rep,name=conversationList,proto3" json:"conversationList,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *PutConversationsReq) Reset() {
+	*x = PutConversationsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PutConversationsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutConversationsReq) ProtoMessage() {}
+
+func (x *PutConversationsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutConversationsReq.ProtoReflect.Descriptor instead.
+func (*PutConversationsReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *PutConversationsReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PutConversationsReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PutConversationsReq) GetConversationList() map[string]*Conversation {
+	if x != nil {
+		return x.ConversationList
+	}
+	return nil
+}
+
+type PutConversationsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *PutConversationsResp) Reset() {
+	*x = PutConversationsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PutConversationsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutConversationsResp) ProtoMessage() {}
+
+func (x *PutConversationsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutConversationsResp.ProtoReflect.Descriptor instead.
+func (*PutConversationsResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{16}
+}
+
+type GetChatLogReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConversationId string `protobuf:"bytes,1,opt,name=conversationId,proto3" json:"conversationId,omitempty"`
+	StartSendTime  int64  `protobuf:"varint,2,opt,name=startSendTime,proto3" json:"startSendTime,omitempty"`
+	EndSendTime    int64  `protobuf:"varint,3,opt,name=endSendTime,proto3" json:"endSendTime,omitempty"`
+	Count          int64  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	MsgId          string `protobuf:"bytes,5,opt,name=msgId,proto3" json:"msgId,omitempty"`
+	// 查询的用户，用于返回用户是否回应过
+	UserId string `protobuf:"bytes,6,opt,name=userId,proto3" json:"userId,omitempty"`
+	// 不指定 msgId 与发送时间时按游标翻页：从 cursor 或 anchorMsgId 对应的消息开始（不包含），
+	// 都为空时向前翻页从最新的消息开始，向后翻页从最早的消息开始
+	Cursor      string `protobuf:"bytes,7,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	AnchorMsgId string `protobuf:"bytes,8,opt,name=anchorMsgId,proto3" json:"anchorMsgId,omitempty"`
+	// 翻页方向 0. 更早的消息 1. 更新的消息
+	Direction int32 `protobuf:"varint,9,opt,name=direction,proto3" json:"direction,omitempty"`
+}
+
+func (x *GetChatLogReq) Reset() {
+	*x = GetChatLogReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetChatLogReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChatLogReq) ProtoMessage() {}
+
+func (x *GetChatLogReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChatLogReq.ProtoReflect.Descriptor instead.
+func (*GetChatLogReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetChatLogReq) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *GetChatLogReq) GetStartSendTime() int64 {
+	if x != nil {
+		return x.StartSendTime
+	}
+	return 0
+}
+
+func (x *GetChatLogReq) GetEndSendTime() int64 {
+	if x != nil {
+		return x.EndSendTime
+	}
+	return 0
+}
+
+func (x *GetChatLogReq) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *GetChatLogReq) GetMsgId() string {
+	if x != nil {
+		return x.MsgId
+	}
+	return ""
+}
+
+func (x *GetChatLogReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetChatLogReq) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetChatLogReq) GetAnchorMsgId() string {
+	if x != nil {
+		return x.AnchorMsgId
+	}
+	return ""
+}
+
+func (x *GetChatLogReq) GetDirection() int32 {
+	if x != nil {
+		return x.Direction
+	}
+	return 0
+}
+
+type GetChatLogResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// 按游标翻页时按序号升序排列
+	List []*ChatLog `protobuf:"bytes,1,rep,name=List,proto3" json:"List,omitempty"`
+	// 翻页方向上是否还有更多消息
+	HasMore bool `protobuf:"varint,2,opt,name=hasMore,proto3" json:"hasMore,omitempty"`
+	// 当前页最早与最新的消息的游标，用于继续向前或向后翻页
+	OlderCursor string `protobuf:"bytes,3,opt,name=olderCursor,proto3" json:"olderCursor,omitempty"`
+	NewerCursor string `protobuf:"bytes,4,opt,name=newerCursor,proto3" json:"newerCursor,omitempty"`
+}
+
+func (x *GetChatLogResp) Reset() {
+	*x = GetChatLogResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetChatLogResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChatLogResp) ProtoMessage() {}
+
+func (x *GetChatLogResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChatLogResp.ProtoReflect.Descriptor instead.
+func (*GetChatLogResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetChatLogResp) GetList() []*ChatLog {
+	if x != nil {
+		return x.List
+	}
+	return nil
+}
+
+func (x *GetChatLogResp) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+func (x *GetChatLogResp) GetOlderCursor() string {
+	if x != nil {
+		return x.OlderCursor
+	}
+	return ""
+}
+
+func (x *GetChatLogResp) GetNewerCursor() string {
+	if x != nil {
+		return x.NewerCursor
+	}
+	return ""
+}
+
+type GetReadSeqsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ConversationId string   `protobuf:"bytes,1,opt,name=conversationId,proto3" json:"conversationId,omitempty"`
+	UserIds        []string `protobuf:"bytes,2,rep,name=userIds,proto3" json:"userIds,omitempty"`
+}
+
+func (x *GetReadSeqsReq) Reset() {
+	*x = GetReadSeqsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetReadSeqsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReadSeqsReq) ProtoMessage() {}
+
+func (x *GetReadSeqsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReadSeqsReq.ProtoReflect.Descriptor instead.
+func (*GetReadSeqsReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetReadSeqsReq) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *GetReadSeqsReq) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type GetReadSeqsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReadSeqs map[string]int64 `protobuf:"bytes,1,rep,name=readSeqs,proto3" json:"readSeqs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *GetReadSeqsResp) Reset() {
+	*x = GetReadSeqsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetReadSeqsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReadSeqsResp) ProtoMessage() {}
+
+func (x *GetReadSeqsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReadSeqsResp.ProtoReflect.Descriptor instead.
+func (*GetReadSeqsResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetReadSeqsResp) GetReadSeqs() map[string]int64 {
+	if x != nil {
+		return x.ReadSeqs
+	}
+	return nil
+}
+
+type RecallMsgReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	MsgId  string `protobuf:"bytes,2,opt,name=msgId,proto3" json:"msgId,omitempty"`
+}
+
+func (x *RecallMsgReq) Reset() {
+	*x = RecallMsgReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecallMsgReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecallMsgReq) ProtoMessage() {}
+
+func (x *RecallMsgReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecallMsgReq.ProtoReflect.Descriptor instead.
+func (*RecallMsgReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RecallMsgReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RecallMsgReq) GetMsgId() string {
+	if x != nil {
+		return x.MsgId
+	}
+	return ""
+}
+
+type RecallMsgResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RecallMsgResp) Reset() {
+	*x = RecallMsgResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecallMsgResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecallMsgResp) ProtoMessage() {}
+
+func (x *RecallMsgResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecallMsgResp.ProtoReflect.Descriptor instead.
+func (*RecallMsgResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{22}
+}
+
+type EditMsgReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId  string `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	MsgId   string `protobuf:"bytes,2,opt,name=msgId,proto3" json:"msgId,omitempty"`
+	Content string `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+}
+
+func (x *EditMsgReq) Reset() {
+	*x = EditMsgReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EditMsgReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditMsgReq) ProtoMessage() {}
+
+func (x *EditMsgReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditMsgReq.ProtoReflect.Descriptor instead.
+func (*EditMsgReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *EditMsgReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *EditMsgReq) GetMsgId() string {
+	if x != nil {
+		return x.MsgId
+	}
+	return ""
+}
+
+func (x *EditMsgReq) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type EditMsgResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version int32 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *EditMsgResp) Reset() {
+	*x = EditMsgResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EditMsgResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditMsgResp) ProtoMessage() {}
+
+func (x *EditMsgResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditMsgResp.ProtoReflect.Descriptor instead.
+func (*EditMsgResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *EditMsgResp) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type GetThreadRepliesReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	// 话题的第一条消息ID
+	ThreadId string `protobuf:"bytes,2,opt,name=threadId,proto3" json:"threadId,omitempty"`
+	// 查询该序号之后的回复
+	AfterSeq int64 `protobuf:"varint,3,opt,name=afterSeq,proto3" json:"afterSeq,omitempty"`
+	Count    int64 `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *GetThreadRepliesReq) Reset() {
+	*x = GetThreadRepliesReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetThreadRepliesReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetThreadRepliesReq) ProtoMessage() {}
+
+func (x *GetThreadRepliesReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetThreadRepliesReq.ProtoReflect.Descriptor instead.
+func (*GetThreadRepliesReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetThreadRepliesReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetThreadRepliesReq) GetThreadId() string {
+	if x != nil {
+		return x.ThreadId
+	}
+	return ""
+}
+
+func (x *GetThreadRepliesReq) GetAfterSeq() int64 {
+	if x != nil {
+		return x.AfterSeq
+	}
+	return 0
+}
+
+func (x *GetThreadRepliesReq) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type GetThreadRepliesResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Root *ChatLog   `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	List []*ChatLog `protobuf:"bytes,2,rep,name=list,proto3" json:"list,omitempty"`
+}
+
+func (x *GetThreadRepliesResp) Reset() {
+	*x = GetThreadRepliesResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetThreadRepliesResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetThreadRepliesResp) ProtoMessage() {}
+
+func (x *GetThreadRepliesResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetThreadRepliesResp.ProtoReflect.Descriptor instead.
+func (*GetThreadRepliesResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetThreadRepliesResp) GetRoot() *ChatLog {
+	if x != nil {
+		return x.Root
+	}
+	return nil
+}
+
+func (x *GetThreadRepliesResp) GetList() []*ChatLog {
+	if x != nil {
+		return x.List
+	}
+	return nil
+}
+
+type ReactMsgReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	MsgId  string `protobuf:"bytes,2,opt,name=msgId,proto3" json:"msgId,omitempty"`
+	Emoji  string `protobuf:"bytes,3,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	// 为 true 时取消回应
+	Remove bool `protobuf:"varint,4,opt,name=remove,proto3" json:"remove,omitempty"`
+}
+
+func (x *ReactMsgReq) Reset() {
+	*x = ReactMsgReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReactMsgReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactMsgReq) ProtoMessage() {}
+
+func (x *ReactMsgReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactMsgReq.ProtoReflect.Descriptor instead.
+func (*ReactMsgReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ReactMsgReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReactMsgReq) GetMsgId() string {
+	if x != nil {
+		return x.MsgId
+	}
+	return ""
+}
+
+func (x *ReactMsgReq) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+func (x *ReactMsgReq) GetRemove() bool {
+	if x != nil {
+		return x.Remove
+	}
+	return false
+}
+
+type ReactMsgResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// 表情当前的回应数
+	Count int64 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *ReactMsgResp) Reset() {
+	*x = ReactMsgResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReactMsgResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactMsgResp) ProtoMessage() {}
+
+func (x *ReactMsgResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactMsgResp.ProtoReflect.Descriptor instead.
+func (*ReactMsgResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ReactMsgResp) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type PinMsgReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	MsgId  string `protobuf:"bytes,2,opt,name=msgId,proto3" json:"msgId,omitempty"`
+	// 为 true 时取消置顶
+	Unpin bool `protobuf:"varint,3,opt,name=unpin,proto3" json:"unpin,omitempty"`
+}
+
+func (x *PinMsgReq) Reset() {
+	*x = PinMsgReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PinMsgReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinMsgReq) ProtoMessage() {}
+
+func (x *PinMsgReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinMsgReq.ProtoReflect.Descriptor instead.
+func (*PinMsgReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *PinMsgReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PinMsgReq) GetMsgId() string {
+	if x != nil {
+		return x.MsgId
+	}
+	return ""
+}
+
+func (x *PinMsgReq) GetUnpin() bool {
+	if x != nil {
+		return x.Unpin
+	}
+	return false
+}
+
+type PinMsgResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *PinMsgResp) Reset() {
+	*x = PinMsgResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PinMsgResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinMsgResp) ProtoMessage() {}
+
+func (x *PinMsgResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinMsgResp.ProtoReflect.Descriptor instead.
+func (*PinMsgResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{30}
+}
+
+type GetPinnedMsgsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId         string `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	ConversationId string `protobuf:"bytes,2,opt,name=conversationId,proto3" json:"conversationId,omitempty"`
+}
+
+func (x *GetPinnedMsgsReq) Reset() {
+	*x = GetPinnedMsgsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPinnedMsgsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPinnedMsgsReq) ProtoMessage() {}
+
+func (x *GetPinnedMsgsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPinnedMsgsReq.ProtoReflect.Descriptor instead.
+func (*GetPinnedMsgsReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetPinnedMsgsReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetPinnedMsgsReq) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+type PinnedMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Msg      *ChatLog `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"`
+	PinnedBy string   `protobuf:"bytes,2,opt,name=pinnedBy,proto3" json:"pinnedBy,omitempty"`
+	PinTime  int64    `protobuf:"varint,3,opt,name=pinTime,proto3" json:"pinTime,omitempty"`
+}
+
+func (x *PinnedMsg) Reset() {
+	*x = PinnedMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PinnedMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinnedMsg) ProtoMessage() {}
+
+func (x *PinnedMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinnedMsg.ProtoReflect.Descriptor instead.
+func (*PinnedMsg) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *PinnedMsg) GetMsg() *ChatLog {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+func (x *PinnedMsg) GetPinnedBy() string {
+	if x != nil {
+		return x.PinnedBy
+	}
+	return ""
+}
+
+func (x *PinnedMsg) GetPinTime() int64 {
+	if x != nil {
+		return x.PinTime
+	}
+	return 0
+}
+
+type GetPinnedMsgsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	List []*PinnedMsg `protobuf:"bytes,1,rep,name=list,proto3" json:"list,omitempty"`
+}
+
+func (x *GetPinnedMsgsResp) Reset() {
+	*x = GetPinnedMsgsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPinnedMsgsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPinnedMsgsResp) ProtoMessage() {}
+
+func (x *GetPinnedMsgsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPinnedMsgsResp.ProtoReflect.Descriptor instead.
+func (*GetPinnedMsgsResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetPinnedMsgsResp) GetList() []*PinnedMsg {
+	if x != nil {
+		return x.List
+	}
+	return nil
+}
+
+type StarMsgReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	MsgId  string `protobuf:"bytes,2,opt,name=msgId,proto3" json:"msgId,omitempty"`
+	// 为 true 时取消收藏
+	Unstar bool `protobuf:"varint,3,opt,name=unstar,proto3" json:"unstar,omitempty"`
+}
+
+func (x *StarMsgReq) Reset() {
+	*x = StarMsgReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StarMsgReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StarMsgReq) ProtoMessage() {}
+
+func (x *StarMsgReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StarMsgReq.ProtoReflect.Descriptor instead.
+func (*StarMsgReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *StarMsgReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *StarMsgReq) GetMsgId() string {
+	if x != nil {
+		return x.MsgId
+	}
+	return ""
+}
+
+func (x *StarMsgReq) GetUnstar() bool {
+	if x != nil {
+		return x.Unstar
+	}
+	return false
+}
+
+type StarMsgResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *StarMsgResp) Reset() {
+	*x = StarMsgResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StarMsgResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StarMsgResp) ProtoMessage() {}
+
+func (x *StarMsgResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StarMsgResp.ProtoReflect.Descriptor instead.
+func (*StarMsgResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{35}
+}
+
+type GetStarredMsgsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	// 查询该时间之前的收藏，为 0 时从最新的开始
+	BeforeTime int64 `protobuf:"varint,2,opt,name=beforeTime,proto3" json:"beforeTime,omitempty"`
+	Count      int64 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *GetStarredMsgsReq) Reset() {
+	*x = GetStarredMsgsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStarredMsgsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStarredMsgsReq) ProtoMessage() {}
+
+func (x *GetStarredMsgsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStarredMsgsReq.ProtoReflect.Descriptor instead.
+func (*GetStarredMsgsReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetStarredMsgsReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetStarredMsgsReq) GetBeforeTime() int64 {
+	if x != nil {
+		return x.BeforeTime
+	}
+	return 0
+}
+
+func (x *GetStarredMsgsReq) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type StarredMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Msg      *ChatLog `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"`
+	StarTime int64    `protobuf:"varint,2,opt,name=starTime,proto3" json:"starTime,omitempty"`
+}
+
+func (x *StarredMsg) Reset() {
+	*x = StarredMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StarredMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StarredMsg) ProtoMessage() {}
+
+func (x *StarredMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StarredMsg.ProtoReflect.Descriptor instead.
+func (*StarredMsg) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *StarredMsg) GetMsg() *ChatLog {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+func (x *StarredMsg) GetStarTime() int64 {
+	if x != nil {
+		return x.StarTime
+	}
+	return 0
+}
+
+type GetStarredMsgsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	List []*StarredMsg `protobuf:"bytes,1,rep,name=list,proto3" json:"list,omitempty"`
+}
+
+func (x *GetStarredMsgsResp) Reset() {
+	*x = GetStarredMsgsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStarredMsgsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStarredMsgsResp) ProtoMessage() {}
+
+func (x *GetStarredMsgsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStarredMsgsResp.ProtoReflect.Descriptor instead.
+func (*GetStarredMsgsResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetStarredMsgsResp) GetList() []*StarredMsg {
+	if x != nil {
+		return x.List
+	}
+	return nil
+}
+
+type SearchMsgsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	// 关键词以空白分隔，消息需要包含所有关键词
+	Keyword string `protobuf:"bytes,2,opt,name=keyword,proto3" json:"keyword,omitempty"`
+	// 以下条件为空时不限
+	ConversationId string `protobuf:"bytes,3,opt,name=conversationId,proto3" json:"conversationId,omitempty"`
+	SendId         string `protobuf:"bytes,4,opt,name=sendId,proto3" json:"sendId,omitempty"`
+	StartTime      int64  `protobuf:"varint,5,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	EndTime        int64  `protobuf:"varint,6,opt,name=endTime,proto3" json:"endTime,omitempty"`
+	Offset         int64  `protobuf:"varint,7,opt,name=offset,proto3" json:"offset,omitempty"`
+	Count          int64  `protobuf:"varint,8,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *SearchMsgsReq) Reset() {
+	*x = SearchMsgsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchMsgsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMsgsReq) ProtoMessage() {}
+
+func (x *SearchMsgsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchMsgsReq.ProtoReflect.Descriptor instead.
+func (*SearchMsgsReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *SearchMsgsReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SearchMsgsReq) GetKeyword() string {
+	if x != nil {
+		return x.Keyword
+	}
+	return ""
+}
+
+func (x *SearchMsgsReq) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *SearchMsgsReq) GetSendId() string {
+	if x != nil {
+		return x.SendId
+	}
+	return ""
+}
+
+func (x *SearchMsgsReq) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *SearchMsgsReq) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *SearchMsgsReq) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *SearchMsgsReq) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type SearchHit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Msg *ChatLog `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"`
+	// 高亮关键词的内容摘要
+	Snippet string `protobuf:"bytes,2,opt,name=snippet,proto3" json:"snippet,omitempty"`
+}
+
+func (x *SearchHit) Reset() {
+	*x = SearchHit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchHit) ProtoMessage() {}
+
+func (x *SearchHit) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchHit.ProtoReflect.Descriptor instead.
+func (*SearchHit) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *SearchHit) GetMsg() *ChatLog {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+func (x *SearchHit) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+type SearchMsgsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	List  []*SearchHit `protobuf:"bytes,1,rep,name=list,proto3" json:"list,omitempty"`
+	Total int64        `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+}
+
+func (x *SearchMsgsResp) Reset() {
+	*x = SearchMsgsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchMsgsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMsgsResp) ProtoMessage() {}
+
+func (x *SearchMsgsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchMsgsResp.ProtoReflect.Descriptor instead.
+func (*SearchMsgsResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *SearchMsgsResp) GetList() []*SearchHit {
+	if x != nil {
+		return x.List
+	}
+	return nil
+}
+
+func (x *SearchMsgsResp) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type PinConversationReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId         string `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	ConversationId string `protobuf:"bytes,2,opt,name=conversationId,proto3" json:"conversationId,omitempty"`
+	// 为 true 时取消置顶
+	Unpin bool `protobuf:"varint,3,opt,name=unpin,proto3" json:"unpin,omitempty"`
+}
+
+func (x *PinConversationReq) Reset() {
+	*x = PinConversationReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PinConversationReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinConversationReq) ProtoMessage() {}
+
+func (x *PinConversationReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinConversationReq.ProtoReflect.Descriptor instead.
+func (*PinConversationReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *PinConversationReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PinConversationReq) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *PinConversationReq) GetUnpin() bool {
+	if x != nil {
+		return x.Unpin
+	}
+	return false
+}
+
+type PinConversationResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *PinConversationResp) Reset() {
+	*x = PinConversationResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PinConversationResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinConversationResp) ProtoMessage() {}
+
+func (x *PinConversationResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinConversationResp.ProtoReflect.Descriptor instead.
+func (*PinConversationResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{43}
+}
+
+type MuteConversationReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId         string `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	ConversationId string `protobuf:"bytes,2,opt,name=conversationId,proto3" json:"conversationId,omitempty"`
+	// 免打扰的截止时间，为 0 时取消免打扰，为 -1 时一直免打扰
+	MuteUntil int64 `protobuf:"varint,3,opt,name=muteUntil,proto3" json:"muteUntil,omitempty"`
+}
+
+func (x *MuteConversationReq) Reset() {
+	*x = MuteConversationReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MuteConversationReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MuteConversationReq) ProtoMessage() {}
+
+func (x *MuteConversationReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MuteConversationReq.ProtoReflect.Descriptor instead.
+func (*MuteConversationReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *MuteConversationReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MuteConversationReq) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *MuteConversationReq) GetMuteUntil() int64 {
+	if x != nil {
+		return x.MuteUntil
+	}
+	return 0
+}
+
+type MuteConversationResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *MuteConversationResp) Reset() {
+	*x = MuteConversationResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MuteConversationResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MuteConversationResp) ProtoMessage() {}
+
+func (x *MuteConversationResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MuteConversationResp.ProtoReflect.Descriptor instead.
+func (*MuteConversationResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{45}
+}
+
+type ArchiveConversationReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId         string `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	ConversationId string `protobuf:"bytes,2,opt,name=conversationId,proto3" json:"conversationId,omitempty"`
+	// 为 true 时取消归档
+	Unarchive bool `protobuf:"varint,3,opt,name=unarchive,proto3" json:"unarchive,omitempty"`
+}
+
+func (x *ArchiveConversationReq) Reset() {
+	*x = ArchiveConversationReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ArchiveConversationReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveConversationReq) ProtoMessage() {}
+
+func (x *ArchiveConversationReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchiveConversationReq.ProtoReflect.Descriptor instead.
+func (*ArchiveConversationReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ArchiveConversationReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ArchiveConversationReq) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *ArchiveConversationReq) GetUnarchive() bool {
+	if x != nil {
+		return x.Unarchive
+	}
+	return false
+}
+
+type ArchiveConversationResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ArchiveConversationResp) Reset() {
+	*x = ArchiveConversationResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ArchiveConversationResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveConversationResp) ProtoMessage() {}
+
+func (x *ArchiveConversationResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchiveConversationResp.ProtoReflect.Descriptor instead.
+func (*ArchiveConversationResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{47}
+}
+
+type DeleteConversationReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId         string `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	ConversationId string `protobuf:"bytes,2,opt,name=conversationId,proto3" json:"conversationId,omitempty"`
+	// 为 true 时同时清空聊天记录
+	ClearHistory bool `protobuf:"varint,3,opt,name=clearHistory,proto3" json:"clearHistory,omitempty"`
+}
+
+func (x *DeleteConversationReq) Reset() {
+	*x = DeleteConversationReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteConversationReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteConversationReq) ProtoMessage() {}
+
+func (x *DeleteConversationReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteConversationReq.ProtoReflect.Descriptor instead.
+func (*DeleteConversationReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *DeleteConversationReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeleteConversationReq) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *DeleteConversationReq) GetClearHistory() bool {
+	if x != nil {
+		return x.ClearHistory
+	}
+	return false
+}
+
+type DeleteConversationResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteConversationResp) Reset() {
+	*x = DeleteConversationResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteConversationResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteConversationResp) ProtoMessage() {}
+
+func (x *DeleteConversationResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteConversationResp.ProtoReflect.Descriptor instead.
+func (*DeleteConversationResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{49}
+}
+
+type ClearConversationHistoryReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId         string `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	ConversationId string `protobuf:"bytes,2,opt,name=conversationId,proto3" json:"conversationId,omitempty"`
+}
+
+func (x *ClearConversationHistoryReq) Reset() {
+	*x = ClearConversationHistoryReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClearConversationHistoryReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearConversationHistoryReq) ProtoMessage() {}
+
+func (x *ClearConversationHistoryReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearConversationHistoryReq.ProtoReflect.Descriptor instead.
+func (*ClearConversationHistoryReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ClearConversationHistoryReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ClearConversationHistoryReq) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+type ClearConversationHistoryResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ClearConversationHistoryResp) Reset() {
+	*x = ClearConversationHistoryResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClearConversationHistoryResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearConversationHistoryResp) ProtoMessage() {}
+
+func (x *ClearConversationHistoryResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearConversationHistoryResp.ProtoReflect.Descriptor instead.
+func (*ClearConversationHistoryResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{51}
+}
+
+type ListConversationsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	// 上一页返回的 nextCursor，为空时从第一页开始
+	Cursor string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Count  int64  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	// 为 true 时查询归档的会话，否则查询未归档的会话
+	Archived bool `protobuf:"varint,4,opt,name=archived,proto3" json:"archived,omitempty"`
+}
+
+func (x *ListConversationsReq) Reset() {
+	*x = ListConversationsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[52]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListConversationsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConversationsReq) ProtoMessage() {}
+
+func (x *ListConversationsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[52]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConversationsReq.ProtoReflect.Descriptor instead.
+func (*ListConversationsReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ListConversationsReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListConversationsReq) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListConversationsReq) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *ListConversationsReq) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+type ListConversationsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// 置顶的会话按置顶时间倒序在前，其他会话按最后一条消息的时间倒序
+	List       []*Conversation `protobuf:"bytes,1,rep,name=list,proto3" json:"list,omitempty"`
+	NextCursor string          `protobuf:"bytes,2,opt,name=nextCursor,proto3" json:"nextCursor,omitempty"`
+	HasMore    bool            `protobuf:"varint,3,opt,name=hasMore,proto3" json:"hasMore,omitempty"`
+}
+
+func (x *ListConversationsResp) Reset() {
+	*x = ListConversationsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[53]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListConversationsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConversationsResp) ProtoMessage() {}
+
+func (x *ListConversationsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[53]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConversationsResp.ProtoReflect.Descriptor instead.
+func (*ListConversationsResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ListConversationsResp) GetList() []*Conversation {
+	if x != nil {
+		return x.List
+	}
+	return nil
+}
+
+func (x *ListConversationsResp) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListConversationsResp) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type SaveDraftReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId         string `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	ConversationId string `protobuf:"bytes,2,opt,name=conversationId,proto3" json:"conversationId,omitempty"`
+	// 草稿内容，为空时清除草稿
+	Content string `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+}
+
+func (x *SaveDraftReq) Reset() {
+	*x = SaveDraftReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[54]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SaveDraftReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveDraftReq) ProtoMessage() {}
+
+func (x *SaveDraftReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[54]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveDraftReq.ProtoReflect.Descriptor instead.
+func (*SaveDraftReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *SaveDraftReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SaveDraftReq) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *SaveDraftReq) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type SaveDraftResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DraftTime int64 `protobuf:"varint,1,opt,name=draftTime,proto3" json:"draftTime,omitempty"`
+}
+
+func (x *SaveDraftResp) Reset() {
+	*x = SaveDraftResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[55]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SaveDraftResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveDraftResp) ProtoMessage() {}
+
+func (x *SaveDraftResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[55]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveDraftResp.ProtoReflect.Descriptor instead.
+func (*SaveDraftResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *SaveDraftResp) GetDraftTime() int64 {
+	if x != nil {
+		return x.DraftTime
+	}
+	return 0
+}
+
+// 定时消息
+type ScheduledMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id             string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ConversationId string   `protobuf:"bytes,2,opt,name=conversationId,proto3" json:"conversationId,omitempty"`
+	ChatType       int32    `protobuf:"varint,3,opt,name=chatType,proto3" json:"chatType,omitempty"`
+	SendId         string   `protobuf:"bytes,4,opt,name=sendId,proto3" json:"sendId,omitempty"`
+	RecvId         string   `protobuf:"bytes,5,opt,name=recvId,proto3" json:"recvId,omitempty"`
+	MsgType        int32    `protobuf:"varint,6,opt,name=msgType,proto3" json:"msgType,omitempty"`
+	MsgContent     string   `protobuf:"bytes,7,opt,name=msgContent,proto3" json:"msgContent,omitempty"`
+	Body           *MsgBody `protobuf:"bytes,8,opt,name=body,proto3" json:"body,omitempty"`
+	// 计划发送的毫秒时间戳
+	SendTime int64 `protobuf:"varint,9,opt,name=sendTime,proto3" json:"sendTime,omitempty"`
+	// 状态 0. 等待发送 1. 发送中 2. 已发送 3. 已取消 4. 发送失败
+	Status int32 `protobuf:"varint,10,opt,name=status,proto3" json:"status,omitempty"`
+	// 发送失败的原因
+	Reason     string `protobuf:"bytes,11,opt,name=reason,proto3" json:"reason,omitempty"`
+	CreateTime int64  `protobuf:"varint,12,opt,name=createTime,proto3" json:"createTime,omitempty"`
+}
+
+func (x *ScheduledMsg) Reset() {
+	*x = ScheduledMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[56]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduledMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledMsg) ProtoMessage() {}
+
+func (x *ScheduledMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[56]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledMsg.ProtoReflect.Descriptor instead.
+func (*ScheduledMsg) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ScheduledMsg) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ScheduledMsg) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *ScheduledMsg) GetChatType() int32 {
+	if x != nil {
+		return x.ChatType
+	}
+	return 0
+}
+
+func (x *ScheduledMsg) GetSendId() string {
+	if x != nil {
+		return x.SendId
+	}
+	return ""
+}
+
+func (x *ScheduledMsg) GetRecvId() string {
+	if x != nil {
+		return x.RecvId
+	}
+	return ""
+}
+
+func (x *ScheduledMsg) GetMsgType() int32 {
+	if x != nil {
+		return x.MsgType
+	}
+	return 0
+}
+
+func (x *ScheduledMsg) GetMsgContent() string {
+	if x != nil {
+		return x.MsgContent
+	}
+	return ""
+}
+
+func (x *ScheduledMsg) GetBody() *MsgBody {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+func (x *ScheduledMsg) GetSendTime() int64 {
+	if x != nil {
+		return x.SendTime
+	}
+	return 0
+}
+
+func (x *ScheduledMsg) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *ScheduledMsg) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ScheduledMsg) GetCreateTime() int64 {
+	if x != nil {
+		return x.CreateTime
+	}
+	return 0
+}
+
+type CreateScheduledMsgReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SendId string `protobuf:"bytes,1,opt,name=sendId,proto3" json:"sendId,omitempty"`
+	// 私聊为对方的用户ID，群聊为群ID
+	RecvId     string   `protobuf:"bytes,2,opt,name=recvId,proto3" json:"recvId,omitempty"`
+	ChatType   int32    `protobuf:"varint,3,opt,name=chatType,proto3" json:"chatType,omitempty"`
+	MsgType    int32    `protobuf:"varint,4,opt,name=msgType,proto3" json:"msgType,omitempty"`
+	MsgContent string   `protobuf:"bytes,5,opt,name=msgContent,proto3" json:"msgContent,omitempty"`
+	Body       *MsgBody `protobuf:"bytes,6,opt,name=body,proto3" json:"body,omitempty"`
+	// 计划发送的毫秒时间戳，需要晚于当前时间
+	SendTime int64 `protobuf:"varint,7,opt,name=sendTime,proto3" json:"sendTime,omitempty"`
+}
+
+func (x *CreateScheduledMsgReq) Reset() {
+	*x = CreateScheduledMsgReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[57]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateScheduledMsgReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateScheduledMsgReq) ProtoMessage() {}
+
+func (x *CreateScheduledMsgReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[57]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateScheduledMsgReq.ProtoReflect.Descriptor instead.
+func (*CreateScheduledMsgReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *CreateScheduledMsgReq) GetSendId() string {
+	if x != nil {
+		return x.SendId
+	}
+	return ""
+}
+
+func (x *CreateScheduledMsgReq) GetRecvId() string {
+	if x != nil {
+		return x.RecvId
+	}
+	return ""
+}
+
+func (x *CreateScheduledMsgReq) GetChatType() int32 {
+	if x != nil {
+		return x.ChatType
+	}
+	return 0
+}
+
+func (x *CreateScheduledMsgReq) GetMsgType() int32 {
+	if x != nil {
+		return x.MsgType
+	}
+	return 0
+}
+
+func (x *CreateScheduledMsgReq) GetMsgContent() string {
+	if x != nil {
+		return x.MsgContent
+	}
+	return ""
+}
+
+func (x *CreateScheduledMsgReq) GetBody() *MsgBody {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+func (x *CreateScheduledMsgReq) GetSendTime() int64 {
+	if x != nil {
+		return x.SendTime
+	}
+	return 0
+}
+
+type CreateScheduledMsgResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Msg *ScheduledMsg `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"`
+}
+
+func (x *CreateScheduledMsgResp) Reset() {
+	*x = CreateScheduledMsgResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[58]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateScheduledMsgResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateScheduledMsgResp) ProtoMessage() {}
+
+func (x *CreateScheduledMsgResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[58]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateScheduledMsgResp.ProtoReflect.Descriptor instead.
+func (*CreateScheduledMsgResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *CreateScheduledMsgResp) GetMsg() *ScheduledMsg {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+type ListScheduledMsgsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+}
+
+func (x *ListScheduledMsgsReq) Reset() {
+	*x = ListScheduledMsgsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[59]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListScheduledMsgsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledMsgsReq) ProtoMessage() {}
+
+func (x *ListScheduledMsgsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[59]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledMsgsReq.ProtoReflect.Descriptor instead.
+func (*ListScheduledMsgsReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *ListScheduledMsgsReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListScheduledMsgsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// 按计划发送的时间升序，包括等待发送、发送中与发送失败的消息
+	List []*ScheduledMsg `protobuf:"bytes,1,rep,name=list,proto3" json:"list,omitempty"`
+}
+
+func (x *ListScheduledMsgsResp) Reset() {
+	*x = ListScheduledMsgsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[60]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListScheduledMsgsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledMsgsResp) ProtoMessage() {}
+
+func (x *ListScheduledMsgsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[60]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledMsgsResp.ProtoReflect.Descriptor instead.
+func (*ListScheduledMsgsResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ListScheduledMsgsResp) GetList() []*ScheduledMsg {
+	if x != nil {
+		return x.List
+	}
+	return nil
+}
+
+type CancelScheduledMsgReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Id     string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *CancelScheduledMsgReq) Reset() {
+	*x = CancelScheduledMsgReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[61]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelScheduledMsgReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledMsgReq) ProtoMessage() {}
+
+func (x *CancelScheduledMsgReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[61]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledMsgReq.ProtoReflect.Descriptor instead.
+func (*CancelScheduledMsgReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *CancelScheduledMsgReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CancelScheduledMsgReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CancelScheduledMsgResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CancelScheduledMsgResp) Reset() {
+	*x = CancelScheduledMsgResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[62]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelScheduledMsgResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledMsgResp) ProtoMessage() {}
+
+func (x *CancelScheduledMsgResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[62]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledMsgResp.ProtoReflect.Descriptor instead.
+func (*CancelScheduledMsgResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{62}
+}
+
+type SetUpUserConversationReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SendId   string `protobuf:"bytes,1,opt,name=SendId,proto3" json:"SendId,omitempty"`
+	RecvId   string `protobuf:"bytes,2,opt,name=recvId,proto3" json:"recvId,omitempty"`
+	ChatType int32  `protobuf:"varint,3,opt,name=chatType,proto3" json:"chatType,omitempty"`
+}
+
+func (x *SetUpUserConversationReq) Reset() {
+	*x = SetUpUserConversationReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[63]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetUpUserConversationReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUpUserConversationReq) ProtoMessage() {}
+
+func (x *SetUpUserConversationReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[63]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUpUserConversationReq.ProtoReflect.Descriptor instead.
+func (*SetUpUserConversationReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *SetUpUserConversationReq) GetSendId() string {
+	if x != nil {
+		return x.SendId
+	}
+	return ""
+}
+
+func (x *SetUpUserConversationReq) GetRecvId() string {
+	if x != nil {
+		return x.RecvId
+	}
+	return ""
+}
+
+func (x *SetUpUserConversationReq) GetChatType() int32 {
+	if x != nil {
+		return x.ChatType
+	}
+	return 0
+}
+
+type SetUpUserConversationResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SetUpUserConversationResp) Reset() {
+	*x = SetUpUserConversationResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[64]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetUpUserConversationResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUpUserConversationResp) ProtoMessage() {}
+
+func (x *SetUpUserConversationResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[64]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUpUserConversationResp.ProtoReflect.Descriptor instead.
+func (*SetUpUserConversationResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{64}
+}
+
+type CreateGroupConversationReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GroupId  string `protobuf:"bytes,1,opt,name=GroupId,proto3" json:"GroupId,omitempty"`
+	CreateId string `protobuf:"bytes,2,opt,name=CreateId,proto3" json:"CreateId,omitempty"`
+}
+
+func (x *CreateGroupConversationReq) Reset() {
+	*x = CreateGroupConversationReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[65]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateGroupConversationReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGroupConversationReq) ProtoMessage() {}
+
+func (x *CreateGroupConversationReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[65]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGroupConversationReq.ProtoReflect.Descriptor instead.
+func (*CreateGroupConversationReq) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *CreateGroupConversationReq) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *CreateGroupConversationReq) GetCreateId() string {
+	if x != nil {
+		return x.CreateId
+	}
+	return ""
+}
+
+type CreateGroupConversationResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CreateGroupConversationResp) Reset() {
+	*x = CreateGroupConversationResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apps_im_rpc_im_proto_msgTypes[66]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateGroupConversationResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGroupConversationResp) ProtoMessage() {}
+
+func (x *CreateGroupConversationResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apps_im_rpc_im_proto_msgTypes[66]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGroupConversationResp.ProtoReflect.Descriptor instead.
+func (*CreateGroupConversationResp) Descriptor() ([]byte, []int) {
+	return file_apps_im_rpc_im_proto_rawDescGZIP(), []int{66}
+}
+
+var File_apps_im_rpc_im_proto protoreflect.FileDescriptor
+
+var file_apps_im_rpc_im_proto_rawDesc = []byte{
+	0x0a, 0x14, 0x61, 0x70, 0x70, 0x73, 0x2f, 0x69, 0x6d, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x69, 0x6d,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x69, 0x6d, 0x22, 0x89, 0x01, 0x0a, 0x05, 0x49,
+	0x6d, 0x61, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x68, 0x75, 0x6d, 0x62, 0x6e,
+	0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x68, 0x75, 0x6d, 0x62,
+	0x6e, 0x61, 0x69, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74,
+	0x49, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68,
+	0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x22, 0x78, 0x0a, 0x04, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x69, 0x6d, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x69, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0c,
+	0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64,
+	0x22, 0x59, 0x0a, 0x05, 0x56, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x61, 0x74, 0x74, 0x61, 0x63,
+	0x68, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61,
+	0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x22, 0x72, 0x0a, 0x08, 0x4c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74,
+	0x75, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74,
+	0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22,
+	0x6a, 0x0a, 0x04, 0x43, 0x61, 0x72, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x72, 0x64, 0x54,
+	0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x63, 0x61, 0x72, 0x64, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72, 0x22, 0xb1, 0x01, 0x0a, 0x07,
+	0x4d, 0x73, 0x67, 0x42, 0x6f, 0x64, 0x79, 0x12, 0x1f, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x69, 0x6d, 0x2e, 0x49, 0x6d, 0x61, 0x67,
+	0x65, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x69, 0x6d, 0x2e, 0x46, 0x69, 0x6c, 0x65,
+	0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x1f, 0x0a, 0x05, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x69, 0x6d, 0x2e, 0x56, 0x6f, 0x69, 0x63, 0x65,
+	0x52, 0x05, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x28, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x69, 0x6d, 0x2e, 0x4c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x1c, 0x0a, 0x04, 0x63, 0x61, 0x72, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x08, 0x2e, 0x69, 0x6d, 0x2e, 0x43, 0x61, 0x72, 0x64, 0x52, 0x04, 0x63, 0x61, 0x72, 0x64, 0x22,
+	0x35, 0x0a, 0x07, 0x4d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x75, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x6c, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x03, 0x61, 0x6c, 0x6c, 0x22, 0x50, 0x0a, 0x08, 0x52, 0x65, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x6f, 0x6a, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x65, 0x6d, 0x6f, 0x6a, 0x69, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x18,
+	0x0a, 0x07, 0x72, 0x65, 0x61, 0x63, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x07, 0x72, 0x65, 0x61, 0x63, 0x74, 0x65, 0x64, 0x22, 0x77, 0x0a, 0x06, 0x54, 0x68, 0x72, 0x65,
+	0x61, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x27, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x69, 0x6d, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65,
+	0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x24, 0x0a, 0x0d, 0x6c,
+	0x61, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x54, 0x69, 0x6d,
+	0x65, 0x22, 0x81, 0x01, 0x0a, 0x05, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6d,
+	0x73, 0x67, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x73, 0x67, 0x49,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x73, 0x67,
+	0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6d, 0x73, 0x67, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xa2, 0x04, 0x0a, 0x07, 0x43, 0x68, 0x61, 0x74, 0x4c, 0x6f,
+	0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65,
+	0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e,
+	0x64, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x49,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x63, 0x76, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x72, 0x65, 0x63, 0x76, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x73, 0x67,
+	0x54, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6d, 0x73, 0x67, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x73, 0x67, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x73, 0x67, 0x43, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x63, 0x68, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x53, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x08, 0x53, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x72,
+	0x65, 0x61, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0b, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x10, 0x0a,
+	0x03, 0x73, 0x65, 0x71, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x1f, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0b, 0x2e, 0x69, 0x6d, 0x2e, 0x4d, 0x73, 0x67, 0x42, 0x6f, 0x64, 0x79, 0x52, 0x04, 0x62, 0x6f,
+	0x64, 0x79, 0x12, 0x23, 0x0a, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x54, 0x6f, 0x18, 0x0e, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x69, 0x6d, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x07,
+	0x72, 0x65, 0x70, 0x6c, 0x79, 0x54, 0x6f, 0x12, 0x25, 0x0a, 0x07, 0x6d, 0x65, 0x6e, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x69, 0x6d, 0x2e, 0x4d, 0x65,
+	0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2a,
+	0x0a, 0x09, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0c, 0x2e, 0x69, 0x6d, 0x2e, 0x52, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x09, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x68,
+	0x72, 0x65, 0x61, 0x64, 0x49, 0x64, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x68,
+	0x72, 0x65, 0x61, 0x64, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x06, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64,
+	0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x69, 0x6d, 0x2e, 0x54, 0x68, 0x72, 0x65,
+	0x61, 0x64, 0x52, 0x06, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x22, 0xb4, 0x04, 0x0a, 0x0c, 0x43,
+	0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x0e, 0x63,
+	0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x63, 0x68, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x69,
+	0x73, 0x53, 0x68, 0x6f, 0x77, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x69, 0x73, 0x53,
+	0x68, 0x6f, 0x77, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x74,
+	0x6f, 0x52, 0x65, 0x61, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x74, 0x6f, 0x52,
+	0x65, 0x61, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x52, 0x65, 0x61, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x04, 0x52, 0x65, 0x61, 0x64, 0x12, 0x1d, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x69, 0x6d, 0x2e, 0x43, 0x68, 0x61, 0x74, 0x4c, 0x6f,
+	0x67, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x61, 0x64, 0x53, 0x65,
+	0x71, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x72, 0x65, 0x61, 0x64, 0x53, 0x65, 0x71,
+	0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x71, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x71,
+	0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x65, 0x64, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x09, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x65, 0x64, 0x12, 0x1b,
+	0x0a, 0x04, 0x70, 0x69, 0x6e, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x07, 0x2e, 0x69,
+	0x6d, 0x2e, 0x50, 0x69, 0x6e, 0x52, 0x04, 0x70, 0x69, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x70,
+	0x69, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x70, 0x69,
+	0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x75, 0x74, 0x65, 0x55, 0x6e, 0x74,
+	0x69, 0x6c, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6d, 0x75, 0x74, 0x65, 0x55, 0x6e,
+	0x74, 0x69, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64, 0x18,
+	0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64, 0x12,
+	0x1a, 0x0a, 0x08, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x53, 0x65, 0x71, 0x18, 0x11, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x08, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x53, 0x65, 0x71, 0x12, 0x20, 0x0a, 0x0b, 0x6c,
+	0x61, 0x73, 0x74, 0x4d, 0x73, 0x67, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x4d, 0x73, 0x67, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x64, 0x72, 0x61, 0x66, 0x74, 0x18, 0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x64, 0x72,
+	0x61, 0x66, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x72, 0x61, 0x66, 0x74, 0x54, 0x69, 0x6d, 0x65,
+	0x18, 0x14, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x64, 0x72, 0x61, 0x66, 0x74, 0x54, 0x69, 0x6d,
+	0x65, 0x22, 0x63, 0x0a, 0x03, 0x50, 0x69, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x73, 0x67, 0x49,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x73, 0x67, 0x49, 0x64, 0x12, 0x10,
+	0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x73, 0x65, 0x71,
+	0x12, 0x1a, 0x0a, 0x08, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x42, 0x79, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x42, 0x79, 0x12, 0x18, 0x0a, 0x07,
+	0x70, 0x69, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x70,
+	0x69, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x2d, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e,
+	0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x95, 0x02, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e,
+	0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x5a,
+	0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69,
+	0x73, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x69, 0x6d, 0x2e, 0x47, 0x65,
+	0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x2e, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c,
+	0x69, 0x73, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x10, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72,
+	0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x0f, 0x63, 0x6f,
+	0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x64, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x75, 0x6e, 0x72, 0x65, 0x61, 0x64, 0x54, 0x6f,
+	0x74, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x75, 0x6e, 0x72, 0x65, 0x61,
+	0x64, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x1a, 0x55, 0x0a, 0x15, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72,
+	0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x26, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x69, 0x6d, 0x2e, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xef, 0x01,
+	0x0a, 0x13, 0x50, 0x75, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x52, 0x65, 0x71, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x59, 0x0a,
+	0x10, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73,
+	0x74, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x69, 0x6d, 0x2e, 0x50, 0x75, 0x74,
+	0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71,
+	0x2e, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73,
+	0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x10, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x1a, 0x55, 0x0a, 0x15, 0x43, 0x6f, 0x6e, 0x76,
+	0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x26, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x69, 0x6d, 0x2e, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x16, 0x0a, 0x14, 0x50, 0x75, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x9b, 0x02, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x43,
+	0x68, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e,
+	0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49,
+	0x64, 0x12, 0x24, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x6e, 0x64, 0x54, 0x69,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x53,
+	0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x65, 0x6e, 0x64, 0x53, 0x65,
+	0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x65, 0x6e,
+	0x64, 0x53, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x6d, 0x73, 0x67, 0x49, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x6d, 0x73, 0x67, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63,
+	0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x4d,
+	0x73, 0x67, 0x49, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x6e, 0x63, 0x68,
+	0x6f, 0x72, 0x4d, 0x73, 0x67, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x8f, 0x01, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x61,
+	0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x12, 0x1f, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x69, 0x6d, 0x2e, 0x43, 0x68, 0x61, 0x74,
+	0x4c, 0x6f, 0x67, 0x52, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x68, 0x61, 0x73,
+	0x4d, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x68, 0x61, 0x73, 0x4d,
+	0x6f, 0x72, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x43, 0x75, 0x72, 0x73,
+	0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x43,
+	0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x6e, 0x65, 0x77, 0x65, 0x72, 0x43, 0x75,
+	0x72, 0x73, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6e, 0x65, 0x77, 0x65,
+	0x72, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x22, 0x52, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x52, 0x65,
+	0x61, 0x64, 0x53, 0x65, 0x71, 0x73, 0x52, 0x65, 0x71, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e,
+	0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49,
+	0x64, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x07, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x73, 0x22, 0x8d, 0x01, 0x0a, 0x0f,
+	0x47, 0x65, 0x74, 0x52, 0x65, 0x61, 0x64, 0x53, 0x65, 0x71, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12,
+	0x3d, 0x0a, 0x08, 0x72, 0x65, 0x61, 0x64, 0x53, 0x65, 0x71, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x21, 0x2e, 0x69, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x61, 0x64, 0x53, 0x65,
+	0x71, 0x73, 0x52, 0x65, 0x73, 0x70, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x53, 0x65, 0x71, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x72, 0x65, 0x61, 0x64, 0x53, 0x65, 0x71, 0x73, 0x1a, 0x3b,
+	0x0a, 0x0d, 0x52, 0x65, 0x61, 0x64, 0x53, 0x65, 0x71, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x3c, 0x0a, 0x0c, 0x52,
+	0x65, 0x63, 0x61, 0x6c, 0x6c, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x75,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x73, 0x67, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x6d, 0x73, 0x67, 0x49, 0x64, 0x22, 0x0f, 0x0a, 0x0d, 0x52, 0x65, 0x63,
+	0x61, 0x6c, 0x6c, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x73, 0x70, 0x22, 0x54, 0x0a, 0x0a, 0x45, 0x64,
+	0x69, 0x74, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72,
+	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x6d, 0x73, 0x67, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x6d, 0x73, 0x67, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x22, 0x27, 0x0a, 0x0b, 0x45, 0x64, 0x69, 0x74, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x73, 0x70, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x7b, 0x0a, 0x13, 0x47, 0x65, 0x74,
+	0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x68, 0x72, 0x65,
+	0x61, 0x64, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x68, 0x72, 0x65,
+	0x61, 0x64, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x66, 0x74, 0x65, 0x72, 0x53, 0x65, 0x71,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x61, 0x66, 0x74, 0x65, 0x72, 0x53, 0x65, 0x71,
+	0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x58, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x54, 0x68, 0x72,
+	0x65, 0x61, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x1f,
+	0x0a, 0x04, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x69,
+	0x6d, 0x2e, 0x43, 0x68, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x04, 0x72, 0x6f, 0x6f, 0x74, 0x12,
+	0x1f, 0x0a, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e,
+	0x69, 0x6d, 0x2e, 0x43, 0x68, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x04, 0x6c, 0x69, 0x73, 0x74,
+	0x22, 0x69, 0x0a, 0x0b, 0x52, 0x65, 0x61, 0x63, 0x74, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x12,
+	0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x73, 0x67, 0x49, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x73, 0x67, 0x49, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x65, 0x6d, 0x6f, 0x6a, 0x69, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d,
+	0x6f, 0x6a, 0x69, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x22, 0x24, 0x0a, 0x0c, 0x52,
+	0x65, 0x61, 0x63, 0x74, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x73, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x22, 0x4f, 0x0a, 0x09, 0x50, 0x69, 0x6e, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x12, 0x16,
+	0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x73, 0x67, 0x49, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x73, 0x67, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x75, 0x6e, 0x70, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x75, 0x6e, 0x70,
+	0x69, 0x6e, 0x22, 0x0c, 0x0a, 0x0a, 0x50, 0x69, 0x6e, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x73, 0x70,
+	0x22, 0x52, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x50, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x4d, 0x73, 0x67,
+	0x73, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x0e,
+	0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x64, 0x22, 0x60, 0x0a, 0x09, 0x50, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x4d, 0x73,
+	0x67, 0x12, 0x1d, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b,
+	0x2e, 0x69, 0x6d, 0x2e, 0x43, 0x68, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x03, 0x6d, 0x73, 0x67,
+	0x12, 0x1a, 0x0a, 0x08, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x42, 0x79, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x42, 0x79, 0x12, 0x18, 0x0a, 0x07,
+	0x70, 0x69, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x70,
+	0x69, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x36, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x50, 0x69, 0x6e,
+	0x6e, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x21, 0x0a, 0x04, 0x6c,
+	0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x69, 0x6d, 0x2e, 0x50,
+	0x69, 0x6e, 0x6e, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x52, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x22, 0x52,
+	0x0a, 0x0a, 0x53, 0x74, 0x61, 0x72, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06,
+	0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x73, 0x67, 0x49, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x73, 0x67, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x6e,
+	0x73, 0x74, 0x61, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x75, 0x6e, 0x73, 0x74,
+	0x61, 0x72, 0x22, 0x0d, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x72, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x73,
+	0x70, 0x22, 0x61, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x72, 0x72, 0x65, 0x64, 0x4d,
+	0x73, 0x67, 0x73, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1e,
+	0x0a, 0x0a, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0a, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x22, 0x47, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x72, 0x72, 0x65, 0x64, 0x4d,
+	0x73, 0x67, 0x12, 0x1d, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0b, 0x2e, 0x69, 0x6d, 0x2e, 0x43, 0x68, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x03, 0x6d, 0x73,
+	0x67, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x72, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x74, 0x61, 0x72, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x38, 0x0a,
+	0x12, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x72, 0x72, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x12, 0x22, 0x0a, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0e, 0x2e, 0x69, 0x6d, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x72, 0x65, 0x64, 0x4d, 0x73,
+	0x67, 0x52, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x22, 0xe7, 0x01, 0x0a, 0x0d, 0x53, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x4d, 0x73, 0x67, 0x73, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x18, 0x0a, 0x07, 0x6b, 0x65, 0x79, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6b, 0x65, 0x79, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x63,
+	0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x49, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x64,
+	0x54, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x22, 0x44, 0x0a, 0x09, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x48, 0x69, 0x74, 0x12, 0x1d,
+	0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x69, 0x6d,
+	0x2e, 0x43, 0x68, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12, 0x18, 0x0a,
+	0x07, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x22, 0x49, 0x0a, 0x0e, 0x53, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x4d, 0x73, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x21, 0x0a, 0x04, 0x6c, 0x69, 0x73,
+	0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x69, 0x6d, 0x2e, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x48, 0x69, 0x74, 0x52, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x22, 0x6a, 0x0a, 0x12, 0x50, 0x69, 0x6e, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72,
+	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72,
+	0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x6e, 0x70, 0x69,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x75, 0x6e, 0x70, 0x69, 0x6e, 0x22, 0x15,
+	0x0a, 0x13, 0x50, 0x69, 0x6e, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x22, 0x73, 0x0a, 0x13, 0x4d, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x6e,
+	0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06,
+	0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f,
+	0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09,
+	0x6d, 0x75, 0x74, 0x65, 0x55, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x6d, 0x75, 0x74, 0x65, 0x55, 0x6e, 0x74, 0x69, 0x6c, 0x22, 0x16, 0x0a, 0x14, 0x4d, 0x75,
+	0x74, 0x65, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x22, 0x76, 0x0a, 0x16, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x43, 0x6f, 0x6e,
+	0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06,
+	0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f,
+	0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09,
+	0x75, 0x6e, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x09, 0x75, 0x6e, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x22, 0x19, 0x0a, 0x17, 0x41, 0x72,
+	0x63, 0x68, 0x69, 0x76, 0x65, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x22, 0x7b, 0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43,
+	0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x12, 0x16,
+	0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72,
+	0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
+	0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x22,
+	0x0a, 0x0c, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x22, 0x18, 0x0a, 0x16, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x76,
+	0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x22, 0x5d, 0x0a, 0x1b,
+	0x43, 0x6c, 0x65, 0x61, 0x72, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x75,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e,
+	0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x1e, 0x0a, 0x1c, 0x43,
+	0x6c, 0x65, 0x61, 0x72, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x22, 0x78, 0x0a, 0x14, 0x4c,
+	0x69, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x63,
+	0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72,
+	0x73, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x72, 0x63,
+	0x68, 0x69, 0x76, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x61, 0x72, 0x63,
+	0x68, 0x69, 0x76, 0x65, 0x64, 0x22, 0x77, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6e,
+	0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x24,
+	0x0a, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x69,
+	0x6d, 0x2e, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04,
+	0x6c, 0x69, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x43, 0x75, 0x72, 0x73,
+	0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x43, 0x75,
+	0x72, 0x73, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x68, 0x61, 0x73, 0x4d, 0x6f, 0x72, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x68, 0x61, 0x73, 0x4d, 0x6f, 0x72, 0x65, 0x22, 0x68,
+	0x0a, 0x0c, 0x53, 0x61, 0x76, 0x65, 0x44, 0x72, 0x61, 0x66, 0x74, 0x52, 0x65, 0x71, 0x12, 0x16,
+	0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72,
+	0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
+	0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x18,
+	0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x2d, 0x0a, 0x0d, 0x53, 0x61, 0x76, 0x65,
+	0x44, 0x72, 0x61, 0x66, 0x74, 0x52, 0x65, 0x73, 0x70, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x72, 0x61,
+	0x66, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x64, 0x72,
+	0x61, 0x66, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x22, 0xd9, 0x02, 0x0a, 0x0c, 0x53, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x76,
+	0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64,
+	0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x63, 0x68, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x65, 0x6e, 0x64, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65,
+	0x6e, 0x64, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x63, 0x76, 0x49, 0x64, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x63, 0x76, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x73, 0x67, 0x54, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6d,
+	0x73, 0x67, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x73, 0x67, 0x43, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x73, 0x67, 0x43,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x69, 0x6d, 0x2e, 0x4d, 0x73, 0x67, 0x42, 0x6f, 0x64,
+	0x79, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x54,
+	0x69, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d,
+	0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54,
+	0x69, 0x6d, 0x65, 0x22, 0xda, 0x01, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x65, 0x6e, 0x64, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x65, 0x6e, 0x64, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x63, 0x76, 0x49, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x63, 0x76, 0x49, 0x64, 0x12, 0x1a, 0x0a,
+	0x08, 0x63, 0x68, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x08, 0x63, 0x68, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x73, 0x67,
+	0x54, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6d, 0x73, 0x67, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x73, 0x67, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x73, 0x67, 0x43, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0b, 0x2e, 0x69, 0x6d, 0x2e, 0x4d, 0x73, 0x67, 0x42, 0x6f, 0x64, 0x79, 0x52, 0x04,
+	0x62, 0x6f, 0x64, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65,
+	0x22, 0x3c, 0x0a, 0x16, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x73, 0x70, 0x12, 0x22, 0x0a, 0x03, 0x6d, 0x73,
+	0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x69, 0x6d, 0x2e, 0x53, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x2e,
+	0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x4d,
+	0x73, 0x67, 0x73, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x3d,
+	0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x4d,
+	0x73, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x24, 0x0a, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x69, 0x6d, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x52, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x22, 0x3f, 0x0a,
+	0x15, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64,
+	0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x18,
+	0x0a, 0x16, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x64, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x73, 0x70, 0x22, 0x66, 0x0a, 0x18, 0x53, 0x65, 0x74, 0x55,
+	0x70, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x65, 0x6e, 0x64, 0x49, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x65, 0x6e, 0x64, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x72, 0x65, 0x63, 0x76, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65,
+	0x63, 0x76, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x63, 0x68, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x22, 0x1b, 0x0a, 0x19, 0x53, 0x65, 0x74, 0x55, 0x70, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e,
+	0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x22, 0x52, 0x0a,
+	0x1a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x43, 0x6f, 0x6e, 0x76,
+	0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x12, 0x18, 0x0a, 0x07, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49,
+	0x64, 0x22, 0x1d, 0x0a, 0x1b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x32, 0xf5, 0x0c, 0x0a, 0x02, 0x49, 0x6d, 0x12, 0x33, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x43, 0x68,
+	0x61, 0x74, 0x4c, 0x6f, 0x67, 0x12, 0x11, 0x2e, 0x69, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x68,
+	0x61, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x1a, 0x12, 0x2e, 0x69, 0x6d, 0x2e, 0x47, 0x65,
+	0x74, 0x43, 0x68, 0x61, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x12, 0x54, 0x0a, 0x15,
+	0x53, 0x65, 0x74, 0x55, 0x70, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x2e, 0x69, 0x6d, 0x2e, 0x53, 0x65, 0x74, 0x55, 0x70,
+	0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x1a, 0x1d, 0x2e, 0x69, 0x6d, 0x2e, 0x53, 0x65, 0x74, 0x55, 0x70, 0x55, 0x73,
+	0x65, 0x72, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x12, 0x45, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x17, 0x2e, 0x69, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x43,
+	0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x1a,
+	0x18, 0x2e, 0x69, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x45, 0x0a, 0x10, 0x50, 0x75, 0x74,
+	0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x17, 0x2e,
+	0x69, 0x6d, 0x2e, 0x50, 0x75, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x18, 0x2e, 0x69, 0x6d, 0x2e, 0x50, 0x75, 0x74, 0x43,
+	0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x12, 0x5a, 0x0a, 0x17, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x43,
+	0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x2e, 0x69, 0x6d,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x43, 0x6f, 0x6e, 0x76,
+	0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x1a, 0x1f, 0x2e, 0x69, 0x6d,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x43, 0x6f, 0x6e, 0x76,
+	0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x12, 0x36, 0x0a, 0x0b,
+	0x47, 0x65, 0x74, 0x52, 0x65, 0x61, 0x64, 0x53, 0x65, 0x71, 0x73, 0x12, 0x12, 0x2e, 0x69, 0x6d,
+	0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x61, 0x64, 0x53, 0x65, 0x71, 0x73, 0x52, 0x65, 0x71, 0x1a,
+	0x13, 0x2e, 0x69, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x61, 0x64, 0x53, 0x65, 0x71, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x12, 0x30, 0x0a, 0x09, 0x52, 0x65, 0x63, 0x61, 0x6c, 0x6c, 0x4d, 0x73,
+	0x67, 0x12, 0x10, 0x2e, 0x69, 0x6d, 0x2e, 0x52, 0x65, 0x63, 0x61, 0x6c, 0x6c, 0x4d, 0x73, 0x67,
+	0x52, 0x65, 0x71, 0x1a, 0x11, 0x2e, 0x69, 0x6d, 0x2e, 0x52, 0x65, 0x63, 0x61, 0x6c, 0x6c, 0x4d,
+	0x73, 0x67, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2a, 0x0a, 0x07, 0x45, 0x64, 0x69, 0x74, 0x4d, 0x73,
+	0x67, 0x12, 0x0e, 0x2e, 0x69, 0x6d, 0x2e, 0x45, 0x64, 0x69, 0x74, 0x4d, 0x73, 0x67, 0x52, 0x65,
+	0x71, 0x1a, 0x0f, 0x2e, 0x69, 0x6d, 0x2e, 0x45, 0x64, 0x69, 0x74, 0x4d, 0x73, 0x67, 0x52, 0x65,
+	0x73, 0x70, 0x12, 0x2d, 0x0a, 0x08, 0x52, 0x65, 0x61, 0x63, 0x74, 0x4d, 0x73, 0x67, 0x12, 0x0f,
+	0x2e, 0x69, 0x6d, 0x2e, 0x52, 0x65, 0x61, 0x63, 0x74, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x1a,
+	0x10, 0x2e, 0x69, 0x6d, 0x2e, 0x52, 0x65, 0x61, 0x63, 0x74, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x73,
+	0x70, 0x12, 0x45, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65,
+	0x70, 0x6c, 0x69, 0x65, 0x73, 0x12, 0x17, 0x2e, 0x69, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x68,
+	0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x18,
+	0x2e, 0x69, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x70,
+	0x6c, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x27, 0x0a, 0x06, 0x50, 0x69, 0x6e, 0x4d,
+	0x73, 0x67, 0x12, 0x0d, 0x2e, 0x69, 0x6d, 0x2e, 0x50, 0x69, 0x6e, 0x4d, 0x73, 0x67, 0x52, 0x65,
+	0x71, 0x1a, 0x0e, 0x2e, 0x69, 0x6d, 0x2e, 0x50, 0x69, 0x6e, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x73,
+	0x70, 0x12, 0x3c, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x50, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x4d, 0x73,
+	0x67, 0x73, 0x12, 0x14, 0x2e, 0x69, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x69, 0x6e, 0x6e, 0x65,
+	0x64, 0x4d, 0x73, 0x67, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x15, 0x2e, 0x69, 0x6d, 0x2e, 0x47, 0x65,
+	0x74, 0x50, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12,
+	0x2a, 0x0a, 0x07, 0x53, 0x74, 0x61, 0x72, 0x4d, 0x73, 0x67, 0x12, 0x0e, 0x2e, 0x69, 0x6d, 0x2e,
+	0x53, 0x74, 0x61, 0x72, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x1a, 0x0f, 0x2e, 0x69, 0x6d, 0x2e,
+	0x53, 0x74, 0x61, 0x72, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x73, 0x70, 0x12, 0x3f, 0x0a, 0x0e, 0x47,
+	0x65, 0x74, 0x53, 0x74, 0x61, 0x72, 0x72, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x73, 0x12, 0x15, 0x2e,
+	0x69, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x72, 0x72, 0x65, 0x64, 0x4d, 0x73, 0x67,
+	0x73, 0x52, 0x65, 0x71, 0x1a, 0x16, 0x2e, 0x69, 0x6d, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61,
+	0x72, 0x72, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x33, 0x0a, 0x0a,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4d, 0x73, 0x67, 0x73, 0x12, 0x11, 0x2e, 0x69, 0x6d, 0x2e,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4d, 0x73, 0x67, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x12, 0x2e,
+	0x69, 0x6d, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4d, 0x73, 0x67, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x12, 0x42, 0x0a, 0x0f, 0x50, 0x69, 0x6e, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x2e, 0x69, 0x6d, 0x2e, 0x50, 0x69, 0x6e, 0x43, 0x6f, 0x6e,
+	0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x69,
+	0x6d, 0x2e, 0x50, 0x69, 0x6e, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x12, 0x45, 0x0a, 0x10, 0x4d, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x6e,
+	0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x17, 0x2e, 0x69, 0x6d, 0x2e, 0x4d,
+	0x75, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x1a, 0x18, 0x2e, 0x69, 0x6d, 0x2e, 0x4d, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x76,
+	0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x12, 0x4e, 0x0a, 0x13,
+	0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x2e, 0x69, 0x6d, 0x2e, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65,
+	0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x1a,
+	0x1b, 0x2e, 0x69, 0x6d, 0x2e, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x43, 0x6f, 0x6e, 0x76,
+	0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x12, 0x4b, 0x0a, 0x12,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x69, 0x6d, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f,
+	0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x1a, 0x1a, 0x2e,
+	0x69, 0x6d, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x12, 0x5d, 0x0a, 0x18, 0x43, 0x6c, 0x65,
+	0x61, 0x72, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x69,
+	0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x1f, 0x2e, 0x69, 0x6d, 0x2e, 0x43, 0x6c, 0x65, 0x61, 0x72,
+	0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x1a, 0x20, 0x2e, 0x69, 0x6d, 0x2e, 0x43, 0x6c, 0x65, 0x61,
+	0x72, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x12, 0x48, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74,
+	0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x18, 0x2e,
+	0x69, 0x6d, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x19, 0x2e, 0x69, 0x6d, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x12, 0x30, 0x0a, 0x09, 0x53, 0x61, 0x76, 0x65, 0x44, 0x72, 0x61, 0x66, 0x74, 0x12,
+	0x10, 0x2e, 0x69, 0x6d, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x44, 0x72, 0x61, 0x66, 0x74, 0x52, 0x65,
+	0x71, 0x1a, 0x11, 0x2e, 0x69, 0x6d, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x44, 0x72, 0x61, 0x66, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x12, 0x4b, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x12, 0x19, 0x2e, 0x69, 0x6d, 0x2e,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x4d,
+	0x73, 0x67, 0x52, 0x65, 0x71, 0x1a, 0x1a, 0x2e, 0x69, 0x6d, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x73,
+	0x70, 0x12, 0x48, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c,
+	0x65, 0x64, 0x4d, 0x73, 0x67, 0x73, 0x12, 0x18, 0x2e, 0x69, 0x6d, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x73, 0x52, 0x65, 0x71,
+	0x1a, 0x19, 0x2e, 0x69, 0x6d, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x4b, 0x0a, 0x12, 0x43,
+	0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x4d, 0x73,
+	0x67, 0x12, 0x19, 0x2e, 0x69, 0x6d, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x1a, 0x1a, 0x2e, 0x69,
+	0x6d, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x64, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x73, 0x70, 0x42, 0x06, 0x5a, 0x04, 0x2e, 0x2f, 0x69, 0x6d,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_apps_im_rpc_im_proto_rawDescOnce sync.Once
+	file_apps_im_rpc_im_proto_rawDescData = file_apps_im_rpc_im_proto_rawDesc
+)
+
+func file_apps_im_rpc_im_proto_rawDescGZIP() []byte {
+	file_apps_im_rpc_im_proto_rawDescOnce.Do(func() {
+		file_apps_im_rpc_im_proto_rawDescData = protoimpl.X.CompressGZIP(file_apps_im_rpc_im_proto_rawDescData)
+	})
+	return file_apps_im_rpc_im_proto_rawDescData
+}
+
+var file_apps_im_rpc_im_proto_msgTypes = make([]protoimpl.MessageInfo, 70)
+var file_apps_im_rpc_im_proto_goTypes = []any{
+	(*Image)(nil),                        // 0: im.Image
+	(*File)(nil),                         // 1: im.File
+	(*Voice)(nil),                        // 2: im.Voice
+	(*Location)(nil),                     // 3: im.Location
+	(*Card)(nil),                         // 4: im.Card
+	(*MsgBody)(nil),                      // 5: im.MsgBody
+	(*Mention)(nil),                      // 6: im.Mention
+	(*Reaction)(nil),                     // 7: im.Reaction
+	(*Thread)(nil),                       // 8: im.Thread
+	(*Quote)(nil),                        // 9: im.Quote
+	(*ChatLog)(nil),                      // 10: im.ChatLog
+	(*Conversation)(nil),                 // 11: im.Conversation
+	(*Pin)(nil),                          // 12: im.Pin
+	(*GetConversationsReq)(nil),          // 13: im.GetConversationsReq
+	(*GetConversationsResp)(nil),         // 14: im.GetConversationsResp
+	(*PutConversationsReq)(nil),          // 15: im.PutConversationsReq
+	(*PutConversationsResp)(nil),         // 16: im.PutConversationsResp
+	(*GetChatLogReq)(nil),                // 17: im.GetChatLogReq
+	(*GetChatLogResp)(nil),               // 18: im.GetChatLogResp
+	(*GetReadSeqsReq)(nil),               // 19: im.GetReadSeqsReq
+	(*GetReadSeqsResp)(nil),              // 20: im.GetReadSeqsResp
+	(*RecallMsgReq)(nil),                 // 21: im.RecallMsgReq
+	(*RecallMsgResp)(nil),                // 22: im.RecallMsgResp
+	(*EditMsgReq)(nil),                   // 23: im.EditMsgReq
+	(*EditMsgResp)(nil),                  // 24: im.EditMsgResp
+	(*GetThreadRepliesReq)(nil),          // 25: im.GetThreadRepliesReq
+	(*GetThreadRepliesResp)(nil),         // 26: im.GetThreadRepliesResp
+	(*ReactMsgReq)(nil),                  // 27: im.ReactMsgReq
+	(*ReactMsgResp)(nil),                 // 28: im.ReactMsgResp
+	(*PinMsgReq)(nil),                    // 29: im.PinMsgReq
+	(*PinMsgResp)(nil),                   // 30: im.PinMsgResp
+	(*GetPinnedMsgsReq)(nil),             // 31: im.GetPinnedMsgsReq
+	(*PinnedMsg)(nil),                    // 32: im.PinnedMsg
+	(*GetPinnedMsgsResp)(nil),            // 33: im.GetPinnedMsgsResp
+	(*StarMsgReq)(nil),                   // 34: im.StarMsgReq
+	(*StarMsgResp)(nil),                  // 35: im.StarMsgResp
+	(*GetStarredMsgsReq)(nil),            // 36: im.GetStarredMsgsReq
+	(*StarredMsg)(nil),                   // 37: im.StarredMsg
+	(*GetStarredMsgsResp)(nil),           // 38: im.GetStarredMsgsResp
+	(*SearchMsgsReq)(nil),                // 39: im.SearchMsgsReq
+	(*SearchHit)(nil),                    // 40: im.SearchHit
+	(*SearchMsgsResp)(nil),               // 41: im.SearchMsgsResp
+	(*PinConversationReq)(nil),           // 42: im.PinConversationReq
+	(*PinConversationResp)(nil),          // 43: im.PinConversationResp
+	(*MuteConversationReq)(nil),          // 44: im.MuteConversationReq
+	(*MuteConversationResp)(nil),         // 45: im.MuteConversationResp
+	(*ArchiveConversationReq)(nil),       // 46: im.ArchiveConversationReq
+	(*ArchiveConversationResp)(nil),      // 47: im.ArchiveConversationResp
+	(*DeleteConversationReq)(nil),        // 48: im.DeleteConversationReq
+	(*DeleteConversationResp)(nil),       // 49: im.DeleteConversationResp
+	(*ClearConversationHistoryReq)(nil),  // 50: im.ClearConversationHistoryReq
+	(*ClearConversationHistoryResp)(nil), // 51: im.ClearConversationHistoryResp
+	(*ListConversationsReq)(nil),         // 52: im.ListConversationsReq
+	(*ListConversationsResp)(nil),        // 53: im.ListConversationsResp
+	(*SaveDraftReq)(nil),                 // 54: im.SaveDraftReq
+	(*SaveDraftResp)(nil),                // 55: im.SaveDraftResp
+	(*ScheduledMsg)(nil),                 // 56: im.ScheduledMsg
+	(*CreateScheduledMsgReq)(nil),        // 57: im.CreateScheduledMsgReq
+	(*CreateScheduledMsgResp)(nil),       // 58: im.CreateScheduledMsgResp
+	(*ListScheduledMsgsReq)(nil),         // 59: im.ListScheduledMsgsReq
+	(*ListScheduledMsgsResp)(nil),        // 60: im.ListScheduledMsgsResp
+	(*CancelScheduledMsgReq)(nil),        // 61: im.CancelScheduledMsgReq
+	(*CancelScheduledMsgResp)(nil),       // 62: im.CancelScheduledMsgResp
+	(*SetUpUserConversationReq)(nil),     // 63: im.SetUpUserConversationReq
+	(*SetUpUserConversationResp)(nil),    // 64: im.SetUpUserConversationResp
+	(*CreateGroupConversationReq)(nil),   // 65: im.CreateGroupConversationReq
+	(*CreateGroupConversationResp)(nil),  // 66: im.CreateGroupConversationResp
+	nil,                                  // 67: im.GetConversationsResp.ConversationListEntry
+	nil,                                  // 68: im.PutConversationsReq.ConversationListEntry
+	nil,                                  // 69: im.GetReadSeqsResp.ReadSeqsEntry
+}
+var file_apps_im_rpc_im_proto_depIdxs = []int32{
+	0,  // 0: im.MsgBody.image:type_name -> im.Image
+	1,  // 1: im.MsgBody.file:type_name -> im.File
+	2,  // 2: im.MsgBody.voice:type_name -> im.Voice
+	3,  // 3: im.MsgBody.location:type_name -> im.Location
+	4,  // 4: im.MsgBody.card:type_name -> im.Card
+	9,  // 5: im.Thread.lastReply:type_name -> im.Quote
+	5,  // 6: im.ChatLog.body:type_name -> im.MsgBody
+	9,  // 7: im.ChatLog.replyTo:type_name -> im.Quote
+	6,  // 8: im.ChatLog.mention:type_name -> im.Mention
+	7,  // 9: im.ChatLog.reactions:type_name -> im.Reaction
+	8,  // 10: im.ChatLog.thread:type_name -> im.Thread
+	10, // 11: im.Conversation.msg:type_name -> im.ChatLog
+	12, // 12: im.Conversation.pins:type_name -> im.Pin
+	67, // 13: im.GetConversationsResp.conversationList:type_name -> im.GetConversationsResp.ConversationListEntry
+	68, // 14: im.PutConversationsReq.conversationList:type_name -> im.PutConversationsReq.ConversationListEntry
+	10, // 15: im.GetChatLogResp.List:type_name -> im.ChatLog
+	69, // 16: im.GetReadSeqsResp.readSeqs:type_name -> im.GetReadSeqsResp.ReadSeqsEntry
+	10, // 17: im.GetThreadRepliesResp.root:type_name -> im.ChatLog
+	10, // 18: im.GetThreadRepliesResp.list:type_name -> im.ChatLog
+	10, // 19: im.PinnedMsg.msg:type_name -> im.ChatLog
+	32, // 20: im.GetPinnedMsgsResp.list:type_name -> im.PinnedMsg
+	10, // 21: im.StarredMsg.msg:type_name -> im.ChatLog
+	37, // 22: im.GetStarredMsgsResp.list:type_name -> im.StarredMsg
+	10, // 23: im.SearchHit.msg:type_name -> im.ChatLog
+	40, // 24: im.SearchMsgsResp.list:type_name -> im.SearchHit
+	11, // 25: im.ListConversationsResp.list:type_name -> im.Conversation
+	5,  // 26: im.ScheduledMsg.body:type_name -> im.MsgBody
+	5,  // 27: im.CreateScheduledMsgReq.body:type_name -> im.MsgBody
+	56, // 28: im.CreateScheduledMsgResp.msg:type_name -> im.ScheduledMsg
+	56, // 29: im.ListScheduledMsgsResp.list:type_name -> im.ScheduledMsg
+	11, // 30: im.GetConversationsResp.ConversationListEntry.value:type_name -> im.Conversation
+	11, // 31: im.PutConversationsReq.ConversationListEntry.value:type_name -> im.Conversation
+	17, // 32: im.Im.GetChatLog:input_type -> im.GetChatLogReq
+	63, // 33: im.Im.SetUpUserConversation:input_type -> im.SetUpUserConversationReq
+	13, // 34: im.Im.GetConversations:input_type -> im.GetConversationsReq
+	15, // 35: im.Im.PutConversations:input_type -> im.PutConversationsReq
+	65, // 36: im.Im.CreateGroupConversation:input_type -> im.CreateGroupConversationReq
+	19, // 37: im.Im.GetReadSeqs:input_type -> im.GetReadSeqsReq
+	21, // 38: im.Im.RecallMsg:input_type -> im.RecallMsgReq
+	23, // 39: im.Im.EditMsg:input_type -> im.EditMsgReq
+	27, // 40: im.Im.ReactMsg:input_type -> im.ReactMsgReq
+	25, // 41: im.Im.GetThreadReplies:input_type -> im.GetThreadRepliesReq
+	29, // 42: im.Im.PinMsg:input_type -> im.PinMsgReq
+	31, // 43: im.Im.GetPinnedMsgs:input_type -> im.GetPinnedMsgsReq
+	34, // 44: im.Im.StarMsg:input_type -> im.StarMsgReq
+	36, // 45: im.Im.GetStarredMsgs:input_type -> im.GetStarredMsgsReq
+	39, // 46: im.Im.SearchMsgs:input_type -> im.SearchMsgsReq
+	42, // 47: im.Im.PinConversation:input_type -> im.PinConversationReq
+	44, // 48: im.Im.MuteConversation:input_type -> im.MuteConversationReq
+	46, // 49: im.Im.ArchiveConversation:input_type -> im.ArchiveConversationReq
+	48, // 50: im.Im.DeleteConversation:input_type -> im.DeleteConversationReq
+	50, // 51: im.Im.ClearConversationHistory:input_type -> im.ClearConversationHistoryReq
+	52, // 52: im.Im.ListConversations:input_type -> im.ListConversationsReq
+	54, // 53: im.Im.SaveDraft:input_type -> im.SaveDraftReq
+	57, // 54: im.Im.CreateScheduledMsg:input_type -> im.CreateScheduledMsgReq
+	59, // 55: im.Im.ListScheduledMsgs:input_type -> im.ListScheduledMsgsReq
+	61, // 56: im.Im.CancelScheduledMsg:input_type -> im.CancelScheduledMsgReq
+	18, // 57: im.Im.GetChatLog:output_type -> im.GetChatLogResp
+	64, // 58: im.Im.SetUpUserConversation:output_type -> im.SetUpUserConversationResp
+	14, // 59: im.Im.GetConversations:output_type -> im.GetConversationsResp
+	16, // 60: im.Im.PutConversations:output_type -> im.PutConversationsResp
+	66, // 61: im.Im.CreateGroupConversation:output_type -> im.CreateGroupConversationResp
+	20, // 62: im.Im.GetReadSeqs:output_type -> im.GetReadSeqsResp
+	22, // 63: im.Im.RecallMsg:output_type -> im.RecallMsgResp
+	24, // 64: im.Im.EditMsg:output_type -> im.EditMsgResp
+	28, // 65: im.Im.ReactMsg:output_type -> im.ReactMsgResp
+	26, // 66: im.Im.GetThreadReplies:output_type -> im.GetThreadRepliesResp
+	30, // 67: im.Im.PinMsg:output_type -> im.PinMsgResp
+	33, // 68: im.Im.GetPinnedMsgs:output_type -> im.GetPinnedMsgsResp
+	35, // 69: im.Im.StarMsg:output_type -> im.StarMsgResp
+	38, // 70: im.Im.GetStarredMsgs:output_type -> im.GetStarredMsgsResp
+	41, // 71: im.Im.SearchMsgs:output_type -> im.SearchMsgsResp
+	43, // 72: im.Im.PinConversation:output_type -> im.PinConversationResp
+	45, // 73: im.Im.MuteConversation:output_type -> im.MuteConversationResp
+	47, // 74: im.Im.ArchiveConversation:output_type -> im.ArchiveConversationResp
+	49, // 75: im.Im.DeleteConversation:output_type -> im.DeleteConversationResp
+	51, // 76: im.Im.ClearConversationHistory:output_type -> im.ClearConversationHistoryResp
+	53, // 77: im.Im.ListConversations:output_type -> im.ListConversationsResp
+	55, // 78: im.Im.SaveDraft:output_type -> im.SaveDraftResp
+	58, // 79: im.Im.CreateScheduledMsg:output_type -> im.CreateScheduledMsgResp
+	60, // 80: im.Im.ListScheduledMsgs:output_type -> im.ListScheduledMsgsResp
+	62, // 81: im.Im.CancelScheduledMsg:output_type -> im.CancelScheduledMsgResp
+	57, // [57:82] is the sub-list for method output_type
+	32, // [32:57] is the sub-list for method input_type
+	32, // [32:32] is the sub-list for extension type_name
+	32, // [32:32] is the sub-list for extension extendee
+	0,  // [0:32] is the sub-list for field type_name
+}
+
+func init() { file_apps_im_rpc_im_proto_init() }
+func file_apps_im_rpc_im_proto_init() {
+	if File_apps_im_rpc_im_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_apps_im_rpc_im_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Image); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*File); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*Voice); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*Location); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*Card); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*MsgBody); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*Mention); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*Reaction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*Thread); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*Quote); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*ChatLog); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*Conversation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*Pin); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*GetConversationsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*GetConversationsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*PutConversationsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*PutConversationsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*GetChatLogReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*GetChatLogResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*GetReadSeqsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*GetReadSeqsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*RecallMsgReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*RecallMsgResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[23].Exporter = func(v any, i int) any {
+			switch v := v.(*EditMsgReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[24].Exporter = func(v any, i int) any {
+			switch v := v.(*EditMsgResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[25].Exporter = func(v any, i int) any {
+			switch v := v.(*GetThreadRepliesReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[26].Exporter = func(v any, i int) any {
+			switch v := v.(*GetThreadRepliesResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[27].Exporter = func(v any, i int) any {
+			switch v := v.(*ReactMsgReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[28].Exporter = func(v any, i int) any {
+			switch v := v.(*ReactMsgResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[29].Exporter = func(v any, i int) any {
+			switch v := v.(*PinMsgReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[30].Exporter = func(v any, i int) any {
+			switch v := v.(*PinMsgResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[31].Exporter = func(v any, i int) any {
+			switch v := v.(*GetPinnedMsgsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[32].Exporter = func(v any, i int) any {
+			switch v := v.(*PinnedMsg); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -957,8 +5477,8 @@ func file_apps_im_rpc_im_proto_init() {
 				return nil
 			}
 		}
-		file_apps_im_rpc_im_proto_msgTypes[1].Exporter = func(v any, i int) any {
-			switch v := v.(*Conversation); i {
+		file_apps_im_rpc_im_proto_msgTypes[33].Exporter = func(v any, i int) any {
+			switch v := v.(*GetPinnedMsgsResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -969,8 +5489,8 @@ func file_apps_im_rpc_im_proto_init() {
 				return nil
 			}
 		}
-		file_apps_im_rpc_im_proto_msgTypes[2].Exporter = func(v any, i int) any {
-			switch v := v.(*GetConversationsReq); i {
+		file_apps_im_rpc_im_proto_msgTypes[34].Exporter = func(v any, i int) any {
+			switch v := v.(*StarMsgReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -981,8 +5501,8 @@ func file_apps_im_rpc_im_proto_init() {
 				return nil
 			}
 		}
-		file_apps_im_rpc_im_proto_msgTypes[3].Exporter = func(v any, i int) any {
-			switch v := v.(*GetConversationsResp); i {
+		file_apps_im_rpc_im_proto_msgTypes[35].Exporter = func(v any, i int) any {
+			switch v := v.(*StarMsgResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -993,8 +5513,8 @@ func file_apps_im_rpc_im_proto_init() {
 				return nil
 			}
 		}
-		file_apps_im_rpc_im_proto_msgTypes[4].Exporter = func(v any, i int) any {
-			switch v := v.(*PutConversationsReq); i {
+		file_apps_im_rpc_im_proto_msgTypes[36].Exporter = func(v any, i int) any {
+			switch v := v.(*GetStarredMsgsReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1005,8 +5525,8 @@ func file_apps_im_rpc_im_proto_init() {
 				return nil
 			}
 		}
-		file_apps_im_rpc_im_proto_msgTypes[5].Exporter = func(v any, i int) any {
-			switch v := v.(*PutConversationsResp); i {
+		file_apps_im_rpc_im_proto_msgTypes[37].Exporter = func(v any, i int) any {
+			switch v := v.(*StarredMsg); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1017,8 +5537,8 @@ func file_apps_im_rpc_im_proto_init() {
 				return nil
 			}
 		}
-		file_apps_im_rpc_im_proto_msgTypes[6].Exporter = func(v any, i int) any {
-			switch v := v.(*GetChatLogReq); i {
+		file_apps_im_rpc_im_proto_msgTypes[38].Exporter = func(v any, i int) any {
+			switch v := v.(*GetStarredMsgsResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1029,8 +5549,8 @@ func file_apps_im_rpc_im_proto_init() {
 				return nil
 			}
 		}
-		file_apps_im_rpc_im_proto_msgTypes[7].Exporter = func(v any, i int) any {
-			switch v := v.(*GetChatLogResp); i {
+		file_apps_im_rpc_im_proto_msgTypes[39].Exporter = func(v any, i int) any {
+			switch v := v.(*SearchMsgsReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1041,7 +5561,283 @@ func file_apps_im_rpc_im_proto_init() {
 				return nil
 			}
 		}
-		file_apps_im_rpc_im_proto_msgTypes[8].Exporter = func(v any, i int) any {
+		file_apps_im_rpc_im_proto_msgTypes[40].Exporter = func(v any, i int) any {
+			switch v := v.(*SearchHit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[41].Exporter = func(v any, i int) any {
+			switch v := v.(*SearchMsgsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[42].Exporter = func(v any, i int) any {
+			switch v := v.(*PinConversationReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[43].Exporter = func(v any, i int) any {
+			switch v := v.(*PinConversationResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[44].Exporter = func(v any, i int) any {
+			switch v := v.(*MuteConversationReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[45].Exporter = func(v any, i int) any {
+			switch v := v.(*MuteConversationResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[46].Exporter = func(v any, i int) any {
+			switch v := v.(*ArchiveConversationReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[47].Exporter = func(v any, i int) any {
+			switch v := v.(*ArchiveConversationResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[48].Exporter = func(v any, i int) any {
+			switch v := v.(*DeleteConversationReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[49].Exporter = func(v any, i int) any {
+			switch v := v.(*DeleteConversationResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[50].Exporter = func(v any, i int) any {
+			switch v := v.(*ClearConversationHistoryReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[51].Exporter = func(v any, i int) any {
+			switch v := v.(*ClearConversationHistoryResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[52].Exporter = func(v any, i int) any {
+			switch v := v.(*ListConversationsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[53].Exporter = func(v any, i int) any {
+			switch v := v.(*ListConversationsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[54].Exporter = func(v any, i int) any {
+			switch v := v.(*SaveDraftReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[55].Exporter = func(v any, i int) any {
+			switch v := v.(*SaveDraftResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[56].Exporter = func(v any, i int) any {
+			switch v := v.(*ScheduledMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[57].Exporter = func(v any, i int) any {
+			switch v := v.(*CreateScheduledMsgReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[58].Exporter = func(v any, i int) any {
+			switch v := v.(*CreateScheduledMsgResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[59].Exporter = func(v any, i int) any {
+			switch v := v.(*ListScheduledMsgsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[60].Exporter = func(v any, i int) any {
+			switch v := v.(*ListScheduledMsgsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[61].Exporter = func(v any, i int) any {
+			switch v := v.(*CancelScheduledMsgReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[62].Exporter = func(v any, i int) any {
+			switch v := v.(*CancelScheduledMsgResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apps_im_rpc_im_proto_msgTypes[63].Exporter = func(v any, i int) any {
 			switch v := v.(*SetUpUserConversationReq); i {
 			case 0:
 				return &v.state
@@ -1053,7 +5849,7 @@ func file_apps_im_rpc_im_proto_init() {
 				return nil
 			}
 		}
-		file_apps_im_rpc_im_proto_msgTypes[9].Exporter = func(v any, i int) any {
+		file_apps_im_rpc_im_proto_msgTypes[64].Exporter = func(v any, i int) any {
 			switch v := v.(*SetUpUserConversationResp); i {
 			case 0:
 				return &v.state
@@ -1065,7 +5861,7 @@ func file_apps_im_rpc_im_proto_init() {
 				return nil
 			}
 		}
-		file_apps_im_rpc_im_proto_msgTypes[10].Exporter = func(v any, i int) any {
+		file_apps_im_rpc_im_proto_msgTypes[65].Exporter = func(v any, i int) any {
 			switch v := v.(*CreateGroupConversationReq); i {
 			case 0:
 				return &v.state
@@ -1077,7 +5873,7 @@ func file_apps_im_rpc_im_proto_init() {
 				return nil
 			}
 		}
-		file_apps_im_rpc_im_proto_msgTypes[11].Exporter = func(v any, i int) any {
+		file_apps_im_rpc_im_proto_msgTypes[66].Exporter = func(v any, i int) any {
 			switch v := v.(*CreateGroupConversationResp); i {
 			case 0:
 				return &v.state
@@ -1096,7 +5892,7 @@ func file_apps_im_rpc_im_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_apps_im_rpc_im_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   14,
+			NumMessages:   70,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
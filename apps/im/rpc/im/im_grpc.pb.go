@@ -19,11 +19,31 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Im_GetChatLog_FullMethodName              = "/im.Im/GetChatLog"
-	Im_SetUpUserConversation_FullMethodName   = "/im.Im/SetUpUserConversation"
-	Im_GetConversations_FullMethodName        = "/im.Im/GetConversations"
-	Im_PutConversations_FullMethodName        = "/im.Im/PutConversations"
-	Im_CreateGroupConversation_FullMethodName = "/im.Im/CreateGroupConversation"
+	Im_GetChatLog_FullMethodName               = "/im.Im/GetChatLog"
+	Im_SetUpUserConversation_FullMethodName    = "/im.Im/SetUpUserConversation"
+	Im_GetConversations_FullMethodName         = "/im.Im/GetConversations"
+	Im_PutConversations_FullMethodName         = "/im.Im/PutConversations"
+	Im_CreateGroupConversation_FullMethodName  = "/im.Im/CreateGroupConversation"
+	Im_GetReadSeqs_FullMethodName              = "/im.Im/GetReadSeqs"
+	Im_RecallMsg_FullMethodName                = "/im.Im/RecallMsg"
+	Im_EditMsg_FullMethodName                  = "/im.Im/EditMsg"
+	Im_ReactMsg_FullMethodName                 = "/im.Im/ReactMsg"
+	Im_GetThreadReplies_FullMethodName         = "/im.Im/GetThreadReplies"
+	Im_PinMsg_FullMethodName                   = "/im.Im/PinMsg"
+	Im_GetPinnedMsgs_FullMethodName            = "/im.Im/GetPinnedMsgs"
+	Im_StarMsg_FullMethodName                  = "/im.Im/StarMsg"
+	Im_GetStarredMsgs_FullMethodName           = "/im.Im/GetStarredMsgs"
+	Im_SearchMsgs_FullMethodName               = "/im.Im/SearchMsgs"
+	Im_PinConversation_FullMethodName          = "/im.Im/PinConversation"
+	Im_MuteConversation_FullMethodName         = "/im.Im/MuteConversation"
+	Im_ArchiveConversation_FullMethodName      = "/im.Im/ArchiveConversation"
+	Im_DeleteConversation_FullMethodName       = "/im.Im/DeleteConversation"
+	Im_ClearConversationHistory_FullMethodName = "/im.Im/ClearConversationHistory"
+	Im_ListConversations_FullMethodName        = "/im.Im/ListConversations"
+	Im_SaveDraft_FullMethodName                = "/im.Im/SaveDraft"
+	Im_CreateScheduledMsg_FullMethodName       = "/im.Im/CreateScheduledMsg"
+	Im_ListScheduledMsgs_FullMethodName        = "/im.Im/ListScheduledMsgs"
+	Im_CancelScheduledMsg_FullMethodName       = "/im.Im/CancelScheduledMsg"
 )
 
 // ImClient is the client API for Im service.
@@ -40,6 +60,46 @@ type ImClient interface {
 	PutConversations(ctx context.Context, in *PutConversationsReq, opts ...grpc.CallOption) (*PutConversationsResp, error)
 	// 创建群聊
 	CreateGroupConversation(ctx context.Context, in *CreateGroupConversationReq, opts ...grpc.CallOption) (*CreateGroupConversationResp, error)
+	// 获取用户在会话中的已读水位
+	GetReadSeqs(ctx context.Context, in *GetReadSeqsReq, opts ...grpc.CallOption) (*GetReadSeqsResp, error)
+	// 撤回消息
+	RecallMsg(ctx context.Context, in *RecallMsgReq, opts ...grpc.CallOption) (*RecallMsgResp, error)
+	// 编辑消息
+	EditMsg(ctx context.Context, in *EditMsgReq, opts ...grpc.CallOption) (*EditMsgResp, error)
+	// 添加或取消消息的表情回应
+	ReactMsg(ctx context.Context, in *ReactMsgReq, opts ...grpc.CallOption) (*ReactMsgResp, error)
+	// 分页获取话题中的回复
+	GetThreadReplies(ctx context.Context, in *GetThreadRepliesReq, opts ...grpc.CallOption) (*GetThreadRepliesResp, error)
+	// 置顶或取消置顶会话中的消息
+	PinMsg(ctx context.Context, in *PinMsgReq, opts ...grpc.CallOption) (*PinMsgResp, error)
+	// 获取会话中置顶的消息
+	GetPinnedMsgs(ctx context.Context, in *GetPinnedMsgsReq, opts ...grpc.CallOption) (*GetPinnedMsgsResp, error)
+	// 收藏或取消收藏消息
+	StarMsg(ctx context.Context, in *StarMsgReq, opts ...grpc.CallOption) (*StarMsgResp, error)
+	// 分页获取用户收藏的消息
+	GetStarredMsgs(ctx context.Context, in *GetStarredMsgsReq, opts ...grpc.CallOption) (*GetStarredMsgsResp, error)
+	// 在用户所在的会话中检索消息
+	SearchMsgs(ctx context.Context, in *SearchMsgsReq, opts ...grpc.CallOption) (*SearchMsgsResp, error)
+	// 置顶或取消置顶会话
+	PinConversation(ctx context.Context, in *PinConversationReq, opts ...grpc.CallOption) (*PinConversationResp, error)
+	// 设置会话免打扰
+	MuteConversation(ctx context.Context, in *MuteConversationReq, opts ...grpc.CallOption) (*MuteConversationResp, error)
+	// 归档或取消归档会话
+	ArchiveConversation(ctx context.Context, in *ArchiveConversationReq, opts ...grpc.CallOption) (*ArchiveConversationResp, error)
+	// 从会话列表中删除会话，有新消息时重新展示
+	DeleteConversation(ctx context.Context, in *DeleteConversationReq, opts ...grpc.CallOption) (*DeleteConversationResp, error)
+	// 清空用户在会话中的聊天记录
+	ClearConversationHistory(ctx context.Context, in *ClearConversationHistoryReq, opts ...grpc.CallOption) (*ClearConversationHistoryResp, error)
+	// 按最后一条消息的时间分页查询会话列表，置顶的会话在前
+	ListConversations(ctx context.Context, in *ListConversationsReq, opts ...grpc.CallOption) (*ListConversationsResp, error)
+	// 保存或清除会话的草稿，并同步到用户的其他设备
+	SaveDraft(ctx context.Context, in *SaveDraftReq, opts ...grpc.CallOption) (*SaveDraftResp, error)
+	// 创建定时消息，到期后以用户的身份发送
+	CreateScheduledMsg(ctx context.Context, in *CreateScheduledMsgReq, opts ...grpc.CallOption) (*CreateScheduledMsgResp, error)
+	// 查询用户尚未发送成功的定时消息
+	ListScheduledMsgs(ctx context.Context, in *ListScheduledMsgsReq, opts ...grpc.CallOption) (*ListScheduledMsgsResp, error)
+	// 取消等待发送或发送失败的定时消息
+	CancelScheduledMsg(ctx context.Context, in *CancelScheduledMsgReq, opts ...grpc.CallOption) (*CancelScheduledMsgResp, error)
 }
 
 type imClient struct {
@@ -100,6 +160,206 @@ func (c *imClient) CreateGroupConversation(ctx context.Context, in *CreateGroupC
 	return out, nil
 }
 
+func (c *imClient) GetReadSeqs(ctx context.Context, in *GetReadSeqsReq, opts ...grpc.CallOption) (*GetReadSeqsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReadSeqsResp)
+	err := c.cc.Invoke(ctx, Im_GetReadSeqs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imClient) RecallMsg(ctx context.Context, in *RecallMsgReq, opts ...grpc.CallOption) (*RecallMsgResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecallMsgResp)
+	err := c.cc.Invoke(ctx, Im_RecallMsg_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imClient) EditMsg(ctx context.Context, in *EditMsgReq, opts ...grpc.CallOption) (*EditMsgResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EditMsgResp)
+	err := c.cc.Invoke(ctx, Im_EditMsg_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imClient) ReactMsg(ctx context.Context, in *ReactMsgReq, opts ...grpc.CallOption) (*ReactMsgResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReactMsgResp)
+	err := c.cc.Invoke(ctx, Im_ReactMsg_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imClient) GetThreadReplies(ctx context.Context, in *GetThreadRepliesReq, opts ...grpc.CallOption) (*GetThreadRepliesResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetThreadRepliesResp)
+	err := c.cc.Invoke(ctx, Im_GetThreadReplies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imClient) PinMsg(ctx context.Context, in *PinMsgReq, opts ...grpc.CallOption) (*PinMsgResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PinMsgResp)
+	err := c.cc.Invoke(ctx, Im_PinMsg_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imClient) GetPinnedMsgs(ctx context.Context, in *GetPinnedMsgsReq, opts ...grpc.CallOption) (*GetPinnedMsgsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPinnedMsgsResp)
+	err := c.cc.Invoke(ctx, Im_GetPinnedMsgs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imClient) StarMsg(ctx context.Context, in *StarMsgReq, opts ...grpc.CallOption) (*StarMsgResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StarMsgResp)
+	err := c.cc.Invoke(ctx, Im_StarMsg_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imClient) GetStarredMsgs(ctx context.Context, in *GetStarredMsgsReq, opts ...grpc.CallOption) (*GetStarredMsgsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStarredMsgsResp)
+	err := c.cc.Invoke(ctx, Im_GetStarredMsgs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imClient) SearchMsgs(ctx context.Context, in *SearchMsgsReq, opts ...grpc.CallOption) (*SearchMsgsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchMsgsResp)
+	err := c.cc.Invoke(ctx, Im_SearchMsgs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imClient) PinConversation(ctx context.Context, in *PinConversationReq, opts ...grpc.CallOption) (*PinConversationResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PinConversationResp)
+	err := c.cc.Invoke(ctx, Im_PinConversation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imClient) MuteConversation(ctx context.Context, in *MuteConversationReq, opts ...grpc.CallOption) (*MuteConversationResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MuteConversationResp)
+	err := c.cc.Invoke(ctx, Im_MuteConversation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imClient) ArchiveConversation(ctx context.Context, in *ArchiveConversationReq, opts ...grpc.CallOption) (*ArchiveConversationResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ArchiveConversationResp)
+	err := c.cc.Invoke(ctx, Im_ArchiveConversation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imClient) DeleteConversation(ctx context.Context, in *DeleteConversationReq, opts ...grpc.CallOption) (*DeleteConversationResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteConversationResp)
+	err := c.cc.Invoke(ctx, Im_DeleteConversation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imClient) ClearConversationHistory(ctx context.Context, in *ClearConversationHistoryReq, opts ...grpc.CallOption) (*ClearConversationHistoryResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClearConversationHistoryResp)
+	err := c.cc.Invoke(ctx, Im_ClearConversationHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imClient) ListConversations(ctx context.Context, in *ListConversationsReq, opts ...grpc.CallOption) (*ListConversationsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListConversationsResp)
+	err := c.cc.Invoke(ctx, Im_ListConversations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imClient) SaveDraft(ctx context.Context, in *SaveDraftReq, opts ...grpc.CallOption) (*SaveDraftResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SaveDraftResp)
+	err := c.cc.Invoke(ctx, Im_SaveDraft_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imClient) CreateScheduledMsg(ctx context.Context, in *CreateScheduledMsgReq, opts ...grpc.CallOption) (*CreateScheduledMsgResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateScheduledMsgResp)
+	err := c.cc.Invoke(ctx, Im_CreateScheduledMsg_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imClient) ListScheduledMsgs(ctx context.Context, in *ListScheduledMsgsReq, opts ...grpc.CallOption) (*ListScheduledMsgsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListScheduledMsgsResp)
+	err := c.cc.Invoke(ctx, Im_ListScheduledMsgs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imClient) CancelScheduledMsg(ctx context.Context, in *CancelScheduledMsgReq, opts ...grpc.CallOption) (*CancelScheduledMsgResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelScheduledMsgResp)
+	err := c.cc.Invoke(ctx, Im_CancelScheduledMsg_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ImServer is the server API for Im service.
 // All implementations must embed UnimplementedImServer
 // for forward compatibility.
@@ -114,6 +374,46 @@ type ImServer interface {
 	PutConversations(context.Context, *PutConversationsReq) (*PutConversationsResp, error)
 	// 创建群聊
 	CreateGroupConversation(context.Context, *CreateGroupConversationReq) (*CreateGroupConversationResp, error)
+	// 获取用户在会话中的已读水位
+	GetReadSeqs(context.Context, *GetReadSeqsReq) (*GetReadSeqsResp, error)
+	// 撤回消息
+	RecallMsg(context.Context, *RecallMsgReq) (*RecallMsgResp, error)
+	// 编辑消息
+	EditMsg(context.Context, *EditMsgReq) (*EditMsgResp, error)
+	// 添加或取消消息的表情回应
+	ReactMsg(context.Context, *ReactMsgReq) (*ReactMsgResp, error)
+	// 分页获取话题中的回复
+	GetThreadReplies(context.Context, *GetThreadRepliesReq) (*GetThreadRepliesResp, error)
+	// 置顶或取消置顶会话中的消息
+	PinMsg(context.Context, *PinMsgReq) (*PinMsgResp, error)
+	// 获取会话中置顶的消息
+	GetPinnedMsgs(context.Context, *GetPinnedMsgsReq) (*GetPinnedMsgsResp, error)
+	// 收藏或取消收藏消息
+	StarMsg(context.Context, *StarMsgReq) (*StarMsgResp, error)
+	// 分页获取用户收藏的消息
+	GetStarredMsgs(context.Context, *GetStarredMsgsReq) (*GetStarredMsgsResp, error)
+	// 在用户所在的会话中检索消息
+	SearchMsgs(context.Context, *SearchMsgsReq) (*SearchMsgsResp, error)
+	// 置顶或取消置顶会话
+	PinConversation(context.Context, *PinConversationReq) (*PinConversationResp, error)
+	// 设置会话免打扰
+	MuteConversation(context.Context, *MuteConversationReq) (*MuteConversationResp, error)
+	// 归档或取消归档会话
+	ArchiveConversation(context.Context, *ArchiveConversationReq) (*ArchiveConversationResp, error)
+	// 从会话列表中删除会话，有新消息时重新展示
+	DeleteConversation(context.Context, *DeleteConversationReq) (*DeleteConversationResp, error)
+	// 清空用户在会话中的聊天记录
+	ClearConversationHistory(context.Context, *ClearConversationHistoryReq) (*ClearConversationHistoryResp, error)
+	// 按最后一条消息的时间分页查询会话列表，置顶的会话在前
+	ListConversations(context.Context, *ListConversationsReq) (*ListConversationsResp, error)
+	// 保存或清除会话的草稿，并同步到用户的其他设备
+	SaveDraft(context.Context, *SaveDraftReq) (*SaveDraftResp, error)
+	// 创建定时消息，到期后以用户的身份发送
+	CreateScheduledMsg(context.Context, *CreateScheduledMsgReq) (*CreateScheduledMsgResp, error)
+	// 查询用户尚未发送成功的定时消息
+	ListScheduledMsgs(context.Context, *ListScheduledMsgsReq) (*ListScheduledMsgsResp, error)
+	// 取消等待发送或发送失败的定时消息
+	CancelScheduledMsg(context.Context, *CancelScheduledMsgReq) (*CancelScheduledMsgResp, error)
 	mustEmbedUnimplementedImServer()
 }
 
@@ -139,6 +439,66 @@ func (UnimplementedImServer) PutConversations(context.Context, *PutConversations
 func (UnimplementedImServer) CreateGroupConversation(context.Context, *CreateGroupConversationReq) (*CreateGroupConversationResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateGroupConversation not implemented")
 }
+func (UnimplementedImServer) GetReadSeqs(context.Context, *GetReadSeqsReq) (*GetReadSeqsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReadSeqs not implemented")
+}
+func (UnimplementedImServer) RecallMsg(context.Context, *RecallMsgReq) (*RecallMsgResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecallMsg not implemented")
+}
+func (UnimplementedImServer) EditMsg(context.Context, *EditMsgReq) (*EditMsgResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditMsg not implemented")
+}
+func (UnimplementedImServer) ReactMsg(context.Context, *ReactMsgReq) (*ReactMsgResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReactMsg not implemented")
+}
+func (UnimplementedImServer) GetThreadReplies(context.Context, *GetThreadRepliesReq) (*GetThreadRepliesResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetThreadReplies not implemented")
+}
+func (UnimplementedImServer) PinMsg(context.Context, *PinMsgReq) (*PinMsgResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PinMsg not implemented")
+}
+func (UnimplementedImServer) GetPinnedMsgs(context.Context, *GetPinnedMsgsReq) (*GetPinnedMsgsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPinnedMsgs not implemented")
+}
+func (UnimplementedImServer) StarMsg(context.Context, *StarMsgReq) (*StarMsgResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StarMsg not implemented")
+}
+func (UnimplementedImServer) GetStarredMsgs(context.Context, *GetStarredMsgsReq) (*GetStarredMsgsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStarredMsgs not implemented")
+}
+func (UnimplementedImServer) SearchMsgs(context.Context, *SearchMsgsReq) (*SearchMsgsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchMsgs not implemented")
+}
+func (UnimplementedImServer) PinConversation(context.Context, *PinConversationReq) (*PinConversationResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PinConversation not implemented")
+}
+func (UnimplementedImServer) MuteConversation(context.Context, *MuteConversationReq) (*MuteConversationResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MuteConversation not implemented")
+}
+func (UnimplementedImServer) ArchiveConversation(context.Context, *ArchiveConversationReq) (*ArchiveConversationResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ArchiveConversation not implemented")
+}
+func (UnimplementedImServer) DeleteConversation(context.Context, *DeleteConversationReq) (*DeleteConversationResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteConversation not implemented")
+}
+func (UnimplementedImServer) ClearConversationHistory(context.Context, *ClearConversationHistoryReq) (*ClearConversationHistoryResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearConversationHistory not implemented")
+}
+func (UnimplementedImServer) ListConversations(context.Context, *ListConversationsReq) (*ListConversationsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListConversations not implemented")
+}
+func (UnimplementedImServer) SaveDraft(context.Context, *SaveDraftReq) (*SaveDraftResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveDraft not implemented")
+}
+func (UnimplementedImServer) CreateScheduledMsg(context.Context, *CreateScheduledMsgReq) (*CreateScheduledMsgResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateScheduledMsg not implemented")
+}
+func (UnimplementedImServer) ListScheduledMsgs(context.Context, *ListScheduledMsgsReq) (*ListScheduledMsgsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListScheduledMsgs not implemented")
+}
+func (UnimplementedImServer) CancelScheduledMsg(context.Context, *CancelScheduledMsgReq) (*CancelScheduledMsgResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelScheduledMsg not implemented")
+}
 func (UnimplementedImServer) mustEmbedUnimplementedImServer() {}
 func (UnimplementedImServer) testEmbeddedByValue()            {}
 
@@ -250,6 +610,366 @@ func _Im_CreateGroupConversation_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Im_GetReadSeqs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReadSeqsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImServer).GetReadSeqs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Im_GetReadSeqs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImServer).GetReadSeqs(ctx, req.(*GetReadSeqsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Im_RecallMsg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecallMsgReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImServer).RecallMsg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Im_RecallMsg_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImServer).RecallMsg(ctx, req.(*RecallMsgReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Im_EditMsg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditMsgReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImServer).EditMsg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Im_EditMsg_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImServer).EditMsg(ctx, req.(*EditMsgReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Im_ReactMsg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReactMsgReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImServer).ReactMsg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Im_ReactMsg_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImServer).ReactMsg(ctx, req.(*ReactMsgReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Im_GetThreadReplies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetThreadRepliesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImServer).GetThreadReplies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Im_GetThreadReplies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImServer).GetThreadReplies(ctx, req.(*GetThreadRepliesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Im_PinMsg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PinMsgReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImServer).PinMsg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Im_PinMsg_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImServer).PinMsg(ctx, req.(*PinMsgReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Im_GetPinnedMsgs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPinnedMsgsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImServer).GetPinnedMsgs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Im_GetPinnedMsgs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImServer).GetPinnedMsgs(ctx, req.(*GetPinnedMsgsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Im_StarMsg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StarMsgReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImServer).StarMsg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Im_StarMsg_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImServer).StarMsg(ctx, req.(*StarMsgReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Im_GetStarredMsgs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStarredMsgsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImServer).GetStarredMsgs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Im_GetStarredMsgs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImServer).GetStarredMsgs(ctx, req.(*GetStarredMsgsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Im_SearchMsgs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchMsgsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImServer).SearchMsgs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Im_SearchMsgs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImServer).SearchMsgs(ctx, req.(*SearchMsgsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Im_PinConversation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PinConversationReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImServer).PinConversation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Im_PinConversation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImServer).PinConversation(ctx, req.(*PinConversationReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Im_MuteConversation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MuteConversationReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImServer).MuteConversation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Im_MuteConversation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImServer).MuteConversation(ctx, req.(*MuteConversationReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Im_ArchiveConversation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveConversationReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImServer).ArchiveConversation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Im_ArchiveConversation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImServer).ArchiveConversation(ctx, req.(*ArchiveConversationReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Im_DeleteConversation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteConversationReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImServer).DeleteConversation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Im_DeleteConversation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImServer).DeleteConversation(ctx, req.(*DeleteConversationReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Im_ClearConversationHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearConversationHistoryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImServer).ClearConversationHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Im_ClearConversationHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImServer).ClearConversationHistory(ctx, req.(*ClearConversationHistoryReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Im_ListConversations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListConversationsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImServer).ListConversations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Im_ListConversations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImServer).ListConversations(ctx, req.(*ListConversationsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Im_SaveDraft_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveDraftReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImServer).SaveDraft(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Im_SaveDraft_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImServer).SaveDraft(ctx, req.(*SaveDraftReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Im_CreateScheduledMsg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateScheduledMsgReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImServer).CreateScheduledMsg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Im_CreateScheduledMsg_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImServer).CreateScheduledMsg(ctx, req.(*CreateScheduledMsgReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Im_ListScheduledMsgs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScheduledMsgsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImServer).ListScheduledMsgs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Im_ListScheduledMsgs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImServer).ListScheduledMsgs(ctx, req.(*ListScheduledMsgsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Im_CancelScheduledMsg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelScheduledMsgReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImServer).CancelScheduledMsg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Im_CancelScheduledMsg_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImServer).CancelScheduledMsg(ctx, req.(*CancelScheduledMsgReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Im_ServiceDesc is the grpc.ServiceDesc for Im service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -277,6 +997,86 @@ var Im_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateGroupConversation",
 			Handler:    _Im_CreateGroupConversation_Handler,
 		},
+		{
+			MethodName: "GetReadSeqs",
+			Handler:    _Im_GetReadSeqs_Handler,
+		},
+		{
+			MethodName: "RecallMsg",
+			Handler:    _Im_RecallMsg_Handler,
+		},
+		{
+			MethodName: "EditMsg",
+			Handler:    _Im_EditMsg_Handler,
+		},
+		{
+			MethodName: "ReactMsg",
+			Handler:    _Im_ReactMsg_Handler,
+		},
+		{
+			MethodName: "GetThreadReplies",
+			Handler:    _Im_GetThreadReplies_Handler,
+		},
+		{
+			MethodName: "PinMsg",
+			Handler:    _Im_PinMsg_Handler,
+		},
+		{
+			MethodName: "GetPinnedMsgs",
+			Handler:    _Im_GetPinnedMsgs_Handler,
+		},
+		{
+			MethodName: "StarMsg",
+			Handler:    _Im_StarMsg_Handler,
+		},
+		{
+			MethodName: "GetStarredMsgs",
+			Handler:    _Im_GetStarredMsgs_Handler,
+		},
+		{
+			MethodName: "SearchMsgs",
+			Handler:    _Im_SearchMsgs_Handler,
+		},
+		{
+			MethodName: "PinConversation",
+			Handler:    _Im_PinConversation_Handler,
+		},
+		{
+			MethodName: "MuteConversation",
+			Handler:    _Im_MuteConversation_Handler,
+		},
+		{
+			MethodName: "ArchiveConversation",
+			Handler:    _Im_ArchiveConversation_Handler,
+		},
+		{
+			MethodName: "DeleteConversation",
+			Handler:    _Im_DeleteConversation_Handler,
+		},
+		{
+			MethodName: "ClearConversationHistory",
+			Handler:    _Im_ClearConversationHistory_Handler,
+		},
+		{
+			MethodName: "ListConversations",
+			Handler:    _Im_ListConversations_Handler,
+		},
+		{
+			MethodName: "SaveDraft",
+			Handler:    _Im_SaveDraft_Handler,
+		},
+		{
+			MethodName: "CreateScheduledMsg",
+			Handler:    _Im_CreateScheduledMsg_Handler,
+		},
+		{
+			MethodName: "ListScheduledMsgs",
+			Handler:    _Im_ListScheduledMsgs_Handler,
+		},
+		{
+			MethodName: "CancelScheduledMsg",
+			Handler:    _Im_CancelScheduledMsg_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "apps/im/rpc/im.proto",
@@ -13,18 +13,73 @@ import (
 )
 
 type (
-	ChatLog                     = im.ChatLog
-	Conversation                = im.Conversation
-	CreateGroupConversationReq  = im.CreateGroupConversationReq
-	CreateGroupConversationResp = im.CreateGroupConversationResp
-	GetChatLogReq               = im.GetChatLogReq
-	GetChatLogResp              = im.GetChatLogResp
-	GetConversationsReq         = im.GetConversationsReq
-	GetConversationsResp        = im.GetConversationsResp
-	PutConversationsReq         = im.PutConversationsReq
-	PutConversationsResp        = im.PutConversationsResp
-	SetUpUserConversationReq    = im.SetUpUserConversationReq
-	SetUpUserConversationResp   = im.SetUpUserConversationResp
+	ArchiveConversationReq       = im.ArchiveConversationReq
+	ArchiveConversationResp      = im.ArchiveConversationResp
+	CancelScheduledMsgReq        = im.CancelScheduledMsgReq
+	CancelScheduledMsgResp       = im.CancelScheduledMsgResp
+	Card                         = im.Card
+	ChatLog                      = im.ChatLog
+	ClearConversationHistoryReq  = im.ClearConversationHistoryReq
+	ClearConversationHistoryResp = im.ClearConversationHistoryResp
+	Conversation                 = im.Conversation
+	CreateGroupConversationReq   = im.CreateGroupConversationReq
+	CreateGroupConversationResp  = im.CreateGroupConversationResp
+	CreateScheduledMsgReq        = im.CreateScheduledMsgReq
+	CreateScheduledMsgResp       = im.CreateScheduledMsgResp
+	DeleteConversationReq        = im.DeleteConversationReq
+	DeleteConversationResp       = im.DeleteConversationResp
+	EditMsgReq                   = im.EditMsgReq
+	EditMsgResp                  = im.EditMsgResp
+	File                         = im.File
+	GetChatLogReq                = im.GetChatLogReq
+	GetChatLogResp               = im.GetChatLogResp
+	GetConversationsReq          = im.GetConversationsReq
+	GetConversationsResp         = im.GetConversationsResp
+	GetPinnedMsgsReq             = im.GetPinnedMsgsReq
+	GetPinnedMsgsResp            = im.GetPinnedMsgsResp
+	GetReadSeqsReq               = im.GetReadSeqsReq
+	GetReadSeqsResp              = im.GetReadSeqsResp
+	GetStarredMsgsReq            = im.GetStarredMsgsReq
+	GetStarredMsgsResp           = im.GetStarredMsgsResp
+	GetThreadRepliesReq          = im.GetThreadRepliesReq
+	GetThreadRepliesResp         = im.GetThreadRepliesResp
+	Image                        = im.Image
+	ListConversationsReq         = im.ListConversationsReq
+	ListConversationsResp        = im.ListConversationsResp
+	ListScheduledMsgsReq         = im.ListScheduledMsgsReq
+	ListScheduledMsgsResp        = im.ListScheduledMsgsResp
+	Location                     = im.Location
+	Mention                      = im.Mention
+	MsgBody                      = im.MsgBody
+	MuteConversationReq          = im.MuteConversationReq
+	MuteConversationResp         = im.MuteConversationResp
+	Pin                          = im.Pin
+	PinConversationReq           = im.PinConversationReq
+	PinConversationResp          = im.PinConversationResp
+	PinMsgReq                    = im.PinMsgReq
+	PinMsgResp                   = im.PinMsgResp
+	PinnedMsg                    = im.PinnedMsg
+	PutConversationsReq          = im.PutConversationsReq
+	PutConversationsResp         = im.PutConversationsResp
+	Quote                        = im.Quote
+	ReactMsgReq                  = im.ReactMsgReq
+	ReactMsgResp                 = im.ReactMsgResp
+	Reaction                     = im.Reaction
+	RecallMsgReq                 = im.RecallMsgReq
+	RecallMsgResp                = im.RecallMsgResp
+	SaveDraftReq                 = im.SaveDraftReq
+	SaveDraftResp                = im.SaveDraftResp
+	ScheduledMsg                 = im.ScheduledMsg
+	SearchHit                    = im.SearchHit
+	SearchMsgsReq                = im.SearchMsgsReq
+	SearchMsgsResp               = im.SearchMsgsResp
+	SetUpUserConversationReq     = im.SetUpUserConversationReq
+	SetUpUserConversationResp    = im.SetUpUserConversationResp
+	StarMsgReq                   = im.StarMsgReq
+	StarMsgResp                  = im.StarMsgResp
+	StarredMsg                   = im.StarredMsg
+	Thread                       = im.Thread
+	Voice                        = im.Voice
 
 	Im interface {
 		// 获取会话记录
@@ -37,6 +92,46 @@ type (
 		PutConversations(ctx context.Context, in *PutConversationsReq, opts ...grpc.CallOption) (*PutConversationsResp, error)
 		// 创建群聊
 		CreateGroupConversation(ctx context.Context, in *CreateGroupConversationReq, opts ...grpc.CallOption) (*CreateGroupConversationResp, error)
+		// 获取用户在会话中的已读水位
+		GetReadSeqs(ctx context.Context, in *GetReadSeqsReq, opts ...grpc.CallOption) (*GetReadSeqsResp, error)
+		// 撤回消息
+		RecallMsg(ctx context.Context, in *RecallMsgReq, opts ...grpc.CallOption) (*RecallMsgResp, error)
+		// 编辑消息
+		EditMsg(ctx context.Context, in *EditMsgReq, opts ...grpc.CallOption) (*EditMsgResp, error)
+		// 添加或取消消息的表情回应
+		ReactMsg(ctx context.Context, in *ReactMsgReq, opts ...grpc.CallOption) (*ReactMsgResp, error)
+		// 分页获取话题中的回复
+		GetThreadReplies(ctx context.Context, in *GetThreadRepliesReq, opts ...grpc.CallOption) (*GetThreadRepliesResp, error)
+		// 置顶或取消置顶会话中的消息
+		PinMsg(ctx context.Context, in *PinMsgReq, opts ...grpc.CallOption) (*PinMsgResp, error)
+		// 获取会话中置顶的消息
+		GetPinnedMsgs(ctx context.Context, in *GetPinnedMsgsReq, opts ...grpc.CallOption) (*GetPinnedMsgsResp, error)
+		// 收藏或取消收藏消息
+		StarMsg(ctx context.Context, in *StarMsgReq, opts ...grpc.CallOption) (*StarMsgResp, error)
+		// 分页获取用户收藏的消息
+		GetStarredMsgs(ctx context.Context, in *GetStarredMsgsReq, opts ...grpc.CallOption) (*GetStarredMsgsResp, error)
+		// 在用户所在的会话中检索消息
+		SearchMsgs(ctx context.Context, in *SearchMsgsReq, opts ...grpc.CallOption) (*SearchMsgsResp, error)
+		// 置顶或取消置顶会话
+		PinConversation(ctx context.Context, in *PinConversationReq, opts ...grpc.CallOption) (*PinConversationResp, error)
+		// 设置会话免打扰
+		MuteConversation(ctx context.Context, in *MuteConversationReq, opts ...grpc.CallOption) (*MuteConversationResp, error)
+		// 归档或取消归档会话
+		ArchiveConversation(ctx context.Context, in *ArchiveConversationReq, opts ...grpc.CallOption) (*ArchiveConversationResp, error)
+		// 从会话列表中删除会话，有新消息时重新展示
+		DeleteConversation(ctx context.Context, in *DeleteConversationReq, opts ...grpc.CallOption) (*DeleteConversationResp, error)
+		// 清空用户在会话中的聊天记录
+		ClearConversationHistory(ctx context.Context, in *ClearConversationHistoryReq, opts ...grpc.CallOption) (*ClearConversationHistoryResp, error)
+		// 按最后一条消息的时间分页查询会话列表，置顶的会话在前
+		ListConversations(ctx context.Context, in *ListConversationsReq, opts ...grpc.CallOption) (*ListConversationsResp, error)
+		// 保存或清除会话的草稿，并同步到用户的其他设备
+		SaveDraft(ctx context.Context, in *SaveDraftReq, opts ...grpc.CallOption) (*SaveDraftResp, error)
+		// 创建定时消息，到期后以用户的身份发送
+		CreateScheduledMsg(ctx context.Context, in *CreateScheduledMsgReq, opts ...grpc.CallOption) (*CreateScheduledMsgResp, error)
+		// 查询用户尚未发送成功的定时消息
+		ListScheduledMsgs(ctx context.Context, in *ListScheduledMsgsReq, opts ...grpc.CallOption) (*ListScheduledMsgsResp, error)
+		// 取消等待发送或发送失败的定时消息
+		CancelScheduledMsg(ctx context.Context, in *CancelScheduledMsgReq, opts ...grpc.CallOption) (*CancelScheduledMsgResp, error)
 	}
 
 	defaultIm struct {
@@ -79,3 +174,123 @@ func (m *defaultIm) CreateGroupConversation(ctx context.Context, in *CreateGroup
 	client := im.NewImClient(m.cli.Conn())
 	return client.CreateGroupConversation(ctx, in, opts...)
 }
+
+// 获取用户在会话中的已读水位
+func (m *defaultIm) GetReadSeqs(ctx context.Context, in *GetReadSeqsReq, opts ...grpc.CallOption) (*GetReadSeqsResp, error) {
+	client := im.NewImClient(m.cli.Conn())
+	return client.GetReadSeqs(ctx, in, opts...)
+}
+
+// 撤回消息
+func (m *defaultIm) RecallMsg(ctx context.Context, in *RecallMsgReq, opts ...grpc.CallOption) (*RecallMsgResp, error) {
+	client := im.NewImClient(m.cli.Conn())
+	return client.RecallMsg(ctx, in, opts...)
+}
+
+// 编辑消息
+func (m *defaultIm) EditMsg(ctx context.Context, in *EditMsgReq, opts ...grpc.CallOption) (*EditMsgResp, error) {
+	client := im.NewImClient(m.cli.Conn())
+	return client.EditMsg(ctx, in, opts...)
+}
+
+// 添加或取消消息的表情回应
+func (m *defaultIm) ReactMsg(ctx context.Context, in *ReactMsgReq, opts ...grpc.CallOption) (*ReactMsgResp, error) {
+	client := im.NewImClient(m.cli.Conn())
+	return client.ReactMsg(ctx, in, opts...)
+}
+
+// 分页获取话题中的回复
+func (m *defaultIm) GetThreadReplies(ctx context.Context, in *GetThreadRepliesReq, opts ...grpc.CallOption) (*GetThreadRepliesResp, error) {
+	client := im.NewImClient(m.cli.Conn())
+	return client.GetThreadReplies(ctx, in, opts...)
+}
+
+// 置顶或取消置顶会话中的消息
+func (m *defaultIm) PinMsg(ctx context.Context, in *PinMsgReq, opts ...grpc.CallOption) (*PinMsgResp, error) {
+	client := im.NewImClient(m.cli.Conn())
+	return client.PinMsg(ctx, in, opts...)
+}
+
+// 获取会话中置顶的消息
+func (m *defaultIm) GetPinnedMsgs(ctx context.Context, in *GetPinnedMsgsReq, opts ...grpc.CallOption) (*GetPinnedMsgsResp, error) {
+	client := im.NewImClient(m.cli.Conn())
+	return client.GetPinnedMsgs(ctx, in, opts...)
+}
+
+// 收藏或取消收藏消息
+func (m *defaultIm) StarMsg(ctx context.Context, in *StarMsgReq, opts ...grpc.CallOption) (*StarMsgResp, error) {
+	client := im.NewImClient(m.cli.Conn())
+	return client.StarMsg(ctx, in, opts...)
+}
+
+// 分页获取用户收藏的消息
+func (m *defaultIm) GetStarredMsgs(ctx context.Context, in *GetStarredMsgsReq, opts ...grpc.CallOption) (*GetStarredMsgsResp, error) {
+	client := im.NewImClient(m.cli.Conn())
+	return client.GetStarredMsgs(ctx, in, opts...)
+}
+
+// 在用户所在的会话中检索消息
+func (m *defaultIm) SearchMsgs(ctx context.Context, in *SearchMsgsReq, opts ...grpc.CallOption) (*SearchMsgsResp, error) {
+	client := im.NewImClient(m.cli.Conn())
+	return client.SearchMsgs(ctx, in, opts...)
+}
+
+// 置顶或取消置顶会话
+func (m *defaultIm) PinConversation(ctx context.Context, in *PinConversationReq, opts ...grpc.CallOption) (*PinConversationResp, error) {
+	client := im.NewImClient(m.cli.Conn())
+	return client.PinConversation(ctx, in, opts...)
+}
+
+// 设置会话免打扰
+func (m *defaultIm) MuteConversation(ctx context.Context, in *MuteConversationReq, opts ...grpc.CallOption) (*MuteConversationResp, error) {
+	client := im.NewImClient(m.cli.Conn())
+	return client.MuteConversation(ctx, in, opts...)
+}
+
+// 归档或取消归档会话
+func (m *defaultIm) ArchiveConversation(ctx context.Context, in *ArchiveConversationReq, opts ...grpc.CallOption) (*ArchiveConversationResp, error) {
+	client := im.NewImClient(m.cli.Conn())
+	return client.ArchiveConversation(ctx, in, opts...)
+}
+
+// 从会话列表中删除会话，有新消息时重新展示
+func (m *defaultIm) DeleteConversation(ctx context.Context, in *DeleteConversationReq, opts ...grpc.CallOption) (*DeleteConversationResp, error) {
+	client := im.NewImClient(m.cli.Conn())
+	return client.DeleteConversation(ctx, in, opts...)
+}
+
+// 清空用户在会话中的聊天记录
+func (m *defaultIm) ClearConversationHistory(ctx context.Context, in *ClearConversationHistoryReq, opts ...grpc.CallOption) (*ClearConversationHistoryResp, error) {
+	client := im.NewImClient(m.cli.Conn())
+	return client.ClearConversationHistory(ctx, in, opts...)
+}
+
+// 按最后一条消息的时间分页查询会话列表，置顶的会话在前
+func (m *defaultIm) ListConversations(ctx context.Context, in *ListConversationsReq, opts ...grpc.CallOption) (*ListConversationsResp, error) {
+	client := im.NewImClient(m.cli.Conn())
+	return client.ListConversations(ctx, in, opts...)
+}
+
+// 保存或清除会话的草稿，并同步到用户的其他设备
+func (m *defaultIm) SaveDraft(ctx context.Context, in *SaveDraftReq, opts ...grpc.CallOption) (*SaveDraftResp, error) {
+	client := im.NewImClient(m.cli.Conn())
+	return client.SaveDraft(ctx, in, opts...)
+}
+
+// 创建定时消息，到期后以用户的身份发送
+func (m *defaultIm) CreateScheduledMsg(ctx context.Context, in *CreateScheduledMsgReq, opts ...grpc.CallOption) (*CreateScheduledMsgResp, error) {
+	client := im.NewImClient(m.cli.Conn())
+	return client.CreateScheduledMsg(ctx, in, opts...)
+}
+
+// 查询用户尚未发送成功的定时消息
+func (m *defaultIm) ListScheduledMsgs(ctx context.Context, in *ListScheduledMsgsReq, opts ...grpc.CallOption) (*ListScheduledMsgsResp, error) {
+	client := im.NewImClient(m.cli.Conn())
+	return client.ListScheduledMsgs(ctx, in, opts...)
+}
+
+// 取消等待发送或发送失败的定时消息
+func (m *defaultIm) CancelScheduledMsg(ctx context.Context, in *CancelScheduledMsgReq, opts ...grpc.CallOption) (*CancelScheduledMsgResp, error) {
+	client := im.NewImClient(m.cli.Conn())
+	return client.CancelScheduledMsg(ctx, in, opts...)
+}
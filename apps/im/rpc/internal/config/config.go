@@ -1,6 +1,9 @@
 package config
 
-import "github.com/zeromicro/go-zero/zrpc"
+import (
+	"github.com/zeromicro/go-queue/kq"
+	"github.com/zeromicro/go-zero/zrpc"
+)
 
 type Config struct {
 	zrpc.RpcServerConf
@@ -8,4 +11,19 @@ type Config struct {
 		Url string
 		Db  string
 	}
+	SocialRpc        zrpc.RpcClientConf
+	MediaRpc         zrpc.RpcClientConf
+	MsgEventTransfer struct {
+		Topic string
+		Addrs []string
+	}
+	MsgRecallTime int64 // 消息可撤回的时间：单位为分钟
+	MsgEditTime   int64 // 消息可编辑的时间：单位为分钟
+	MaxPins       int   // 每个会话最多置顶的消息数
+	// 全文检索，索引在进程内，由聊天消息与消息事件建立
+	Search struct {
+		Rebuild          bool `json:",default=true"` // 启动时从聊天记录重建索引
+		MsgChatTransfer  kq.KqConf
+		MsgEventTransfer kq.KqConf
+	}
 }
@@ -0,0 +1,195 @@
+// 从消息队列建立聊天记录的全文索引
+
+package indexer
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/im/rpc/internal/svc"
+	"easy-chat/apps/im/search"
+	"easy-chat/apps/task/mq/mq"
+	"easy-chat/pkg/constants"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/zeromicro/go-queue/kq"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/service"
+)
+
+var (
+	// RebuildBatchSize 重建索引时每次读取的聊天记录数
+	RebuildBatchSize int64 = 1000
+	// ChatLogWait 等待消息写入聊天记录的最长时间，超过后不再索引，由重建补上
+	ChatLogWait = 10 * time.Second
+	// ChatLogRetryInterval 查询尚未写入的聊天记录的间隔
+	ChatLogRetryInterval = 100 * time.Millisecond
+)
+
+// Indexer 消费聊天消息与消息事件，维护进程内的全文索引
+//
+// 索引在进程内，每个 im rpc 实例都需要以不同的消费者组消费完整的消息。
+type Indexer struct {
+	svc *svc.ServiceContext
+	// 重建索引开始的时间，之前发送的消息已经由重建写入索引
+	since int64
+	logx.Logger
+}
+
+func NewIndexer(svc *svc.ServiceContext) *Indexer {
+	return &Indexer{
+		svc:    svc,
+		Logger: logx.WithContext(context.Background()),
+	}
+}
+
+// Services 返回聊天消息与消息事件的消费者
+func (i *Indexer) Services() []service.Service {
+	return []service.Service{
+		kq.MustNewQueue(i.svc.Config.Search.MsgChatTransfer, kq.WithHandle(i.consumeChat)),
+		kq.MustNewQueue(i.svc.Config.Search.MsgEventTransfer, kq.WithHandle(i.consumeEvent)),
+	}
+}
+
+// Rebuild 从聊天记录重建索引
+func (i *Indexer) Rebuild(ctx context.Context) error {
+	i.since = time.Now().UnixMilli()
+
+	var (
+		afterId string
+		count   int
+	)
+	for {
+		chatLogs, err := i.svc.ChatLogModel.ListAfterId(ctx, afterId, RebuildBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(chatLogs) == 0 {
+			break
+		}
+
+		docs := make([]*search.Document, 0, len(chatLogs))
+		for _, chatLog := range chatLogs {
+			if doc := NewDocument(chatLog); doc != nil {
+				docs = append(docs, doc)
+			}
+		}
+		if err := i.svc.Index.Index(ctx, docs...); err != nil {
+			return err
+		}
+		count += len(docs)
+		afterId = chatLogs[len(chatLogs)-1].ID.Hex()
+	}
+	i.Infof("search index rebuilt, count %v", count)
+	return nil
+}
+
+// consumeChat 以写入后的聊天记录建立索引，不索引未能写入的消息
+func (i *Indexer) consumeChat(ctx context.Context, key, value string) error {
+	var data mq.MsgChatTransfer
+	if err := json.Unmarshal([]byte(value), &data); err != nil {
+		return err
+	}
+	// 没有预先分配ID的消息无法关联聊天记录
+	if data.ChatLogId == "" || data.SendTime < i.since {
+		return nil
+	}
+
+	chatLog, err := i.findChatLog(ctx, data.ChatLogId)
+	switch err {
+	case nil:
+	case immodels.ErrNotFound, immodels.ErrInvalidObjectId:
+		i.Errorf("chat log %v not persisted in %v, skip indexing", data.ChatLogId, ChatLogWait)
+		return nil
+	default:
+		return err
+	}
+	if doc := NewDocument(chatLog); doc != nil {
+		return i.svc.Index.Index(ctx, doc)
+	}
+	return nil
+}
+
+// findChatLog 查询聊天记录，消息与写入聊天记录的任务并行消费，尚未写入时等待
+func (i *Indexer) findChatLog(ctx context.Context, id string) (*immodels.ChatLog, error) {
+	deadline := time.Now().Add(ChatLogWait)
+	for {
+		chatLog, err := i.svc.ChatLogModel.FindOne(ctx, id)
+		if err != immodels.ErrNotFound || !time.Now().Before(deadline) {
+			return chatLog, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(ChatLogRetryInterval):
+		}
+	}
+}
+
+func (i *Indexer) consumeEvent(ctx context.Context, key, value string) error {
+	var data mq.MsgEventTransfer
+	if err := json.Unmarshal([]byte(value), &data); err != nil {
+		return err
+	}
+
+	switch data.ContentType {
+	case constants.ContentRecall:
+		return i.svc.Index.Delete(ctx, data.MsgId)
+	case constants.ContentEdit:
+		// 以聊天记录中最新的内容重新索引
+		chatLog, err := i.svc.ChatLogModel.FindOne(ctx, data.MsgId)
+		switch err {
+		case nil:
+		case immodels.ErrNotFound, immodels.ErrInvalidObjectId:
+			return nil
+		default:
+			return err
+		}
+		if doc := NewDocument(chatLog); doc != nil {
+			return i.svc.Index.Index(ctx, doc)
+		}
+		return i.svc.Index.Delete(ctx, data.MsgId)
+	}
+	return nil
+}
+
+// NewDocument 将聊天记录转换为索引的文档，没有可检索的文本或已撤回时返回 nil
+//
+// 文本消息检索内容，文件检索文件名，位置检索名称与地址，名片检索名称。
+func NewDocument(chatLog *immodels.ChatLog) *search.Document {
+	if chatLog.Status == constants.RecallMsgStatus {
+		return nil
+	}
+
+	var content string
+	switch body := chatLog.Body; chatLog.MsgType {
+	case constants.TextMtype:
+		content = chatLog.MsgContent
+	case constants.FileMtype:
+		if body != nil && body.File != nil {
+			content = body.File.Name
+		}
+	case constants.LocationMtype:
+		if body != nil && body.Location != nil {
+			content = strings.TrimSpace(body.Location.Name + " " + body.Location.Address)
+		}
+	case constants.CardMtype:
+		if body != nil && body.Card != nil {
+			content = body.Card.Name
+		}
+	}
+	if content == "" {
+		return nil
+	}
+
+	return &search.Document{
+		Id:             chatLog.ID.Hex(),
+		ConversationId: chatLog.ConversationId,
+		SendId:         chatLog.SendId,
+		SendTime:       chatLog.SendTime,
+		Seq:            chatLog.Seq,
+		Content:        content,
+	}
+}
@@ -0,0 +1,82 @@
+package indexer
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/im/rpc/internal/svc"
+	"easy-chat/apps/im/search"
+	"easy-chat/apps/task/mq/mq"
+	"easy-chat/pkg/constants"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// delayedChatLogModel 前 misses 次查询时聊天记录尚未写入
+type delayedChatLogModel struct {
+	immodels.ChatLogModel
+	chatLog *immodels.ChatLog
+	misses  int
+}
+
+func (f *delayedChatLogModel) FindOne(ctx context.Context, id string) (*immodels.ChatLog, error) {
+	if f.chatLog == nil || f.chatLog.ID.Hex() != id || f.misses > 0 {
+		f.misses--
+		return nil, immodels.ErrNotFound
+	}
+	return f.chatLog, nil
+}
+
+func TestIndexer_ConsumeChat(t *testing.T) {
+	defer func(wait, interval time.Duration) {
+		ChatLogWait, ChatLogRetryInterval = wait, interval
+	}(ChatLogWait, ChatLogRetryInterval)
+	ChatLogWait, ChatLogRetryInterval = 50*time.Millisecond, time.Millisecond
+
+	id := primitive.NewObjectID()
+	persisted := func(status constants.MsgStatus) *immodels.ChatLog {
+		return &immodels.ChatLog{ID: id, ConversationId: "g1", SendId: "u1", MsgType: constants.TextMtype,
+			MsgContent: "persisted", Status: status, SendTime: 1}
+	}
+
+	tests := []struct {
+		name    string
+		chatLog *immodels.ChatLog
+		misses  int
+		want    int64
+	}{
+		{"persisted", persisted(constants.NormalMsgStatus), 0, 1},
+		{"persisted later", persisted(constants.NormalMsgStatus), 3, 1},
+		{"never persisted", nil, 0, 0},
+		{"recalled", persisted(constants.RecallMsgStatus), 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index := search.NewMemoryIndex()
+			i := NewIndexer(&svc.ServiceContext{
+				ChatLogModel: &delayedChatLogModel{chatLog: tt.chatLog, misses: tt.misses},
+				Index:        index,
+			})
+
+			// 队列中的内容与写入的聊天记录不同，以写入的为准
+			value, _ := json.Marshal(&mq.MsgChatTransfer{
+				ChatLogId: id.Hex(), ConversationId: "g1", SendId: "u1", MType: constants.TextMtype, Content: "queued", SendTime: 1,
+			})
+			if err := i.consumeChat(context.Background(), "", string(value)); err != nil {
+				t.Fatalf("consumeChat() err = %v", err)
+			}
+
+			for keyword, want := range map[string]int64{"persisted": tt.want, "queued": 0} {
+				_, total, err := index.Search(context.Background(), &search.Query{Keyword: keyword, ConversationIds: []string{"g1"}, Limit: 10})
+				if err != nil {
+					t.Fatalf("Search() err = %v", err)
+				}
+				if total != want {
+					t.Errorf("Search(%q) total = %d, want %d", keyword, total, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/pkg/xerr"
+	"github.com/pkg/errors"
+
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/im/rpc/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ArchiveConversationLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewArchiveConversationLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ArchiveConversationLogic {
+	return &ArchiveConversationLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// ArchiveConversation 归档或取消归档会话
+//
+// 归档的会话排在会话列表的最后，有新消息时仍保持归档。
+func (l *ArchiveConversationLogic) ArchiveConversation(in *im.ArchiveConversationReq) (*im.ArchiveConversationResp, error) {
+	ok, err := l.svcCtx.UserConversationModel.SetArchived(l.ctx, in.UserId, in.ConversationId, !in.Unarchive)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "UserConversationModel.SetArchived err %v, req %v", err, in)
+	}
+	if !ok {
+		return nil, errors.WithStack(ErrConversationNotFound)
+	}
+	return &im.ArchiveConversationResp{}, nil
+}
@@ -0,0 +1,44 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/pkg/xerr"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/im/rpc/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CancelScheduledMsgLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewCancelScheduledMsgLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CancelScheduledMsgLogic {
+	return &CancelScheduledMsgLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// CancelScheduledMsg 取消等待发送或发送失败的定时消息，已被领取发送的消息不能取消
+func (l *CancelScheduledMsgLogic) CancelScheduledMsg(in *im.CancelScheduledMsgReq) (*im.CancelScheduledMsgResp, error) {
+	id, err := primitive.ObjectIDFromHex(in.Id)
+	if err != nil {
+		return nil, errors.WithStack(ErrScheduledMsgNotFound)
+	}
+
+	ok, err := l.svcCtx.ScheduledMsgModel.Cancel(l.ctx, id, in.UserId)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "ScheduledMsgModel.Cancel err %v, req %v", err, in)
+	}
+	if !ok {
+		return nil, errors.WithStack(ErrScheduledMsgNotFound)
+	}
+	return &im.CancelScheduledMsgResp{}, nil
+}
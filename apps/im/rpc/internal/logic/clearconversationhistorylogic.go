@@ -0,0 +1,59 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/pkg/xerr"
+	"github.com/pkg/errors"
+
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/im/rpc/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ClearConversationHistoryLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewClearConversationHistoryLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ClearConversationHistoryLogic {
+	return &ClearConversationHistoryLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// ClearConversationHistory 清空用户在会话中的聊天记录
+//
+// 只对用户自己生效：记录会话当前的序号，之后查询聊天记录只返回序号更大的消息，清空的消息同时视为已读。
+func (l *ClearConversationHistoryLogic) ClearConversationHistory(in *im.ClearConversationHistoryReq) (*im.ClearConversationHistoryResp, error) {
+	if err := clearHistory(l.ctx, l.svcCtx, in.UserId, in.ConversationId); err != nil {
+		return nil, err
+	}
+	return &im.ClearConversationHistoryResp{}, nil
+}
+
+// clearHistory 清空用户在会话中到当前序号为止的聊天记录
+func clearHistory(ctx context.Context, svcCtx *svc.ServiceContext, uid, conversationId string) error {
+	var seq int64
+	conversation, err := svcCtx.ConversationModel.FindByConversationId(ctx, conversationId)
+	switch err {
+	case nil:
+		seq = conversation.Seq
+	case immodels.ErrNotFound:
+	default:
+		return errors.Wrapf(xerr.NewDBErr(), "ConversationModel.FindByConversationId err %v, conversationId %v", err, conversationId)
+	}
+
+	ok, err := svcCtx.UserConversationModel.ClearHistory(ctx, uid, conversationId, seq)
+	if err != nil {
+		return errors.Wrapf(xerr.NewDBErr(), "UserConversationModel.ClearHistory err %v, uid %v, conversationId %v", err, uid, conversationId)
+	}
+	if !ok {
+		return errors.WithStack(ErrConversationNotFound)
+	}
+	return nil
+}
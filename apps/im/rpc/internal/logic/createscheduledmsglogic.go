@@ -0,0 +1,166 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/authz"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/pkg/constants"
+	"easy-chat/pkg/wuid"
+	"easy-chat/pkg/xerr"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/im/rpc/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+var (
+	ErrScheduledMsgTarget   = xerr.New(xerr.REQUEST_PARAM_ERROR, "定时消息的接收者有误")
+	ErrScheduledMsgTime     = xerr.New(xerr.REQUEST_PARAM_ERROR, "定时消息的发送时间需要晚于当前时间且不超过30天")
+	ErrScheduledMsgTooMany  = xerr.New(xerr.REQUEST_PARAM_ERROR, "等待发送的定时消息过多")
+	ErrScheduledMsgNotFound = xerr.New(xerr.REQUEST_PARAM_ERROR, "定时消息不存在或已发送")
+)
+
+type CreateScheduledMsgLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewCreateScheduledMsgLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateScheduledMsgLogic {
+	return &CreateScheduledMsgLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// CreateScheduledMsg 创建定时消息，到期后由 task 服务以用户的身份发送
+//
+// 创建时校验消息内容以及用户是否可以向接收者发送消息，发送时会重新校验好友关系与群成员。
+func (l *CreateScheduledMsgLogic) CreateScheduledMsg(in *im.CreateScheduledMsgReq) (*im.CreateScheduledMsgResp, error) {
+	body := fromMsgBody(in.Body)
+	if err := immodels.ValidateMsg(constants.MType(in.MsgType), in.MsgContent, body); err != nil {
+		return nil, errors.WithStack(xerr.New(xerr.REQUEST_PARAM_ERROR, err.Error()))
+	}
+	now := time.Now()
+	if in.SendTime <= now.UnixMilli() || in.SendTime > now.Add(immodels.MaxScheduleAhead).UnixMilli() {
+		return nil, errors.WithStack(ErrScheduledMsgTime)
+	}
+
+	chatType := constants.ChatType(in.ChatType)
+	var conversationId string
+	switch chatType {
+	case constants.SingleChatType:
+		if in.RecvId != "" && in.RecvId != in.SendId {
+			conversationId = wuid.CombineId(in.SendId, in.RecvId)
+		}
+	case constants.GroupChatType:
+		conversationId = in.RecvId
+	}
+	if conversationId == "" {
+		return nil, errors.WithStack(ErrScheduledMsgTarget)
+	}
+	if err := l.svcCtx.Auth.CheckSend(l.ctx, in.SendId, chatType, in.RecvId); err != nil {
+		return nil, err
+	}
+	if err := authz.CheckAttachment(l.ctx, l.svcCtx.Media, in.SendId, body); err != nil {
+		return nil, err
+	}
+
+	count, err := l.svcCtx.ScheduledMsgModel.CountPending(l.ctx, in.SendId)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "ScheduledMsgModel.CountPending err %v, req %v", err, in)
+	}
+	if count >= immodels.MaxPendingScheduledMsgs {
+		return nil, errors.WithStack(ErrScheduledMsgTooMany)
+	}
+
+	msg := &immodels.ScheduledMsg{
+		SendId:         in.SendId,
+		ConversationId: conversationId,
+		RecvId:         in.RecvId,
+		ChatType:       chatType,
+		MsgType:        constants.MType(in.MsgType),
+		MsgContent:     in.MsgContent,
+		Body:           body,
+		SendTime:       in.SendTime,
+		Status:         constants.PendingScheduledMsgStatus,
+		ChatLogId:      primitive.NewObjectID().Hex(),
+	}
+	if err := l.svcCtx.ScheduledMsgModel.Insert(l.ctx, msg); err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "ScheduledMsgModel.Insert err %v, req %v", err, in)
+	}
+	return &im.CreateScheduledMsgResp{Msg: toScheduledMsg(msg)}, nil
+}
+
+func toScheduledMsg(v *immodels.ScheduledMsg) *im.ScheduledMsg {
+	return &im.ScheduledMsg{
+		Id:             v.ID.Hex(),
+		ConversationId: v.ConversationId,
+		ChatType:       int32(v.ChatType),
+		SendId:         v.SendId,
+		RecvId:         v.RecvId,
+		MsgType:        int32(v.MsgType),
+		MsgContent:     v.MsgContent,
+		Body:           toMsgBody(v.Body),
+		SendTime:       v.SendTime,
+		Status:         int32(v.Status),
+		Reason:         v.Reason,
+		CreateTime:     v.CreateAt.UnixMilli(),
+	}
+}
+
+// fromMsgBody 转换非文本消息的结构化内容，与 toMsgBody 相反
+func fromMsgBody(body *im.MsgBody) *immodels.MsgBody {
+	if body == nil {
+		return nil
+	}
+
+	res := &immodels.MsgBody{}
+	if body.Image != nil {
+		res.Image = &immodels.Image{
+			Url:          body.Image.Url,
+			Thumbnail:    body.Image.Thumbnail,
+			Width:        body.Image.Width,
+			Height:       body.Image.Height,
+			AttachmentId: body.Image.AttachmentId,
+		}
+	}
+	if body.File != nil {
+		res.File = &immodels.File{
+			Url:          body.File.Url,
+			Name:         body.File.Name,
+			Size:         body.File.Size,
+			Mime:         body.File.Mime,
+			AttachmentId: body.File.AttachmentId,
+		}
+	}
+	if body.Voice != nil {
+		res.Voice = &immodels.Voice{
+			Url:          body.Voice.Url,
+			Duration:     body.Voice.Duration,
+			AttachmentId: body.Voice.AttachmentId,
+		}
+	}
+	if body.Location != nil {
+		res.Location = &immodels.Location{
+			Latitude:  body.Location.Latitude,
+			Longitude: body.Location.Longitude,
+			Name:      body.Location.Name,
+			Address:   body.Location.Address,
+		}
+	}
+	if body.Card != nil {
+		res.Card = &immodels.Card{
+			CardType: constants.CardType(body.Card.CardType),
+			TargetId: body.Card.TargetId,
+			Name:     body.Card.Name,
+			Avatar:   body.Card.Avatar,
+		}
+	}
+	return res
+}
@@ -0,0 +1,138 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/authz"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/pkg/constants"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeScheduledMsgModel 只实现创建、查询与取消，发送相关的方法由 task 服务使用
+type fakeScheduledMsgModel struct {
+	immodels.ScheduledMsgModel
+	msgs []*immodels.ScheduledMsg
+}
+
+func (f *fakeScheduledMsgModel) Insert(ctx context.Context, data *immodels.ScheduledMsg) error {
+	data.ID = primitive.NewObjectID()
+	data.CreateAt = time.Now()
+	f.msgs = append(f.msgs, data)
+	return nil
+}
+
+func (f *fakeScheduledMsgModel) ListByUserId(ctx context.Context, uid string) ([]*immodels.ScheduledMsg, error) {
+	var res []*immodels.ScheduledMsg
+	for _, msg := range f.msgs {
+		if msg.SendId == uid && msg.Status != constants.CanceledScheduledMsgStatus && msg.Status != constants.SentScheduledMsgStatus {
+			res = append(res, msg)
+		}
+	}
+	return res, nil
+}
+
+func (f *fakeScheduledMsgModel) CountPending(ctx context.Context, uid string) (int64, error) {
+	var n int64
+	for _, msg := range f.msgs {
+		if msg.SendId == uid && msg.Status == constants.PendingScheduledMsgStatus {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (f *fakeScheduledMsgModel) Cancel(ctx context.Context, id primitive.ObjectID, uid string) (bool, error) {
+	for _, msg := range f.msgs {
+		if msg.ID == id && msg.SendId == uid &&
+			(msg.Status == constants.PendingScheduledMsgStatus || msg.Status == constants.FailedScheduledMsgStatus) {
+			msg.Status = constants.CanceledScheduledMsgStatus
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func newScheduledTestServiceContext() (*fakeScheduledMsgModel, *CreateScheduledMsgLogic, *ListScheduledMsgsLogic, *CancelScheduledMsgLogic) {
+	svcCtx, _ := newTestServiceContext()
+	model := &fakeScheduledMsgModel{}
+	svcCtx.ScheduledMsgModel = model
+	svcCtx.Auth = authz.NewAuthorizer(&fakeSocial{
+		groups:  map[string][]string{"g1": {"u1", "u3"}},
+		friends: map[string][]string{"u1": {"u2"}},
+	})
+	ctx := context.Background()
+	return model, NewCreateScheduledMsgLogic(ctx, svcCtx), NewListScheduledMsgsLogic(ctx, svcCtx), NewCancelScheduledMsgLogic(ctx, svcCtx)
+}
+
+func TestCreateScheduledMsgLogic_CreateScheduledMsg(t *testing.T) {
+	model, create, list, cancel := newScheduledTestServiceContext()
+	later := time.Now().Add(time.Hour).UnixMilli()
+
+	resp, err := create.CreateScheduledMsg(&im.CreateScheduledMsgReq{
+		SendId: "u1", RecvId: "u2", ChatType: int32(constants.SingleChatType), MsgContent: "hello", SendTime: later,
+	})
+	if err != nil {
+		t.Fatalf("CreateScheduledMsg() err = %v", err)
+	}
+	if resp.Msg.ConversationId != "u1_u2" || resp.Msg.Status != int32(constants.PendingScheduledMsgStatus) {
+		t.Errorf("CreateScheduledMsg() = %+v, want pending msg in u1_u2", resp.Msg)
+	}
+	if model.msgs[0].ChatLogId == "" {
+		t.Errorf("CreateScheduledMsg() did not allocate chat log id")
+	}
+
+	tests := []struct {
+		name    string
+		req     *im.CreateScheduledMsgReq
+		wantErr error
+	}{
+		{"not friend", &im.CreateScheduledMsgReq{SendId: "u1", RecvId: "u3", ChatType: int32(constants.SingleChatType), MsgContent: "hi", SendTime: later}, authz.ErrNotFriend},
+		{"not member", &im.CreateScheduledMsgReq{SendId: "u2", RecvId: "g1", ChatType: int32(constants.GroupChatType), MsgContent: "hi", SendTime: later}, authz.ErrNotMember},
+		{"to self", &im.CreateScheduledMsgReq{SendId: "u1", RecvId: "u1", ChatType: int32(constants.SingleChatType), MsgContent: "hi", SendTime: later}, ErrScheduledMsgTarget},
+		{"past", &im.CreateScheduledMsgReq{SendId: "u1", RecvId: "g1", ChatType: int32(constants.GroupChatType), MsgContent: "hi", SendTime: time.Now().UnixMilli() - 1}, ErrScheduledMsgTime},
+		{"too far", &im.CreateScheduledMsgReq{SendId: "u1", RecvId: "g1", ChatType: int32(constants.GroupChatType), MsgContent: "hi", SendTime: time.Now().Add(immodels.MaxScheduleAhead + time.Hour).UnixMilli()}, ErrScheduledMsgTime},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := create.CreateScheduledMsg(tt.req); errors.Cause(err) != tt.wantErr {
+				t.Errorf("CreateScheduledMsg() err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+	if _, err := create.CreateScheduledMsg(&im.CreateScheduledMsgReq{SendId: "u1", RecvId: "g1", ChatType: int32(constants.GroupChatType), SendTime: later}); err == nil {
+		t.Errorf("CreateScheduledMsg() with empty content err = nil")
+	}
+
+	listResp, err := list.ListScheduledMsgs(&im.ListScheduledMsgsReq{UserId: "u1"})
+	if err != nil || len(listResp.List) != 1 {
+		t.Fatalf("ListScheduledMsgs() = %v, %v, want one msg", listResp, err)
+	}
+	if _, err := cancel.CancelScheduledMsg(&im.CancelScheduledMsgReq{UserId: "u2", Id: resp.Msg.Id}); errors.Cause(err) != ErrScheduledMsgNotFound {
+		t.Errorf("CancelScheduledMsg() by other user err = %v, want ErrScheduledMsgNotFound", err)
+	}
+	if _, err := cancel.CancelScheduledMsg(&im.CancelScheduledMsgReq{UserId: "u1", Id: resp.Msg.Id}); err != nil {
+		t.Fatalf("CancelScheduledMsg() err = %v", err)
+	}
+	if _, err := cancel.CancelScheduledMsg(&im.CancelScheduledMsgReq{UserId: "u1", Id: resp.Msg.Id}); errors.Cause(err) != ErrScheduledMsgNotFound {
+		t.Errorf("CancelScheduledMsg() twice err = %v, want ErrScheduledMsgNotFound", err)
+	}
+}
+
+func TestCreateScheduledMsgLogic_TooMany(t *testing.T) {
+	model, create, _, _ := newScheduledTestServiceContext()
+	for i := 0; i < immodels.MaxPendingScheduledMsgs; i++ {
+		model.msgs = append(model.msgs, &immodels.ScheduledMsg{SendId: "u1", Status: constants.PendingScheduledMsgStatus})
+	}
+
+	_, err := create.CreateScheduledMsg(&im.CreateScheduledMsgReq{
+		SendId: "u1", RecvId: "u2", ChatType: int32(constants.SingleChatType), MsgContent: "hello", SendTime: time.Now().Add(time.Hour).UnixMilli(),
+	})
+	if errors.Cause(err) != ErrScheduledMsgTooMany {
+		t.Errorf("CreateScheduledMsg() err = %v, want ErrScheduledMsgTooMany", err)
+	}
+}
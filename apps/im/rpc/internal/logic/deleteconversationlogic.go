@@ -0,0 +1,46 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/pkg/xerr"
+	"github.com/pkg/errors"
+
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/im/rpc/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DeleteConversationLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewDeleteConversationLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeleteConversationLogic {
+	return &DeleteConversationLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// DeleteConversation 从会话列表中删除会话
+//
+// 只隐藏用户自己的会话，不影响其他成员；会话有新消息时重新展示。
+func (l *DeleteConversationLogic) DeleteConversation(in *im.DeleteConversationReq) (*im.DeleteConversationResp, error) {
+	ok, err := l.svcCtx.UserConversationModel.SetShow(l.ctx, in.UserId, in.ConversationId, false)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "UserConversationModel.SetShow err %v, req %v", err, in)
+	}
+	if !ok {
+		return nil, errors.WithStack(ErrConversationNotFound)
+	}
+
+	if in.ClearHistory {
+		if err := clearHistory(l.ctx, l.svcCtx, in.UserId, in.ConversationId); err != nil {
+			return nil, err
+		}
+	}
+	return &im.DeleteConversationResp{}, nil
+}
@@ -0,0 +1,126 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/task/mq/mq"
+	"easy-chat/pkg/constants"
+	"easy-chat/pkg/xerr"
+	"github.com/pkg/errors"
+	"time"
+
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/im/rpc/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// DefaultMsgEditTime 未配置时消息可编辑的时间
+var DefaultMsgEditTime = 10 * time.Minute
+
+var (
+	ErrEditMsgNotFound   = xerr.New(xerr.REQUEST_PARAM_ERROR, "消息不存在")
+	ErrEditMsgContent    = xerr.New(xerr.REQUEST_PARAM_ERROR, "消息内容不能为空")
+	ErrEditMsgTimeout    = xerr.NewMsg("消息已超过可编辑的时间")
+	ErrEditMsgPermission = xerr.New(xerr.NO_PERMISSION_ERROR, "只能编辑自己发送的消息")
+	ErrEditMsgRecalled   = xerr.NewMsg("消息已被撤回")
+	ErrEditMsgType       = xerr.New(xerr.REQUEST_PARAM_ERROR, "只能编辑文本消息")
+	ErrEditMsgConflict   = xerr.NewMsg("消息已被修改，请刷新后重试")
+)
+
+type EditMsgLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewEditMsgLogic(ctx context.Context, svcCtx *svc.ServiceContext) *EditMsgLogic {
+	return &EditMsgLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// EditMsg 编辑消息
+//
+// 只有发送者可以在可编辑的时间内修改文本消息，修改前的内容保留在消息的历史版本中，
+// 修改后向会话成员推送编辑事件。
+func (l *EditMsgLogic) EditMsg(in *im.EditMsgReq) (*im.EditMsgResp, error) {
+	if in.Content == "" {
+		return nil, errors.WithStack(ErrEditMsgContent)
+	}
+
+	chatLog, err := l.svcCtx.ChatLogModel.FindOne(l.ctx, in.MsgId)
+	switch err {
+	case nil:
+	case immodels.ErrNotFound, immodels.ErrInvalidObjectId:
+		return nil, errors.WithStack(ErrEditMsgNotFound)
+	default:
+		return nil, errors.Wrapf(xerr.NewDBErr(), "find chatlog by msgId err %v, req %v", err, in)
+	}
+
+	if err := l.checkEdit(in.UserId, chatLog); err != nil {
+		return nil, err
+	}
+	if chatLog.MsgContent == in.Content {
+		return &im.EditMsgResp{Version: int32(chatLog.Version)}, nil
+	}
+
+	now := time.Now().UnixMilli()
+	ok, err := l.svcCtx.ChatLogModel.Edit(l.ctx, chatLog, in.Content, now)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "ChatLogModel.Edit err %v, req %v", err, in)
+	}
+	if !ok {
+		return nil, errors.WithStack(ErrEditMsgConflict)
+	}
+	// 会话的最后一条消息同步修改
+	if err := l.svcCtx.ConversationModel.EditMsg(l.ctx, chatLog); err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "ConversationModel.EditMsg err %v, req %v", err, in)
+	}
+	// 引用了该消息的回复同步更新摘要
+	if _, err := l.svcCtx.ChatLogModel.UpdateQuotes(l.ctx, immodels.NewQuote(chatLog)); err != nil {
+		l.Errorf("ChatLogModel.UpdateQuotes err %v, req %v", err, in)
+	}
+
+	err = l.svcCtx.MsgEventTransferClient.Push(&mq.MsgEventTransfer{
+		ContentType:    constants.ContentEdit,
+		ConversationId: chatLog.ConversationId,
+		ChatType:       chatLog.ChatType,
+		SendId:         in.UserId,
+		RecvId:         chatLog.RecvId,
+		SendTime:       now,
+		MsgId:          in.MsgId,
+		Seq:            chatLog.Seq,
+		Version:        chatLog.Version,
+		Content:        chatLog.MsgContent,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewInternalErr(), "push edit event err %v, req %v", err, in)
+	}
+	return &im.EditMsgResp{Version: int32(chatLog.Version)}, nil
+}
+
+// checkEdit 校验用户是否可以编辑该消息
+func (l *EditMsgLogic) checkEdit(uid string, chatLog *immodels.ChatLog) error {
+	if chatLog.SendId != uid {
+		return errors.WithStack(ErrEditMsgPermission)
+	}
+	if chatLog.Status == constants.RecallMsgStatus {
+		return errors.WithStack(ErrEditMsgRecalled)
+	}
+	// 图片、文件等消息的内容是资源地址，不允许修改
+	if chatLog.MsgType != constants.TextMtype {
+		return errors.WithStack(ErrEditMsgType)
+	}
+
+	editTime := DefaultMsgEditTime
+	if l.svcCtx.Config.MsgEditTime > 0 {
+		editTime = time.Duration(l.svcCtx.Config.MsgEditTime) * time.Minute
+	}
+	if time.Since(time.UnixMilli(chatLog.SendTime)) > editTime {
+		return errors.WithStack(ErrEditMsgTimeout)
+	}
+	return nil
+}
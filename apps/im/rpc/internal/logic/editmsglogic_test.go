@@ -0,0 +1,103 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/pkg/constants"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type editChatLogModel struct {
+	recallChatLogModel
+}
+
+func (f *editChatLogModel) Edit(ctx context.Context, data *immodels.ChatLog, content string, editTime int64) (bool, error) {
+	if data.Status == constants.RecallMsgStatus || data.MsgType != constants.TextMtype {
+		return false, nil
+	}
+	data.Edits = append(data.Edits, &immodels.ChatLogEdit{Version: data.Version, MsgContent: data.MsgContent, EditTime: editTime})
+	data.MsgContent = content
+	data.Version++
+	return true, nil
+}
+
+type editConversationModel struct {
+	fakeConversationModel
+}
+
+func (f *editConversationModel) EditMsg(ctx context.Context, chatLog *immodels.ChatLog) error {
+	return nil
+}
+
+func TestEditMsgLogic_EditMsg(t *testing.T) {
+	now := time.Now().UnixMilli()
+	text := func(sendTime int64) *immodels.ChatLog {
+		return &immodels.ChatLog{ConversationId: "u1_u2", ChatType: constants.SingleChatType, SendId: "u1", RecvId: "u2",
+			MsgType: constants.TextMtype, MsgContent: "hello", SendTime: sendTime}
+	}
+	tests := []struct {
+		name    string
+		uid     string
+		chatLog *immodels.ChatLog
+		content string
+		wantErr error
+	}{
+		{"sender", "u1", text(now), "hi", nil},
+		{"unchanged", "u1", text(now), "hello", nil},
+		{"empty", "u1", text(now), "", ErrEditMsgContent},
+		{"not sender", "u2", text(now), "hi", ErrEditMsgPermission},
+		{"timeout", "u1", text(now - time.Hour.Milliseconds()), "hi", ErrEditMsgTimeout},
+		{"recalled", "u1", func() *immodels.ChatLog {
+			chatLog := text(now)
+			chatLog.Status = constants.RecallMsgStatus
+			return chatLog
+		}(), "hi", ErrEditMsgRecalled},
+		{"image", "u1", func() *immodels.ChatLog {
+			chatLog := text(now)
+			chatLog.MsgType, chatLog.MsgContent = constants.ImageMtype, "https://example.com/a.png"
+			return chatLog
+		}(), "https://example.com/b.png", ErrEditMsgType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svcCtx, _ := newTestServiceContext()
+			tt.chatLog.ID = primitive.NewObjectID()
+			msgId := tt.chatLog.ID.Hex()
+			content := tt.chatLog.MsgContent
+			events := &fakeEventTransferClient{}
+			svcCtx.ChatLogModel = &editChatLogModel{recallChatLogModel{fakeChatLogModel{chatLogs: map[string]*immodels.ChatLog{msgId: tt.chatLog}}}}
+			svcCtx.ConversationModel = &editConversationModel{}
+			svcCtx.MsgEventTransferClient = events
+
+			resp, err := NewEditMsgLogic(context.Background(), svcCtx).EditMsg(&im.EditMsgReq{UserId: tt.uid, MsgId: msgId, Content: tt.content})
+			if errors.Cause(err) != tt.wantErr {
+				t.Fatalf("EditMsg() err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if tt.chatLog.MsgContent != content || len(events.pushed) != 0 {
+					t.Errorf("EditMsg() changed the message on error")
+				}
+				return
+			}
+
+			// 内容没有变化时不产生新的版本
+			edited := tt.content != content
+			wantVersion, wantPushed := 0, 0
+			if edited {
+				wantVersion, wantPushed = 1, 1
+			}
+			if tt.chatLog.MsgContent != tt.content || int(resp.Version) != wantVersion || len(events.pushed) != wantPushed {
+				t.Errorf("EditMsg() content %q version %d pushed %d, want %q %d %d",
+					tt.chatLog.MsgContent, resp.Version, len(events.pushed), tt.content, wantVersion, wantPushed)
+			}
+			if edited && (events.pushed[0].ContentType != constants.ContentEdit || events.pushed[0].Content != tt.content) {
+				t.Errorf("EditMsg() pushed %+v, want edit with %q", events.pushed[0], tt.content)
+			}
+		})
+	}
+}
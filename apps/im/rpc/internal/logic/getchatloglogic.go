@@ -2,6 +2,9 @@ package logic
 
 import (
 	"context"
+	"easy-chat/apps/im/authz"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/pkg/constants"
 	"easy-chat/pkg/xerr"
 	"github.com/pkg/errors"
 
@@ -11,6 +14,11 @@ import (
 	"github.com/zeromicro/go-zero/core/logx"
 )
 
+var (
+	ErrChatLogCursor = xerr.New(xerr.REQUEST_PARAM_ERROR, "游标有误")
+	ErrChatLogAnchor = xerr.New(xerr.REQUEST_PARAM_ERROR, "锚点消息不存在")
+)
+
 type GetChatLogLogic struct {
 	ctx    context.Context
 	svcCtx *svc.ServiceContext
@@ -30,6 +38,8 @@ func NewGetChatLogLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetCha
 // 该方法根据请求中的参数从数据库中获取聊天记录。根据是否提供了 msgId，
 // 方法会选择不同的查询方式：如果 msgId 不为空，则直接查询该消息记录；
 // 如果 msgId 为空，则根据时间段进行查询。查询的结果会按照时间排序，并返回符合条件的聊天记录。
+// msgId 与时间段都为空时按游标翻页，见 listByCursor。
+// 只有会话的成员可以查询，非成员返回 authz.ErrNotMember；用户清空聊天记录之前的消息不再返回。
 //
 // 参数:
 //   - in: 请求对象，包含查询条件。
@@ -38,6 +48,16 @@ func NewGetChatLogLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetCha
 //
 //   - *im.GetChatLogResp: 查询结果的响应对象。
 func (l *GetChatLogLogic) GetChatLog(in *im.GetChatLogReq) (*im.GetChatLogResp, error) {
+	if in.MsgId == "" {
+		if err := l.checkConversation(in.UserId, in.ConversationId); err != nil {
+			return nil, err
+		}
+	}
+	if in.MsgId == "" && in.StartSendTime == 0 && in.EndSendTime == 0 {
+		return l.listByCursor(in)
+	}
+
+	var data []*immodels.ChatLog
 	// 如果请求中提供了 msgId，直接查询该消息记录
 	if in.MsgId != "" {
 		chatLog, err := l.svcCtx.ChatLogModel.FindOne(l.ctx, in.MsgId)
@@ -45,48 +65,271 @@ func (l *GetChatLogLogic) GetChatLog(in *im.GetChatLogReq) (*im.GetChatLogResp,
 			// 如果查询过程中发生错误，返回包装后的错误信息
 			return nil, errors.Wrapf(xerr.NewDBErr(), "find chatlog by msgId %s failed", in.MsgId)
 		}
-		// 构造并返回响应对象，包含查询到的单条聊天记录
-		return &im.GetChatLogResp{
-			List: []*im.ChatLog{
-				{
-					Id:             chatLog.ID.Hex(),
-					ConversationId: chatLog.ConversationId,
-					SendId:         chatLog.SendId,
-					RecvId:         chatLog.RecvId,
-					MsgType:        int32(chatLog.MsgType),
-					MsgContent:     chatLog.MsgContent,
-					ChatType:       int32(chatLog.ChatType),
-					SendTime:       chatLog.SendTime,
-					ReadRecords:    chatLog.ReadRecords,
-				},
-			},
-		}, nil
-	}
-
-	// 如果没有提供 msgId，基于时间范围查询聊天记录
-	data, err := l.svcCtx.ChatLogModel.ListBySendTime(l.ctx, in.ConversationId, in.StartSendTime, in.EndSendTime, in.Count)
-	if err != nil {
-		// 如果查询过程中发生错误，返回包装后的错误信息
-		return nil, errors.Wrapf(xerr.NewDBErr(), "find chatLog list by SendTime failed, err: %v req: %v", err.Error(), in)
+		if err := l.svcCtx.Auth.CheckChatLog(l.ctx, in.UserId, chatLog.ChatType, chatLog.SendId, chatLog.RecvId); err != nil {
+			return nil, err
+		}
+		clearSeq, err := l.clearSeq(in.UserId, chatLog.ConversationId)
+		if err != nil {
+			return nil, err
+		}
+		if chatLog.Seq > clearSeq {
+			data = []*immodels.ChatLog{chatLog}
+		}
+	} else {
+		// 如果没有提供 msgId，基于时间范围查询聊天记录
+		clearSeq, err := l.clearSeq(in.UserId, in.ConversationId)
+		if err != nil {
+			return nil, err
+		}
+		data, err = l.svcCtx.ChatLogModel.ListBySendTime(l.ctx, in.ConversationId, clearSeq, in.StartSendTime, in.EndSendTime, in.Count)
+		if err != nil {
+			// 如果查询过程中发生错误，返回包装后的错误信息
+			return nil, errors.Wrapf(xerr.NewDBErr(), "find chatLog list by SendTime failed, err: %v req: %v", err.Error(), in)
+		}
 	}
 
 	// 构造查询结果列表
-	res := make([]*im.ChatLog, 0, len(data))
-	for _, v := range data {
-		res = append(res, &im.ChatLog{
-			Id:             v.ID.Hex(),
-			ConversationId: v.ConversationId,
-			SendId:         v.SendId,
-			RecvId:         v.RecvId,
-			MsgType:        int32(v.MsgType),
-			MsgContent:     v.MsgContent,
-			ChatType:       int32(v.ChatType),
-			SendTime:       v.SendTime,
-			ReadRecords:    v.ReadRecords,
-		})
+	res, err := toChatLogs(l.ctx, l.svcCtx, data, in.UserId)
+	if err != nil {
+		return nil, err
 	}
 	// 返回包含聊天记录列表的响应对象
 	return &im.GetChatLogResp{
 		List: res,
 	}, nil
 }
+
+// checkConversation 校验用户是否为会话的成员，会话不存在时同样视为没有权限
+func (l *GetChatLogLogic) checkConversation(uid, conversationId string) error {
+	conversation, err := l.svcCtx.ConversationModel.FindByConversationId(l.ctx, conversationId)
+	switch err {
+	case nil:
+	case immodels.ErrNotFound:
+		return errors.WithStack(authz.ErrNotMember)
+	default:
+		return errors.Wrapf(xerr.NewDBErr(), "find conversation by conversationId err %v, conversationId %v", err, conversationId)
+	}
+	return l.svcCtx.Auth.CheckConversation(l.ctx, uid, conversation.ChatType, conversationId)
+}
+
+// clearSeq 用户清空聊天记录时会话的序号，用户没有该会话时为 0
+func (l *GetChatLogLogic) clearSeq(uid, conversationId string) (int64, error) {
+	conversation, err := l.svcCtx.UserConversationModel.FindOne(l.ctx, uid, conversationId)
+	switch err {
+	case nil:
+		return conversation.ClearSeq, nil
+	case immodels.ErrNotFound:
+		return 0, nil
+	default:
+		return 0, errors.Wrapf(xerr.NewDBErr(), "UserConversationModel.FindOne err %v, uid %v, conversationId %v", err, uid, conversationId)
+	}
+}
+
+// listByCursor 从游标或锚点消息开始按方向翻页，以序号与ID排序，同一毫秒发送的消息也不会重复或遗漏
+func (l *GetChatLogLogic) listByCursor(in *im.GetChatLogReq) (*im.GetChatLogResp, error) {
+	var (
+		anchor *immodels.ChatLogCursor
+		err    error
+	)
+	switch {
+	case in.Cursor != "":
+		if anchor, err = immodels.DecodeChatLogCursor(in.Cursor); err != nil {
+			return nil, errors.WithStack(ErrChatLogCursor)
+		}
+	case in.AnchorMsgId != "":
+		chatLog, err := l.svcCtx.ChatLogModel.FindOne(l.ctx, in.AnchorMsgId)
+		switch err {
+		case nil:
+		case immodels.ErrNotFound, immodels.ErrInvalidObjectId:
+			return nil, errors.WithStack(ErrChatLogAnchor)
+		default:
+			return nil, errors.Wrapf(xerr.NewDBErr(), "find chatlog by msgId err %v, req %v", err, in)
+		}
+		if chatLog.ConversationId != in.ConversationId {
+			return nil, errors.WithStack(ErrChatLogAnchor)
+		}
+		anchor = immodels.NewChatLogCursor(chatLog)
+	}
+
+	count := in.Count
+	switch {
+	case count <= 0:
+		count = immodels.DefaultChatLogPageSize
+	case count > immodels.MaxChatLogPageSize:
+		count = immodels.MaxChatLogPageSize
+	}
+	clearSeq, err := l.clearSeq(in.UserId, in.ConversationId)
+	if err != nil {
+		return nil, err
+	}
+	data, hasMore, err := l.svcCtx.ChatLogModel.ListByCursor(l.ctx, in.ConversationId, clearSeq, anchor, constants.PageDirection(in.Direction), count)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "ChatLogModel.ListByCursor err %v, req %v", err, in)
+	}
+
+	list, err := toChatLogs(l.ctx, l.svcCtx, data, in.UserId)
+	if err != nil {
+		return nil, err
+	}
+	resp := &im.GetChatLogResp{
+		List:    list,
+		HasMore: hasMore,
+	}
+	if len(data) > 0 {
+		resp.OlderCursor = immodels.NewChatLogCursor(data[0]).Encode()
+		resp.NewerCursor = immodels.NewChatLogCursor(data[len(data)-1]).Encode()
+	}
+	return resp, nil
+}
+
+// toChatLogs 转换聊天记录，并查询 uid 视角下消息的表情回应
+func toChatLogs(ctx context.Context, svcCtx *svc.ServiceContext, data []*immodels.ChatLog, uid string) ([]*im.ChatLog, error) {
+	msgIds := make([]string, 0, len(data))
+	for _, v := range data {
+		msgIds = append(msgIds, v.ID.Hex())
+	}
+	reactions, err := svcCtx.ReactionModel.ListCountsByMsgIds(ctx, msgIds, uid)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "ReactionModel.ListCountsByMsgIds err %v, msgIds %v", err, msgIds)
+	}
+
+	res := make([]*im.ChatLog, 0, len(data))
+	for _, v := range data {
+		chatLog := toChatLog(v)
+		chatLog.Reactions = toReactions(reactions[chatLog.Id])
+		res = append(res, chatLog)
+	}
+	return res, nil
+}
+
+func toChatLog(v *immodels.ChatLog) *im.ChatLog {
+	// 撤回的消息不返回内容，即使记录中仍保留着撤回之前的内容
+	if v.Status == constants.RecallMsgStatus {
+		recalled := *v
+		recalled.MsgContent = ""
+		recalled.Body = nil
+		recalled.ReplyTo = nil
+		recalled.Mention = nil
+		v = &recalled
+	}
+	return &im.ChatLog{
+		Id:             v.ID.Hex(),
+		ConversationId: v.ConversationId,
+		SendId:         v.SendId,
+		RecvId:         v.RecvId,
+		MsgType:        int32(v.MsgType),
+		MsgContent:     v.MsgContent,
+		ChatType:       int32(v.ChatType),
+		SendTime:       v.SendTime,
+		ReadRecords:    v.ReadRecords,
+		Seq:            v.Seq,
+		Status:         int32(v.Status),
+		Version:        int32(v.Version),
+		Body:           toMsgBody(v.Body),
+		ReplyTo:        toQuote(v.ReplyTo),
+		Mention:        toMention(v.Mention),
+		ThreadId:       v.ThreadId,
+		Thread:         toThread(v.Thread),
+	}
+}
+
+// toMsgBody 转换非文本消息的结构化内容
+func toMsgBody(body *immodels.MsgBody) *im.MsgBody {
+	if body == nil {
+		return nil
+	}
+
+	res := &im.MsgBody{}
+	if body.Image != nil {
+		res.Image = &im.Image{
+			Url:          body.Image.Url,
+			Thumbnail:    body.Image.Thumbnail,
+			Width:        body.Image.Width,
+			Height:       body.Image.Height,
+			AttachmentId: body.Image.AttachmentId,
+		}
+	}
+	if body.File != nil {
+		res.File = &im.File{
+			Url:          body.File.Url,
+			Name:         body.File.Name,
+			Size:         body.File.Size,
+			Mime:         body.File.Mime,
+			AttachmentId: body.File.AttachmentId,
+		}
+	}
+	if body.Voice != nil {
+		res.Voice = &im.Voice{
+			Url:          body.Voice.Url,
+			Duration:     body.Voice.Duration,
+			AttachmentId: body.Voice.AttachmentId,
+		}
+	}
+	if body.Location != nil {
+		res.Location = &im.Location{
+			Latitude:  body.Location.Latitude,
+			Longitude: body.Location.Longitude,
+			Name:      body.Location.Name,
+			Address:   body.Location.Address,
+		}
+	}
+	if body.Card != nil {
+		res.Card = &im.Card{
+			CardType: int32(body.Card.CardType),
+			TargetId: body.Card.TargetId,
+			Name:     body.Card.Name,
+			Avatar:   body.Card.Avatar,
+		}
+	}
+	return res
+}
+
+func toQuote(quote *immodels.Quote) *im.Quote {
+	if quote == nil {
+		return nil
+	}
+	return &im.Quote{
+		MsgId:   quote.MsgId,
+		SendId:  quote.SendId,
+		MsgType: int32(quote.MsgType),
+		Snippet: quote.Snippet,
+		Status:  int32(quote.Status),
+	}
+}
+
+func toMention(mention *immodels.Mention) *im.Mention {
+	if mention == nil {
+		return nil
+	}
+	return &im.Mention{
+		UserIds: mention.UserIds,
+		All:     mention.All,
+	}
+}
+
+func toReactions(counts []*immodels.ReactionCount) []*im.Reaction {
+	if len(counts) == 0 {
+		return nil
+	}
+
+	res := make([]*im.Reaction, 0, len(counts))
+	for _, count := range counts {
+		res = append(res, &im.Reaction{
+			Emoji:   count.Emoji,
+			Count:   count.Count,
+			Reacted: count.Reacted,
+		})
+	}
+	return res
+}
+
+func toThread(thread *immodels.Thread) *im.Thread {
+	if thread == nil {
+		return nil
+	}
+	return &im.Thread{
+		ReplyCount:    thread.ReplyCount,
+		LastReply:     toQuote(thread.LastReply),
+		LastReplyTime: thread.LastReplyTime,
+	}
+}
@@ -0,0 +1,191 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/authz"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/im/rpc/internal/svc"
+	"easy-chat/apps/social/rpc/socialclient"
+	"easy-chat/pkg/constants"
+	"easy-chat/pkg/xerr"
+	"testing"
+
+	"github.com/pkg/errors"
+	zerr "github.com/zeromicro/x/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+)
+
+type fakeChatLogModel struct {
+	immodels.ChatLogModel
+	chatLogs map[string]*immodels.ChatLog
+}
+
+func (f *fakeChatLogModel) FindOne(ctx context.Context, id string) (*immodels.ChatLog, error) {
+	if chatLog, ok := f.chatLogs[id]; ok {
+		return chatLog, nil
+	}
+	return nil, immodels.ErrNotFound
+}
+
+func (f *fakeChatLogModel) list(conversationId string) []*immodels.ChatLog {
+	var res []*immodels.ChatLog
+	for _, chatLog := range f.chatLogs {
+		if chatLog.ConversationId == conversationId {
+			res = append(res, chatLog)
+		}
+	}
+	return res
+}
+
+func (f *fakeChatLogModel) ListBySendTime(ctx context.Context, conversationId string, clearSeq, startSendTime, endSendTime, limit int64) ([]*immodels.ChatLog, error) {
+	return f.list(conversationId), nil
+}
+
+func (f *fakeChatLogModel) ListByCursor(ctx context.Context, conversationId string, clearSeq int64, anchor *immodels.ChatLogCursor, direction constants.PageDirection, limit int64) ([]*immodels.ChatLog, bool, error) {
+	return f.list(conversationId), false, nil
+}
+
+type fakeConversationModel struct {
+	immodels.ConversationModel
+	conversations map[string]*immodels.Conversation
+}
+
+func (f *fakeConversationModel) FindByConversationId(ctx context.Context, conversationId string) (*immodels.Conversation, error) {
+	if conversation, ok := f.conversations[conversationId]; ok {
+		return conversation, nil
+	}
+	return nil, immodels.ErrNotFound
+}
+
+type fakeUserConversationModel struct {
+	immodels.UserConversationModel
+}
+
+func (f *fakeUserConversationModel) FindOne(ctx context.Context, uid, conversationId string) (*immodels.UserConversation, error) {
+	return nil, immodels.ErrNotFound
+}
+
+type fakeReactionModel struct {
+	immodels.ReactionModel
+}
+
+func (f *fakeReactionModel) ListCountsByMsgIds(ctx context.Context, msgIds []string, uid string) (map[string][]*immodels.ReactionCount, error) {
+	return nil, nil
+}
+
+type fakeSocial struct {
+	groups  map[string][]string
+	friends map[string][]string
+	roles   map[string]constants.GroupRoleLevel // 群成员的角色，未设置时为普通成员
+}
+
+func (f *fakeSocial) FriendList(ctx context.Context, in *socialclient.FriendListReq, opts ...grpc.CallOption) (*socialclient.FriendListResp, error) {
+	var list []*socialclient.Friends
+	for _, uid := range f.friends[in.UserId] {
+		list = append(list, &socialclient.Friends{UserId: in.UserId, FriendUid: uid})
+	}
+	return &socialclient.FriendListResp{List: list}, nil
+}
+
+func (f *fakeSocial) GroupUsers(ctx context.Context, in *socialclient.GroupUsersReq, opts ...grpc.CallOption) (*socialclient.GroupUsersResp, error) {
+	var list []*socialclient.GroupMembers
+	for _, uid := range f.groups[in.GroupId] {
+		role, ok := f.roles[uid]
+		if !ok {
+			role = constants.AtLargeGroupRoleLevel
+		}
+		list = append(list, &socialclient.GroupMembers{GroupId: in.GroupId, UserId: uid, RoleLevel: int32(role)})
+	}
+	return &socialclient.GroupUsersResp{List: list}, nil
+}
+
+// newTestServiceContext 私聊会话 u1_u2 与群 g1（成员 u1、u3）各有一条消息
+func newTestServiceContext() (*svc.ServiceContext, map[string]*immodels.ChatLog) {
+	chatLogs := map[string]*immodels.ChatLog{}
+	for _, chatLog := range []*immodels.ChatLog{
+		{ID: primitive.NewObjectID(), ConversationId: "u1_u2", ChatType: constants.SingleChatType, SendId: "u1", RecvId: "u2", Seq: 1},
+		{ID: primitive.NewObjectID(), ConversationId: "g1", ChatType: constants.GroupChatType, SendId: "u1", RecvId: "g1", Seq: 1},
+	} {
+		chatLogs[chatLog.ID.Hex()] = chatLog
+	}
+
+	return &svc.ServiceContext{
+		ChatLogModel: &fakeChatLogModel{chatLogs: chatLogs},
+		ConversationModel: &fakeConversationModel{conversations: map[string]*immodels.Conversation{
+			"u1_u2": {ConversationId: "u1_u2", ChatType: constants.SingleChatType},
+			"g1":    {ConversationId: "g1", ChatType: constants.GroupChatType},
+		}},
+		UserConversationModel: &fakeUserConversationModel{},
+		ReactionModel:         &fakeReactionModel{},
+		Auth:                  authz.NewAuthorizer(&fakeSocial{groups: map[string][]string{"g1": {"u1", "u3"}}}),
+	}, chatLogs
+}
+
+func isNoPermission(err error) bool {
+	e, ok := errors.Cause(err).(*zerr.CodeMsg)
+	return ok && e.Code == xerr.NO_PERMISSION_ERROR
+}
+
+func TestGetChatLogLogic_GetChatLog(t *testing.T) {
+	svcCtx, chatLogs := newTestServiceContext()
+	msgIds := map[string]string{}
+	for id, chatLog := range chatLogs {
+		msgIds[chatLog.ConversationId] = id
+	}
+
+	tests := []struct {
+		name    string
+		req     *im.GetChatLogReq
+		wantErr bool
+	}{
+		{"single participant by cursor", &im.GetChatLogReq{UserId: "u2", ConversationId: "u1_u2"}, false},
+		{"single outsider by cursor", &im.GetChatLogReq{UserId: "u3", ConversationId: "u1_u2"}, true},
+		{"single outsider by send time", &im.GetChatLogReq{UserId: "u3", ConversationId: "u1_u2", EndSendTime: 1}, true},
+		{"group member by send time", &im.GetChatLogReq{UserId: "u3", ConversationId: "g1", EndSendTime: 1}, false},
+		{"group outsider by cursor", &im.GetChatLogReq{UserId: "u2", ConversationId: "g1"}, true},
+		{"unknown conversation", &im.GetChatLogReq{UserId: "u1", ConversationId: "u1_u4"}, true},
+		{"single participant by msgId", &im.GetChatLogReq{UserId: "u1", MsgId: msgIds["u1_u2"]}, false},
+		{"single outsider by msgId", &im.GetChatLogReq{UserId: "u3", MsgId: msgIds["u1_u2"]}, true},
+		{"group outsider by msgId", &im.GetChatLogReq{UserId: "u2", MsgId: msgIds["g1"]}, true},
+		{"no user", &im.GetChatLogReq{ConversationId: "u1_u2"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := NewGetChatLogLogic(context.Background(), svcCtx).GetChatLog(tt.req)
+			if tt.wantErr {
+				if !isNoPermission(err) {
+					t.Fatalf("GetChatLog() err = %v, want permission error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetChatLog() err = %v", err)
+			}
+			if len(resp.List) != 1 {
+				t.Errorf("GetChatLog() got %d chat logs, want 1", len(resp.List))
+			}
+		})
+	}
+}
+
+func TestToChatLog_Recalled(t *testing.T) {
+	chatLog := &immodels.ChatLog{
+		ID:         primitive.NewObjectID(),
+		MsgType:    constants.ImageMtype,
+		MsgContent: "hello",
+		Body:       &immodels.MsgBody{Image: &immodels.Image{Url: "http://a", Width: 1, Height: 1}},
+		ReplyTo:    &immodels.Quote{MsgId: "m1"},
+		Mention:    &immodels.Mention{UserIds: []string{"u2"}},
+		Status:     constants.RecallMsgStatus,
+	}
+
+	res := toChatLog(chatLog)
+	if res.MsgContent != "" || res.Body != nil || res.ReplyTo != nil || res.Mention != nil {
+		t.Errorf("toChatLog() = %+v, want recalled content masked", res)
+	}
+	if chatLog.Body == nil {
+		t.Error("toChatLog() modified the chat log")
+	}
+}
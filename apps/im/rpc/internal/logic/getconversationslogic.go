@@ -6,8 +6,9 @@ import (
 	"easy-chat/apps/im/rpc/im"
 	"easy-chat/apps/im/rpc/internal/svc"
 	"easy-chat/pkg/xerr"
-	"github.com/jinzhu/copier"
 	"github.com/pkg/errors"
+	"sort"
+	"time"
 
 	"github.com/zeromicro/go-zero/core/logx"
 )
@@ -27,44 +28,101 @@ func NewGetConversationsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *
 }
 
 // 获取会话
+//
+// 返回用户的所有会话，以及展示的会话的排列顺序与未读总数。
 
 func (l *GetConversationsLogic) GetConversations(in *im.GetConversationsReq) (*im.GetConversationsResp, error) {
-	// todo: add your logic here and delete this line
-	//根据用户查询用户会话列表
-	data, err := l.svcCtx.ConversationsModel.FindByUserId(l.ctx, in.UserId)
+	//根据用户查询用户的会话
+	userConversations, err := l.svcCtx.UserConversationModel.ListByUserId(l.ctx, in.UserId)
 	if err != nil {
-		if err == immodels.ErrNotFound {
-			return &im.GetConversationsResp{}, nil
-		}
-		return nil, errors.Wrapf(xerr.NewDBErr(), "ConversationsModel.GetConversations err %v,req %v", err, in.UserId)
+		return nil, errors.Wrapf(xerr.NewDBErr(), "UserConversationModel.ListByUserId err %v,req %v", err, in.UserId)
+	}
+	if len(userConversations) == 0 {
+		return &im.GetConversationsResp{}, nil
+	}
 
+	res := im.GetConversationsResp{
+		ConversationList: make(map[string]*im.Conversation, len(userConversations)),
 	}
-	var res im.GetConversationsResp
-	copier.Copy(&res, &data)
-	//根据会话列表，查询具体的会话
-	ids := make([]string, 0, len(data.ConversationList))
-	for _, conversation := range data.ConversationList {
+	ids := make([]string, 0, len(userConversations))
+	for _, conversation := range userConversations {
+		res.ConversationList[conversation.ConversationId] = toConversation(conversation)
 		ids = append(ids, conversation.ConversationId)
 	}
+	//根据会话列表，查询具体的会话
 	conversations, err := l.svcCtx.ConversationModel.ListByConversationIds(l.ctx, ids)
 	if err != nil {
 		return nil, errors.Wrapf(xerr.NewDBErr(), "ConversationModel.ListByConversationIds err %v,req %v", err, ids)
 	}
-	//计算是否存在未读消息
+	//未读数由服务端维护，这里只补充会话的最新状态
+	lastMsgTimes := make(map[string]int64, len(conversations))
 	for _, conversation := range conversations {
-		if _, ok := res.ConversationList[conversation.ConversationId]; !ok {
+		userConversation, ok := res.ConversationList[conversation.ConversationId]
+		if !ok {
 			continue
 		}
-		//用户读取的消息
-		total := res.ConversationList[conversation.ConversationId].Total
-		if total < int32(conversation.Total) {
-			//有新的消息
-			res.ConversationList[conversation.ConversationId].Total = int32(conversation.Total)
-			//有多少消息是未读
-			res.ConversationList[conversation.ConversationId].ToRead = int32(conversation.Total) - total
-			//更改当前会话状态为显示状态
-			res.ConversationList[conversation.ConversationId].IsShow = true
+		userConversation.Total = int32(conversation.Total)
+		userConversation.Seq = conversation.Seq
+		userConversation.Pins = toPins(conversation.Pins)
+		if conversation.Msg != nil {
+			lastMsgTimes[conversation.ConversationId] = conversation.Msg.SendTime
+		}
+	}
+
+	res.ConversationIds = sortConversations(userConversations, lastMsgTimes)
+	now := time.Now()
+	for _, conversation := range userConversations {
+		if conversation.IsShow && !conversation.Archived && !conversation.Muted(now) {
+			res.UnreadTotal += conversation.Unread
 		}
 	}
 	return &res, nil
 }
+
+// sortConversations 返回展示的会话的顺序
+//
+// 未归档的会话在前；置顶的会话按置顶时间倒序排在最前面，其他会话按最后一条消息的时间倒序。
+func sortConversations(conversations []*immodels.UserConversation, lastMsgTimes map[string]int64) []string {
+	shown := make([]*immodels.UserConversation, 0, len(conversations))
+	for _, conversation := range conversations {
+		if conversation.IsShow {
+			shown = append(shown, conversation)
+		}
+	}
+
+	sort.SliceStable(shown, func(i, j int) bool {
+		a, b := shown[i], shown[j]
+		if a.Archived != b.Archived {
+			return !a.Archived
+		}
+		if a.PinTime != b.PinTime {
+			return a.PinTime > b.PinTime
+		}
+		if ta, tb := lastMsgTimes[a.ConversationId], lastMsgTimes[b.ConversationId]; ta != tb {
+			return ta > tb
+		}
+		return a.ConversationId < b.ConversationId
+	})
+
+	ids := make([]string, 0, len(shown))
+	for _, conversation := range shown {
+		ids = append(ids, conversation.ConversationId)
+	}
+	return ids
+}
+
+func toPins(pins []*immodels.Pin) []*im.Pin {
+	if len(pins) == 0 {
+		return nil
+	}
+	res := make([]*im.Pin, 0, len(pins))
+	for _, pin := range pins {
+		res = append(res, &im.Pin{
+			MsgId:    pin.MsgId,
+			Seq:      pin.Seq,
+			PinnedBy: pin.PinnedBy,
+			PinTime:  pin.PinTime,
+		})
+	}
+	return res
+}
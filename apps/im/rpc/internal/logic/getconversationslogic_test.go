@@ -0,0 +1,30 @@
+package logic
+
+import (
+	"easy-chat/apps/im/immodels"
+	"reflect"
+	"testing"
+)
+
+func TestSortConversations(t *testing.T) {
+	conversations := []*immodels.UserConversation{
+		{ConversationId: "old", IsShow: true},
+		{ConversationId: "new", IsShow: true},
+		{ConversationId: "pinned", IsShow: true, PinTime: 10},
+		{ConversationId: "pinned-later", IsShow: true, PinTime: 20},
+		{ConversationId: "archived", IsShow: true, Archived: true, PinTime: 30},
+		{ConversationId: "hidden", IsShow: false},
+	}
+	lastMsgTimes := map[string]int64{
+		"old":      100,
+		"new":      200,
+		"archived": 300,
+		"hidden":   400,
+	}
+
+	got := sortConversations(conversations, lastMsgTimes)
+	want := []string{"pinned-later", "pinned", "new", "old", "archived"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sortConversations() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,109 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/pkg/xerr"
+	"github.com/pkg/errors"
+
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/im/rpc/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+var ErrPinnedMsgsPermission = xerr.New(xerr.NO_PERMISSION_ERROR, "不是会话成员，不能查看置顶的消息")
+
+type GetPinnedMsgsLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewGetPinnedMsgsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetPinnedMsgsLogic {
+	return &GetPinnedMsgsLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// GetPinnedMsgs 获取会话中置顶的消息，最近置顶的排在前面，不返回用户清空之前的消息
+func (l *GetPinnedMsgsLogic) GetPinnedMsgs(in *im.GetPinnedMsgsReq) (*im.GetPinnedMsgsResp, error) {
+	// 只有会话列表中有该会话的用户可以查看
+	userConversation, err := l.svcCtx.UserConversationModel.FindOne(l.ctx, in.UserId, in.ConversationId)
+	switch err {
+	case nil:
+	case immodels.ErrNotFound:
+		return nil, errors.WithStack(ErrPinnedMsgsPermission)
+	default:
+		return nil, errors.Wrapf(xerr.NewDBErr(), "UserConversationModel.FindOne err %v, req %v", err, in)
+	}
+
+	conversation, err := l.svcCtx.ConversationModel.FindByConversationId(l.ctx, in.ConversationId)
+	switch err {
+	case nil:
+	case immodels.ErrNotFound:
+		return &im.GetPinnedMsgsResp{}, nil
+	default:
+		return nil, errors.Wrapf(xerr.NewDBErr(), "ConversationModel.FindByConversationId err %v, req %v", err, in)
+	}
+	if len(conversation.Pins) == 0 {
+		return &im.GetPinnedMsgsResp{}, nil
+	}
+
+	msgIds := make([]string, 0, len(conversation.Pins))
+	for _, pin := range conversation.Pins {
+		msgIds = append(msgIds, pin.MsgId)
+	}
+	chatLogs, err := listChatLogsByMsgIds(l.ctx, l.svcCtx, msgIds, in.UserId, map[string]int64{
+		in.ConversationId: userConversation.ClearSeq,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*im.PinnedMsg, 0, len(conversation.Pins))
+	for i := len(conversation.Pins) - 1; i >= 0; i-- {
+		pin := conversation.Pins[i]
+		chatLog, ok := chatLogs[pin.MsgId]
+		if !ok {
+			continue
+		}
+		list = append(list, &im.PinnedMsg{
+			Msg:      chatLog,
+			PinnedBy: pin.PinnedBy,
+			PinTime:  pin.PinTime,
+		})
+	}
+	return &im.GetPinnedMsgsResp{List: list}, nil
+}
+
+// listChatLogsByMsgIds 按消息ID查询聊天记录，返回以消息ID为键的结果
+//
+// clearSeqs 为用户清空会话时的序号，不返回序号不大于它的消息；为 nil 时不过滤。
+func listChatLogsByMsgIds(ctx context.Context, svcCtx *svc.ServiceContext, msgIds []string, uid string, clearSeqs map[string]int64) (map[string]*im.ChatLog, error) {
+	data, err := svcCtx.ChatLogModel.ListByMsgIds(ctx, msgIds)
+	if err != nil && err != immodels.ErrNotFound {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "ChatLogModel.ListByMsgIds err %v, msgIds %v", err, msgIds)
+	}
+	if clearSeqs != nil {
+		visible := make([]*immodels.ChatLog, 0, len(data))
+		for _, v := range data {
+			if v.Seq > clearSeqs[v.ConversationId] {
+				visible = append(visible, v)
+			}
+		}
+		data = visible
+	}
+	chatLogs, err := toChatLogs(ctx, svcCtx, data, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]*im.ChatLog, len(chatLogs))
+	for _, chatLog := range chatLogs {
+		res[chatLog.Id] = chatLog
+	}
+	return res, nil
+}
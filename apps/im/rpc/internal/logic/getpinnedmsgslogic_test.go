@@ -0,0 +1,42 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/im/rpc/im"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestGetPinnedMsgsLogic_GetPinnedMsgs(t *testing.T) {
+	svcCtx, chatLogs := newSearchTestServiceContext(t)
+	conversation := svcCtx.ConversationModel.(*fakeConversationModel).conversations["g1"]
+	for id, chatLog := range chatLogs {
+		if chatLog.ConversationId == "g1" {
+			conversation.Pins = []*immodels.Pin{{MsgId: id, Seq: chatLog.Seq, PinnedBy: "u1", PinTime: 1}}
+		}
+	}
+
+	tests := []struct {
+		name    string
+		uid     string
+		want    int
+		wantErr error
+	}{
+		{"member", "u1", 1, nil},
+		{"cleared", "u3", 0, nil},
+		{"not in list", "u4", 0, ErrPinnedMsgsPermission},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := NewGetPinnedMsgsLogic(context.Background(), svcCtx).GetPinnedMsgs(&im.GetPinnedMsgsReq{UserId: tt.uid, ConversationId: "g1"})
+			if errors.Cause(err) != tt.wantErr {
+				t.Fatalf("GetPinnedMsgs() err = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && len(resp.List) != tt.want {
+				t.Errorf("GetPinnedMsgs() got %d pins, want %d", len(resp.List), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/pkg/xerr"
+	"github.com/pkg/errors"
+
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/im/rpc/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetReadSeqsLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewGetReadSeqsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetReadSeqsLogic {
+	return &GetReadSeqsLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 获取用户在会话中的已读水位
+
+func (l *GetReadSeqsLogic) GetReadSeqs(in *im.GetReadSeqsReq) (*im.GetReadSeqsResp, error) {
+	if len(in.UserIds) == 0 {
+		return &im.GetReadSeqsResp{}, nil
+	}
+
+	readSeqs, err := l.svcCtx.UserConversationModel.ListReadSeqs(l.ctx, in.ConversationId, in.UserIds)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "UserConversationModel.ListReadSeqs err %v, req %v", err, in)
+	}
+	return &im.GetReadSeqsResp{
+		ReadSeqs: readSeqs,
+	}, nil
+}
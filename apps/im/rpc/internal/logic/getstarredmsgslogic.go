@@ -0,0 +1,60 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/pkg/xerr"
+	"github.com/pkg/errors"
+
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/im/rpc/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetStarredMsgsLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewGetStarredMsgsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetStarredMsgsLogic {
+	return &GetStarredMsgsLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// GetStarredMsgs 按收藏时间倒序分页获取用户收藏的消息
+func (l *GetStarredMsgsLogic) GetStarredMsgs(in *im.GetStarredMsgsReq) (*im.GetStarredMsgsResp, error) {
+	stars, err := l.svcCtx.StarModel.ListByUserId(l.ctx, in.UserId, in.BeforeTime, in.Count)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "StarModel.ListByUserId err %v, req %v", err, in)
+	}
+	if len(stars) == 0 {
+		return &im.GetStarredMsgsResp{}, nil
+	}
+
+	msgIds := make([]string, 0, len(stars))
+	for _, star := range stars {
+		msgIds = append(msgIds, star.MsgId)
+	}
+	// 收藏的消息在清空聊天记录后仍然保留
+	chatLogs, err := listChatLogsByMsgIds(l.ctx, l.svcCtx, msgIds, in.UserId, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*im.StarredMsg, 0, len(stars))
+	for _, star := range stars {
+		chatLog, ok := chatLogs[star.MsgId]
+		if !ok {
+			continue
+		}
+		list = append(list, &im.StarredMsg{
+			Msg:      chatLog,
+			StarTime: star.CreateAt.UnixMilli(),
+		})
+	}
+	return &im.GetStarredMsgsResp{List: list}, nil
+}
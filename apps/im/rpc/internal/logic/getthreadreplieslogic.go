@@ -0,0 +1,69 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/pkg/constants"
+	"easy-chat/pkg/xerr"
+	"github.com/pkg/errors"
+
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/im/rpc/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+var (
+	ErrThreadNotFound   = xerr.New(xerr.REQUEST_PARAM_ERROR, "话题不存在")
+	ErrThreadPermission = xerr.New(xerr.NO_PERMISSION_ERROR, "不是群成员，不能查看该话题")
+)
+
+type GetThreadRepliesLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewGetThreadRepliesLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetThreadRepliesLogic {
+	return &GetThreadRepliesLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// GetThreadReplies 按序号升序分页获取话题中的回复，同时返回话题的第一条消息
+func (l *GetThreadRepliesLogic) GetThreadReplies(in *im.GetThreadRepliesReq) (*im.GetThreadRepliesResp, error) {
+	root, err := l.svcCtx.ChatLogModel.FindOne(l.ctx, in.ThreadId)
+	switch err {
+	case nil:
+	case immodels.ErrNotFound, immodels.ErrInvalidObjectId:
+		return nil, errors.WithStack(ErrThreadNotFound)
+	default:
+		return nil, errors.Wrapf(xerr.NewDBErr(), "find chatlog by msgId err %v, req %v", err, in)
+	}
+	if root.ChatType != constants.GroupChatType || root.ThreadId != "" {
+		return nil, errors.WithStack(ErrThreadNotFound)
+	}
+	ok, err := isConversationMember(l.ctx, l.svcCtx, in.UserId, root)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.WithStack(ErrThreadPermission)
+	}
+
+	replies, err := l.svcCtx.ChatLogModel.ListByThreadId(l.ctx, in.ThreadId, in.AfterSeq, in.Count)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "ChatLogModel.ListByThreadId err %v, req %v", err, in)
+	}
+
+	list, err := toChatLogs(l.ctx, l.svcCtx, append([]*immodels.ChatLog{root}, replies...), in.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return &im.GetThreadRepliesResp{
+		Root: list[0],
+		List: list[1:],
+	}, nil
+}
@@ -0,0 +1,133 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/pkg/constants"
+	"easy-chat/pkg/wuid"
+	"easy-chat/pkg/xerr"
+	"github.com/pkg/errors"
+
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/im/rpc/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// ErrConversationCursor 会话列表的游标有误
+var ErrConversationCursor = xerr.New(xerr.REQUEST_PARAM_ERROR, "游标有误")
+
+type ListConversationsLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewListConversationsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListConversationsLogic {
+	return &ListConversationsLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// ListConversations 按最后一条消息的时间分页查询会话列表，置顶的会话在前
+//
+// 每个会话带有最后一条消息的预览与用户的未读数；用户清空聊天记录之前的消息不作为预览。
+// 私聊会话的 targetId 为对方的用户，群聊会话的 targetId 为群。
+func (l *ListConversationsLogic) ListConversations(in *im.ListConversationsReq) (*im.ListConversationsResp, error) {
+	var after *immodels.ConversationCursor
+	if in.Cursor != "" {
+		var err error
+		if after, err = immodels.DecodeConversationCursor(in.Cursor); err != nil {
+			return nil, errors.WithStack(ErrConversationCursor)
+		}
+	}
+
+	count := in.Count
+	switch {
+	case count <= 0:
+		count = immodels.DefaultConversationPageSize
+	case count > immodels.MaxConversationPageSize:
+		count = immodels.MaxConversationPageSize
+	}
+	userConversations, hasMore, err := l.svcCtx.UserConversationModel.ListPage(l.ctx, in.UserId, in.Archived, after, count)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "UserConversationModel.ListPage err %v, req %v", err, in)
+	}
+	if len(userConversations) == 0 {
+		return &im.ListConversationsResp{}, nil
+	}
+
+	ids := make([]string, 0, len(userConversations))
+	for _, conversation := range userConversations {
+		ids = append(ids, conversation.ConversationId)
+	}
+	conversations, err := l.svcCtx.ConversationModel.ListByConversationIds(l.ctx, ids)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "ConversationModel.ListByConversationIds err %v, req %v", err, ids)
+	}
+	byId := make(map[string]*immodels.Conversation, len(conversations))
+	for _, conversation := range conversations {
+		byId[conversation.ConversationId] = conversation
+	}
+
+	list := make([]*im.Conversation, 0, len(userConversations))
+	for _, userConversation := range userConversations {
+		conversation := toConversation(userConversation)
+		conversation.TargetId = targetId(userConversation)
+		if v, ok := byId[userConversation.ConversationId]; ok {
+			conversation.Total = int32(v.Total)
+			conversation.Seq = v.Seq
+			conversation.Pins = toPins(v.Pins)
+			if v.Msg != nil && v.Msg.Seq > userConversation.ClearSeq {
+				conversation.Msg = toChatLog(v.Msg)
+			}
+		}
+		list = append(list, conversation)
+	}
+
+	resp := &im.ListConversationsResp{
+		List:    list,
+		HasMore: hasMore,
+	}
+	if hasMore {
+		resp.NextCursor = immodels.NewConversationCursor(userConversations[len(userConversations)-1]).Encode()
+	}
+	return resp, nil
+}
+
+// toConversation 转换用户的会话，会话本身的状态由调用方补充
+func toConversation(conversation *immodels.UserConversation) *im.Conversation {
+	return &im.Conversation{
+		ConversationId: conversation.ConversationId,
+		ChatType:       int32(conversation.ChatType),
+		IsShow:         conversation.IsShow,
+		ToRead:         int32(conversation.Unread),
+		ReadSeq:        conversation.ReadSeq,
+		MentionSeq:     conversation.MentionSeq,
+		Mentioned:      conversation.Mentioned(),
+		PinTime:        conversation.PinTime,
+		MuteUntil:      conversation.MuteUntil,
+		Archived:       conversation.Archived,
+		ClearSeq:       conversation.ClearSeq,
+		LastMsgTime:    conversation.LastMsgTime,
+		Draft:          conversation.Draft,
+		DraftTime:      conversation.DraftTime,
+	}
+}
+
+// targetId 私聊会话中对方的用户，群聊会话中的群
+func targetId(conversation *immodels.UserConversation) string {
+	if conversation.ChatType != constants.SingleChatType {
+		return conversation.ConversationId
+	}
+	aid, bid, ok := wuid.SplitId(conversation.ConversationId)
+	if !ok {
+		return ""
+	}
+	if aid == conversation.UserId {
+		return bid
+	}
+	return aid
+}
@@ -0,0 +1,72 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/pkg/constants"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+type pagedUserConversationModel struct {
+	fakeUserConversationModel
+	conversations []*immodels.UserConversation
+}
+
+func (f *pagedUserConversationModel) ListPage(ctx context.Context, uid string, archived bool, after *immodels.ConversationCursor, limit int64) ([]*immodels.UserConversation, bool, error) {
+	if int64(len(f.conversations)) > limit {
+		return f.conversations[:limit], true, nil
+	}
+	return f.conversations, false, nil
+}
+
+func (f *fakeConversationModel) ListByConversationIds(ctx context.Context, ids []string) ([]*immodels.Conversation, error) {
+	var res []*immodels.Conversation
+	for _, id := range ids {
+		if conversation, ok := f.conversations[id]; ok {
+			res = append(res, conversation)
+		}
+	}
+	return res, nil
+}
+
+func TestListConversationsLogic_ListConversations(t *testing.T) {
+	svcCtx, _ := newTestServiceContext()
+	svcCtx.ConversationModel = &fakeConversationModel{conversations: map[string]*immodels.Conversation{
+		"1_2": {ConversationId: "1_2", ChatType: constants.SingleChatType, Seq: 3, Msg: &immodels.ChatLog{ConversationId: "1_2", Seq: 3}},
+		"g1":  {ConversationId: "g1", ChatType: constants.GroupChatType, Seq: 5, Msg: &immodels.ChatLog{ConversationId: "g1", Seq: 5}},
+	}}
+	svcCtx.UserConversationModel = &pagedUserConversationModel{conversations: []*immodels.UserConversation{
+		{ID: "2:g1", UserId: "2", ConversationId: "g1", ChatType: constants.GroupChatType, PinTime: 10, LastMsgTime: 100, Unread: 2},
+		{ID: "2:1_2", UserId: "2", ConversationId: "1_2", ChatType: constants.SingleChatType, LastMsgTime: 200, ClearSeq: 3},
+	}}
+
+	resp, err := NewListConversationsLogic(context.Background(), svcCtx).ListConversations(&im.ListConversationsReq{UserId: "2", Count: 1})
+	if err != nil {
+		t.Fatalf("ListConversations() err = %v", err)
+	}
+	if len(resp.List) != 1 || !resp.HasMore || resp.NextCursor == "" {
+		t.Fatalf("ListConversations() = %v, want one conversation with next cursor", resp)
+	}
+	if got := resp.List[0]; got.TargetId != "g1" || got.ToRead != 2 || got.Msg == nil {
+		t.Errorf("ListConversations() group = %v, want target g1, 2 unread and msg preview", got)
+	}
+
+	resp, err = NewListConversationsLogic(context.Background(), svcCtx).ListConversations(&im.ListConversationsReq{UserId: "2"})
+	if err != nil {
+		t.Fatalf("ListConversations() err = %v", err)
+	}
+	if len(resp.List) != 2 || resp.HasMore || resp.NextCursor != "" {
+		t.Fatalf("ListConversations() = %v, want the last page", resp)
+	}
+	if got := resp.List[1]; got.TargetId != "1" || got.Msg != nil {
+		t.Errorf("ListConversations() single = %v, want target 1 and no preview after clearing history", got)
+	}
+
+	_, err = NewListConversationsLogic(context.Background(), svcCtx).ListConversations(&im.ListConversationsReq{UserId: "2", Cursor: "!!!"})
+	if errors.Cause(err) != ErrConversationCursor {
+		t.Errorf("ListConversations() err = %v, want ErrConversationCursor", err)
+	}
+}
@@ -0,0 +1,40 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/pkg/xerr"
+	"github.com/pkg/errors"
+
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/im/rpc/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListScheduledMsgsLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewListScheduledMsgsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListScheduledMsgsLogic {
+	return &ListScheduledMsgsLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// ListScheduledMsgs 查询用户尚未发送成功的定时消息，发送失败的消息带有失败的原因
+func (l *ListScheduledMsgsLogic) ListScheduledMsgs(in *im.ListScheduledMsgsReq) (*im.ListScheduledMsgsResp, error) {
+	data, err := l.svcCtx.ScheduledMsgModel.ListByUserId(l.ctx, in.UserId)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "ScheduledMsgModel.ListByUserId err %v, req %v", err, in)
+	}
+
+	list := make([]*im.ScheduledMsg, 0, len(data))
+	for _, msg := range data {
+		list = append(list, toScheduledMsg(msg))
+	}
+	return &im.ListScheduledMsgsResp{List: list}, nil
+}
@@ -0,0 +1,47 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/pkg/xerr"
+	"github.com/pkg/errors"
+
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/im/rpc/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+var ErrMuteUntil = xerr.New(xerr.REQUEST_PARAM_ERROR, "免打扰的截止时间有误")
+
+type MuteConversationLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewMuteConversationLogic(ctx context.Context, svcCtx *svc.ServiceContext) *MuteConversationLogic {
+	return &MuteConversationLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// MuteConversation 设置会话免打扰
+//
+// 免打扰的会话照常统计未读数，但不计入未读总数，由客户端决定是否提醒。
+func (l *MuteConversationLogic) MuteConversation(in *im.MuteConversationReq) (*im.MuteConversationResp, error) {
+	if in.MuteUntil < immodels.MuteForever {
+		return nil, errors.WithStack(ErrMuteUntil)
+	}
+
+	ok, err := l.svcCtx.UserConversationModel.Mute(l.ctx, in.UserId, in.ConversationId, in.MuteUntil)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "UserConversationModel.Mute err %v, req %v", err, in)
+	}
+	if !ok {
+		return nil, errors.WithStack(ErrConversationNotFound)
+	}
+	return &im.MuteConversationResp{}, nil
+}
@@ -0,0 +1,49 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/pkg/xerr"
+	"github.com/pkg/errors"
+	"time"
+
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/im/rpc/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// ErrConversationNotFound 用户没有该会话
+var ErrConversationNotFound = xerr.New(xerr.REQUEST_PARAM_ERROR, "会话不存在")
+
+type PinConversationLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewPinConversationLogic(ctx context.Context, svcCtx *svc.ServiceContext) *PinConversationLogic {
+	return &PinConversationLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// PinConversation 置顶或取消置顶会话
+//
+// 重复置顶会刷新置顶时间，使会话排到置顶会话的最前面。
+func (l *PinConversationLogic) PinConversation(in *im.PinConversationReq) (*im.PinConversationResp, error) {
+	var pinTime int64
+	if !in.Unpin {
+		pinTime = time.Now().UnixMilli()
+	}
+
+	ok, err := l.svcCtx.UserConversationModel.Pin(l.ctx, in.UserId, in.ConversationId, pinTime)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "UserConversationModel.Pin err %v, req %v", err, in)
+	}
+	if !ok {
+		return nil, errors.WithStack(ErrConversationNotFound)
+	}
+	return &im.PinConversationResp{}, nil
+}
@@ -0,0 +1,150 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/task/mq/mq"
+	"easy-chat/pkg/constants"
+	"easy-chat/pkg/xerr"
+	"github.com/pkg/errors"
+	"time"
+
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/im/rpc/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+var (
+	ErrPinMsgNotFound   = xerr.New(xerr.REQUEST_PARAM_ERROR, "消息不存在")
+	ErrPinMsgRecalled   = xerr.NewMsg("消息已被撤回")
+	ErrPinMsgPermission = xerr.New(xerr.NO_PERMISSION_ERROR, "没有权限置顶该消息")
+	ErrPinMsgLimit      = xerr.NewMsg("置顶的消息数已达上限")
+)
+
+type PinMsgLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewPinMsgLogic(ctx context.Context, svcCtx *svc.ServiceContext) *PinMsgLogic {
+	return &PinMsgLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// PinMsg 置顶或取消置顶会话中的消息
+//
+// 私聊的双方都可以置顶，群聊中只有群主与管理员可以置顶。置顶对会话成员都可见，
+// 发生变化时向会话成员推送置顶事件；重复置顶或取消不会推送。
+func (l *PinMsgLogic) PinMsg(in *im.PinMsgReq) (*im.PinMsgResp, error) {
+	chatLog, err := l.svcCtx.ChatLogModel.FindOne(l.ctx, in.MsgId)
+	switch err {
+	case nil:
+	case immodels.ErrNotFound, immodels.ErrInvalidObjectId:
+		return nil, errors.WithStack(ErrPinMsgNotFound)
+	default:
+		return nil, errors.Wrapf(xerr.NewDBErr(), "find chatlog by msgId err %v, req %v", err, in)
+	}
+	if err := l.checkPin(in.UserId, chatLog); err != nil {
+		return nil, err
+	}
+
+	var changed bool
+	if in.Unpin {
+		changed, err = l.svcCtx.ConversationModel.Unpin(l.ctx, chatLog.ConversationId, in.MsgId)
+		if err != nil {
+			return nil, errors.Wrapf(xerr.NewDBErr(), "ConversationModel.Unpin err %v, req %v", err, in)
+		}
+	} else {
+		if chatLog.Status == constants.RecallMsgStatus {
+			return nil, errors.WithStack(ErrPinMsgRecalled)
+		}
+		if changed, err = l.pin(in.UserId, chatLog); err != nil {
+			return nil, err
+		}
+	}
+	if !changed {
+		return &im.PinMsgResp{}, nil
+	}
+
+	recvId := chatLog.RecvId
+	if chatLog.ChatType == constants.SingleChatType && in.UserId != chatLog.SendId {
+		recvId = chatLog.SendId
+	}
+	err = l.svcCtx.MsgEventTransferClient.Push(&mq.MsgEventTransfer{
+		ContentType:    constants.ContentPin,
+		ConversationId: chatLog.ConversationId,
+		ChatType:       chatLog.ChatType,
+		SendId:         in.UserId,
+		RecvId:         recvId,
+		SendTime:       time.Now().UnixMilli(),
+		MsgId:          in.MsgId,
+		Seq:            chatLog.Seq,
+		Removed:        in.Unpin,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewInternalErr(), "push pin event err %v, req %v", err, in)
+	}
+	return &im.PinMsgResp{}, nil
+}
+
+// pin 置顶消息，消息已经置顶时返回 false
+func (l *PinMsgLogic) pin(uid string, chatLog *immodels.ChatLog) (bool, error) {
+	maxPins := immodels.DefaultMaxPins
+	if l.svcCtx.Config.MaxPins > 0 {
+		maxPins = l.svcCtx.Config.MaxPins
+	}
+
+	msgId := chatLog.ID.Hex()
+	ok, err := l.svcCtx.ConversationModel.Pin(l.ctx, chatLog.ConversationId, &immodels.Pin{
+		MsgId:    msgId,
+		Seq:      chatLog.Seq,
+		PinnedBy: uid,
+		PinTime:  time.Now().UnixMilli(),
+	}, maxPins)
+	if err != nil {
+		return false, errors.Wrapf(xerr.NewDBErr(), "ConversationModel.Pin err %v, msgId %v", err, msgId)
+	}
+	if ok {
+		return true, nil
+	}
+
+	// 没有追加时区分已经置顶与达到上限
+	conversation, err := l.svcCtx.ConversationModel.FindByConversationId(l.ctx, chatLog.ConversationId)
+	if err != nil {
+		return false, errors.Wrapf(xerr.NewDBErr(), "ConversationModel.FindByConversationId err %v, msgId %v", err, msgId)
+	}
+	for _, pin := range conversation.Pins {
+		if pin.MsgId == msgId {
+			return false, nil
+		}
+	}
+	return false, errors.WithStack(ErrPinMsgLimit)
+}
+
+// checkPin 校验用户是否可以置顶该消息
+func (l *PinMsgLogic) checkPin(uid string, chatLog *immodels.ChatLog) error {
+	if chatLog.ChatType != constants.GroupChatType {
+		if uid != chatLog.SendId && uid != chatLog.RecvId {
+			return errors.WithStack(ErrPinMsgPermission)
+		}
+		return nil
+	}
+
+	role, ok, err := l.svcCtx.Auth.GroupRole(l.ctx, chatLog.RecvId, uid)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.WithStack(ErrPinMsgPermission)
+	}
+	switch role {
+	case constants.CreatorGroupRoleLevel, constants.ManagerGroupRoleLevel:
+		return nil
+	}
+	return errors.WithStack(ErrPinMsgPermission)
+}
@@ -0,0 +1,153 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/authz"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/pkg/constants"
+	"testing"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// pinConversationModel 在内存中置顶会话的消息
+type pinConversationModel struct {
+	fakeConversationModel
+}
+
+func newPinConversationModel() *pinConversationModel {
+	return &pinConversationModel{fakeConversationModel{conversations: map[string]*immodels.Conversation{
+		"u1_u2": {ConversationId: "u1_u2", ChatType: constants.SingleChatType},
+		"g1":    {ConversationId: "g1", ChatType: constants.GroupChatType},
+	}}}
+}
+
+func (f *pinConversationModel) Pin(ctx context.Context, conversationId string, pin *immodels.Pin, max int) (bool, error) {
+	conversation, ok := f.conversations[conversationId]
+	if !ok || f.pinned(conversationId, pin.MsgId) || (max > 0 && len(conversation.Pins) >= max) {
+		return false, nil
+	}
+	conversation.Pins = append(conversation.Pins, pin)
+	return true, nil
+}
+
+func (f *pinConversationModel) Unpin(ctx context.Context, conversationId, msgId string) (bool, error) {
+	conversation, ok := f.conversations[conversationId]
+	if !ok {
+		return false, nil
+	}
+	for i, pin := range conversation.Pins {
+		if pin.MsgId == msgId {
+			conversation.Pins = append(conversation.Pins[:i], conversation.Pins[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *pinConversationModel) RecallMsg(ctx context.Context, chatLog *immodels.ChatLog) error {
+	return nil
+}
+
+// pinned 消息是否在会话中置顶
+func (f *pinConversationModel) pinned(conversationId, msgId string) bool {
+	conversation, ok := f.conversations[conversationId]
+	if !ok {
+		return false
+	}
+	for _, pin := range conversation.Pins {
+		if pin.MsgId == msgId {
+			return true
+		}
+	}
+	return false
+}
+
+func TestPinMsgLogic_PinMsg(t *testing.T) {
+	single := func() *immodels.ChatLog {
+		return &immodels.ChatLog{ConversationId: "u1_u2", ChatType: constants.SingleChatType, SendId: "u1", RecvId: "u2", Seq: 1}
+	}
+	group := func(status constants.MsgStatus) *immodels.ChatLog {
+		return &immodels.ChatLog{ConversationId: "g1", ChatType: constants.GroupChatType, SendId: "u4", RecvId: "g1", Seq: 1, Status: status}
+	}
+	tests := []struct {
+		name     string
+		uid      string
+		chatLog  *immodels.ChatLog
+		pinned   bool // 消息已经置顶
+		others   int  // 会话中其他置顶的消息数
+		maxPins  int
+		unpin    bool
+		wantErr  error
+		wantRecv string // 推送的接收者，为空时不推送
+	}{
+		{name: "single sender", uid: "u1", chatLog: single(), wantRecv: "u2"},
+		{name: "single receiver", uid: "u2", chatLog: single(), wantRecv: "u1"},
+		{name: "single outsider", uid: "u3", chatLog: single(), wantErr: ErrPinMsgPermission},
+		{name: "group creator", uid: "u1", chatLog: group(constants.NormalMsgStatus), wantRecv: "g1"},
+		{name: "group manager", uid: "u3", chatLog: group(constants.NormalMsgStatus), wantRecv: "g1"},
+		{name: "group member", uid: "u4", chatLog: group(constants.NormalMsgStatus), wantErr: ErrPinMsgPermission},
+		{name: "not member", uid: "u2", chatLog: group(constants.NormalMsgStatus), wantErr: ErrPinMsgPermission},
+		{name: "recalled", uid: "u1", chatLog: group(constants.RecallMsgStatus), wantErr: ErrPinMsgRecalled},
+		{name: "already pinned", uid: "u1", chatLog: group(constants.NormalMsgStatus), pinned: true},
+		{name: "limit", uid: "u1", chatLog: group(constants.NormalMsgStatus), others: 2, maxPins: 2, wantErr: ErrPinMsgLimit},
+		{name: "unpin", uid: "u3", chatLog: group(constants.NormalMsgStatus), pinned: true, unpin: true, wantRecv: "g1"},
+		{name: "unpin recalled", uid: "u1", chatLog: group(constants.RecallMsgStatus), pinned: true, unpin: true, wantRecv: "g1"},
+		{name: "unpin not pinned", uid: "u1", chatLog: group(constants.NormalMsgStatus), unpin: true},
+		{name: "unpin by member", uid: "u4", chatLog: group(constants.NormalMsgStatus), pinned: true, unpin: true, wantErr: ErrPinMsgPermission},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svcCtx, _ := newTestServiceContext()
+			tt.chatLog.ID = primitive.NewObjectID()
+			msgId := tt.chatLog.ID.Hex()
+			conversations := newPinConversationModel()
+			conversation := conversations.conversations[tt.chatLog.ConversationId]
+			for i := 0; i < tt.others; i++ {
+				conversation.Pins = append(conversation.Pins, &immodels.Pin{MsgId: primitive.NewObjectID().Hex()})
+			}
+			if tt.pinned {
+				conversation.Pins = append(conversation.Pins, &immodels.Pin{MsgId: msgId})
+			}
+			events := &fakeEventTransferClient{}
+			svcCtx.Config.MaxPins = tt.maxPins
+			svcCtx.ChatLogModel = &fakeChatLogModel{chatLogs: map[string]*immodels.ChatLog{msgId: tt.chatLog}}
+			svcCtx.ConversationModel = conversations
+			svcCtx.MsgEventTransferClient = events
+			svcCtx.Auth = authz.NewAuthorizer(&fakeSocial{
+				groups: map[string][]string{"g1": {"u1", "u3", "u4"}},
+				roles:  map[string]constants.GroupRoleLevel{"u1": constants.CreatorGroupRoleLevel, "u3": constants.ManagerGroupRoleLevel},
+			})
+
+			_, err := NewPinMsgLogic(context.Background(), svcCtx).PinMsg(&im.PinMsgReq{UserId: tt.uid, MsgId: msgId, Unpin: tt.unpin})
+			if errors.Cause(err) != tt.wantErr {
+				t.Fatalf("PinMsg() err = %v, want %v", err, tt.wantErr)
+			}
+
+			wantPinned := tt.pinned
+			if err == nil {
+				wantPinned = !tt.unpin
+			}
+			if got := conversations.pinned(tt.chatLog.ConversationId, msgId); got != wantPinned {
+				t.Errorf("pinned = %v, want %v", got, wantPinned)
+			}
+
+			// 置顶没有变化时不推送
+			if tt.wantRecv == "" {
+				if len(events.pushed) != 0 {
+					t.Errorf("PinMsg() pushed %+v, want none", events.pushed)
+				}
+				return
+			}
+			if len(events.pushed) != 1 {
+				t.Fatalf("PinMsg() pushed %d events, want 1", len(events.pushed))
+			}
+			e := events.pushed[0]
+			if e.ContentType != constants.ContentPin || e.SendId != tt.uid || e.RecvId != tt.wantRecv || e.Removed != tt.unpin {
+				t.Errorf("PinMsg() pushed %+v, want pin from %v to %v removed %v", e, tt.uid, tt.wantRecv, tt.unpin)
+			}
+		})
+	}
+}
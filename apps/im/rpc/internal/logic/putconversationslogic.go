@@ -5,7 +5,6 @@ import (
 	"easy-chat/apps/im/immodels"
 	"easy-chat/apps/im/rpc/im"
 	"easy-chat/apps/im/rpc/internal/svc"
-	"easy-chat/pkg/constants"
 	"easy-chat/pkg/xerr"
 	"github.com/pkg/errors"
 
@@ -27,33 +26,23 @@ func NewPutConversationsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *
 }
 
 // 更新会话
-
+//
+// 逐个会话原子地更新，只更新用户已有的会话，并发的更新不会互相覆盖。
 func (l *PutConversationsLogic) PutConversations(in *im.PutConversationsReq) (*im.PutConversationsResp, error) {
-	//根据用户查询用户会话列表
-	data, err := l.svcCtx.ConversationsModel.FindByUserId(l.ctx, in.UserId)
-	if err != nil {
-		return nil, errors.Wrapf(xerr.NewDBErr(), "ConversationsModel.FindByUserId err %v,req %v", err, in.UserId)
-	}
-	if data.ConversationList == nil {
-		data.ConversationList = make(map[string]*immodels.Conversation)
-	}
-	for s, conversation := range in.ConversationList {
-		var oldTotal int
-		if data.ConversationList[s] != nil {
-			oldTotal = data.ConversationList[s].Total
-		}
-		data.ConversationList[s] = &immodels.Conversation{
-			ConversationId: conversation.ConversationId,
-			ChatType:       constants.ChatType(conversation.ChatType),
+	for conversationId, conversation := range in.ConversationList {
+		//已读水位只增不减
+		ok, err := l.svcCtx.UserConversationModel.Put(l.ctx, &immodels.UserConversation{
+			UserId:         in.UserId,
+			ConversationId: conversationId,
 			IsShow:         conversation.IsShow,
-			Total:          int(conversation.Read) + oldTotal,
-			Seq:            conversation.Seq,
+			ReadSeq:        conversation.ReadSeq,
+		}, int(conversation.Read))
+		if err != nil {
+			return nil, errors.Wrapf(xerr.NewDBErr(), "UserConversationModel.Put err %v,req %v", err, conversation)
+		}
+		if !ok {
+			l.Infof("put conversation not found, userId %v, conversationId %v", in.UserId, conversationId)
 		}
-	}
-	//更新
-	_, err = l.svcCtx.ConversationsModel.Update(l.ctx, data)
-	if err != nil {
-		return nil, errors.Wrapf(xerr.NewDBErr(), "ConversationsModel.Update err %v,req %v", err, data)
 	}
 	return &im.PutConversationsResp{}, nil
 }
@@ -0,0 +1,120 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/task/mq/mq"
+	"easy-chat/pkg/constants"
+	"easy-chat/pkg/xerr"
+	"github.com/pkg/errors"
+	"strings"
+	"time"
+
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/im/rpc/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// MaxReactionEmojiLen 表情的最大字节数，组合表情由多个字符组成
+var MaxReactionEmojiLen = 32
+
+var (
+	ErrReactMsgNotFound   = xerr.New(xerr.REQUEST_PARAM_ERROR, "消息不存在")
+	ErrReactMsgEmoji      = xerr.New(xerr.REQUEST_PARAM_ERROR, "表情有误")
+	ErrReactMsgRecalled   = xerr.NewMsg("消息已被撤回")
+	ErrReactMsgPermission = xerr.New(xerr.NO_PERMISSION_ERROR, "不是会话成员，不能回应该消息")
+)
+
+type ReactMsgLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewReactMsgLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ReactMsgLogic {
+	return &ReactMsgLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// ReactMsg 添加或取消消息的表情回应
+//
+// 会话成员可以回应任意未撤回的消息，回应发生变化时向会话成员推送表情的最新回应数；
+// 重复添加或取消不会推送。
+func (l *ReactMsgLogic) ReactMsg(in *im.ReactMsgReq) (*im.ReactMsgResp, error) {
+	emoji := strings.TrimSpace(in.Emoji)
+	if emoji == "" || len(emoji) > MaxReactionEmojiLen {
+		return nil, errors.WithStack(ErrReactMsgEmoji)
+	}
+
+	chatLog, err := l.svcCtx.ChatLogModel.FindOne(l.ctx, in.MsgId)
+	switch err {
+	case nil:
+	case immodels.ErrNotFound, immodels.ErrInvalidObjectId:
+		return nil, errors.WithStack(ErrReactMsgNotFound)
+	default:
+		return nil, errors.Wrapf(xerr.NewDBErr(), "find chatlog by msgId err %v, req %v", err, in)
+	}
+	if chatLog.Status == constants.RecallMsgStatus {
+		return nil, errors.WithStack(ErrReactMsgRecalled)
+	}
+	ok, err := isConversationMember(l.ctx, l.svcCtx, in.UserId, chatLog)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.WithStack(ErrReactMsgPermission)
+	}
+
+	var changed bool
+	if in.Remove {
+		changed, err = l.svcCtx.ReactionModel.Remove(l.ctx, in.MsgId, in.UserId, emoji)
+	} else {
+		changed, err = l.svcCtx.ReactionModel.Add(l.ctx, &immodels.Reaction{
+			MsgId:          in.MsgId,
+			ConversationId: chatLog.ConversationId,
+			UserId:         in.UserId,
+			Emoji:          emoji,
+		})
+	}
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "update reaction err %v, req %v", err, in)
+	}
+	count, err := l.svcCtx.ReactionModel.CountByEmoji(l.ctx, in.MsgId, emoji)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "ReactionModel.CountByEmoji err %v, req %v", err, in)
+	}
+	if !changed {
+		return &im.ReactMsgResp{Count: count}, nil
+	}
+
+	recvId := chatLog.RecvId
+	if chatLog.ChatType == constants.SingleChatType && in.UserId != chatLog.SendId {
+		recvId = chatLog.SendId
+	}
+	err = l.svcCtx.MsgEventTransferClient.Push(&mq.MsgEventTransfer{
+		ContentType:    constants.ContentReaction,
+		ConversationId: chatLog.ConversationId,
+		ChatType:       chatLog.ChatType,
+		SendId:         in.UserId,
+		RecvId:         recvId,
+		SendTime:       time.Now().UnixMilli(),
+		MsgId:          in.MsgId,
+		Seq:            chatLog.Seq,
+		Emoji:          emoji,
+		Removed:        in.Remove,
+		Count:          count,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewInternalErr(), "push reaction event err %v, req %v", err, in)
+	}
+	return &im.ReactMsgResp{Count: count}, nil
+}
+
+// isConversationMember 用户是否为消息所在会话的成员：私聊的双方或群成员
+func isConversationMember(ctx context.Context, svcCtx *svc.ServiceContext, uid string, chatLog *immodels.ChatLog) (bool, error) {
+	return svcCtx.Auth.IsChatLogMember(ctx, uid, chatLog.ChatType, chatLog.SendId, chatLog.RecvId)
+}
@@ -0,0 +1,103 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/pkg/constants"
+	"testing"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memReactionModel 以消息、用户与表情为键保存回应，与唯一索引一致
+type memReactionModel struct {
+	fakeReactionModel
+	reactions map[[3]string]bool
+}
+
+func (f *memReactionModel) Add(ctx context.Context, data *immodels.Reaction) (bool, error) {
+	key := [3]string{data.MsgId, data.UserId, data.Emoji}
+	if f.reactions[key] {
+		return false, nil
+	}
+	f.reactions[key] = true
+	return true, nil
+}
+
+func (f *memReactionModel) Remove(ctx context.Context, msgId, uid, emoji string) (bool, error) {
+	key := [3]string{msgId, uid, emoji}
+	if !f.reactions[key] {
+		return false, nil
+	}
+	delete(f.reactions, key)
+	return true, nil
+}
+
+func (f *memReactionModel) CountByEmoji(ctx context.Context, msgId, emoji string) (int64, error) {
+	var n int64
+	for key := range f.reactions {
+		if key[0] == msgId && key[2] == emoji {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func TestReactMsgLogic_ReactMsg(t *testing.T) {
+	svcCtx, chatLogs := newTestServiceContext()
+	events := &fakeEventTransferClient{}
+	svcCtx.ReactionModel = &memReactionModel{reactions: map[[3]string]bool{}}
+	svcCtx.MsgEventTransferClient = events
+
+	msgIds := map[string]string{}
+	for id, chatLog := range chatLogs {
+		msgIds[chatLog.ConversationId] = id
+	}
+	recalled := &immodels.ChatLog{ID: primitive.NewObjectID(), ConversationId: "g1", ChatType: constants.GroupChatType,
+		SendId: "u1", RecvId: "g1", Seq: 2, Status: constants.RecallMsgStatus}
+	chatLogs[recalled.ID.Hex()] = recalled
+
+	// 按顺序执行，后面的步骤依赖前面添加的回应
+	tests := []struct {
+		name       string
+		req        *im.ReactMsgReq
+		wantCount  int64
+		wantPushed bool
+		wantErr    error
+	}{
+		{"add", &im.ReactMsgReq{UserId: "u1", MsgId: msgIds["g1"], Emoji: "👍"}, 1, true, nil},
+		{"add again", &im.ReactMsgReq{UserId: "u1", MsgId: msgIds["g1"], Emoji: "👍"}, 1, false, nil},
+		{"add trimmed", &im.ReactMsgReq{UserId: "u1", MsgId: msgIds["g1"], Emoji: " 👍 "}, 1, false, nil},
+		{"other member", &im.ReactMsgReq{UserId: "u3", MsgId: msgIds["g1"], Emoji: "👍"}, 2, true, nil},
+		{"other emoji", &im.ReactMsgReq{UserId: "u3", MsgId: msgIds["g1"], Emoji: "❤️"}, 1, true, nil},
+		{"remove", &im.ReactMsgReq{UserId: "u1", MsgId: msgIds["g1"], Emoji: "👍", Remove: true}, 1, true, nil},
+		{"remove again", &im.ReactMsgReq{UserId: "u1", MsgId: msgIds["g1"], Emoji: "👍", Remove: true}, 1, false, nil},
+		{"outsider", &im.ReactMsgReq{UserId: "u2", MsgId: msgIds["g1"], Emoji: "👍"}, 0, false, ErrReactMsgPermission},
+		{"recalled", &im.ReactMsgReq{UserId: "u1", MsgId: recalled.ID.Hex(), Emoji: "👍"}, 0, false, ErrReactMsgRecalled},
+		{"empty emoji", &im.ReactMsgReq{UserId: "u1", MsgId: msgIds["g1"], Emoji: " "}, 0, false, ErrReactMsgEmoji},
+		{"not found", &im.ReactMsgReq{UserId: "u1", MsgId: primitive.NewObjectID().Hex(), Emoji: "👍"}, 0, false, ErrReactMsgNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pushed := len(events.pushed)
+			resp, err := NewReactMsgLogic(context.Background(), svcCtx).ReactMsg(tt.req)
+			if errors.Cause(err) != tt.wantErr {
+				t.Fatalf("ReactMsg() err = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && resp.Count != tt.wantCount {
+				t.Errorf("ReactMsg() count = %d, want %d", resp.Count, tt.wantCount)
+			}
+			if got := len(events.pushed) > pushed; got != tt.wantPushed {
+				t.Fatalf("ReactMsg() pushed = %v, want %v", got, tt.wantPushed)
+			}
+			if tt.wantPushed {
+				e := events.pushed[len(events.pushed)-1]
+				if e.ContentType != constants.ContentReaction || e.Count != tt.wantCount || e.Removed != tt.req.Remove {
+					t.Errorf("ReactMsg() pushed %+v, want count %d removed %v", e, tt.wantCount, tt.req.Remove)
+				}
+			}
+		})
+	}
+}
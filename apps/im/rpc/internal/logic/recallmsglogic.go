@@ -0,0 +1,144 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/task/mq/mq"
+	"easy-chat/pkg/constants"
+	"easy-chat/pkg/xerr"
+	"github.com/pkg/errors"
+	"time"
+
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/im/rpc/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// DefaultMsgRecallTime 未配置时消息可撤回的时间
+var DefaultMsgRecallTime = 2 * time.Minute
+
+var (
+	ErrRecallMsgNotFound   = xerr.New(xerr.REQUEST_PARAM_ERROR, "消息不存在")
+	ErrRecallMsgTimeout    = xerr.NewMsg("消息已超过可撤回的时间")
+	ErrRecallMsgPermission = xerr.New(xerr.NO_PERMISSION_ERROR, "没有权限撤回该消息")
+)
+
+type RecallMsgLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewRecallMsgLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RecallMsgLogic {
+	return &RecallMsgLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// RecallMsg 撤回消息
+//
+// 发送者可以撤回自己的消息，群主与管理员可以撤回群内任意成员的消息，都需要在可撤回的时间内。
+// 撤回后清除消息内容并取消置顶，向会话成员与发起者的其他设备推送撤回与取消置顶事件，
+// 成员的未读数由消息队列重新统计；重复撤回直接返回成功。
+func (l *RecallMsgLogic) RecallMsg(in *im.RecallMsgReq) (*im.RecallMsgResp, error) {
+	chatLog, err := l.svcCtx.ChatLogModel.FindOne(l.ctx, in.MsgId)
+	switch err {
+	case nil:
+	case immodels.ErrNotFound, immodels.ErrInvalidObjectId:
+		return nil, errors.WithStack(ErrRecallMsgNotFound)
+	default:
+		return nil, errors.Wrapf(xerr.NewDBErr(), "find chatlog by msgId err %v, req %v", err, in)
+	}
+
+	if chatLog.Status == constants.RecallMsgStatus {
+		return &im.RecallMsgResp{}, nil
+	}
+	if err := l.checkRecall(in.UserId, chatLog); err != nil {
+		return nil, err
+	}
+
+	ok, err := l.svcCtx.ChatLogModel.Recall(l.ctx, chatLog.ID)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "ChatLogModel.Recall err %v, req %v", err, in)
+	}
+	if !ok {
+		return &im.RecallMsgResp{}, nil
+	}
+	// 会话的最后一条消息同步撤回
+	if err := l.svcCtx.ConversationModel.RecallMsg(l.ctx, chatLog); err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "ConversationModel.RecallMsg err %v, req %v", err, in)
+	}
+	// 引用了该消息的回复不再展示原内容
+	chatLog.Status = constants.RecallMsgStatus
+	if _, err := l.svcCtx.ChatLogModel.UpdateQuotes(l.ctx, immodels.NewQuote(chatLog)); err != nil {
+		l.Errorf("ChatLogModel.UpdateQuotes err %v, req %v", err, in)
+	}
+	// 撤回的消息不再置顶
+	unpinned, err := l.svcCtx.ConversationModel.Unpin(l.ctx, chatLog.ConversationId, in.MsgId)
+	if err != nil {
+		l.Errorf("ConversationModel.Unpin err %v, req %v", err, in)
+	}
+
+	if err := l.push(in.UserId, chatLog, constants.ContentRecall); err != nil {
+		return nil, errors.Wrapf(xerr.NewInternalErr(), "push recall event err %v, req %v", err, in)
+	}
+	if unpinned {
+		if err := l.push(in.UserId, chatLog, constants.ContentPin); err != nil {
+			return nil, errors.Wrapf(xerr.NewInternalErr(), "push unpin event err %v, req %v", err, in)
+		}
+	}
+	return &im.RecallMsgResp{}, nil
+}
+
+// push 推送撤回或取消置顶事件
+func (l *RecallMsgLogic) push(uid string, chatLog *immodels.ChatLog, contentType constants.ContentType) error {
+	recvId := chatLog.RecvId
+	if chatLog.ChatType == constants.SingleChatType && uid != chatLog.SendId {
+		recvId = chatLog.SendId
+	}
+	return l.svcCtx.MsgEventTransferClient.Push(&mq.MsgEventTransfer{
+		ContentType:    contentType,
+		ConversationId: chatLog.ConversationId,
+		ChatType:       chatLog.ChatType,
+		SendId:         uid,
+		RecvId:         recvId,
+		SendTime:       time.Now().UnixMilli(),
+		MsgId:          chatLog.ID.Hex(),
+		Seq:            chatLog.Seq,
+		Removed:        contentType == constants.ContentPin, // 撤回时只会取消置顶
+	})
+}
+
+// checkRecall 校验用户是否可以撤回该消息
+func (l *RecallMsgLogic) checkRecall(uid string, chatLog *immodels.ChatLog) error {
+	recallTime := DefaultMsgRecallTime
+	if l.svcCtx.Config.MsgRecallTime > 0 {
+		recallTime = time.Duration(l.svcCtx.Config.MsgRecallTime) * time.Minute
+	}
+	if time.Since(time.UnixMilli(chatLog.SendTime)) > recallTime {
+		return errors.WithStack(ErrRecallMsgTimeout)
+	}
+
+	if chatLog.SendId == uid {
+		return nil
+	}
+	if chatLog.ChatType != constants.GroupChatType {
+		return errors.WithStack(ErrRecallMsgPermission)
+	}
+
+	role, ok, err := l.svcCtx.Auth.GroupRole(l.ctx, chatLog.RecvId, uid)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.WithStack(ErrRecallMsgPermission)
+	}
+	switch role {
+	case constants.CreatorGroupRoleLevel, constants.ManagerGroupRoleLevel:
+		return nil
+	}
+	return errors.WithStack(ErrRecallMsgPermission)
+}
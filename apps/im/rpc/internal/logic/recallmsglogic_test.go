@@ -0,0 +1,155 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/authz"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/pkg/constants"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type recallChatLogModel struct {
+	fakeChatLogModel
+}
+
+func (f *recallChatLogModel) Recall(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	chatLog, ok := f.chatLogs[id.Hex()]
+	if !ok || chatLog.Status == constants.RecallMsgStatus {
+		return false, nil
+	}
+	chatLog.Status = constants.RecallMsgStatus
+	return true, nil
+}
+
+func (f *recallChatLogModel) UpdateQuotes(ctx context.Context, quote *immodels.Quote) (int64, error) {
+	return 0, nil
+}
+
+func TestRecallMsgLogic_RecallMsg(t *testing.T) {
+	now := time.Now().UnixMilli()
+	tests := []struct {
+		name     string
+		uid      string
+		chatLog  *immodels.ChatLog
+		pinned   bool
+		wantErr  error
+		wantPush []constants.ContentType
+		wantRecv string
+	}{
+		{
+			name:     "sender",
+			uid:      "u1",
+			chatLog:  &immodels.ChatLog{ConversationId: "g1", ChatType: constants.GroupChatType, SendId: "u1", RecvId: "g1", SendTime: now},
+			wantPush: []constants.ContentType{constants.ContentRecall},
+			wantRecv: "g1",
+		},
+		{
+			name:     "sender pinned",
+			uid:      "u1",
+			chatLog:  &immodels.ChatLog{ConversationId: "g1", ChatType: constants.GroupChatType, SendId: "u1", RecvId: "g1", SendTime: now},
+			pinned:   true,
+			wantPush: []constants.ContentType{constants.ContentRecall, constants.ContentPin},
+			wantRecv: "g1",
+		},
+		{
+			name:     "single sender",
+			uid:      "u1",
+			chatLog:  &immodels.ChatLog{ConversationId: "u1_u2", ChatType: constants.SingleChatType, SendId: "u1", RecvId: "u2", SendTime: now},
+			wantPush: []constants.ContentType{constants.ContentRecall},
+			wantRecv: "u2",
+		},
+		{
+			name:     "group admin",
+			uid:      "u3",
+			chatLog:  &immodels.ChatLog{ConversationId: "g1", ChatType: constants.GroupChatType, SendId: "u4", RecvId: "g1", SendTime: now},
+			wantPush: []constants.ContentType{constants.ContentRecall},
+			wantRecv: "g1",
+		},
+		{
+			name:    "group member",
+			uid:     "u4",
+			chatLog: &immodels.ChatLog{ConversationId: "g1", ChatType: constants.GroupChatType, SendId: "u1", RecvId: "g1", SendTime: now},
+			wantErr: ErrRecallMsgPermission,
+		},
+		{
+			name:    "not member",
+			uid:     "u2",
+			chatLog: &immodels.ChatLog{ConversationId: "g1", ChatType: constants.GroupChatType, SendId: "u1", RecvId: "g1", SendTime: now},
+			wantErr: ErrRecallMsgPermission,
+		},
+		{
+			name:    "single receiver",
+			uid:     "u2",
+			chatLog: &immodels.ChatLog{ConversationId: "u1_u2", ChatType: constants.SingleChatType, SendId: "u1", RecvId: "u2", SendTime: now},
+			wantErr: ErrRecallMsgPermission,
+		},
+		{
+			name:    "timeout",
+			uid:     "u1",
+			chatLog: &immodels.ChatLog{ConversationId: "g1", ChatType: constants.GroupChatType, SendId: "u1", RecvId: "g1", SendTime: now - time.Hour.Milliseconds()},
+			wantErr: ErrRecallMsgTimeout,
+		},
+		{
+			name:    "admin timeout",
+			uid:     "u3",
+			chatLog: &immodels.ChatLog{ConversationId: "g1", ChatType: constants.GroupChatType, SendId: "u4", RecvId: "g1", SendTime: now - time.Hour.Milliseconds()},
+			wantErr: ErrRecallMsgTimeout,
+		},
+		{
+			name: "recalled",
+			uid:  "u1",
+			chatLog: &immodels.ChatLog{ConversationId: "g1", ChatType: constants.GroupChatType, SendId: "u1", RecvId: "g1", SendTime: now,
+				Status: constants.RecallMsgStatus},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svcCtx, _ := newTestServiceContext()
+			tt.chatLog.ID = primitive.NewObjectID()
+			msgId := tt.chatLog.ID.Hex()
+			conversations := newPinConversationModel()
+			if tt.pinned {
+				conversations.conversations[tt.chatLog.ConversationId].Pins = []*immodels.Pin{{MsgId: msgId}}
+			}
+			events := &fakeEventTransferClient{}
+			svcCtx.ChatLogModel = &recallChatLogModel{fakeChatLogModel{chatLogs: map[string]*immodels.ChatLog{msgId: tt.chatLog}}}
+			svcCtx.ConversationModel = conversations
+			svcCtx.MsgEventTransferClient = events
+			svcCtx.Auth = authz.NewAuthorizer(&fakeSocial{
+				groups: map[string][]string{"g1": {"u1", "u3", "u4"}},
+				roles:  map[string]constants.GroupRoleLevel{"u1": constants.CreatorGroupRoleLevel, "u3": constants.ManagerGroupRoleLevel},
+			})
+
+			_, err := NewRecallMsgLogic(context.Background(), svcCtx).RecallMsg(&im.RecallMsgReq{UserId: tt.uid, MsgId: msgId})
+			if errors.Cause(err) != tt.wantErr {
+				t.Fatalf("RecallMsg() err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil && tt.chatLog.Status == constants.RecallMsgStatus {
+				t.Errorf("RecallMsg() recalled the message on error")
+			}
+
+			var got []constants.ContentType
+			for _, e := range events.pushed {
+				got = append(got, e.ContentType)
+				if e.SendId != tt.uid || e.RecvId != tt.wantRecv || e.MsgId != msgId {
+					t.Errorf("RecallMsg() pushed %+v, want from %v to %v", e, tt.uid, tt.wantRecv)
+				}
+				if e.Removed != (e.ContentType == constants.ContentPin) {
+					t.Errorf("RecallMsg() pushed %+v, want unpin only", e)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.wantPush) {
+				t.Errorf("RecallMsg() pushed %v, want %v", got, tt.wantPush)
+			}
+			if conversations.pinned(tt.chatLog.ConversationId, msgId) {
+				t.Errorf("RecallMsg() kept the pin")
+			}
+		})
+	}
+}
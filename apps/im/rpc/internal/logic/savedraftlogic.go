@@ -0,0 +1,75 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/task/mq/mq"
+	"easy-chat/pkg/constants"
+	"easy-chat/pkg/xerr"
+	"github.com/pkg/errors"
+	"time"
+	"unicode/utf8"
+
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/im/rpc/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// ErrDraftTooLong 草稿超过最大长度
+var ErrDraftTooLong = xerr.New(xerr.REQUEST_PARAM_ERROR, "草稿过长")
+
+type SaveDraftLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewSaveDraftLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SaveDraftLogic {
+	return &SaveDraftLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// SaveDraft 保存或清除会话的草稿，并同步到用户的其他设备
+//
+// 草稿以服务端的时间为准，后保存的覆盖先保存的；用户在会话中发送消息后，之前保存的草稿自动清除。
+func (l *SaveDraftLogic) SaveDraft(in *im.SaveDraftReq) (*im.SaveDraftResp, error) {
+	if utf8.RuneCountInString(in.Content) > immodels.MaxDraftLength {
+		return nil, errors.WithStack(ErrDraftTooLong)
+	}
+
+	conversation, err := l.svcCtx.UserConversationModel.FindOne(l.ctx, in.UserId, in.ConversationId)
+	switch err {
+	case nil:
+	case immodels.ErrNotFound:
+		return nil, errors.WithStack(ErrConversationNotFound)
+	default:
+		return nil, errors.Wrapf(xerr.NewDBErr(), "UserConversationModel.FindOne err %v, req %v", err, in)
+	}
+
+	draftTime := time.Now().UnixMilli()
+	ok, err := l.svcCtx.UserConversationModel.SaveDraft(l.ctx, in.UserId, in.ConversationId, in.Content, draftTime)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "UserConversationModel.SaveDraft err %v, req %v", err, in)
+	}
+	if !ok {
+		return nil, errors.WithStack(ErrConversationNotFound)
+	}
+
+	err = l.svcCtx.MsgEventTransferClient.Push(&mq.MsgEventTransfer{
+		ContentType:    constants.ContentDraft,
+		ConversationId: in.ConversationId,
+		ChatType:       conversation.ChatType,
+		SendId:         in.UserId,
+		RecvId:         in.UserId,
+		SendTime:       draftTime,
+		Content:        in.Content,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewInternalErr(), "push draft event err %v, req %v", err, in)
+	}
+	return &im.SaveDraftResp{DraftTime: draftTime}, nil
+}
@@ -0,0 +1,75 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/task/mq/mq"
+	"easy-chat/pkg/constants"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+type draftUserConversationModel struct {
+	fakeUserConversationModel
+	conversation *immodels.UserConversation
+}
+
+func (f *draftUserConversationModel) FindOne(ctx context.Context, uid, conversationId string) (*immodels.UserConversation, error) {
+	if f.conversation.UserId != uid || f.conversation.ConversationId != conversationId {
+		return nil, immodels.ErrNotFound
+	}
+	return f.conversation, nil
+}
+
+func (f *draftUserConversationModel) SaveDraft(ctx context.Context, uid, conversationId, draft string, draftTime int64) (bool, error) {
+	f.conversation.Draft, f.conversation.DraftTime = draft, draftTime
+	return true, nil
+}
+
+type fakeEventTransferClient struct {
+	pushed []*mq.MsgEventTransfer
+}
+
+func (f *fakeEventTransferClient) Push(msg *mq.MsgEventTransfer) error {
+	f.pushed = append(f.pushed, msg)
+	return nil
+}
+
+func TestSaveDraftLogic_SaveDraft(t *testing.T) {
+	svcCtx, _ := newTestServiceContext()
+	model := &draftUserConversationModel{conversation: &immodels.UserConversation{
+		UserId: "u1", ConversationId: "g1", ChatType: constants.GroupChatType,
+	}}
+	events := &fakeEventTransferClient{}
+	svcCtx.UserConversationModel = model
+	svcCtx.MsgEventTransferClient = events
+
+	resp, err := NewSaveDraftLogic(context.Background(), svcCtx).SaveDraft(&im.SaveDraftReq{UserId: "u1", ConversationId: "g1", Content: "hello"})
+	if err != nil {
+		t.Fatalf("SaveDraft() err = %v", err)
+	}
+	if model.conversation.Draft != "hello" || model.conversation.DraftTime != resp.DraftTime {
+		t.Errorf("SaveDraft() saved %q at %v, want hello at %v", model.conversation.Draft, model.conversation.DraftTime, resp.DraftTime)
+	}
+	if len(events.pushed) != 1 {
+		t.Fatalf("SaveDraft() pushed %d events, want 1", len(events.pushed))
+	}
+	if e := events.pushed[0]; e.ContentType != constants.ContentDraft || e.RecvId != "u1" || e.Content != "hello" {
+		t.Errorf("SaveDraft() pushed %+v, want draft event to u1", e)
+	}
+
+	_, err = NewSaveDraftLogic(context.Background(), svcCtx).SaveDraft(&im.SaveDraftReq{UserId: "u3", ConversationId: "g1", Content: "hello"})
+	if errors.Cause(err) != ErrConversationNotFound {
+		t.Errorf("SaveDraft() err = %v, want ErrConversationNotFound", err)
+	}
+	_, err = NewSaveDraftLogic(context.Background(), svcCtx).SaveDraft(&im.SaveDraftReq{UserId: "u1", ConversationId: "g1", Content: strings.Repeat("字", immodels.MaxDraftLength+1)})
+	if errors.Cause(err) != ErrDraftTooLong {
+		t.Errorf("SaveDraft() err = %v, want ErrDraftTooLong", err)
+	}
+	if len(events.pushed) != 1 {
+		t.Errorf("SaveDraft() pushed %d events after failures, want 1", len(events.pushed))
+	}
+}
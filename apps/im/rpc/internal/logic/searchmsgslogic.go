@@ -0,0 +1,141 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/im/search"
+	"easy-chat/pkg/xerr"
+	"github.com/pkg/errors"
+	"strings"
+
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/im/rpc/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// MaxSearchCount 每页最多返回的检索结果数
+var MaxSearchCount int64 = 100
+
+var (
+	ErrSearchKeyword    = xerr.New(xerr.REQUEST_PARAM_ERROR, "关键词不能为空")
+	ErrSearchPermission = xerr.New(xerr.NO_PERMISSION_ERROR, "不是会话成员，不能检索该会话")
+)
+
+type SearchMsgsLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewSearchMsgsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SearchMsgsLogic {
+	return &SearchMsgsLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// SearchMsgs 在用户所在的会话中检索消息
+//
+// 只检索用户会话列表中用户仍是成员的会话，不返回用户清空之前的消息，可以按发送者与发送时间筛选，结果按发送时间倒序排列。
+func (l *SearchMsgsLogic) SearchMsgs(in *im.SearchMsgsReq) (*im.SearchMsgsResp, error) {
+	if strings.TrimSpace(in.Keyword) == "" {
+		return nil, errors.WithStack(ErrSearchKeyword)
+	}
+
+	clearSeqs, err := l.clearSeqs(in)
+	if err != nil {
+		return nil, err
+	}
+	if len(clearSeqs) == 0 {
+		return &im.SearchMsgsResp{}, nil
+	}
+	conversationIds := make([]string, 0, len(clearSeqs))
+	for id := range clearSeqs {
+		conversationIds = append(conversationIds, id)
+	}
+
+	count := in.Count
+	if count > MaxSearchCount {
+		count = MaxSearchCount
+	}
+	hits, total, err := l.svcCtx.Index.Search(l.ctx, &search.Query{
+		Keyword:         in.Keyword,
+		ConversationIds: conversationIds,
+		SendId:          in.SendId,
+		StartTime:       in.StartTime,
+		EndTime:         in.EndTime,
+		ClearSeqs:       clearSeqs,
+		Offset:          int(in.Offset),
+		Limit:           int(count),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewInternalErr(), "Index.Search err %v, req %v", err, in)
+	}
+	if len(hits) == 0 {
+		return &im.SearchMsgsResp{Total: total}, nil
+	}
+
+	msgIds := make([]string, 0, len(hits))
+	for _, hit := range hits {
+		msgIds = append(msgIds, hit.Id)
+	}
+	chatLogs, err := listChatLogsByMsgIds(l.ctx, l.svcCtx, msgIds, in.UserId, clearSeqs)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*im.SearchHit, 0, len(hits))
+	for _, hit := range hits {
+		// 索引与聊天记录不一致时以聊天记录为准
+		chatLog, ok := chatLogs[hit.Id]
+		if !ok {
+			continue
+		}
+		list = append(list, &im.SearchHit{
+			Msg:     chatLog,
+			Snippet: hit.Snippet,
+		})
+	}
+	return &im.SearchMsgsResp{List: list, Total: total}, nil
+}
+
+// clearSeqs 检索的会话与用户清空会话时的序号
+//
+// 只包含用户会话列表中用户仍是成员的会话，退出的群不再检索。
+func (l *SearchMsgsLogic) clearSeqs(in *im.SearchMsgsReq) (map[string]int64, error) {
+	var userConversations []*immodels.UserConversation
+	if in.ConversationId != "" {
+		userConversation, err := l.svcCtx.UserConversationModel.FindOne(l.ctx, in.UserId, in.ConversationId)
+		switch err {
+		case nil:
+		case immodels.ErrNotFound:
+			return nil, errors.WithStack(ErrSearchPermission)
+		default:
+			return nil, errors.Wrapf(xerr.NewDBErr(), "UserConversationModel.FindOne err %v, req %v", err, in)
+		}
+		userConversations = []*immodels.UserConversation{userConversation}
+	} else {
+		var err error
+		userConversations, err = l.svcCtx.UserConversationModel.ListByUserId(l.ctx, in.UserId)
+		if err != nil {
+			return nil, errors.Wrapf(xerr.NewDBErr(), "UserConversationModel.ListByUserId err %v, req %v", err, in)
+		}
+	}
+
+	res := make(map[string]int64, len(userConversations))
+	for _, conversation := range userConversations {
+		ok, err := l.svcCtx.Auth.IsConversationMember(l.ctx, in.UserId, conversation.ChatType, conversation.ConversationId)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			res[conversation.ConversationId] = conversation.ClearSeq
+		}
+	}
+	if in.ConversationId != "" && len(res) == 0 {
+		return nil, errors.WithStack(ErrSearchPermission)
+	}
+	return res, nil
+}
@@ -0,0 +1,129 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/im/rpc/internal/svc"
+	"easy-chat/apps/im/search"
+	"easy-chat/pkg/constants"
+	"sort"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// listUserConversationModel 保存多个用户的会话
+type listUserConversationModel struct {
+	fakeUserConversationModel
+	conversations []*immodels.UserConversation
+}
+
+func (f *listUserConversationModel) FindOne(ctx context.Context, uid, conversationId string) (*immodels.UserConversation, error) {
+	for _, conversation := range f.conversations {
+		if conversation.UserId == uid && conversation.ConversationId == conversationId {
+			return conversation, nil
+		}
+	}
+	return nil, immodels.ErrNotFound
+}
+
+func (f *listUserConversationModel) ListByUserId(ctx context.Context, uid string) ([]*immodels.UserConversation, error) {
+	var res []*immodels.UserConversation
+	for _, conversation := range f.conversations {
+		if conversation.UserId == uid {
+			res = append(res, conversation)
+		}
+	}
+	return res, nil
+}
+
+func (f *fakeChatLogModel) ListByMsgIds(ctx context.Context, msgIds []string) ([]*immodels.ChatLog, error) {
+	var res []*immodels.ChatLog
+	for _, id := range msgIds {
+		if chatLog, ok := f.chatLogs[id]; ok {
+			res = append(res, chatLog)
+		}
+	}
+	return res, nil
+}
+
+// newSearchTestServiceContext 索引所有的消息，u2 的会话列表中还留有已经退出的群 g1，u3 清空过群 g1
+func newSearchTestServiceContext(t *testing.T) (*svc.ServiceContext, map[string]*immodels.ChatLog) {
+	svcCtx, chatLogs := newTestServiceContext()
+	index := search.NewMemoryIndex()
+	for id, chatLog := range chatLogs {
+		chatLog.MsgType, chatLog.MsgContent = constants.TextMtype, "hello "+chatLog.ConversationId
+		err := index.Index(context.Background(), &search.Document{
+			Id: id, ConversationId: chatLog.ConversationId, SendId: chatLog.SendId, Seq: chatLog.Seq, Content: chatLog.MsgContent,
+		})
+		if err != nil {
+			t.Fatalf("Index() err = %v", err)
+		}
+	}
+	svcCtx.Index = index
+
+	var conversations []*immodels.UserConversation
+	for _, v := range []struct {
+		uid, conversationId string
+		chatType            constants.ChatType
+		clearSeq            int64
+	}{
+		{"u1", "u1_u2", constants.SingleChatType, 0},
+		{"u1", "g1", constants.GroupChatType, 0},
+		{"u2", "u1_u2", constants.SingleChatType, 0},
+		{"u2", "g1", constants.GroupChatType, 0},
+		{"u3", "g1", constants.GroupChatType, 1},
+	} {
+		conversations = append(conversations, &immodels.UserConversation{
+			UserId: v.uid, ConversationId: v.conversationId, ChatType: v.chatType, ClearSeq: v.clearSeq,
+		})
+	}
+	svcCtx.UserConversationModel = &listUserConversationModel{conversations: conversations}
+	return svcCtx, chatLogs
+}
+
+func TestSearchMsgsLogic_SearchMsgs(t *testing.T) {
+	svcCtx, _ := newSearchTestServiceContext(t)
+
+	tests := []struct {
+		name    string
+		req     *im.SearchMsgsReq
+		want    []string // 命中消息所在的会话
+		wantErr error
+	}{
+		{"all conversations", &im.SearchMsgsReq{UserId: "u1", Keyword: "hello", Count: 10}, []string{"g1", "u1_u2"}, nil},
+		{"one conversation", &im.SearchMsgsReq{UserId: "u1", ConversationId: "g1", Keyword: "hello", Count: 10}, []string{"g1"}, nil},
+		{"left group skipped", &im.SearchMsgsReq{UserId: "u2", Keyword: "hello", Count: 10}, []string{"u1_u2"}, nil},
+		{"left group", &im.SearchMsgsReq{UserId: "u2", ConversationId: "g1", Keyword: "hello", Count: 10}, nil, ErrSearchPermission},
+		{"not in list", &im.SearchMsgsReq{UserId: "u3", ConversationId: "u1_u2", Keyword: "hello", Count: 10}, nil, ErrSearchPermission},
+		{"cleared", &im.SearchMsgsReq{UserId: "u3", ConversationId: "g1", Keyword: "hello", Count: 10}, nil, nil},
+		{"empty keyword", &im.SearchMsgsReq{UserId: "u1", Keyword: " ", Count: 10}, nil, ErrSearchKeyword},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := NewSearchMsgsLogic(context.Background(), svcCtx).SearchMsgs(tt.req)
+			if errors.Cause(err) != tt.wantErr {
+				t.Fatalf("SearchMsgs() err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			var got []string
+			for _, hit := range resp.List {
+				got = append(got, hit.Msg.ConversationId)
+			}
+			sort.Strings(got)
+			if len(got) != len(tt.want) || int(resp.Total) != len(tt.want) {
+				t.Fatalf("SearchMsgs() = %v, total %d, want %v", got, resp.Total, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("SearchMsgs() = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
@@ -9,7 +9,6 @@ import (
 	"easy-chat/pkg/wuid"
 	"easy-chat/pkg/xerr"
 	"github.com/pkg/errors"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"github.com/zeromicro/go-zero/core/logx"
 )
@@ -102,34 +101,15 @@ func (l *SetUpUserConversationLogic) SetUpUserConversation(in *im.SetUpUserConve
 // 返回:
 // - error: 发生的错误（如果有的话），返回nil表示操作成功。
 func (l *SetUpUserConversationLogic) setUpUserConversations(conversationId, userId, recvId string, chatType constants.ChatType, isShow bool) error {
-	//用户会话列表
-	conversations, err := l.svcCtx.ConversationsModel.FindByUserId(l.ctx, userId)
-	if err != nil {
-		if err == immodels.ErrNotFound { //不存在则创建用户会话列表
-			conversations = &immodels.Conversations{
-				ID:               primitive.NewObjectID(),
-				UserId:           userId,
-				ConversationList: make(map[string]*immodels.Conversation),
-			}
-		} else {
-			return errors.Wrapf(xerr.NewDBErr(), "ConversationsModel.FindByUserId err %v,req %v", err, userId)
-		}
-	}
-	//更新会话记录
-	if _, ok := conversations.ConversationList[conversationId]; ok {
-		return nil
-	}
-	//添加会话记录
-	conversations.ConversationList[conversationId] = &immodels.Conversation{
+	//添加会话记录，已经存在时不做修改
+	_, err := l.svcCtx.UserConversationModel.SetUp(l.ctx, &immodels.UserConversation{
+		UserId:         userId,
 		ConversationId: conversationId,
 		ChatType:       chatType,
 		IsShow:         isShow,
-	}
-
-	//更新
-	_, err = l.svcCtx.ConversationsModel.Update(l.ctx, conversations)
+	})
 	if err != nil {
-		return errors.Wrapf(xerr.NewDBErr(), "ConversationsModel.Insert err %v,req %v", err, conversations)
+		return errors.Wrapf(xerr.NewDBErr(), "UserConversationModel.SetUp err %v,req %v", err, conversationId)
 	}
 	return nil
 }
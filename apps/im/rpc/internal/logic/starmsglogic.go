@@ -0,0 +1,75 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/pkg/constants"
+	"easy-chat/pkg/xerr"
+	"github.com/pkg/errors"
+
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/apps/im/rpc/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+var (
+	ErrStarMsgNotFound   = xerr.New(xerr.REQUEST_PARAM_ERROR, "消息不存在")
+	ErrStarMsgRecalled   = xerr.NewMsg("消息已被撤回")
+	ErrStarMsgPermission = xerr.New(xerr.NO_PERMISSION_ERROR, "不是会话成员，不能收藏该消息")
+)
+
+type StarMsgLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewStarMsgLogic(ctx context.Context, svcCtx *svc.ServiceContext) *StarMsgLogic {
+	return &StarMsgLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// StarMsg 收藏或取消收藏消息
+//
+// 收藏仅收藏者本人可见，不推送事件；重复收藏或取消直接返回成功。
+func (l *StarMsgLogic) StarMsg(in *im.StarMsgReq) (*im.StarMsgResp, error) {
+	if in.Unstar {
+		if _, err := l.svcCtx.StarModel.Remove(l.ctx, in.UserId, in.MsgId); err != nil {
+			return nil, errors.Wrapf(xerr.NewDBErr(), "StarModel.Remove err %v, req %v", err, in)
+		}
+		return &im.StarMsgResp{}, nil
+	}
+
+	chatLog, err := l.svcCtx.ChatLogModel.FindOne(l.ctx, in.MsgId)
+	switch err {
+	case nil:
+	case immodels.ErrNotFound, immodels.ErrInvalidObjectId:
+		return nil, errors.WithStack(ErrStarMsgNotFound)
+	default:
+		return nil, errors.Wrapf(xerr.NewDBErr(), "find chatlog by msgId err %v, req %v", err, in)
+	}
+	if chatLog.Status == constants.RecallMsgStatus {
+		return nil, errors.WithStack(ErrStarMsgRecalled)
+	}
+	ok, err := isConversationMember(l.ctx, l.svcCtx, in.UserId, chatLog)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.WithStack(ErrStarMsgPermission)
+	}
+
+	_, err = l.svcCtx.StarModel.Add(l.ctx, &immodels.Star{
+		UserId:         in.UserId,
+		MsgId:          in.MsgId,
+		ConversationId: chatLog.ConversationId,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "StarModel.Add err %v, req %v", err, in)
+	}
+	return &im.StarMsgResp{}, nil
+}
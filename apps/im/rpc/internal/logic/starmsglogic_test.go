@@ -0,0 +1,83 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/im/rpc/im"
+	"easy-chat/pkg/constants"
+	"testing"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memStarModel 按用户与消息记录收藏
+type memStarModel struct {
+	immodels.StarModel
+	stars map[string]*immodels.Star
+}
+
+func (f *memStarModel) Add(ctx context.Context, data *immodels.Star) (bool, error) {
+	key := data.UserId + ":" + data.MsgId
+	if _, ok := f.stars[key]; ok {
+		return false, nil
+	}
+	f.stars[key] = data
+	return true, nil
+}
+
+func (f *memStarModel) Remove(ctx context.Context, uid, msgId string) (bool, error) {
+	key := uid + ":" + msgId
+	_, ok := f.stars[key]
+	delete(f.stars, key)
+	return ok, nil
+}
+
+func TestStarMsgLogic_StarMsg(t *testing.T) {
+	newChatLog := func(conversationId string, chatType constants.ChatType, recvId string, status constants.MsgStatus) *immodels.ChatLog {
+		return &immodels.ChatLog{ID: primitive.NewObjectID(), ConversationId: conversationId, ChatType: chatType,
+			SendId: "u1", RecvId: recvId, Status: status}
+	}
+	tests := []struct {
+		name      string
+		uid       string
+		chatLog   *immodels.ChatLog
+		starred   bool
+		unstar    bool
+		wantErr   error
+		wantStars int
+	}{
+		{"single receiver", "u2", newChatLog("u1_u2", constants.SingleChatType, "u2", constants.NormalMsgStatus), false, false, nil, 1},
+		{"group member", "u3", newChatLog("g1", constants.GroupChatType, "g1", constants.NormalMsgStatus), false, false, nil, 1},
+		{"starred twice", "u3", newChatLog("g1", constants.GroupChatType, "g1", constants.NormalMsgStatus), true, false, nil, 1},
+		{"single outsider", "u3", newChatLog("u1_u2", constants.SingleChatType, "u2", constants.NormalMsgStatus), false, false, ErrStarMsgPermission, 0},
+		{"not member", "u2", newChatLog("g1", constants.GroupChatType, "g1", constants.NormalMsgStatus), false, false, ErrStarMsgPermission, 0},
+		{"recalled", "u1", newChatLog("g1", constants.GroupChatType, "g1", constants.RecallMsgStatus), false, false, ErrStarMsgRecalled, 0},
+		// 取消收藏不校验消息，撤回后仍可以取消
+		{"unstar recalled", "u1", newChatLog("g1", constants.GroupChatType, "g1", constants.RecallMsgStatus), true, true, nil, 0},
+		{"unstar not starred", "u1", newChatLog("g1", constants.GroupChatType, "g1", constants.NormalMsgStatus), false, true, nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svcCtx, _ := newTestServiceContext()
+			msgId := tt.chatLog.ID.Hex()
+			stars := &memStarModel{stars: make(map[string]*immodels.Star)}
+			if tt.starred {
+				stars.stars[tt.uid+":"+msgId] = &immodels.Star{UserId: tt.uid, MsgId: msgId}
+			}
+			svcCtx.ChatLogModel = &fakeChatLogModel{chatLogs: map[string]*immodels.ChatLog{msgId: tt.chatLog}}
+			svcCtx.StarModel = stars
+
+			_, err := NewStarMsgLogic(context.Background(), svcCtx).StarMsg(&im.StarMsgReq{UserId: tt.uid, MsgId: msgId, Unstar: tt.unstar})
+			if errors.Cause(err) != tt.wantErr {
+				t.Fatalf("StarMsg() err = %v, want %v", err, tt.wantErr)
+			}
+			if len(stars.stars) != tt.wantStars {
+				t.Errorf("stars = %v, want %d", stars.stars, tt.wantStars)
+			}
+			if star, ok := stars.stars[tt.uid+":"+msgId]; ok && !tt.starred && star.ConversationId != tt.chatLog.ConversationId {
+				t.Errorf("star = %+v, want conversation %v", star, tt.chatLog.ConversationId)
+			}
+		})
+	}
+}
@@ -51,3 +51,123 @@ func (s *ImServer) CreateGroupConversation(ctx context.Context, in *im.CreateGro
 	l := logic.NewCreateGroupConversationLogic(ctx, s.svcCtx)
 	return l.CreateGroupConversation(in)
 }
+
+// 获取用户在会话中的已读水位
+func (s *ImServer) GetReadSeqs(ctx context.Context, in *im.GetReadSeqsReq) (*im.GetReadSeqsResp, error) {
+	l := logic.NewGetReadSeqsLogic(ctx, s.svcCtx)
+	return l.GetReadSeqs(in)
+}
+
+// 撤回消息
+func (s *ImServer) RecallMsg(ctx context.Context, in *im.RecallMsgReq) (*im.RecallMsgResp, error) {
+	l := logic.NewRecallMsgLogic(ctx, s.svcCtx)
+	return l.RecallMsg(in)
+}
+
+// 编辑消息
+func (s *ImServer) EditMsg(ctx context.Context, in *im.EditMsgReq) (*im.EditMsgResp, error) {
+	l := logic.NewEditMsgLogic(ctx, s.svcCtx)
+	return l.EditMsg(in)
+}
+
+// 添加或取消消息的表情回应
+func (s *ImServer) ReactMsg(ctx context.Context, in *im.ReactMsgReq) (*im.ReactMsgResp, error) {
+	l := logic.NewReactMsgLogic(ctx, s.svcCtx)
+	return l.ReactMsg(in)
+}
+
+// 分页获取话题中的回复
+func (s *ImServer) GetThreadReplies(ctx context.Context, in *im.GetThreadRepliesReq) (*im.GetThreadRepliesResp, error) {
+	l := logic.NewGetThreadRepliesLogic(ctx, s.svcCtx)
+	return l.GetThreadReplies(in)
+}
+
+// 置顶或取消置顶会话中的消息
+func (s *ImServer) PinMsg(ctx context.Context, in *im.PinMsgReq) (*im.PinMsgResp, error) {
+	l := logic.NewPinMsgLogic(ctx, s.svcCtx)
+	return l.PinMsg(in)
+}
+
+// 获取会话中置顶的消息
+func (s *ImServer) GetPinnedMsgs(ctx context.Context, in *im.GetPinnedMsgsReq) (*im.GetPinnedMsgsResp, error) {
+	l := logic.NewGetPinnedMsgsLogic(ctx, s.svcCtx)
+	return l.GetPinnedMsgs(in)
+}
+
+// 收藏或取消收藏消息
+func (s *ImServer) StarMsg(ctx context.Context, in *im.StarMsgReq) (*im.StarMsgResp, error) {
+	l := logic.NewStarMsgLogic(ctx, s.svcCtx)
+	return l.StarMsg(in)
+}
+
+// 分页获取用户收藏的消息
+func (s *ImServer) GetStarredMsgs(ctx context.Context, in *im.GetStarredMsgsReq) (*im.GetStarredMsgsResp, error) {
+	l := logic.NewGetStarredMsgsLogic(ctx, s.svcCtx)
+	return l.GetStarredMsgs(in)
+}
+
+// 在用户所在的会话中检索消息
+func (s *ImServer) SearchMsgs(ctx context.Context, in *im.SearchMsgsReq) (*im.SearchMsgsResp, error) {
+	l := logic.NewSearchMsgsLogic(ctx, s.svcCtx)
+	return l.SearchMsgs(in)
+}
+
+// 置顶或取消置顶会话
+func (s *ImServer) PinConversation(ctx context.Context, in *im.PinConversationReq) (*im.PinConversationResp, error) {
+	l := logic.NewPinConversationLogic(ctx, s.svcCtx)
+	return l.PinConversation(in)
+}
+
+// 设置会话免打扰
+func (s *ImServer) MuteConversation(ctx context.Context, in *im.MuteConversationReq) (*im.MuteConversationResp, error) {
+	l := logic.NewMuteConversationLogic(ctx, s.svcCtx)
+	return l.MuteConversation(in)
+}
+
+// 归档或取消归档会话
+func (s *ImServer) ArchiveConversation(ctx context.Context, in *im.ArchiveConversationReq) (*im.ArchiveConversationResp, error) {
+	l := logic.NewArchiveConversationLogic(ctx, s.svcCtx)
+	return l.ArchiveConversation(in)
+}
+
+// 从会话列表中删除会话，有新消息时重新展示
+func (s *ImServer) DeleteConversation(ctx context.Context, in *im.DeleteConversationReq) (*im.DeleteConversationResp, error) {
+	l := logic.NewDeleteConversationLogic(ctx, s.svcCtx)
+	return l.DeleteConversation(in)
+}
+
+// 清空用户在会话中的聊天记录
+func (s *ImServer) ClearConversationHistory(ctx context.Context, in *im.ClearConversationHistoryReq) (*im.ClearConversationHistoryResp, error) {
+	l := logic.NewClearConversationHistoryLogic(ctx, s.svcCtx)
+	return l.ClearConversationHistory(in)
+}
+
+// 按最后一条消息的时间分页查询会话列表，置顶的会话在前
+func (s *ImServer) ListConversations(ctx context.Context, in *im.ListConversationsReq) (*im.ListConversationsResp, error) {
+	l := logic.NewListConversationsLogic(ctx, s.svcCtx)
+	return l.ListConversations(in)
+}
+
+// 保存或清除会话的草稿，并同步到用户的其他设备
+func (s *ImServer) SaveDraft(ctx context.Context, in *im.SaveDraftReq) (*im.SaveDraftResp, error) {
+	l := logic.NewSaveDraftLogic(ctx, s.svcCtx)
+	return l.SaveDraft(in)
+}
+
+// 创建定时消息，到期后以用户的身份发送
+func (s *ImServer) CreateScheduledMsg(ctx context.Context, in *im.CreateScheduledMsgReq) (*im.CreateScheduledMsgResp, error) {
+	l := logic.NewCreateScheduledMsgLogic(ctx, s.svcCtx)
+	return l.CreateScheduledMsg(in)
+}
+
+// 查询用户尚未发送成功的定时消息
+func (s *ImServer) ListScheduledMsgs(ctx context.Context, in *im.ListScheduledMsgsReq) (*im.ListScheduledMsgsResp, error) {
+	l := logic.NewListScheduledMsgsLogic(ctx, s.svcCtx)
+	return l.ListScheduledMsgs(in)
+}
+
+// 取消等待发送或发送失败的定时消息
+func (s *ImServer) CancelScheduledMsg(ctx context.Context, in *im.CancelScheduledMsgReq) (*im.CancelScheduledMsgResp, error) {
+	l := logic.NewCancelScheduledMsgLogic(ctx, s.svcCtx)
+	return l.CancelScheduledMsg(in)
+}
@@ -1,22 +1,43 @@
 package svc
 
 import (
+	"easy-chat/apps/im/authz"
 	"easy-chat/apps/im/immodels"
 	"easy-chat/apps/im/rpc/internal/config"
+	"easy-chat/apps/im/search"
+	"easy-chat/apps/media/rpc/mediaclient"
+	"easy-chat/apps/social/rpc/socialclient"
+	"easy-chat/apps/task/mq/mqclient"
+	"github.com/zeromicro/go-zero/zrpc"
 )
 
 type ServiceContext struct {
 	Config config.Config
 	immodels.ChatLogModel
 	immodels.ConversationModel
-	immodels.ConversationsModel
+	immodels.UserConversationModel
+	immodels.ReactionModel
+	immodels.StarModel
+	immodels.ScheduledMsgModel
+	mqclient.MsgEventTransferClient
+	Index search.Index
+	Auth  *authz.Authorizer
+	// 校验消息引用的附件
+	Media mediaclient.Media
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
 	return &ServiceContext{
-		Config:             c,
-		ChatLogModel:       immodels.MustChatLogModel(c.Mongo.Url, c.Mongo.Db),
-		ConversationModel:  immodels.MustConversationModel(c.Mongo.Url, c.Mongo.Db),
-		ConversationsModel: immodels.MustConversationsModel(c.Mongo.Url, c.Mongo.Db),
+		Config:                 c,
+		ChatLogModel:           immodels.MustChatLogModel(c.Mongo.Url, c.Mongo.Db),
+		ConversationModel:      immodels.MustConversationModel(c.Mongo.Url, c.Mongo.Db),
+		UserConversationModel:  immodels.MustUserConversationModel(c.Mongo.Url, c.Mongo.Db),
+		ReactionModel:          immodels.MustReactionModel(c.Mongo.Url, c.Mongo.Db),
+		StarModel:              immodels.MustStarModel(c.Mongo.Url, c.Mongo.Db),
+		ScheduledMsgModel:      immodels.MustScheduledMsgModel(c.Mongo.Url, c.Mongo.Db),
+		MsgEventTransferClient: mqclient.NewMsgEventTransferClient(c.MsgEventTransfer.Addrs, c.MsgEventTransfer.Topic),
+		Index:                  search.NewMemoryIndex(),
+		Auth:                   authz.NewAuthorizer(socialclient.NewSocial(zrpc.MustNewClient(c.SocialRpc))),
+		Media:                  mediaclient.NewMedia(zrpc.MustNewClient(c.MediaRpc)),
 	}
 }
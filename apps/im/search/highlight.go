@@ -0,0 +1,97 @@
+package search
+
+import (
+	"strings"
+)
+
+var (
+	// HighlightPre HighlightPost 包裹摘要中的关键词
+	HighlightPre  = "<em>"
+	HighlightPost = "</em>"
+	// SnippetLen 摘要的最大字数，不含高亮标记
+	SnippetLen = 60
+)
+
+// Highlight 截取内容中第一个关键词附近的摘要，并高亮其中的关键词
+//
+// 关键词不区分大小写；摘要没有从内容的开头或结尾开始时以 "..." 表示省略。
+func Highlight(content string, terms []string) string {
+	runes := []rune(content)
+	lower := []rune(strings.ToLower(content))
+	if len(lower) != len(runes) {
+		// 大小写转换改变了字数时无法对应位置，直接按原内容匹配
+		lower = runes
+	}
+
+	// 标记每个字是否属于关键词
+	marks := make([]bool, len(runes))
+	first := -1
+	for _, term := range terms {
+		t := []rune(term)
+		if len(t) == 0 {
+			continue
+		}
+		for i := 0; i+len(t) <= len(lower); i++ {
+			if !equalRunes(lower[i:i+len(t)], t) {
+				continue
+			}
+			for j := i; j < i+len(t); j++ {
+				marks[j] = true
+			}
+			if first < 0 || i < first {
+				first = i
+			}
+		}
+	}
+
+	// 关键词前保留摘要长度的三分之一作为上下文
+	start := 0
+	if first > SnippetLen/3 {
+		start = first - SnippetLen/3
+	}
+	end := start + SnippetLen
+	if end > len(runes) {
+		end = len(runes)
+		if start = end - SnippetLen; start < 0 {
+			start = 0
+		}
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("...")
+	}
+	for i := start; i < end; i++ {
+		if marks[i] && (i == start || !marks[i-1]) {
+			b.WriteString(HighlightPre)
+		}
+		b.WriteRune(runes[i])
+		if marks[i] && (i == end-1 || !marks[i+1]) {
+			b.WriteString(HighlightPost)
+		}
+	}
+	if end < len(runes) {
+		b.WriteString("...")
+	}
+	return b.String()
+}
+
+// contains 内容是否不区分大小写地包含所有关键词
+func contains(content string, terms []string) bool {
+	lower := strings.ToLower(content)
+	for _, term := range terms {
+		if !strings.Contains(lower, term) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalRunes(a, b []rune) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
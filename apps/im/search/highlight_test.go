@@ -0,0 +1,51 @@
+package search
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"Hello, World! hello", []string{"hello", "world"}},
+		{"你好吗", []string{"你", "好", "你好", "吗", "好吗"}},
+		{"go语言", []string{"go", "语", "言", "语言"}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := tokenize(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("tokenize(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		terms   []string
+		want    string
+	}{
+		{"word", "Hello World", []string{"world"}, "Hello <em>World</em>"},
+		{"several", "hello again, hello", []string{"hello"}, "<em>hello</em> again, <em>hello</em>"},
+		{"adjacent terms", "abc", []string{"a", "b"}, "<em>ab</em>c"},
+		{"cjk", "明天一起吃饭吗", []string{"吃饭"}, "明天一起<em>吃饭</em>吗"},
+		{
+			"truncated",
+			strings.Repeat("a", 100) + " key " + strings.Repeat("b", 100),
+			[]string{"key"},
+			"..." + strings.Repeat("a", 19) + " <em>key</em> " + strings.Repeat("b", 36) + "...",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Highlight(tt.content, tt.terms); got != tt.want {
+				t.Errorf("Highlight() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package search
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// DefaultLimit 未指定时每页的结果数
+var DefaultLimit = 20
+
+// MemoryIndex 进程内的倒排索引
+//
+// 不依赖外部服务，用于开发环境与单实例部署；重启后需要重新建立索引。
+type MemoryIndex struct {
+	mu       sync.RWMutex
+	docs     map[string]*Document
+	postings map[string]map[string]struct{} // 索引词对应的文档
+}
+
+func NewMemoryIndex() *MemoryIndex {
+	return &MemoryIndex{
+		docs:     make(map[string]*Document),
+		postings: make(map[string]map[string]struct{}),
+	}
+}
+
+func (m *MemoryIndex) Index(ctx context.Context, docs ...*Document) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, doc := range docs {
+		m.delete(doc.Id)
+		if doc.Content == "" {
+			continue
+		}
+
+		d := *doc
+		m.docs[d.Id] = &d
+		for _, token := range tokenize(d.Content) {
+			ids, ok := m.postings[token]
+			if !ok {
+				ids = make(map[string]struct{})
+				m.postings[token] = ids
+			}
+			ids[d.Id] = struct{}{}
+		}
+	}
+	return nil
+}
+
+func (m *MemoryIndex) Delete(ctx context.Context, ids ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, id := range ids {
+		m.delete(id)
+	}
+	return nil
+}
+
+func (m *MemoryIndex) delete(id string) {
+	doc, ok := m.docs[id]
+	if !ok {
+		return
+	}
+	for _, token := range tokenize(doc.Content) {
+		delete(m.postings[token], id)
+		if len(m.postings[token]) == 0 {
+			delete(m.postings, token)
+		}
+	}
+	delete(m.docs, id)
+}
+
+func (m *MemoryIndex) Search(ctx context.Context, q *Query) ([]*Hit, int64, error) {
+	ts := terms(q.Keyword)
+	if len(ts) == 0 {
+		return nil, 0, ErrEmptyKeyword
+	}
+	conversationIds := make(map[string]struct{}, len(q.ConversationIds))
+	for _, id := range q.ConversationIds {
+		conversationIds[id] = struct{}{}
+	}
+
+	m.mu.RLock()
+	var matches []*Document
+	for id := range m.candidates(ts) {
+		doc := m.docs[id]
+		if _, ok := conversationIds[doc.ConversationId]; !ok {
+			continue
+		}
+		if clearSeq := q.ClearSeqs[doc.ConversationId]; clearSeq > 0 && doc.Seq <= clearSeq {
+			continue
+		}
+		if q.SendId != "" && doc.SendId != q.SendId {
+			continue
+		}
+		if q.StartTime > 0 && doc.SendTime < q.StartTime {
+			continue
+		}
+		if q.EndTime > 0 && doc.SendTime >= q.EndTime {
+			continue
+		}
+		// 索引词只用于筛选，关键词需要在内容中连续出现
+		if !contains(doc.Content, ts) {
+			continue
+		}
+		matches = append(matches, doc)
+	}
+	m.mu.RUnlock()
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].SendTime != matches[j].SendTime {
+			return matches[i].SendTime > matches[j].SendTime
+		}
+		return matches[i].Id > matches[j].Id
+	})
+
+	total := int64(len(matches))
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if q.Offset >= len(matches) {
+		return nil, total, nil
+	}
+	matches = matches[q.Offset:]
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	hits := make([]*Hit, 0, len(matches))
+	for _, doc := range matches {
+		hits = append(hits, &Hit{
+			Id:             doc.Id,
+			ConversationId: doc.ConversationId,
+			SendTime:       doc.SendTime,
+			Snippet:        Highlight(doc.Content, ts),
+		})
+	}
+	return hits, total, nil
+}
+
+// candidates 包含所有关键词的索引词的文档
+func (m *MemoryIndex) candidates(terms []string) map[string]struct{} {
+	var res map[string]struct{}
+	for _, term := range terms {
+		for _, token := range tokenize(term) {
+			ids := m.postings[token]
+			if res == nil {
+				res = make(map[string]struct{}, len(ids))
+				for id := range ids {
+					res[id] = struct{}{}
+				}
+				continue
+			}
+			for id := range res {
+				if _, ok := ids[id]; !ok {
+					delete(res, id)
+				}
+			}
+		}
+	}
+	return res
+}
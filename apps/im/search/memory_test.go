@@ -0,0 +1,106 @@
+package search
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func newTestIndex(t *testing.T) *MemoryIndex {
+	idx := NewMemoryIndex()
+	err := idx.Index(context.Background(),
+		&Document{Id: "1", ConversationId: "c1", SendId: "u1", SendTime: 100, Seq: 1, Content: "Hello World"},
+		&Document{Id: "2", ConversationId: "c1", SendId: "u2", SendTime: 200, Seq: 2, Content: "hello again, world"},
+		&Document{Id: "3", ConversationId: "c2", SendId: "u1", SendTime: 300, Seq: 1, Content: "明天一起吃饭吗"},
+		&Document{Id: "4", ConversationId: "c1", SendId: "u1", SendTime: 400, Seq: 3, Content: "吃饭了没有"},
+	)
+	if err != nil {
+		t.Fatalf("Index() err = %v", err)
+	}
+	return idx
+}
+
+func hitIds(hits []*Hit) []string {
+	var ids []string
+	for _, hit := range hits {
+		ids = append(ids, hit.Id)
+	}
+	return ids
+}
+
+func TestMemoryIndex_Search(t *testing.T) {
+	idx := newTestIndex(t)
+	all := []string{"c1", "c2"}
+
+	tests := []struct {
+		name  string
+		query Query
+		want  []string
+	}{
+		{"keyword", Query{Keyword: "hello", ConversationIds: all}, []string{"2", "1"}},
+		{"case insensitive", Query{Keyword: "WORLD", ConversationIds: all}, []string{"2", "1"}},
+		{"all terms", Query{Keyword: "hello again", ConversationIds: all}, []string{"2"}},
+		{"phrase not word", Query{Keyword: "hell", ConversationIds: all}, nil},
+		{"cjk", Query{Keyword: "吃饭", ConversationIds: all}, []string{"4", "3"}},
+		{"cjk single", Query{Keyword: "饭", ConversationIds: all}, []string{"4", "3"}},
+		{"cjk not contiguous", Query{Keyword: "明饭", ConversationIds: all}, nil},
+		{"conversation", Query{Keyword: "吃饭", ConversationIds: []string{"c1"}}, []string{"4"}},
+		{"no conversation", Query{Keyword: "hello"}, nil},
+		{"sender", Query{Keyword: "hello", ConversationIds: all, SendId: "u1"}, []string{"1"}},
+		{"time range", Query{Keyword: "hello", ConversationIds: all, StartTime: 100, EndTime: 200}, []string{"1"}},
+		{"cleared", Query{Keyword: "hello", ConversationIds: all, ClearSeqs: map[string]int64{"c1": 1}}, []string{"2"}},
+		{"cleared other conversation", Query{Keyword: "吃饭", ConversationIds: all, ClearSeqs: map[string]int64{"c2": 1}}, []string{"4"}},
+		{"page", Query{Keyword: "hello", ConversationIds: all, Offset: 1, Limit: 1}, []string{"1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits, _, err := idx.Search(context.Background(), &tt.query)
+			if err != nil {
+				t.Fatalf("Search() err = %v", err)
+			}
+			if got := hitIds(hits); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Search() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, _, err := idx.Search(context.Background(), &Query{Keyword: "  ", ConversationIds: all}); err != ErrEmptyKeyword {
+		t.Errorf("Search() empty keyword err = %v, want ErrEmptyKeyword", err)
+	}
+	_, total, _ := idx.Search(context.Background(), &Query{Keyword: "hello", ConversationIds: all, Limit: 1})
+	if total != 2 {
+		t.Errorf("Search() total = %v, want 2", total)
+	}
+}
+
+func TestMemoryIndex_UpdateAndDelete(t *testing.T) {
+	var (
+		ctx = context.Background()
+		idx = newTestIndex(t)
+		all = []string{"c1", "c2"}
+	)
+
+	// 编辑后以相同的 Id 重新索引
+	if err := idx.Index(ctx, &Document{Id: "1", ConversationId: "c1", SendId: "u1", SendTime: 100, Content: "goodbye"}); err != nil {
+		t.Fatalf("Index() err = %v", err)
+	}
+	hits, _, _ := idx.Search(ctx, &Query{Keyword: "hello", ConversationIds: all})
+	if got := hitIds(hits); !reflect.DeepEqual(got, []string{"2"}) {
+		t.Errorf("Search() after update = %v, want [2]", got)
+	}
+	hits, _, _ = idx.Search(ctx, &Query{Keyword: "goodbye", ConversationIds: all})
+	if got := hitIds(hits); !reflect.DeepEqual(got, []string{"1"}) {
+		t.Errorf("Search() updated content = %v, want [1]", got)
+	}
+
+	if err := idx.Delete(ctx, "2", "missing"); err != nil {
+		t.Fatalf("Delete() err = %v", err)
+	}
+	hits, _, _ = idx.Search(ctx, &Query{Keyword: "hello", ConversationIds: all})
+	if len(hits) != 0 {
+		t.Errorf("Search() after delete = %v, want none", hitIds(hits))
+	}
+	if len(idx.postings["hello"]) != 0 {
+		t.Errorf("postings of deleted doc not removed: %v", idx.postings["hello"])
+	}
+}
@@ -0,0 +1,48 @@
+package search
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrEmptyKeyword = errors.New("empty keyword")
+
+// Document 被索引的一条消息
+type Document struct {
+	Id             string // 聊天记录ID
+	ConversationId string
+	SendId         string
+	SendTime       int64
+	Seq            int64  // 消息在会话中的序号
+	Content        string // 可检索的文本
+}
+
+// Query 检索条件，关键词以空白分隔，消息需要包含所有关键词
+type Query struct {
+	Keyword         string
+	ConversationIds []string         // 只在这些会话中检索，为空时不返回结果
+	SendId          string           // 发送者，为空时不限
+	StartTime       int64            // 发送时间的下限（包含），为 0 时不限
+	EndTime         int64            // 发送时间的上限（不包含），为 0 时不限
+	ClearSeqs       map[string]int64 // 用户清空会话时的序号，只检索序号更大的消息
+	Offset          int
+	Limit           int
+}
+
+// Hit 命中的消息，按发送时间倒序排列
+type Hit struct {
+	Id             string
+	ConversationId string
+	SendTime       int64
+	Snippet        string // 高亮关键词的内容摘要
+}
+
+// Index 聊天记录的全文索引
+//
+// 以相同的 Id 重复索引时覆盖原有的文档，用于消息被编辑后更新索引。
+type Index interface {
+	Index(ctx context.Context, docs ...*Document) error
+	Delete(ctx context.Context, ids ...string) error
+	// Search 返回当前页的结果与命中的总数
+	Search(ctx context.Context, q *Query) ([]*Hit, int64, error)
+}
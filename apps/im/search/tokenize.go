@@ -0,0 +1,73 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// isCJK 中日韩文字没有空白分词，按相邻的两个字切分
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+// tokenize 将文本切分为去重的索引词
+//
+// 字母与数字连续的部分作为一个词；中日韩文字切分为单字与相邻的两个字，
+// 检索单字与多字的关键词都能命中。
+func tokenize(text string) []string {
+	var (
+		seen   = make(map[string]struct{})
+		tokens []string
+		word   []rune
+		prev   rune
+	)
+	add := func(token string) {
+		if _, ok := seen[token]; ok {
+			return
+		}
+		seen[token] = struct{}{}
+		tokens = append(tokens, token)
+	}
+	flush := func() {
+		if len(word) > 0 {
+			add(string(word))
+			word = word[:0]
+		}
+	}
+
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case isCJK(r):
+			flush()
+			add(string(r))
+			if prev != 0 {
+				add(string([]rune{prev, r}))
+			}
+			prev = r
+			continue
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word = append(word, r)
+		default:
+			flush()
+		}
+		prev = 0
+	}
+	flush()
+	return tokens
+}
+
+// terms 将关键词按空白切分并转为小写，去除重复
+func terms(keyword string) []string {
+	var (
+		seen = make(map[string]struct{})
+		res  []string
+	)
+	for _, term := range strings.Fields(strings.ToLower(keyword)) {
+		if _, ok := seen[term]; ok {
+			continue
+		}
+		seen[term] = struct{}{}
+		res = append(res, term)
+	}
+	return res
+}
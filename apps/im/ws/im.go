@@ -26,9 +26,15 @@ func main() {
 		//websocket.WithServerMaxConnectionIdle(10*time.Second),
 		//websocket.WithServerAck(websocket.OnlyAck),
 		websocket.WithServerAck(websocket.RigorAck),
+		websocket.WithServerConnListener(ctx.Online),
 	)
 	defer srv.Stop()
 
+	if err := ctx.Online.Start(srv); err != nil {
+		panic(err)
+	}
+	defer ctx.Online.Stop()
+
 	handler.RegisterHandlers(srv, ctx)
 
 	fmt.Println("启动 websocket 服务 at", c.ListenOn, "......")
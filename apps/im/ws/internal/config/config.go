@@ -1,6 +1,10 @@
 package config
 
-import "github.com/zeromicro/go-zero/core/service"
+import (
+	"github.com/zeromicro/go-zero/core/service"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+	"github.com/zeromicro/go-zero/zrpc"
+)
 
 type Config struct {
 	service.ServiceConf
@@ -13,6 +17,7 @@ type Config struct {
 		Url string
 		Db  string
 	}
+	Redisx          redis.RedisConf
 	MsgChatTransfer struct {
 		Topic string
 		Addrs []string
@@ -21,4 +26,16 @@ type Config struct {
 		Topic string
 		Addrs []string
 	}
+	// 正在输入的节流间隔与自动停止的时间，单位为秒，不设置时使用默认值
+	Typing struct {
+		Throttle int64
+		Timeout  int64
+	}
+	// 设备没有心跳后仍视为在线的时间，单位为秒，不设置时使用默认值
+	Presence struct {
+		TTL int64
+	}
+	ImRpc     zrpc.RpcClientConf
+	SocialRpc zrpc.RpcClientConf
+	MediaRpc  zrpc.RpcClientConf
 }
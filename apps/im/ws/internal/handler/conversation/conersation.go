@@ -1,16 +1,39 @@
 package conversation
 
 import (
+	"context"
+	"easy-chat/apps/im/authz"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/im/rpc/imclient"
 	"easy-chat/apps/im/ws/internal/svc"
 	"easy-chat/apps/im/ws/websocket"
 	"easy-chat/apps/im/ws/ws"
+	"easy-chat/apps/social/rpc/socialclient"
 	"easy-chat/apps/task/mq/mq"
 	"easy-chat/pkg/constants"
 	"easy-chat/pkg/wuid"
+	"errors"
 	"github.com/mitchellh/mapstructure"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"time"
 )
 
+var (
+	ErrChatTarget = errors.New("发送的会话有误")
+
+	ErrReplyNotFound = errors.New("回复的消息不存在")
+	ErrReplyRecalled = errors.New("回复的消息已被撤回")
+
+	ErrMentionChatType = errors.New("只能在群聊中提及成员")
+	ErrMentionAll      = errors.New("只有群主与管理员可以提及所有人")
+	ErrNotGroupMember  = errors.New("不是群成员")
+
+	ErrThreadChatType = errors.New("只能在群聊中发起话题")
+	ErrThreadNotFound = errors.New("话题不存在")
+	ErrThreadRecalled = errors.New("话题的消息已被撤回")
+	ErrThreadNested   = errors.New("不能在话题的回复中发起话题")
+)
+
 func Chat(svc *svc.ServiceContext) websocket.HandlerFunc {
 	return func(srv *websocket.Server, conn *websocket.Conn, msg *websocket.Message) {
 		// todo: 私聊
@@ -19,15 +42,43 @@ func Chat(svc *svc.ServiceContext) websocket.HandlerFunc {
 			srv.Send(websocket.NewErrMessage(err), conn)
 			return
 		}
-		if data.ConversationId == "" {
-			switch data.ChatType {
-			case constants.SingleChatType:
-				data.ConversationId = wuid.CombineId(conn.Uid, data.RecvId)
-			case constants.GroupChatType:
-				data.ConversationId = data.RecvId
+		if err := immodels.ValidateMsg(data.MType, data.Content, data.Body); err != nil {
+			srv.Send(websocket.NewErrMessage(err), conn)
+			return
+		}
+		conversationId, err := chatTarget(svc, conn.Uid, data.ChatType, data.RecvId, data.ConversationId)
+		if err != nil {
+			srv.Send(websocket.NewErrMessage(err), conn)
+			return
+		}
+		data.ConversationId = conversationId
+		if err := authz.CheckAttachment(context.Background(), svc.Media, conn.Uid, data.Body); err != nil {
+			srv.Send(websocket.NewErrMessage(err), conn)
+			return
+		}
+		var mention *immodels.Mention
+		if !data.Mention.IsEmpty() {
+			var err error
+			if mention, err = checkMention(svc, data.ChatType, data.RecvId, conn.Uid, data.Mention); err != nil {
+				srv.Send(websocket.NewErrMessage(err), conn)
+				return
+			}
+		}
+		if data.ThreadId != "" {
+			if _, err := findThread(svc, data.ChatType, data.ConversationId, data.ThreadId); err != nil {
+				srv.Send(websocket.NewErrMessage(err), conn)
+				return
 			}
 		}
-		err := svc.MsgChatTransferClient.Push(&mq.MsgChatTransfer{
+		var quote *immodels.Quote
+		if data.ReplyTo != "" {
+			var err error
+			if quote, err = findQuote(svc, data.ConversationId, data.ReplyTo); err != nil {
+				srv.Send(websocket.NewErrMessage(err), conn)
+				return
+			}
+		}
+		err = svc.MsgChatTransferClient.Push(&mq.MsgChatTransfer{
 			ConversationId: data.ConversationId,
 			ChatType:       data.ChatType,
 			SendId:         conn.Uid,
@@ -35,7 +86,12 @@ func Chat(svc *svc.ServiceContext) websocket.HandlerFunc {
 			SendTime:       time.Now().UnixMilli(),
 			MType:          data.Msg.MType,
 			Content:        data.Msg.Content,
-			MsgId:          msg.Id,
+			Body:           data.Msg.Body,
+			Quote:          quote,
+			Mention:        mention,
+			ThreadId:       data.ThreadId,
+			ChatLogId:      primitive.NewObjectID().Hex(),
+			ClientMsgId:    msg.Id,
 		})
 		if err != nil {
 			srv.Send(websocket.NewErrMessage(err))
@@ -44,6 +100,133 @@ func Chat(svc *svc.ServiceContext) websocket.HandlerFunc {
 	}
 }
 
+// chatTarget 由服务端计算发送的会话并校验发送者的权限，客户端传入的会话ID只用于核对
+//
+// 回复、话题等按会话ID查找消息，需要先确认发送者属于该会话。
+func chatTarget(svc *svc.ServiceContext, uid string, chatType constants.ChatType, recvId, clientId string) (string, error) {
+	conversationId := targetConversationId(uid, chatType, recvId)
+	if conversationId == "" || (clientId != "" && clientId != conversationId) {
+		return "", ErrChatTarget
+	}
+	if err := svc.Auth.CheckSend(context.Background(), uid, chatType, recvId); err != nil {
+		return "", err
+	}
+	return conversationId, nil
+}
+
+// targetConversationId 私聊的会话由双方的用户ID组成，群聊的会话为群ID，接收者有误时返回空
+func targetConversationId(uid string, chatType constants.ChatType, recvId string) string {
+	if recvId == "" {
+		return ""
+	}
+	switch chatType {
+	case constants.SingleChatType:
+		if recvId == uid {
+			return ""
+		}
+		return wuid.CombineId(uid, recvId)
+	case constants.GroupChatType:
+		return recvId
+	}
+	return ""
+}
+
+// findQuote 查找回复的消息，只能引用同一会话内未撤回的消息
+func findQuote(svc *svc.ServiceContext, conversationId, msgId string) (*immodels.Quote, error) {
+	chatLog, err := svc.ChatLogModel.FindOne(context.Background(), msgId)
+	switch err {
+	case nil:
+	case immodels.ErrNotFound, immodels.ErrInvalidObjectId:
+		return nil, ErrReplyNotFound
+	default:
+		return nil, err
+	}
+
+	if chatLog.ConversationId != conversationId {
+		return nil, ErrReplyNotFound
+	}
+	if chatLog.Status == constants.RecallMsgStatus {
+		return nil, ErrReplyRecalled
+	}
+	return immodels.NewQuote(chatLog), nil
+}
+
+// checkMention 校验提及的成员，只保留群内除发送者以外的成员
+func checkMention(svc *svc.ServiceContext, chatType constants.ChatType, groupId, uid string, mention *immodels.Mention) (*immodels.Mention, error) {
+	if chatType != constants.GroupChatType {
+		return nil, ErrMentionChatType
+	}
+
+	members, err := groupMembers(svc, groupId)
+	if err != nil {
+		return nil, err
+	}
+
+	roleLevel, ok := members[uid]
+	if !ok {
+		return nil, ErrNotGroupMember
+	}
+	if mention.All && roleLevel != constants.CreatorGroupRoleLevel && roleLevel != constants.ManagerGroupRoleLevel {
+		return nil, ErrMentionAll
+	}
+
+	res := &immodels.Mention{All: mention.All}
+	seen := make(map[string]bool, len(mention.UserIds))
+	for _, id := range mention.UserIds {
+		if _, ok := members[id]; !ok || id == uid || seen[id] {
+			continue
+		}
+		seen[id] = true
+		res.UserIds = append(res.UserIds, id)
+	}
+	if res.IsEmpty() {
+		return nil, nil
+	}
+	return res, nil
+}
+
+// findThread 查找话题的第一条消息，只能在同一群聊内未撤回的普通消息上发起话题
+func findThread(svc *svc.ServiceContext, chatType constants.ChatType, conversationId, msgId string) (*immodels.ChatLog, error) {
+	if chatType != constants.GroupChatType {
+		return nil, ErrThreadChatType
+	}
+
+	root, err := svc.ChatLogModel.FindOne(context.Background(), msgId)
+	switch err {
+	case nil:
+	case immodels.ErrNotFound, immodels.ErrInvalidObjectId:
+		return nil, ErrThreadNotFound
+	default:
+		return nil, err
+	}
+
+	if root.ConversationId != conversationId {
+		return nil, ErrThreadNotFound
+	}
+	if root.Status == constants.RecallMsgStatus {
+		return nil, ErrThreadRecalled
+	}
+	if root.ThreadId != "" {
+		return nil, ErrThreadNested
+	}
+	return root, nil
+}
+
+// groupMembers 查询群成员及其角色
+func groupMembers(svc *svc.ServiceContext, groupId string) (map[string]constants.GroupRoleLevel, error) {
+	users, err := svc.Social.GroupUsers(context.Background(), &socialclient.GroupUsersReq{
+		GroupId: groupId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	members := make(map[string]constants.GroupRoleLevel, len(users.List))
+	for _, member := range users.List {
+		members[member.UserId] = constants.GroupRoleLevel(member.RoleLevel)
+	}
+	return members, nil
+}
+
 //err := logic.NewConversation(context.Background(), srv, svc).SingleChat(&data, conn.Uid)
 //if err != nil {
 //	srv.Send(websocket.NewErrMessage(err), conn)
@@ -72,7 +255,7 @@ func MarkRead(svc *svc.ServiceContext) websocket.HandlerFunc {
 			ConversationId: data.ConversationId,
 			SendId:         conn.Uid,
 			RecvId:         data.RecvId,
-			MsgIds:         data.MsgIds,
+			ReadSeq:        data.ReadSeq,
 		})
 		if err != nil {
 			srv.Send(websocket.NewErrMessage(err), conn)
@@ -80,3 +263,109 @@ func MarkRead(svc *svc.ServiceContext) websocket.HandlerFunc {
 		}
 	}
 }
+
+func Recall(svc *svc.ServiceContext) websocket.HandlerFunc {
+	return func(srv *websocket.Server, conn *websocket.Conn, msg *websocket.Message) {
+		var data ws.Recall
+		if err := mapstructure.Decode(msg.Data, &data); err != nil {
+			srv.Send(websocket.NewErrMessage(err), conn)
+			return
+		}
+		_, err := svc.Im.RecallMsg(context.Background(), &imclient.RecallMsgReq{
+			UserId: conn.Uid,
+			MsgId:  data.MsgId,
+		})
+		if err != nil {
+			srv.Send(websocket.NewErrMessage(err), conn)
+			return
+		}
+	}
+}
+
+func Edit(svc *svc.ServiceContext) websocket.HandlerFunc {
+	return func(srv *websocket.Server, conn *websocket.Conn, msg *websocket.Message) {
+		var data ws.Edit
+		if err := mapstructure.Decode(msg.Data, &data); err != nil {
+			srv.Send(websocket.NewErrMessage(err), conn)
+			return
+		}
+		_, err := svc.Im.EditMsg(context.Background(), &imclient.EditMsgReq{
+			UserId:  conn.Uid,
+			MsgId:   data.MsgId,
+			Content: data.Content,
+		})
+		if err != nil {
+			srv.Send(websocket.NewErrMessage(err), conn)
+			return
+		}
+	}
+}
+
+func React(svc *svc.ServiceContext) websocket.HandlerFunc {
+	return func(srv *websocket.Server, conn *websocket.Conn, msg *websocket.Message) {
+		var data ws.React
+		if err := mapstructure.Decode(msg.Data, &data); err != nil {
+			srv.Send(websocket.NewErrMessage(err), conn)
+			return
+		}
+		_, err := svc.Im.ReactMsg(context.Background(), &imclient.ReactMsgReq{
+			UserId: conn.Uid,
+			MsgId:  data.MsgId,
+			Emoji:  data.Emoji,
+			Remove: data.Remove,
+		})
+		if err != nil {
+			srv.Send(websocket.NewErrMessage(err), conn)
+			return
+		}
+	}
+}
+
+func Draft(svc *svc.ServiceContext) websocket.HandlerFunc {
+	return func(srv *websocket.Server, conn *websocket.Conn, msg *websocket.Message) {
+		var data ws.Draft
+		if err := mapstructure.Decode(msg.Data, &data); err != nil {
+			srv.Send(websocket.NewErrMessage(err), conn)
+			return
+		}
+		_, err := svc.Im.SaveDraft(context.Background(), &imclient.SaveDraftReq{
+			UserId:         conn.Uid,
+			ConversationId: data.ConversationId,
+			Content:        data.Content,
+		})
+		if err != nil {
+			srv.Send(websocket.NewErrMessage(err), conn)
+			return
+		}
+	}
+}
+
+func SubscribeThread(svc *svc.ServiceContext) websocket.HandlerFunc {
+	return func(srv *websocket.Server, conn *websocket.Conn, msg *websocket.Message) {
+		var data ws.SubscribeThread
+		if err := mapstructure.Decode(msg.Data, &data); err != nil {
+			srv.Send(websocket.NewErrMessage(err), conn)
+			return
+		}
+		if data.Unsubscribe {
+			conn.Unsubscribe(ws.ThreadTopic(data.MsgId))
+			return
+		}
+
+		root, err := svc.ChatLogModel.FindOne(context.Background(), data.MsgId)
+		if err != nil || root.ChatType != constants.GroupChatType || root.ThreadId != "" {
+			srv.Send(websocket.NewErrMessage(ErrThreadNotFound), conn)
+			return
+		}
+		members, err := groupMembers(svc, root.RecvId)
+		if err != nil {
+			srv.Send(websocket.NewErrMessage(err), conn)
+			return
+		}
+		if _, ok := members[conn.Uid]; !ok {
+			srv.Send(websocket.NewErrMessage(ErrNotGroupMember), conn)
+			return
+		}
+		conn.Subscribe(ws.ThreadTopic(data.MsgId))
+	}
+}
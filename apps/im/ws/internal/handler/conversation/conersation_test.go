@@ -0,0 +1,137 @@
+package conversation
+
+import (
+	"context"
+	"easy-chat/apps/im/authz"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/im/ws/internal/svc"
+	"easy-chat/pkg/constants"
+	"easy-chat/pkg/wuid"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type fakeChatLogModel struct {
+	immodels.ChatLogModel
+	chatLogs map[string]*immodels.ChatLog
+}
+
+func (f *fakeChatLogModel) FindOne(ctx context.Context, id string) (*immodels.ChatLog, error) {
+	if chatLog, ok := f.chatLogs[id]; ok {
+		return chatLog, nil
+	}
+	return nil, immodels.ErrNotFound
+}
+
+func TestChatTarget(t *testing.T) {
+	social := &fakeSocial{}
+	svcCtx := &svc.ServiceContext{Social: social, Auth: authz.NewAuthorizer(social)}
+
+	tests := []struct {
+		name     string
+		uid      string
+		chatType constants.ChatType
+		recvId   string
+		clientId string
+		want     string
+		wantErr  error
+	}{
+		{name: "friend", uid: "u1", chatType: constants.SingleChatType, recvId: "u2", want: wuid.CombineId("u1", "u2")},
+		{name: "friend with conversation", uid: "u1", chatType: constants.SingleChatType, recvId: "u2", clientId: wuid.CombineId("u1", "u2"), want: wuid.CombineId("u1", "u2")},
+		{name: "group member", uid: "u1", chatType: constants.GroupChatType, recvId: "g1", want: "g1"},
+		{name: "other conversation", uid: "u1", chatType: constants.GroupChatType, recvId: "g1", clientId: "g2", wantErr: ErrChatTarget},
+		{name: "self", uid: "u1", chatType: constants.SingleChatType, recvId: "u1", wantErr: ErrChatTarget},
+		{name: "stranger", uid: "u1", chatType: constants.SingleChatType, recvId: "u3", wantErr: authz.ErrNotFriend},
+		{name: "not group member", uid: "u4", chatType: constants.GroupChatType, recvId: "g1", clientId: "g1", wantErr: authz.ErrNotMember},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := chatTarget(svcCtx, tt.uid, tt.chatType, tt.recvId, tt.clientId)
+			if errors.Cause(err) != tt.wantErr {
+				t.Fatalf("chatTarget() err = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("chatTarget() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindThread(t *testing.T) {
+	chatLogs := make(map[string]*immodels.ChatLog)
+	newChatLog := func(conversationId string, status constants.MsgStatus, threadId string) string {
+		id := primitive.NewObjectID()
+		chatLogs[id.Hex()] = &immodels.ChatLog{ID: id, ConversationId: conversationId, ChatType: constants.GroupChatType,
+			Status: status, ThreadId: threadId}
+		return id.Hex()
+	}
+	root := newChatLog("g1", constants.NormalMsgStatus, "")
+	other := newChatLog("g2", constants.NormalMsgStatus, "")
+	recalled := newChatLog("g1", constants.RecallMsgStatus, "")
+	reply := newChatLog("g1", constants.NormalMsgStatus, root)
+	svcCtx := &svc.ServiceContext{ChatLogModel: &fakeChatLogModel{chatLogs: chatLogs}}
+
+	tests := []struct {
+		name     string
+		chatType constants.ChatType
+		msgId    string
+		wantErr  error
+	}{
+		{"root", constants.GroupChatType, root, nil},
+		{"single chat", constants.SingleChatType, root, ErrThreadChatType},
+		{"not found", constants.GroupChatType, primitive.NewObjectID().Hex(), ErrThreadNotFound},
+		{"other conversation", constants.GroupChatType, other, ErrThreadNotFound},
+		{"recalled", constants.GroupChatType, recalled, ErrThreadRecalled},
+		{"nested", constants.GroupChatType, reply, ErrThreadNested},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := findThread(svcCtx, tt.chatType, "g1", tt.msgId)
+			if err != tt.wantErr {
+				t.Fatalf("findThread() err = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && got.ID.Hex() != tt.msgId {
+				t.Errorf("findThread() = %v, want %v", got.ID.Hex(), tt.msgId)
+			}
+		})
+	}
+}
+
+func TestCheckMention(t *testing.T) {
+	svcCtx := &svc.ServiceContext{Social: &fakeSocial{}}
+
+	tests := []struct {
+		name     string
+		uid      string
+		chatType constants.ChatType
+		mention  *immodels.Mention
+		want     *immodels.Mention
+		wantErr  error
+	}{
+		{name: "members", uid: "u2", chatType: constants.GroupChatType, mention: &immodels.Mention{UserIds: []string{"u1", "u3"}},
+			want: &immodels.Mention{UserIds: []string{"u1", "u3"}}},
+		// 自己、非成员与重复的成员不会被提及
+		{name: "filtered", uid: "u2", chatType: constants.GroupChatType, mention: &immodels.Mention{UserIds: []string{"u2", "u4", "u3", "u3"}},
+			want: &immodels.Mention{UserIds: []string{"u3"}}},
+		{name: "only self", uid: "u2", chatType: constants.GroupChatType, mention: &immodels.Mention{UserIds: []string{"u2"}}},
+		{name: "all by creator", uid: "u1", chatType: constants.GroupChatType, mention: &immodels.Mention{All: true},
+			want: &immodels.Mention{All: true}},
+		{name: "all by member", uid: "u2", chatType: constants.GroupChatType, mention: &immodels.Mention{All: true}, wantErr: ErrMentionAll},
+		{name: "not member", uid: "u4", chatType: constants.GroupChatType, mention: &immodels.Mention{UserIds: []string{"u1"}}, wantErr: ErrNotGroupMember},
+		{name: "single chat", uid: "u1", chatType: constants.SingleChatType, mention: &immodels.Mention{UserIds: []string{"u2"}}, wantErr: ErrMentionChatType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := checkMention(svcCtx, tt.chatType, "g1", tt.uid, tt.mention)
+			if err != tt.wantErr {
+				t.Fatalf("checkMention() err = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("checkMention() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package conversation
+
+import (
+	"context"
+	"easy-chat/apps/im/ws/internal/svc"
+	"easy-chat/apps/im/ws/internal/typing"
+	"easy-chat/apps/im/ws/websocket"
+	"easy-chat/apps/im/ws/ws"
+	"easy-chat/pkg/constants"
+	"errors"
+	"github.com/mitchellh/mapstructure"
+	"time"
+)
+
+var ErrTypingTarget = errors.New("输入的会话有误")
+
+// Typing 转发正在输入的状态，只在内存中处理，不经过消息队列也不落库
+func Typing(svc *svc.ServiceContext) websocket.HandlerFunc {
+	return func(srv *websocket.Server, conn *websocket.Conn, msg *websocket.Message) {
+		var data ws.Typing
+		if err := mapstructure.Decode(msg.Data, &data); err != nil {
+			srv.Send(websocket.NewErrMessage(err), conn)
+			return
+		}
+		conversationId, err := typingConversationId(conn.Uid, &data)
+		if err != nil {
+			srv.Send(websocket.NewErrMessage(err), conn)
+			return
+		}
+
+		if data.Stop {
+			if signal := svc.Typing.Stop(conn.Uid, conversationId); signal != nil {
+				relayTyping(srv, signal, false)
+			}
+			return
+		}
+		signal, err := svc.Typing.Start(conn.Uid, conversationId,
+			func() (*typing.Signal, error) {
+				return typingSignal(svc, conn.Uid, conversationId, &data)
+			},
+			func(signal *typing.Signal) {
+				relayTyping(srv, signal, false)
+			},
+		)
+		if err != nil {
+			srv.Send(websocket.NewErrMessage(err), conn)
+			return
+		}
+		if signal != nil {
+			relayTyping(srv, signal, true)
+		}
+	}
+}
+
+// typingConversationId 校验输入的会话，与发送消息的会话相同
+func typingConversationId(uid string, data *ws.Typing) (string, error) {
+	conversationId := targetConversationId(uid, data.ChatType, data.RecvId)
+	if conversationId == "" || (data.ConversationId != "" && data.ConversationId != conversationId) {
+		return "", ErrTypingTarget
+	}
+	return conversationId, nil
+}
+
+// typingSignal 查询转发的接收者，与发送消息的权限相同：私聊需要是好友，群聊需要是群成员
+func typingSignal(svc *svc.ServiceContext, uid, conversationId string, data *ws.Typing) (*typing.Signal, error) {
+	if err := svc.Auth.CheckSend(context.Background(), uid, data.ChatType, data.RecvId); err != nil {
+		return nil, err
+	}
+
+	signal := &typing.Signal{Uid: uid, ConversationId: conversationId, ChatType: data.ChatType}
+	if data.ChatType == constants.SingleChatType {
+		signal.RecvIds = []string{data.RecvId}
+		return signal, nil
+	}
+
+	members, err := groupMembers(svc, data.RecvId)
+	if err != nil {
+		return nil, err
+	}
+	for id := range members {
+		if id != uid {
+			signal.RecvIds = append(signal.RecvIds, id)
+		}
+	}
+	return signal, nil
+}
+
+// relayTyping 推送给接收者在线的设备，离线的接收者直接忽略
+func relayTyping(srv *websocket.Server, signal *typing.Signal, isTyping bool) {
+	now := time.Now().UnixMilli()
+	for _, id := range signal.RecvIds {
+		rconns := srv.GetConns(id)
+		if len(rconns) == 0 {
+			continue
+		}
+		srv.Send(websocket.NewMessage(signal.Uid, &ws.Event{
+			Version:        ws.PushVersion,
+			Kind:           constants.ContentTyping,
+			ConversationId: signal.ConversationId,
+			ChatType:       signal.ChatType,
+			SendId:         signal.Uid,
+			RecvId:         id,
+			SendTime:       now,
+			Payload:        &ws.TypingPayload{Typing: isTyping},
+		}), rconns...)
+	}
+}
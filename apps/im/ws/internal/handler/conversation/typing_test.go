@@ -0,0 +1,78 @@
+package conversation
+
+import (
+	"context"
+	"easy-chat/apps/im/authz"
+	"easy-chat/apps/im/ws/internal/svc"
+	"easy-chat/apps/im/ws/ws"
+	"easy-chat/apps/social/rpc/socialclient"
+	"easy-chat/pkg/constants"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+// fakeSocial u1 的好友只有 u2，群 g1 的成员为群主 u1 与普通成员 u2、u3
+type fakeSocial struct {
+	socialclient.Social
+}
+
+func (f *fakeSocial) GroupUsers(ctx context.Context, in *socialclient.GroupUsersReq, opts ...grpc.CallOption) (*socialclient.GroupUsersResp, error) {
+	var list []*socialclient.GroupMembers
+	if in.GroupId == "g1" {
+		for _, uid := range []string{"u1", "u2", "u3"} {
+			roleLevel := constants.AtLargeGroupRoleLevel
+			if uid == "u1" {
+				roleLevel = constants.CreatorGroupRoleLevel
+			}
+			list = append(list, &socialclient.GroupMembers{GroupId: "g1", UserId: uid, RoleLevel: int32(roleLevel)})
+		}
+	}
+	return &socialclient.GroupUsersResp{List: list}, nil
+}
+
+func (f *fakeSocial) FriendList(ctx context.Context, in *socialclient.FriendListReq, opts ...grpc.CallOption) (*socialclient.FriendListResp, error) {
+	var list []*socialclient.Friends
+	if in.UserId == "u1" {
+		list = append(list, &socialclient.Friends{UserId: "u1", FriendUid: "u2"})
+	}
+	return &socialclient.FriendListResp{List: list}, nil
+}
+
+func TestTypingSignal(t *testing.T) {
+	social := &fakeSocial{}
+	svcCtx := &svc.ServiceContext{Social: social, Auth: authz.NewAuthorizer(social)}
+
+	tests := []struct {
+		name     string
+		uid      string
+		chatType constants.ChatType
+		recvId   string
+		want     []string
+		wantErr  error
+	}{
+		{name: "friend", uid: "u1", chatType: constants.SingleChatType, recvId: "u2", want: []string{"u2"}},
+		{name: "stranger", uid: "u1", chatType: constants.SingleChatType, recvId: "u3", wantErr: authz.ErrNotFriend},
+		{name: "group member", uid: "u1", chatType: constants.GroupChatType, recvId: "g1", want: []string{"u2", "u3"}},
+		{name: "not group member", uid: "u4", chatType: constants.GroupChatType, recvId: "g1", wantErr: authz.ErrNotMember},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &ws.Typing{ChatType: tt.chatType, RecvId: tt.recvId}
+			signal, err := typingSignal(svcCtx, tt.uid, "c1", data)
+			if errors.Cause(err) != tt.wantErr {
+				t.Fatalf("typingSignal() err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			sort.Strings(signal.RecvIds)
+			if !reflect.DeepEqual(signal.RecvIds, tt.want) {
+				t.Errorf("typingSignal() recvIds = %v, want %v", signal.RecvIds, tt.want)
+			}
+		})
+	}
+}
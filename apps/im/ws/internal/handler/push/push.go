@@ -17,45 +17,121 @@ func Push(svc *svc.ServiceContext) websocket.HandlerFunc {
 			srv.Send(websocket.NewErrMessage(err))
 			return
 		}
-		//发送的目标
-		switch data.ChatType {
-		case constants.SingleChatType:
+		//发送的目标，角标与草稿只推送给 RecvId 对应的用户
+		switch {
+		case data.ContentType.Private(), data.ChatType == constants.SingleChatType:
 			single(srv, &data, data.RecvId)
-		case constants.GroupChatType:
+		case data.ChatType == constants.GroupChatType:
 			group(srv, &data)
 		}
+		//同步到发送者的其他设备
+		if syncSend(&data) {
+			single(srv, &data, data.SendId)
+		}
+	}
+}
+
+// Batch 处理批量推送
+//
+// 按接收者聚合消息，每个在线接收者只会收到一个包含多个推送信封的 push.batch 帧，
+// 信封在帧内保持消息队列中的顺序。帧在处理中直接发送，同一连接上的帧与消息队列的顺序一致。
+func Batch(svc *svc.ServiceContext) websocket.HandlerFunc {
+	return func(srv *websocket.Server, conn *websocket.Conn, msg *websocket.Message) {
+		var data ws.PushBatch
+		if err := mapstructure.Decode(msg.Data, &data); err != nil {
+			srv.Send(websocket.NewErrMessage(err), conn)
+			return
+		}
+
+		for id, pushes := range groupByRecv(data.List) {
+			//目标离线时没有连接，用户的每个在线设备各收到一帧
+			for _, rconn := range srv.GetConns(id) {
+				events := make([]*ws.Event, 0, len(pushes))
+				for _, push := range pushes {
+					if subscribed(rconn, push) {
+						events = append(events, ws.NewEvent(push))
+					}
+				}
+				if len(events) == 0 {
+					continue
+				}
+				//不能交给 Schedule 并发发送，否则后一批的帧可能先于前一批到达
+				err := srv.Send(&websocket.Message{
+					FrameType: websocket.FrameData,
+					Method:    "push.batch",
+					FormId:    constants.SYSTEM_ROOT_UID,
+					Data:      events,
+				}, rconn)
+				if err != nil {
+					srv.Errorf("push batch to %v err %v", id, err)
+				}
+			}
+		}
+	}
+}
+
+// groupByRecv 按接收者聚合推送，每个接收者的推送保持原有的顺序
+func groupByRecv(list []*ws.Push) map[string][]*ws.Push {
+	recvPushes := make(map[string][]*ws.Push)
+	for _, push := range list {
+		switch {
+		case push.ContentType.Private(), push.ChatType == constants.SingleChatType:
+			recvPushes[push.RecvId] = append(recvPushes[push.RecvId], push)
+		case push.ChatType == constants.GroupChatType:
+			for _, id := range push.RecvIds {
+				recvPushes[id] = append(recvPushes[id], push)
+			}
+		}
+		if syncSend(push) {
+			recvPushes[push.SendId] = append(recvPushes[push.SendId], push)
+		}
+	}
+	return recvPushes
+}
+
+// syncSend 是否还需要推送给发送者，发送者已经是接收者时不重复推送
+func syncSend(push *ws.Push) bool {
+	if !push.SyncSend || push.SendId == "" || push.SendId == push.RecvId {
+		return false
+	}
+	for _, id := range push.RecvIds {
+		if id == push.SendId {
+			return false
+		}
 	}
+	return true
 }
 
 // 处理私聊
 func single(srv *websocket.Server, data *ws.Push, recvId string) error {
-	rconn := srv.GetConn(recvId)
-	if rconn == nil {
-		//todo：目标离线
+	//目标离线时没有连接，推送给用户的每个在线设备
+	var conns []*websocket.Conn
+	for _, rconn := range srv.GetConns(recvId) {
+		if subscribed(rconn, data) {
+			conns = append(conns, rconn)
+		}
+	}
+	if len(conns) == 0 {
 		return nil
 	}
 	srv.Infof("push msg %v", data)
-	return srv.Send(websocket.NewMessage(data.SendId, &ws.Chat{
-		ConversationId: data.ConversationId,
-		ChatType:       data.ChatType,
-		SendTime:       data.SendTime,
-		Msg: ws.Msg{
-			ReadRecords: data.ReadRecords,
-			MsgId:       data.MsgId,
-			MType:       data.MType,
-			Content:     data.Content,
-		},
-	}), rconn)
+	return srv.Send(websocket.NewMessage(data.SendId, ws.NewEvent(data)), conns...)
 }
 
-// 处理群聊
+// 处理群聊，与批量推送相同，依次发送给每个成员以保持同一连接上推送的顺序
 func group(srv *websocket.Server, data *ws.Push) error {
 	for _, id := range data.RecvIds {
-		func(id string) {
-			srv.Schedule(func() { //这里用到了go-zero的Schedule函数，可以并发执行函数
-				single(srv, data, id)
-			})
-		}(id)
+		if err := single(srv, data, id); err != nil {
+			srv.Errorf("push msg to %v err %v", id, err)
+		}
 	}
 	return nil
 }
+
+// subscribed 话题中的回复只推送给订阅了该话题的连接
+func subscribed(conn *websocket.Conn, push *ws.Push) bool {
+	if push.ThreadId == "" || push.ContentType != constants.ContentChatMsg {
+		return true
+	}
+	return conn.Subscribed(ws.ThreadTopic(push.ThreadId))
+}
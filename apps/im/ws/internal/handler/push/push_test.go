@@ -0,0 +1,113 @@
+package push
+
+import (
+	"easy-chat/apps/im/ws/websocket"
+	"easy-chat/apps/im/ws/ws"
+	"easy-chat/pkg/constants"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	gws "github.com/gorilla/websocket"
+)
+
+// userAuth 以请求中的 userId 作为用户ID
+type userAuth struct{}
+
+func (userAuth) Auth(w http.ResponseWriter, r *http.Request) bool { return true }
+
+func (userAuth) UserId(r *http.Request) string { return r.URL.Query().Get("userId") }
+
+func TestGroupByRecv(t *testing.T) {
+	list := []*ws.Push{
+		{ChatType: constants.SingleChatType, RecvId: "u2", Content: "1"},
+		{ChatType: constants.GroupChatType, RecvId: "g1", RecvIds: []string{"u2", "u3"}, Content: "2"},
+		{ChatType: constants.GroupChatType, RecvId: "u3", ContentType: constants.ContentBadge, Content: "3"},
+		{ChatType: constants.SingleChatType, RecvId: "u2", Content: "4"},
+		{ChatType: constants.GroupChatType, SendId: "u1", RecvId: "g1", RecvIds: []string{"u2"}, SyncSend: true, Content: "5"},
+		{ChatType: constants.SingleChatType, SendId: "u1", RecvId: "u2", SyncSend: true, Content: "6"},
+		{ChatType: constants.GroupChatType, SendId: "u3", RecvId: "g1", RecvIds: []string{"u2", "u3"}, SyncSend: true, Content: "7"},
+	}
+
+	got := make(map[string][]string)
+	for id, pushes := range groupByRecv(list) {
+		for _, push := range pushes {
+			got[id] = append(got[id], push.Content)
+		}
+	}
+	// 每个接收者只有一组推送，角标只推送给 RecvId 对应的用户，同步给发送者的推送不会重复
+	want := map[string][]string{
+		"u1": {"5", "6"},
+		"u2": {"1", "2", "4", "5", "6", "7"},
+		"u3": {"2", "3", "7"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("groupByRecv() = %v, want %v", got, want)
+	}
+}
+
+func TestSubscribed(t *testing.T) {
+	conn := &websocket.Conn{}
+	conn.Subscribe(ws.ThreadTopic("t1"))
+
+	tests := []struct {
+		name string
+		push *ws.Push
+		want bool
+	}{
+		{"chat", &ws.Push{ContentType: constants.ContentChatMsg}, true},
+		{"subscribed reply", &ws.Push{ThreadId: "t1", ContentType: constants.ContentChatMsg}, true},
+		{"unsubscribed reply", &ws.Push{ThreadId: "t2", ContentType: constants.ContentChatMsg}, false},
+		// 话题的更新事件推送给所有成员
+		{"thread event", &ws.Push{ThreadId: "t2", ContentType: constants.ContentThread}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := subscribed(conn, tt.push); got != tt.want {
+				t.Errorf("subscribed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBatch_Order(t *testing.T) {
+	srv := websocket.NewServer("", websocket.WithServerAuthentication(userAuth{}))
+	ts := httptest.NewServer(http.HandlerFunc(srv.ServerWs))
+	defer ts.Close()
+
+	client, _, err := gws.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http")+"?userId=u2", nil)
+	if err != nil {
+		t.Fatalf("Dial() err = %v", err)
+	}
+	defer client.Close()
+	for deadline := time.Now().Add(time.Second); len(srv.GetConns("u2")) == 0; time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("connection not registered")
+		}
+	}
+
+	// 依次处理的批量推送在同一连接上按顺序到达
+	const n = 50
+	batch := Batch(nil)
+	for i := 1; i <= n; i++ {
+		batch(srv, nil, &websocket.Message{Data: map[string]any{
+			"list": []map[string]any{{"chatType": constants.SingleChatType, "recvId": "u2", "seq": i}},
+		}})
+	}
+	client.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for i := 1; i <= n; i++ {
+		var frame struct {
+			Method string
+			Data   []struct{ Seq int64 }
+		}
+		if err := client.ReadJSON(&frame); err != nil {
+			t.Fatalf("ReadJSON() err = %v", err)
+		}
+		if frame.Method != "push.batch" || len(frame.Data) != 1 || frame.Data[0].Seq != int64(i) {
+			t.Fatalf("frame %d = %+v, want push.batch with seq %d", i, frame, i)
+		}
+	}
+}
@@ -14,6 +14,10 @@ func RegisterHandlers(srv *websocket.Server, svc *svc.ServiceContext) {
 			Method:  "user.online",
 			Handler: user.Online(svc),
 		},
+		{
+			Method:  "user.subscribePresence",
+			Handler: user.SubscribePresence(svc),
+		},
 		{
 			Method:  "conversation.chat",
 			Handler: conversation.Chat(svc),
@@ -22,9 +26,37 @@ func RegisterHandlers(srv *websocket.Server, svc *svc.ServiceContext) {
 			Method:  "conversation.markRead",
 			Handler: conversation.MarkRead(svc),
 		},
+		{
+			Method:  "conversation.recall",
+			Handler: conversation.Recall(svc),
+		},
+		{
+			Method:  "conversation.edit",
+			Handler: conversation.Edit(svc),
+		},
+		{
+			Method:  "conversation.react",
+			Handler: conversation.React(svc),
+		},
+		{
+			Method:  "conversation.draft",
+			Handler: conversation.Draft(svc),
+		},
+		{
+			Method:  "conversation.typing",
+			Handler: conversation.Typing(svc),
+		},
+		{
+			Method:  "conversation.subscribeThread",
+			Handler: conversation.SubscribeThread(svc),
+		},
 		{
 			Method:  "push",
 			Handler: push.Push(svc),
 		},
+		{
+			Method:  "push.batch",
+			Handler: push.Batch(svc),
+		},
 	})
 }
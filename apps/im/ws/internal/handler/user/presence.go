@@ -0,0 +1,76 @@
+package user
+
+import (
+	"context"
+	"easy-chat/apps/im/ws/internal/online"
+	"easy-chat/apps/im/ws/internal/svc"
+	"easy-chat/apps/im/ws/websocket"
+	"easy-chat/apps/im/ws/ws"
+	"easy-chat/apps/social/rpc/socialclient"
+	"easy-chat/pkg/constants"
+	"errors"
+	"github.com/mitchellh/mapstructure"
+)
+
+var ErrPresenceNotFriend = errors.New("只能订阅好友的在线状态")
+
+// SubscribePresence 订阅好友的在线状态，订阅后以一帧 push.batch 推送好友当前的状态
+func SubscribePresence(svc *svc.ServiceContext) websocket.HandlerFunc {
+	return func(srv *websocket.Server, conn *websocket.Conn, msg *websocket.Message) {
+		var data ws.PresenceSubscribe
+		if err := mapstructure.Decode(msg.Data, &data); err != nil {
+			srv.Send(websocket.NewErrMessage(err), conn)
+			return
+		}
+		if data.Unsubscribe {
+			svc.Online.Unsubscribe(conn, data.UserIds)
+			return
+		}
+		if len(data.UserIds) == 0 {
+			return
+		}
+
+		friendList, err := svc.Social.FriendList(context.Background(), &socialclient.FriendListReq{
+			UserId: conn.Uid,
+		})
+		if err != nil {
+			srv.Send(websocket.NewErrMessage(err), conn)
+			return
+		}
+		friends := make(map[string]struct{}, len(friendList.List))
+		for _, friend := range friendList.List {
+			friends[friend.FriendUid] = struct{}{}
+		}
+		for _, uid := range data.UserIds {
+			if _, ok := friends[uid]; !ok {
+				srv.Send(websocket.NewErrMessage(ErrPresenceNotFriend), conn)
+				return
+			}
+		}
+
+		// 先订阅再查询，避免查询与订阅之间的状态变化丢失
+		svc.Online.Subscribe(conn, data.UserIds)
+		statuses, err := svc.Online.Lookup(context.Background(), data.UserIds)
+		if err != nil {
+			srv.Send(websocket.NewErrMessage(err), conn)
+			return
+		}
+		events := make([]*ws.Event, 0, len(statuses))
+		for _, uid := range data.UserIds {
+			if status, ok := statuses[uid]; ok {
+				events = append(events, online.NewEvent(&ws.PresencePayload{
+					UserId:   status.UserId,
+					Online:   status.Online,
+					Devices:  status.Devices,
+					LastSeen: status.LastSeen,
+				}))
+			}
+		}
+		srv.Send(&websocket.Message{
+			FrameType: websocket.FrameData,
+			Method:    "push.batch",
+			FormId:    constants.SYSTEM_ROOT_UID,
+			Data:      events,
+		}, conn)
+	}
+}
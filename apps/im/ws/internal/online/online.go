@@ -0,0 +1,289 @@
+// 在线状态的维护与订阅，连接建立与关闭时更新 presence 存储并通知订阅者
+
+package online
+
+import (
+	"context"
+	"easy-chat/apps/im/ws/websocket"
+	"easy-chat/apps/im/ws/ws"
+	"easy-chat/pkg/constants"
+	"easy-chat/pkg/presence"
+	"sync"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// Store 在线状态的存储，由 presence.Store 实现
+type Store interface {
+	Connect(ctx context.Context, uid, device string) (bool, error)
+	Disconnect(ctx context.Context, uid, device string) (bool, int64, error)
+	Heartbeat(ctx context.Context, devices []presence.Device) error
+	Sweep(ctx context.Context) ([]*presence.Status, error)
+	Lookup(ctx context.Context, uids []string) (map[string]*presence.Status, error)
+}
+
+// Notifier 在 websocket 服务之间广播在线状态的变化，由 presence.Notifier 实现
+type Notifier interface {
+	Publish(ctx context.Context, status *presence.Status) error
+	Subscribe(ctx context.Context, fn func(status *presence.Status)) error
+}
+
+// Hub 维护本服务上连接的在线状态，并把状态变化推送给订阅者
+//
+// 作为 websocket.ConnListener 使用：
+//   - 连接建立时设备上线，用户由离线变为在线时广播变化
+//   - 连接关闭时设备离线并取消该连接的订阅，用户由在线变为离线时广播变化
+//   - 定时为本服务上的连接心跳续期，服务异常退出后设备在 TTL 之后自动离线
+//   - 定时清理其他服务异常退出后过期的设备，用户因此离线时广播变化
+//
+// 订阅者与被订阅的用户可能连接在不同的服务上，变化经 Notifier 广播到所有服务，
+// 每个服务收到后推送给本服务上的订阅者。
+type Hub struct {
+	mu        sync.Mutex
+	store     Store
+	notifier  Notifier
+	heartbeat time.Duration
+	send      func(event *ws.Event, conns ...*websocket.Conn)
+
+	conns         map[*websocket.Conn]struct{}
+	subscribers   map[string]map[*websocket.Conn]struct{} // 被订阅的用户到订阅的连接
+	subscriptions map[*websocket.Conn]map[string]struct{} // 连接到订阅的用户，用于关闭时清理
+
+	ctx      context.Context
+	cancel   context.CancelFunc
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewHub heartbeat 为心跳的间隔，需小于存储的 TTL
+func NewHub(store Store, notifier Notifier, heartbeat time.Duration) *Hub {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Hub{
+		store:         store,
+		notifier:      notifier,
+		heartbeat:     heartbeat,
+		send:          func(*ws.Event, ...*websocket.Conn) {},
+		conns:         make(map[*websocket.Conn]struct{}),
+		subscribers:   make(map[string]map[*websocket.Conn]struct{}),
+		subscriptions: make(map[*websocket.Conn]map[string]struct{}),
+		ctx:           ctx,
+		cancel:        cancel,
+		done:          make(chan struct{}),
+	}
+}
+
+// Start 订阅其他服务广播的变化，通过 srv 推送并开始心跳，需在 srv 接受连接之前调用
+func (h *Hub) Start(srv *websocket.Server) error {
+	h.send = func(event *ws.Event, conns ...*websocket.Conn) {
+		if err := srv.Send(websocket.NewMessage(event.SendId, event), conns...); err != nil {
+			logx.Errorf("push presence %v err %v", event.Payload, err)
+		}
+	}
+	if err := h.notifier.Subscribe(h.ctx, h.onChange); err != nil {
+		return err
+	}
+	go h.run()
+	return nil
+}
+
+// Stop 停止心跳并取消订阅
+func (h *Hub) Stop() {
+	h.stopOnce.Do(func() {
+		h.cancel()
+		close(h.done)
+	})
+}
+
+func (h *Hub) OnConnect(conn *websocket.Conn) {
+	h.mu.Lock()
+	h.conns[conn] = struct{}{}
+	h.mu.Unlock()
+
+	online, err := h.store.Connect(context.Background(), conn.Uid, conn.Device)
+	if err != nil {
+		logx.Errorf("presence connect uid %v device %v err %v", conn.Uid, conn.Device, err)
+		return
+	}
+	if online {
+		h.publish(&presence.Status{
+			UserId:   conn.Uid,
+			Online:   true,
+			Devices:  []string{conn.Device},
+			LastSeen: time.Now().UnixMilli(),
+		})
+	}
+}
+
+func (h *Hub) OnClose(conn *websocket.Conn, replaced bool) {
+	h.mu.Lock()
+	delete(h.conns, conn)
+	h.unsubscribe(conn, nil)
+	h.mu.Unlock()
+
+	if replaced {
+		return
+	}
+	offline, lastSeen, err := h.store.Disconnect(context.Background(), conn.Uid, conn.Device)
+	if err != nil {
+		logx.Errorf("presence disconnect uid %v device %v err %v", conn.Uid, conn.Device, err)
+		return
+	}
+	if offline {
+		h.publish(&presence.Status{
+			UserId:   conn.Uid,
+			LastSeen: lastSeen,
+		})
+	}
+}
+
+// Subscribe 连接订阅用户的在线状态变化，调用方需校验是否可以订阅
+func (h *Hub) Subscribe(conn *websocket.Conn, uids []string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// 连接已关闭时不再订阅，避免关闭之后的订阅无法清理
+	if _, ok := h.conns[conn]; !ok {
+		return
+	}
+	targets, ok := h.subscriptions[conn]
+	if !ok {
+		targets = make(map[string]struct{}, len(uids))
+		h.subscriptions[conn] = targets
+	}
+	for _, uid := range uids {
+		targets[uid] = struct{}{}
+		conns, ok := h.subscribers[uid]
+		if !ok {
+			conns = make(map[*websocket.Conn]struct{})
+			h.subscribers[uid] = conns
+		}
+		conns[conn] = struct{}{}
+	}
+}
+
+// Unsubscribe 取消订阅，uids 为空时取消连接的全部订阅
+func (h *Hub) Unsubscribe(conn *websocket.Conn, uids []string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.unsubscribe(conn, uids)
+}
+
+// Lookup 批量查询用户的在线状态
+func (h *Hub) Lookup(ctx context.Context, uids []string) (map[string]*presence.Status, error) {
+	return h.store.Lookup(ctx, uids)
+}
+
+// unsubscribe 调用方需持有锁
+func (h *Hub) unsubscribe(conn *websocket.Conn, uids []string) {
+	targets := h.subscriptions[conn]
+	if len(uids) == 0 {
+		uids = make([]string, 0, len(targets))
+		for uid := range targets {
+			uids = append(uids, uid)
+		}
+	}
+	for _, uid := range uids {
+		delete(targets, uid)
+		delete(h.subscribers[uid], conn)
+		if len(h.subscribers[uid]) == 0 {
+			delete(h.subscribers, uid)
+		}
+	}
+	if len(targets) == 0 {
+		delete(h.subscriptions, conn)
+	}
+}
+
+// publish 广播状态变化，本服务同样经订阅收到；广播失败时只推送给本服务上的订阅者
+func (h *Hub) publish(status *presence.Status) {
+	if err := h.notifier.Publish(context.Background(), status); err != nil {
+		logx.Errorf("presence publish uid %v err %v", status.UserId, err)
+		h.onChange(status)
+	}
+}
+
+// onChange 收到广播的状态变化
+func (h *Hub) onChange(status *presence.Status) {
+	h.notify(&ws.PresencePayload{
+		UserId:   status.UserId,
+		Online:   status.Online,
+		Devices:  status.Devices,
+		LastSeen: status.LastSeen,
+	})
+}
+
+// notify 推送给订阅了该用户的连接
+func (h *Hub) notify(payload *ws.PresencePayload) {
+	h.mu.Lock()
+	conns := make([]*websocket.Conn, 0, len(h.subscribers[payload.UserId]))
+	for conn := range h.subscribers[payload.UserId] {
+		conns = append(conns, conn)
+	}
+	h.mu.Unlock()
+
+	if len(conns) == 0 {
+		return
+	}
+	h.send(NewEvent(payload), conns...)
+}
+
+// NewEvent 在线状态的推送
+func NewEvent(payload *ws.PresencePayload) *ws.Event {
+	return &ws.Event{
+		Version:  ws.PushVersion,
+		Kind:     constants.ContentPresence,
+		SendId:   payload.UserId,
+		SendTime: time.Now().UnixMilli(),
+		Payload:  payload,
+	}
+}
+
+func (h *Hub) run() {
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-h.done:
+			return
+		case <-ticker.C:
+			h.tick()
+		}
+	}
+}
+
+// tick 心跳续期并清理过期的设备
+func (h *Hub) tick() {
+	if err := h.store.Heartbeat(context.Background(), h.devices()); err != nil {
+		logx.Errorf("presence heartbeat err %v", err)
+	}
+
+	// 清理出错时已经清理的用户同样广播
+	statuses, err := h.store.Sweep(context.Background())
+	if err != nil {
+		logx.Errorf("presence sweep err %v", err)
+	}
+	for _, status := range statuses {
+		h.publish(status)
+	}
+}
+
+// devices 本服务上在线的设备
+func (h *Hub) devices() []presence.Device {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	seen := make(map[presence.Device]struct{}, len(h.conns))
+	devices := make([]presence.Device, 0, len(h.conns))
+	for conn := range h.conns {
+		d := presence.Device{UserId: conn.Uid, Device: conn.Device}
+		if _, ok := seen[d]; ok {
+			continue
+		}
+		seen[d] = struct{}{}
+		devices = append(devices, d)
+	}
+	return devices
+}
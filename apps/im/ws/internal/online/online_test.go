@@ -0,0 +1,254 @@
+package online
+
+import (
+	"context"
+	"easy-chat/apps/im/ws/websocket"
+	"easy-chat/apps/im/ws/ws"
+	"easy-chat/pkg/presence"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeStore 按设备计数的内存存储，expired 为已经过期等待清理的设备
+type fakeStore struct {
+	devices    map[string]map[string]struct{}
+	heartbeats [][]presence.Device
+	expired    []presence.Device
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{devices: make(map[string]map[string]struct{})}
+}
+
+func (s *fakeStore) Connect(ctx context.Context, uid, device string) (bool, error) {
+	devices, ok := s.devices[uid]
+	if !ok {
+		devices = make(map[string]struct{})
+		s.devices[uid] = devices
+	}
+	online := len(devices) == 0
+	devices[device] = struct{}{}
+	return online, nil
+}
+
+func (s *fakeStore) Disconnect(ctx context.Context, uid, device string) (bool, int64, error) {
+	delete(s.devices[uid], device)
+	return len(s.devices[uid]) == 0, 100, nil
+}
+
+func (s *fakeStore) Heartbeat(ctx context.Context, devices []presence.Device) error {
+	s.heartbeats = append(s.heartbeats, devices)
+	return nil
+}
+
+func (s *fakeStore) Sweep(ctx context.Context) ([]*presence.Status, error) {
+	var res []*presence.Status
+	for _, d := range s.expired {
+		if _, ok := s.devices[d.UserId][d.Device]; !ok {
+			continue
+		}
+		delete(s.devices[d.UserId], d.Device)
+		if len(s.devices[d.UserId]) == 0 {
+			res = append(res, &presence.Status{UserId: d.UserId, LastSeen: 200})
+		}
+	}
+	s.expired = nil
+	return res, nil
+}
+
+func (s *fakeStore) Lookup(ctx context.Context, uids []string) (map[string]*presence.Status, error) {
+	res := make(map[string]*presence.Status, len(uids))
+	for _, uid := range uids {
+		res[uid] = &presence.Status{UserId: uid, Online: len(s.devices[uid]) > 0}
+	}
+	return res, nil
+}
+
+// fakeNotifier 同步地把变化广播给所有订阅的服务，err 不为空时模拟广播失败
+type fakeNotifier struct {
+	subscribers []func(status *presence.Status)
+	err         error
+}
+
+func (n *fakeNotifier) Publish(ctx context.Context, status *presence.Status) error {
+	if n.err != nil {
+		return n.err
+	}
+	for _, fn := range n.subscribers {
+		fn(status)
+	}
+	return nil
+}
+
+func (n *fakeNotifier) Subscribe(ctx context.Context, fn func(status *presence.Status)) error {
+	n.subscribers = append(n.subscribers, fn)
+	return nil
+}
+
+type sent struct {
+	payload *ws.PresencePayload
+	conns   []*websocket.Conn
+}
+
+func newTestHub() (*Hub, *[]sent) {
+	return newTestHubWith(newFakeStore(), &fakeNotifier{})
+}
+
+// newTestHubWith 共享存储与广播的多个 Hub 模拟多个 websocket 服务
+func newTestHubWith(store Store, notifier *fakeNotifier) (*Hub, *[]sent) {
+	h := NewHub(store, notifier, time.Second)
+	notifier.Subscribe(context.Background(), h.onChange)
+	var res []sent
+	h.send = func(event *ws.Event, conns ...*websocket.Conn) {
+		res = append(res, sent{payload: event.Payload.(*ws.PresencePayload), conns: conns})
+	}
+	return h, &res
+}
+
+func TestHub_Notify(t *testing.T) {
+	var (
+		h, res   = newTestHub()
+		watcher  = &websocket.Conn{Uid: "u1", Device: "web"}
+		friendA  = &websocket.Conn{Uid: "u2", Device: "web"}
+		friendB  = &websocket.Conn{Uid: "u2", Device: "ios"}
+		stranger = &websocket.Conn{Uid: "u3", Device: "web"}
+	)
+	h.OnConnect(watcher)
+	h.Subscribe(watcher, []string{"u2"})
+
+	h.OnConnect(friendA)
+	h.OnConnect(friendB)
+	h.OnConnect(stranger)
+	if len(*res) != 1 || !(*res)[0].payload.Online || (*res)[0].payload.UserId != "u2" {
+		t.Fatalf("sent = %+v, want u2 online once", *res)
+	}
+	if len((*res)[0].conns) != 1 || (*res)[0].conns[0] != watcher {
+		t.Errorf("sent to %v, want watcher", (*res)[0].conns)
+	}
+
+	// 仍有设备在线时不通知
+	h.OnClose(friendA, false)
+	if len(*res) != 1 {
+		t.Fatalf("sent = %+v after one device closed, want no change", *res)
+	}
+	h.OnClose(friendB, false)
+	if len(*res) != 2 || (*res)[1].payload.Online || (*res)[1].payload.LastSeen != 100 {
+		t.Fatalf("sent = %+v, want u2 offline with last seen", *res)
+	}
+}
+
+func TestHub_Replaced(t *testing.T) {
+	var (
+		h, res  = newTestHub()
+		watcher = &websocket.Conn{Uid: "u1", Device: "web"}
+		old     = &websocket.Conn{Uid: "u2", Device: "web"}
+		renewed = &websocket.Conn{Uid: "u2", Device: "web"}
+	)
+	h.OnConnect(watcher)
+	h.Subscribe(watcher, []string{"u2"})
+	h.OnConnect(old)
+	h.OnConnect(renewed)
+	h.OnClose(old, true)
+	if len(*res) != 1 || !(*res)[0].payload.Online {
+		t.Fatalf("sent = %+v, want only u2 online", *res)
+	}
+	if devices := h.devices(); len(devices) != 2 {
+		t.Errorf("devices() = %v, want u1 and u2 once each", devices)
+	}
+}
+
+func TestHub_Unsubscribe(t *testing.T) {
+	var (
+		h, res  = newTestHub()
+		watcher = &websocket.Conn{Uid: "u1", Device: "web"}
+		friend  = &websocket.Conn{Uid: "u2", Device: "web"}
+	)
+	h.OnConnect(watcher)
+	h.Subscribe(watcher, []string{"u2", "u3"})
+	h.Unsubscribe(watcher, []string{"u2"})
+	h.OnConnect(friend)
+	if len(*res) != 0 {
+		t.Fatalf("sent = %+v after unsubscribe, want none", *res)
+	}
+
+	// 连接关闭后清理全部订阅，之后的订阅也不再生效
+	h.OnClose(watcher, false)
+	h.Subscribe(watcher, []string{"u2"})
+	if len(h.subscribers) != 0 || len(h.subscriptions) != 0 {
+		t.Errorf("subscribers = %v, subscriptions = %v, want empty", h.subscribers, h.subscriptions)
+	}
+}
+
+func TestHub_CrossInstance(t *testing.T) {
+	var (
+		store    = newFakeStore()
+		notifier = &fakeNotifier{}
+		a, resA  = newTestHubWith(store, notifier)
+		b, resB  = newTestHubWith(store, notifier)
+		watcher  = &websocket.Conn{Uid: "u1", Device: "web"}
+		friend   = &websocket.Conn{Uid: "u2", Device: "ios"}
+	)
+	a.OnConnect(watcher)
+	a.Subscribe(watcher, []string{"u2"})
+
+	// 被订阅的用户连接在另一个服务上，变化经广播推送给订阅者所在的服务
+	b.OnConnect(friend)
+	if len(*resA) != 1 || !(*resA)[0].payload.Online || (*resA)[0].conns[0] != watcher {
+		t.Fatalf("sent on a = %+v, want u2 online to watcher", *resA)
+	}
+	if len(*resB) != 0 {
+		t.Errorf("sent on b = %+v, want none", *resB)
+	}
+
+	b.OnClose(friend, false)
+	if len(*resA) != 2 || (*resA)[1].payload.Online {
+		t.Fatalf("sent on a = %+v, want u2 offline", *resA)
+	}
+}
+
+func TestHub_Sweep(t *testing.T) {
+	var (
+		store    = newFakeStore()
+		notifier = &fakeNotifier{}
+		a, resA  = newTestHubWith(store, notifier)
+		b, _     = newTestHubWith(store, notifier)
+		watcher  = &websocket.Conn{Uid: "u1", Device: "web"}
+		friend   = &websocket.Conn{Uid: "u2", Device: "ios"}
+	)
+	a.OnConnect(watcher)
+	a.Subscribe(watcher, []string{"u2"})
+	b.OnConnect(friend)
+
+	// b 异常退出没有离线，设备过期后由 a 清理并广播离线
+	store.expired = []presence.Device{{UserId: "u2", Device: "ios"}}
+	a.tick()
+	if len(*resA) != 2 || (*resA)[1].payload.Online || (*resA)[1].payload.LastSeen != 200 {
+		t.Fatalf("sent on a = %+v, want u2 offline with last seen", *resA)
+	}
+	if len(store.heartbeats) != 1 || len(store.heartbeats[0]) != 1 || store.heartbeats[0][0].UserId != "u1" {
+		t.Errorf("heartbeats = %v, want u1 only", store.heartbeats)
+	}
+
+	// 已经清理的设备不再重复广播
+	a.tick()
+	if len(*resA) != 2 {
+		t.Errorf("sent on a = %+v after second sweep, want no change", *resA)
+	}
+}
+
+func TestHub_PublishFailed(t *testing.T) {
+	var (
+		h, res  = newTestHubWith(newFakeStore(), &fakeNotifier{err: errors.New("unavailable")})
+		watcher = &websocket.Conn{Uid: "u1", Device: "web"}
+		friend  = &websocket.Conn{Uid: "u2", Device: "web"}
+	)
+	h.OnConnect(watcher)
+	h.Subscribe(watcher, []string{"u2"})
+
+	// 广播失败时仍推送给本服务上的订阅者
+	h.OnConnect(friend)
+	if len(*res) != 1 || !(*res)[0].payload.Online {
+		t.Fatalf("sent = %+v, want u2 online", *res)
+	}
+}
@@ -1,9 +1,19 @@
 package svc
 
 import (
+	"easy-chat/apps/im/authz"
 	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/im/rpc/imclient"
 	"easy-chat/apps/im/ws/internal/config"
+	"easy-chat/apps/im/ws/internal/online"
+	"easy-chat/apps/im/ws/internal/typing"
+	"easy-chat/apps/media/rpc/mediaclient"
+	"easy-chat/apps/social/rpc/socialclient"
 	"easy-chat/apps/task/mq/mqclient"
+	"easy-chat/pkg/presence"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+	"github.com/zeromicro/go-zero/zrpc"
+	"time"
 )
 
 type ServiceContext struct {
@@ -11,13 +21,29 @@ type ServiceContext struct {
 	immodels.ChatLogModel
 	mqclient.MsgChatTransferClient
 	mqclient.MsgReadTransferClient
+	imclient.Im
+	socialclient.Social
+	// 校验消息引用的附件
+	Media mediaclient.Media
+	// 校验发送消息与输入状态的权限
+	Auth   *authz.Authorizer
+	Typing *typing.Tracker
+	Online *online.Hub
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
+	store := presence.NewStore(redis.MustNewRedis(c.Redisx), time.Duration(c.Presence.TTL)*time.Second)
+	social := socialclient.NewSocial(zrpc.MustNewClient(c.SocialRpc))
 	return &ServiceContext{
 		Config:                c,
 		ChatLogModel:          immodels.MustChatLogModel(c.Mongo.Url, c.Mongo.Db),
 		MsgChatTransferClient: mqclient.NewMsgChatTransferClient(c.MsgChatTransfer.Addrs, c.MsgChatTransfer.Topic),
 		MsgReadTransferClient: mqclient.NewMsgReadTransferClient(c.MsgReadTransfer.Addrs, c.MsgReadTransfer.Topic),
+		Im:                    imclient.NewIm(zrpc.MustNewClient(c.ImRpc)),
+		Social:                social,
+		Media:                 mediaclient.NewMedia(zrpc.MustNewClient(c.MediaRpc)),
+		Auth:                  authz.NewAuthorizer(social),
+		Typing:                typing.NewTracker(time.Duration(c.Typing.Throttle)*time.Second, time.Duration(c.Typing.Timeout)*time.Second),
+		Online:                online.NewHub(store, presence.NewNotifier(c.Redisx), store.TTL()/3),
 	}
 }
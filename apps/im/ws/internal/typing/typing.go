@@ -0,0 +1,157 @@
+// 正在输入的状态，只在 websocket 服务的内存中转发，不经过消息队列也不落库
+
+package typing
+
+import (
+	"easy-chat/pkg/constants"
+	"sync"
+	"time"
+)
+
+var (
+	// DefaultThrottle 同一用户在同一会话中转发开始输入的最小间隔
+	DefaultThrottle = 3 * time.Second
+	// DefaultTimeout 最后一次开始输入之后没有收到停止输入时，自动停止的时间
+	DefaultTimeout = 6 * time.Second
+)
+
+// clock 抽象时间与计时器，便于在测试中替换为可控的时钟
+type clock interface {
+	Now() time.Time
+	AfterFunc(d time.Duration, f func()) timer
+}
+
+type timer interface {
+	Stop() bool
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) timer {
+	return time.AfterFunc(d, f)
+}
+
+// Signal 需要转发的正在输入的状态
+type Signal struct {
+	Uid            string             // 正在输入的用户
+	ConversationId string             // 输入所在的会话
+	ChatType       constants.ChatType // 聊天的类型
+	RecvIds        []string           // 转发的接收者，私聊为对方，群聊为除自己以外的成员
+}
+
+type key struct{ uid, conversationId string }
+
+type state struct {
+	signal   *Signal
+	relayAt  time.Time // 最近一次转发开始输入的时间
+	timer    timer
+	gen      int // 计时器的代数，用于忽略过期的回调
+	onExpire func(signal *Signal)
+}
+
+// Tracker 记录用户正在输入的会话，负责节流与自动过期
+//
+// 生命周期：
+//   - 开始输入时转发，并开启 timeout 的过期计时
+//   - throttle 内重复的开始输入不再转发，只重新开始过期计时
+//   - 停止输入时转发停止并释放；超过 timeout 没有收到停止输入时自动转发停止并释放
+type Tracker struct {
+	mu       sync.Mutex
+	throttle time.Duration
+	timeout  time.Duration
+	clock    clock
+	states   map[key]*state
+}
+
+// NewTracker throttle 与 timeout 不大于 0 时使用默认值
+func NewTracker(throttle, timeout time.Duration) *Tracker {
+	if throttle <= 0 {
+		throttle = DefaultThrottle
+	}
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Tracker{
+		throttle: throttle,
+		timeout:  timeout,
+		clock:    realClock{},
+		states:   make(map[key]*state),
+	}
+}
+
+// Start 用户开始输入，返回需要转发的信号；在节流间隔内返回 nil，只重新开始过期计时
+//
+// resolve 查询转发的接收者，只在需要转发时调用，且不持有锁；onExpire 在自动过期时调用。
+func (t *Tracker) Start(uid, conversationId string, resolve func() (*Signal, error), onExpire func(signal *Signal)) (*Signal, error) {
+	k := key{uid, conversationId}
+
+	t.mu.Lock()
+	if s, ok := t.states[k]; ok && t.clock.Now().Sub(s.relayAt) < t.throttle {
+		t.schedule(k, s)
+		t.mu.Unlock()
+		return nil, nil
+	}
+	t.mu.Unlock()
+
+	signal, err := resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s, ok := t.states[k]
+	if !ok {
+		s = &state{}
+		t.states[k] = s
+	}
+	s.signal = signal
+	s.relayAt = t.clock.Now()
+	s.onExpire = onExpire
+	t.schedule(k, s)
+	return signal, nil
+}
+
+// Stop 用户停止输入，返回需要转发的信号；用户没有在输入时返回 nil
+func (t *Tracker) Stop(uid, conversationId string) *Signal {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	k := key{uid, conversationId}
+	s, ok := t.states[k]
+	if !ok {
+		return nil
+	}
+	s.timer.Stop()
+	delete(t.states, k)
+	return s.signal
+}
+
+// schedule 重新开始过期计时，调用方需持有锁
+func (t *Tracker) schedule(k key, s *state) {
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	s.gen++
+	gen := s.gen
+	s.timer = t.clock.AfterFunc(t.timeout, func() {
+		t.expire(k, s, gen)
+	})
+}
+
+// expire 过期计时到期，自动停止输入
+func (t *Tracker) expire(k key, s *state, gen int) {
+	t.mu.Lock()
+	if t.states[k] != s || s.gen != gen {
+		t.mu.Unlock()
+		return
+	}
+	delete(t.states, k)
+	t.mu.Unlock()
+
+	s.onExpire(s.signal)
+}
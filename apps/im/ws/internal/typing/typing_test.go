@@ -0,0 +1,176 @@
+package typing
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock 手动推进的时钟，到期的回调在 Advance 中同步执行
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	c       *fakeClock
+	at      time.Time
+	f       func()
+	stopped bool
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) AfterFunc(d time.Duration, f func()) timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &fakeTimer{c: c, at: c.now.Add(d), f: f}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.c.mu.Lock()
+	defer t.c.mu.Unlock()
+
+	active := !t.stopped
+	t.stopped = true
+	return active
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	var (
+		due     []*fakeTimer
+		pending []*fakeTimer
+	)
+	for _, t := range c.timers {
+		switch {
+		case t.stopped:
+		case !t.at.After(c.now):
+			t.stopped = true
+			due = append(due, t)
+		default:
+			pending = append(pending, t)
+		}
+	}
+	c.timers = pending
+	c.mu.Unlock()
+
+	sort.SliceStable(due, func(i, j int) bool { return due[i].at.Before(due[j].at) })
+	for _, t := range due {
+		t.f()
+	}
+}
+
+type recorder struct {
+	resolves int
+	expired  []*Signal
+}
+
+func (r *recorder) resolve() (*Signal, error) {
+	r.resolves++
+	return &Signal{Uid: "u1", ConversationId: "g1", RecvIds: []string{"u2", "u3"}}, nil
+}
+
+func (r *recorder) onExpire(signal *Signal) {
+	r.expired = append(r.expired, signal)
+}
+
+func newTestTracker() (*Tracker, *fakeClock) {
+	c := &fakeClock{now: time.Unix(0, 0)}
+	t := NewTracker(3*time.Second, 6*time.Second)
+	t.clock = c
+	return t, c
+}
+
+func TestTracker_Throttle(t *testing.T) {
+	var (
+		tracker, c = newTestTracker()
+		rec        = new(recorder)
+	)
+
+	if signal, err := tracker.Start("u1", "g1", rec.resolve, rec.onExpire); err != nil || signal == nil {
+		t.Fatalf("Start() = %v, %v, want signal", signal, err)
+	}
+	c.Advance(2 * time.Second)
+	if signal, _ := tracker.Start("u1", "g1", rec.resolve, rec.onExpire); signal != nil {
+		t.Errorf("Start() within throttle = %v, want nil", signal)
+	}
+	c.Advance(time.Second)
+	if signal, _ := tracker.Start("u1", "g1", rec.resolve, rec.onExpire); signal == nil {
+		t.Errorf("Start() after throttle = nil, want signal")
+	}
+	if rec.resolves != 2 {
+		t.Errorf("resolves = %d, want 2", rec.resolves)
+	}
+}
+
+func TestTracker_Expire(t *testing.T) {
+	var (
+		tracker, c = newTestTracker()
+		rec        = new(recorder)
+	)
+
+	tracker.Start("u1", "g1", rec.resolve, rec.onExpire)
+	c.Advance(5 * time.Second)
+	// 节流间隔内的开始输入重新开始过期计时
+	tracker.Start("u1", "g1", rec.resolve, rec.onExpire)
+	c.Advance(5 * time.Second)
+	if len(rec.expired) != 0 {
+		t.Fatalf("expired while typing: %v", rec.expired)
+	}
+
+	c.Advance(time.Second)
+	if len(rec.expired) != 1 || rec.expired[0].ConversationId != "g1" {
+		t.Fatalf("expired = %v, want one signal for g1", rec.expired)
+	}
+	if signal := tracker.Stop("u1", "g1"); signal != nil {
+		t.Errorf("Stop() after expiry = %v, want nil", signal)
+	}
+}
+
+func TestTracker_Stop(t *testing.T) {
+	var (
+		tracker, c = newTestTracker()
+		rec        = new(recorder)
+	)
+
+	if signal := tracker.Stop("u1", "g1"); signal != nil {
+		t.Errorf("Stop() without typing = %v, want nil", signal)
+	}
+	tracker.Start("u1", "g1", rec.resolve, rec.onExpire)
+	if signal := tracker.Stop("u1", "g1"); signal == nil || len(signal.RecvIds) != 2 {
+		t.Errorf("Stop() = %v, want signal to u2 and u3", signal)
+	}
+	c.Advance(10 * time.Second)
+	if len(rec.expired) != 0 {
+		t.Errorf("expired after stop: %v", rec.expired)
+	}
+	// 停止后重新开始输入不受节流限制
+	if signal, _ := tracker.Start("u1", "g1", rec.resolve, rec.onExpire); signal == nil {
+		t.Errorf("Start() after stop = nil, want signal")
+	}
+}
+
+func TestTracker_ResolveErr(t *testing.T) {
+	tracker, _ := newTestTracker()
+	errResolve := errors.New("resolve")
+
+	_, err := tracker.Start("u1", "g1", func() (*Signal, error) { return nil, errResolve }, func(*Signal) {})
+	if err != errResolve {
+		t.Errorf("Start() err = %v, want %v", err, errResolve)
+	}
+	if signal := tracker.Stop("u1", "g1"); signal != nil {
+		t.Errorf("Stop() after failed start = %v, want nil", signal)
+	}
+}
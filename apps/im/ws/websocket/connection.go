@@ -10,6 +10,7 @@ import (
 type Conn struct {
 	idleMu sync.Mutex
 	Uid    string
+	Device string // 连接的设备，同一用户的每个设备最多保留一个连接
 	*websocket.Conn
 	s *Server
 	//当前空闲时间
@@ -22,6 +23,10 @@ type Conn struct {
 	readMessageSeq map[string]*Message
 	message        chan *Message
 
+	//订阅的主题，连接关闭后随之释放
+	topicMu sync.RWMutex
+	topics  map[string]struct{}
+
 	//关闭通道
 	done chan struct{}
 }
@@ -49,6 +54,7 @@ func NewConn(s *Server, w http.ResponseWriter, r *http.Request) *Conn {
 		readMessage:       make([]*Message, 0, 2),
 		readMessageSeq:    make(map[string]*Message, 2),
 		message:           make(chan *Message, 1),
+		topics:            make(map[string]struct{}),
 		done:              make(chan struct{}),
 	}
 	go conn.keepalive()
@@ -97,6 +103,31 @@ func (c *Conn) WriteMessage(messageType int, data []byte) error {
 	return err
 }
 
+// Subscribe 订阅主题
+func (c *Conn) Subscribe(topic string) {
+	c.topicMu.Lock()
+	defer c.topicMu.Unlock()
+	if c.topics == nil {
+		c.topics = make(map[string]struct{})
+	}
+	c.topics[topic] = struct{}{}
+}
+
+// Unsubscribe 取消订阅主题
+func (c *Conn) Unsubscribe(topic string) {
+	c.topicMu.Lock()
+	defer c.topicMu.Unlock()
+	delete(c.topics, topic)
+}
+
+// Subscribed 是否订阅了主题
+func (c *Conn) Subscribed(topic string) bool {
+	c.topicMu.RLock()
+	defer c.topicMu.RUnlock()
+	_, ok := c.topics[topic]
+	return ok
+}
+
 // 关闭连接
 
 func (c *Conn) Close() error {
@@ -9,4 +9,5 @@ const (
 	defaultMaxConnectionIdle = time.Duration(math.MaxInt64)
 	defaultAckTime           = 30 * time.Second
 	defaultConcurrency       = 10
+	defaultDevice            = "default"
 )
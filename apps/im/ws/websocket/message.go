@@ -28,9 +28,9 @@ const (
 type Message struct {
 	Id        string `json:"id"`
 	FrameType `json:"frameType"`
-	AckSeq    int         `json:"ackSeq"`
-	ackTime   time.Time   `json:"ackTime"`
-	errCount  int         `json:"errCount"`
+	AckSeq    int `json:"ackSeq"`
+	ackTime   time.Time
+	errCount  int
 	Method    string      `json:"method"`
 	FormId    string      `json:"formId"`
 	Data      interface{} `json:"data"`
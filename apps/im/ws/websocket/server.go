@@ -47,8 +47,8 @@ func (t AckType) ToString() string {
 //     日志记录器，用于记录服务器的日志信息，包括错误、信息和调试日志。
 //   - connToUser: map[*Conn]string
 //     连接到用户映射表，将每个 WebSocket 连接映射到其对应的用户 ID。
-//   - userToConn: map[string]*Conn
-//     用户到连接映射表，将每个用户 ID 映射到其当前的 WebSocket 连接。
+//   - userToConn: map[string]map[string]*Conn
+//     用户到连接映射表，将每个用户 ID 映射到其各个设备当前的 WebSocket 连接。
 //   - TaskRunner: *threading.TaskRunner
 //     任务运行器，用于管理和执行异步任务。
 //   - RWMutex: sync.RWMutex
@@ -68,7 +68,7 @@ type Server struct {
 	//websocket连接对象存储
 	connToUser map[*Conn]string //从连接找到用户
 
-	userToConn map[string]*Conn //从用户找到连接对象
+	userToConn map[string]map[string]*Conn //从用户找到各个设备的连接对象
 	upgradee   websocket.Upgrader
 	logx.Logger
 }
@@ -84,7 +84,7 @@ func NewServer(addr string, opts ...ServerOptions) *Server {
 		authentication: opt.Authentication,
 		patten:         opt.patten,
 		connToUser:     make(map[*Conn]string),
-		userToConn:     make(map[string]*Conn),
+		userToConn:     make(map[string]map[string]*Conn),
 		upgradee: websocket.Upgrader{
 			CheckOrigin: func(r *http.Request) bool {
 				return true
@@ -122,14 +122,15 @@ func (s *Server) ServerWs(w http.ResponseWriter, r *http.Request) {
 	}
 	//记录连接
 	s.addConn(conn, r)
+	if s.opt.connListener != nil {
+		s.opt.connListener.OnConnect(conn)
+	}
 	//处理连接
 	go s.handlerConn(conn)
 }
 
 // 根据连接对象进行任务处理
 func (s *Server) handlerConn(conn *Conn) {
-	uids := s.GetUsers(conn)
-	conn.Uid = uids[0]
 	//处理任务
 	go s.handlerWrite(conn)
 
@@ -292,26 +293,30 @@ func (s *Server) handlerWrite(conn *Conn) {
 func (s *Server) addConn(conn *Conn, req *http.Request) {
 	//这里解析请求中的userId，原方法中中如果没有就根据时间戳生成id
 	uid := s.authentication.UserId(req)
+	//同一用户可以在多个设备上同时连接，未指定设备时视为同一个默认设备
+	device := req.URL.Query().Get("deviceId")
+	if device == "" {
+		device = defaultDevice
+	}
+	conn.Uid, conn.Device = uid, device
+
 	s.RWMutex.Lock()
 	defer s.RWMutex.Unlock()
-	// 验证用户是否之前登入过
-	if c := s.userToConn[uid]; c != nil {
-		//关闭之前的连接
+	devices, ok := s.userToConn[uid]
+	if !ok {
+		devices = make(map[string]*Conn, 1)
+		s.userToConn[uid] = devices
+	}
+	// 验证该设备是否之前登入过
+	if c := devices[device]; c != nil {
+		//关闭之前的连接，之前的连接不再对应该设备
 		c.Close()
 	}
 	s.connToUser[conn] = uid
-	s.userToConn[uid] = conn
-}
-
-//根据uid获取ws连接
-
-func (s *Server) GetConn(uid string) *Conn {
-	s.RWMutex.RLock()
-	defer s.RWMutex.RUnlock()
-	return s.userToConn[uid]
+	devices[device] = conn
 }
 
-//根据uid组获取ws连接组
+//根据uid组获取所有设备的ws连接组，不包含离线的用户
 
 func (s *Server) GetConns(uids ...string) []*Conn {
 	if len(uids) == 0 {
@@ -323,7 +328,9 @@ func (s *Server) GetConns(uids ...string) []*Conn {
 
 	res := make([]*Conn, 0, len(uids))
 	for _, uid := range uids {
-		res = append(res, s.userToConn[uid])
+		for _, conn := range s.userToConn[uid] {
+			res = append(res, conn)
+		}
 	}
 	return res
 }
@@ -337,9 +344,9 @@ func (s *Server) GetUsers(conns ...*Conn) []string {
 
 	var res []string
 	if len(conns) == 0 {
-		// 获取全部
-		res = make([]string, 0, len(s.connToUser))
-		for _, uid := range s.connToUser {
+		// 获取全部，多个设备在线的用户只返回一次
+		res = make([]string, 0, len(s.userToConn))
+		for uid := range s.userToConn {
 			res = append(res, uid)
 		}
 	} else {
@@ -356,22 +363,31 @@ func (s *Server) GetUsers(conns ...*Conn) []string {
 //关闭ws连接
 
 func (s *Server) Close(conn *Conn) {
-
 	s.RWMutex.Lock()
-	defer s.RWMutex.Unlock()
-
-	uid := s.connToUser[conn]
+	uid, ok := s.connToUser[conn]
 	//防止重复关闭
-	if uid == "" {
+	if !ok {
 		// 已经被关闭
+		s.RWMutex.Unlock()
 		return
 	}
 
 	conn.Close()
 
 	delete(s.connToUser, conn)
-	delete(s.userToConn, uid)
+	//被同一设备的新连接替换时，设备仍然在线
+	replaced := s.userToConn[uid][conn.Device] != conn
+	if !replaced {
+		delete(s.userToConn[uid], conn.Device)
+		if len(s.userToConn[uid]) == 0 {
+			delete(s.userToConn, uid)
+		}
+	}
+	s.RWMutex.Unlock()
 
+	if s.opt.connListener != nil {
+		s.opt.connListener.OnClose(conn, replaced)
+	}
 }
 
 //根据用户id发送消息
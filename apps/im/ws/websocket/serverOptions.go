@@ -12,6 +12,16 @@ type serverOption struct {
 	maxConnectionIdle time.Duration
 	//设置并发量级
 	concurrency int
+	//连接建立与关闭的通知
+	connListener ConnListener
+}
+
+// ConnListener 连接建立与关闭的通知
+//
+// 同一设备的旧连接被新连接替换时，旧连接关闭的 replaced 为 true，此时设备仍然在线。
+type ConnListener interface {
+	OnConnect(conn *Conn)
+	OnClose(conn *Conn, replaced bool)
 }
 
 func newServerOptions(opts ...ServerOptions) serverOption {
@@ -52,3 +62,9 @@ func WithServerAck(ack AckType) ServerOptions {
 		opt.ack = ack
 	}
 }
+
+func WithServerConnListener(listener ConnListener) ServerOptions {
+	return func(opt *serverOption) {
+		opt.connListener = listener
+	}
+}
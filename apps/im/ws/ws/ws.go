@@ -1,6 +1,7 @@
 package ws
 
 import (
+	"easy-chat/apps/im/immodels"
 	"easy-chat/pkg/constants"
 )
 
@@ -9,9 +10,16 @@ import (
 // 该结构体包含消息的唯一标识符、已读记录、消息类型和消息内容。
 type Msg struct {
 	MsgId           string                 `mapstructure:"msgId"`       // 消息的唯一标识符
-	ReadRecords     map[string]string      `mapstructure:"readRecords"` // 消息的已读记录，键为用户ID，值为已读时间戳
+	ClientMsgId     string                 `mapstructure:"clientMsgId"` // 发送者客户端的消息标识，发送者据此确认消息已经记录
+	Seq             int64                  `mapstructure:"seq"`         // 消息在会话内的序号
+	ReadRecords     map[string]string      `mapstructure:"readRecords"` // 已读水位，键为用户ID，值为已读到的消息序号
 	constants.MType `mapstructure:"mType"` // 消息的类型，定义在 constants 中
-	Content         string                 `mapstructure:"content"` // 消息的实际内容
+	Content         string                 `mapstructure:"content"`  // 消息的实际内容
+	Body            *immodels.MsgBody      `mapstructure:"body"`     // 非文本消息的结构化内容
+	ReplyTo         string                 `mapstructure:"replyTo"`  // 回复的消息ID，发送时设置
+	Quote           *immodels.Quote        `mapstructure:"quote"`    // 回复时引用的消息，由服务端填充
+	Mention         *immodels.Mention      `mapstructure:"mention"`  // 群消息中提及的成员
+	ThreadId        string                 `mapstructure:"threadId"` // 所属话题的第一条消息ID，仅话题中的回复
 }
 
 // Chat 表示一个聊天消息的结构体。
@@ -36,21 +44,257 @@ type Push struct {
 	RecvId             string                    `mapstructure:"recvId"`   // 单一接收者的ID
 	RecvIds            []string                  `mapstructure:"recvIds"`  // 多个接收者的ID列表
 	SendTime           int64                     `mapstructure:"sendTime"` // 推送消息发送的时间戳
+	SyncSend           bool                      `mapstructure:"syncSend"` // 同时推送给发送者的所有在线设备，用于撤回等由发送者发起的事件
 
 	MsgId       string                `mapstructure:"msgId"`       // 消息的唯一标识符
-	ReadRecords map[string]string     `mapstructure:"readRecords"` // 消息的已读记录，键为用户ID，值为已读时间戳
+	ClientMsgId string                `mapstructure:"clientMsgId"` // 发送者客户端的消息标识，仅聊天消息
+	Seq         int64                 `mapstructure:"seq"`         // 消息在会话内的序号
+	ReadRecords map[string]string     `mapstructure:"readRecords"` // 已读水位，键为用户ID，值为已读到的消息序号
 	ContentType constants.ContentType `mapstructure:"contentType"` // 消息内容的类型，定义在 constants 中
+	Version     int                   `mapstructure:"version"`     // 消息的编辑版本
+	Reaction    *ReactionPayload      `mapstructure:"reaction"`    // 表情回应的变化
+	Pin         *PinPayload           `mapstructure:"pin"`         // 置顶的变化
+	Badge       *BadgePayload         `mapstructure:"badge"`       // 未读数的变化
+	Draft       *DraftPayload         `mapstructure:"draft"`       // 草稿的变化
 
 	constants.MType `mapstructure:"mType"` // 消息的类型，定义在 constants 中
-	Content         string                 `mapstructure:"content"` // 推送消息的实际内容
+	Content         string                 `mapstructure:"content"`  // 推送消息的实际内容
+	Body            *immodels.MsgBody      `mapstructure:"body"`     // 非文本消息的结构化内容
+	Quote           *immodels.Quote        `mapstructure:"quote"`    // 回复时引用的消息
+	Mention         *immodels.Mention      `mapstructure:"mention"`  // 群消息中提及的成员
+	ThreadId        string                 `mapstructure:"threadId"` // 所属话题的第一条消息ID，仅话题中的回复
+	Thread          *immodels.Thread       `mapstructure:"thread"`   // 话题的统计，仅话题更新事件
+}
+
+// PushBatch 表示一次批量推送的结构体。
+//
+// 该结构体由消息队列在批量消费后发送给 websocket 服务，服务端再按接收者聚合为单帧推送。
+type PushBatch struct {
+	List []*Push `mapstructure:"list"` // 按发送顺序排列的推送消息
+}
+
+// PushVersion 推送信封的版本，信封结构发生不兼容的变化时递增
+const PushVersion = 1
+
+// Event 表示推送给客户端的信封。
+//
+// 客户端根据 Kind 区分事件并解析 Payload：
+//   - ContentChatMsg: *Msg
+//   - ContentMakeRead: *ReadPayload
+//   - ContentRecall: *RecallPayload
+//   - ContentSystem: *SystemPayload
+//   - ContentEdit: *EditPayload
+//   - ContentReaction: *ReactionPayload
+//   - ContentThread: *ThreadPayload
+//   - ContentPin: *PinPayload
+//   - ContentBadge: *BadgePayload
+//   - ContentDraft: *DraftPayload
+//   - ContentTyping: *TypingPayload
+//   - ContentPresence: *PresencePayload
+type Event struct {
+	Version            int                       `mapstructure:"version"`        // 信封版本
+	Kind               constants.ContentType     `mapstructure:"kind"`           // 事件类型
+	Seq                int64                     `mapstructure:"seq"`            // 事件对应的消息序号
+	ConversationId     string                    `mapstructure:"conversationId"` // 会话ID
+	constants.ChatType `mapstructure:"chatType"` // 聊天类型
+	SendId             string                    `mapstructure:"sendId"`   // 事件的发起者ID
+	RecvId             string                    `mapstructure:"recvId"`   // 事件的接收者ID
+	SendTime           int64                     `mapstructure:"sendTime"` // 事件发生的时间戳
+	Payload            interface{}               `mapstructure:"payload"`  // 事件内容，类型由 Kind 决定
+}
+
+// ReadPayload 已读回执，键为用户ID，值为已读到的消息序号
+type ReadPayload struct {
+	ReadRecords map[string]string `mapstructure:"readRecords"`
+}
+
+// RecallPayload 撤回的消息
+type RecallPayload struct {
+	MsgId string `mapstructure:"msgId"`
+	Seq   int64  `mapstructure:"seq"`
+}
+
+// EditPayload 编辑后的消息
+type EditPayload struct {
+	MsgId   string `mapstructure:"msgId"`
+	Seq     int64  `mapstructure:"seq"`
+	Version int    `mapstructure:"version"` // 编辑后的版本
+	Content string `mapstructure:"content"` // 编辑后的内容
+}
+
+// ReactionPayload 消息的表情回应变化
+type ReactionPayload struct {
+	MsgId   string `mapstructure:"msgId"`
+	Seq     int64  `mapstructure:"seq"`
+	UserId  string `mapstructure:"userId"`  // 添加或取消回应的用户
+	Emoji   string `mapstructure:"emoji"`   // 回应的表情
+	Removed bool   `mapstructure:"removed"` // 是否为取消回应
+	Count   int64  `mapstructure:"count"`   // 表情当前的回应数
+}
+
+// ThreadPayload 话题有新的回复
+type ThreadPayload struct {
+	MsgId  string           `mapstructure:"msgId"` // 话题的第一条消息ID
+	Seq    int64            `mapstructure:"seq"`
+	Thread *immodels.Thread `mapstructure:"thread"`
+}
+
+// PinPayload 会话中的消息置顶或取消置顶
+type PinPayload struct {
+	MsgId    string `mapstructure:"msgId"`
+	Seq      int64  `mapstructure:"seq"`
+	UserId   string `mapstructure:"userId"`   // 置顶或取消置顶的用户
+	Unpinned bool   `mapstructure:"unpinned"` // 是否为取消置顶
+}
+
+// BadgePayload 用户在会话中的未读数，客户端以此覆盖本地的角标
+type BadgePayload struct {
+	Unread    int64 `mapstructure:"unread"`    // 未读消息数
+	ReadSeq   int64 `mapstructure:"readSeq"`   // 已读到的消息序号
+	Mentioned bool  `mapstructure:"mentioned"` // 是否有未读的提及
+	Muted     bool  `mapstructure:"muted"`     // 是否免打扰，免打扰的会话不计入未读总数
+	Archived  bool  `mapstructure:"archived"`  // 是否归档，归档的会话不计入未读总数
+}
+
+// DraftPayload 用户在会话中的草稿，客户端以此覆盖其他设备上的草稿
+type DraftPayload struct {
+	Content   string `mapstructure:"content"`   // 草稿内容，为空表示草稿已清除
+	DraftTime int64  `mapstructure:"draftTime"` // 保存草稿的时间，客户端忽略比本地更早的草稿
+}
+
+// TypingPayload 对方开始或停止输入
+type TypingPayload struct {
+	Typing bool `mapstructure:"typing"` // 为 false 表示停止输入，包括超时自动停止
+}
+
+// PresencePayload 用户的在线状态
+type PresencePayload struct {
+	UserId   string   `mapstructure:"userId"`   // 状态变化的用户
+	Online   bool     `mapstructure:"online"`   // 至少有一个设备在线
+	Devices  []string `mapstructure:"devices"`  // 在线的设备
+	LastSeen int64    `mapstructure:"lastSeen"` // 最后在线的毫秒时间戳，离线时客户端据此显示最后在线的时间
+}
+
+// SystemPayload 系统通知
+type SystemPayload struct {
+	Content string `mapstructure:"content"`
+}
+
+// NewEvent 将消息队列转发的推送转换为推送给客户端的信封
+func NewEvent(push *Push) *Event {
+	e := &Event{
+		Version:        PushVersion,
+		Kind:           push.ContentType,
+		Seq:            push.Seq,
+		ConversationId: push.ConversationId,
+		ChatType:       push.ChatType,
+		SendId:         push.SendId,
+		RecvId:         push.RecvId,
+		SendTime:       push.SendTime,
+	}
+	switch push.ContentType {
+	case constants.ContentMakeRead:
+		e.Payload = &ReadPayload{ReadRecords: push.ReadRecords}
+	case constants.ContentRecall:
+		e.Payload = &RecallPayload{MsgId: push.MsgId, Seq: push.Seq}
+	case constants.ContentSystem:
+		e.Payload = &SystemPayload{Content: push.Content}
+	case constants.ContentEdit:
+		e.Payload = &EditPayload{
+			MsgId:   push.MsgId,
+			Seq:     push.Seq,
+			Version: push.Version,
+			Content: push.Content,
+		}
+	case constants.ContentReaction:
+		e.Payload = push.Reaction
+	case constants.ContentPin:
+		e.Payload = push.Pin
+	case constants.ContentBadge:
+		e.Payload = push.Badge
+	case constants.ContentDraft:
+		e.Payload = push.Draft
+	case constants.ContentThread:
+		e.Payload = &ThreadPayload{MsgId: push.MsgId, Seq: push.Seq, Thread: push.Thread}
+	default:
+		e.Payload = &Msg{
+			MsgId:       push.MsgId,
+			ClientMsgId: push.ClientMsgId,
+			Seq:         push.Seq,
+			MType:       push.MType,
+			Content:     push.Content,
+			Body:        push.Body,
+			Quote:       push.Quote,
+			Mention:     push.Mention,
+			ThreadId:    push.ThreadId,
+		}
+	}
+	return e
 }
 
 // MarkRead 表示一个标记消息已读的结构体。
 //
-// 该结构体用于处理标记消息已读的操作，包括会话ID、接收者ID和已读的消息ID列表。
+// 该结构体用于处理标记消息已读的操作，包括会话ID、接收者ID和已读到的消息序号。
 type MarkRead struct {
 	constants.ChatType `mapstructure:"chatType"` // 聊天的类型，定义在 constants 中
 	RecvId             string                    `mapstructure:"recvId"`         // 已读结果的接收者ID
 	ConversationId     string                    `mapstructure:"conversationId"` // 会话的唯一标识符
-	MsgIds             []string                  `mapstructure:"msgIds"`         // 已读消息的ID列表
+	ReadSeq            int64                     `mapstructure:"readSeq"`        // 已读到的消息序号，为 0 表示读到会话的最新消息
+}
+
+// Recall 表示一个撤回消息的结构体。
+type Recall struct {
+	MsgId string `mapstructure:"msgId"` // 撤回消息的ID
+}
+
+// Edit 表示一个编辑消息的结构体。
+type Edit struct {
+	MsgId   string `mapstructure:"msgId"`   // 编辑消息的ID
+	Content string `mapstructure:"content"` // 编辑后的内容
+}
+
+// React 表示一个表情回应的结构体。
+type React struct {
+	MsgId  string `mapstructure:"msgId"`  // 回应的消息ID
+	Emoji  string `mapstructure:"emoji"`  // 回应的表情
+	Remove bool   `mapstructure:"remove"` // 为 true 时取消回应
+}
+
+// SubscribeThread 表示一个订阅话题的结构体。
+//
+// 话题中的回复只推送给订阅了该话题的连接，话题的统计变化推送给所有群成员。
+type SubscribeThread struct {
+	MsgId       string `mapstructure:"msgId"`       // 话题的第一条消息ID
+	Unsubscribe bool   `mapstructure:"unsubscribe"` // 为 true 时取消订阅
+}
+
+// ThreadTopic 话题在连接上的订阅主题
+func ThreadTopic(msgId string) string {
+	return "thread:" + msgId
+}
+
+// Draft 表示一个保存草稿的结构体。
+//
+// 保存后推送给用户的其他设备，用户在会话中发送消息后草稿自动清除。
+type Draft struct {
+	ConversationId string `mapstructure:"conversationId"` // 草稿所在的会话
+	Content        string `mapstructure:"content"`        // 草稿内容，为空时清除草稿
+}
+
+// Typing 表示一个正在输入的结构体。
+//
+// 私聊转发给对方，群聊转发给在线的群成员；服务端会节流，超时没有收到停止输入时自动停止。
+type Typing struct {
+	ConversationId     string                    `mapstructure:"conversationId"` // 输入所在的会话
+	constants.ChatType `mapstructure:"chatType"` // 聊天的类型，定义在 constants 中
+	RecvId             string                    `mapstructure:"recvId"` // 私聊为对方的用户ID，群聊为群ID
+	Stop               bool                      `mapstructure:"stop"`   // 为 true 时停止输入
+}
+
+// PresenceSubscribe 表示一个订阅在线状态的结构体。
+//
+// 只能订阅好友，订阅后立即推送当前的状态，之后好友上线或离线时推送变化；连接关闭后订阅失效。
+type PresenceSubscribe struct {
+	UserIds     []string `mapstructure:"userIds"`     // 订阅的好友ID
+	Unsubscribe bool     `mapstructure:"unsubscribe"` // 为 true 时取消订阅
 }
@@ -0,0 +1,17 @@
+package config
+
+import (
+	"easy-chat/apps/media/storage"
+	"github.com/zeromicro/go-zero/rest"
+	"github.com/zeromicro/go-zero/zrpc"
+)
+
+type Config struct {
+	rest.RestConf
+	MediaRpc zrpc.RpcClientConf
+	Storage  storage.LocalConf
+	JwtAuth  struct {
+		AccessSecret string
+		AccessExpire int64
+	}
+}
@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"net/http"
+
+	"easy-chat/apps/media/api/internal/logic"
+	"easy-chat/apps/media/api/internal/svc"
+	"easy-chat/apps/media/api/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func completeUploadHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.CompleteUploadReq
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := logic.NewCompleteUploadLogic(r.Context(), svcCtx)
+		resp, err := l.CompleteUpload(&req)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
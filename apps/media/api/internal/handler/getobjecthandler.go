@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"net/http"
+
+	"easy-chat/apps/media/api/internal/logic"
+	"easy-chat/apps/media/api/internal/svc"
+	"easy-chat/apps/media/api/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func getObjectHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.ObjectReq
+		if err := httpx.ParseForm(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := logic.NewGetObjectLogic(r.Context(), svcCtx)
+		// 对象内容直接写入响应，出错时还未写入任何数据
+		if err := l.GetObject(&req, w); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"net/http"
+
+	"easy-chat/apps/media/api/internal/logic"
+	"easy-chat/apps/media/api/internal/svc"
+	"easy-chat/apps/media/api/internal/types"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func putObjectHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.ObjectReq
+		if err := httpx.ParseForm(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		l := logic.NewPutObjectLogic(r.Context(), svcCtx)
+		err := l.PutObject(&req, r.Body)
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.Ok(w)
+		}
+	}
+}
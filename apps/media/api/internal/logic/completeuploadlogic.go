@@ -0,0 +1,42 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/media/rpc/mediaclient"
+	"easy-chat/pkg/ctxdata"
+	"github.com/jinzhu/copier"
+
+	"easy-chat/apps/media/api/internal/svc"
+	"easy-chat/apps/media/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CompleteUploadLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCompleteUploadLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CompleteUploadLogic {
+	return &CompleteUploadLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// CompleteUpload 上传完成后确认附件，返回的附件ID可以在图片、文件与语音消息中引用
+func (l *CompleteUploadLogic) CompleteUpload(req *types.CompleteUploadReq) (resp *types.CompleteUploadResp, err error) {
+	data, err := l.svcCtx.Media.CompleteUpload(l.ctx, &mediaclient.CompleteUploadReq{
+		UserId:       ctxdata.GetUid(l.ctx),
+		AttachmentId: req.AttachmentId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var res types.CompleteUploadResp
+	copier.Copy(&res, data)
+	return &res, nil
+}
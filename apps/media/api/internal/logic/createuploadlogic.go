@@ -0,0 +1,44 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/media/rpc/mediaclient"
+	"easy-chat/pkg/ctxdata"
+	"github.com/jinzhu/copier"
+
+	"easy-chat/apps/media/api/internal/svc"
+	"easy-chat/apps/media/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CreateUploadLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCreateUploadLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateUploadLogic {
+	return &CreateUploadLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// CreateUpload 创建附件并获取上传地址，客户端以 PUT 请求将文件内容上传到该地址
+func (l *CreateUploadLogic) CreateUpload(req *types.CreateUploadReq) (resp *types.CreateUploadResp, err error) {
+	data, err := l.svcCtx.Media.CreateUpload(l.ctx, &mediaclient.CreateUploadReq{
+		UserId: ctxdata.GetUid(l.ctx),
+		Name:   req.Name,
+		Size:   req.Size,
+		Mime:   req.Mime,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var res types.CreateUploadResp
+	copier.Copy(&res, data)
+	return &res, nil
+}
@@ -0,0 +1,42 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/media/rpc/mediaclient"
+	"easy-chat/pkg/ctxdata"
+	"github.com/jinzhu/copier"
+
+	"easy-chat/apps/media/api/internal/svc"
+	"easy-chat/apps/media/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetAttachmentLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetAttachmentLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetAttachmentLogic {
+	return &GetAttachmentLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// GetAttachment 获取附件信息与有时效的下载地址
+func (l *GetAttachmentLogic) GetAttachment(req *types.GetAttachmentReq) (resp *types.GetAttachmentResp, err error) {
+	data, err := l.svcCtx.Media.GetAttachment(l.ctx, &mediaclient.GetAttachmentReq{
+		AttachmentId: req.AttachmentId,
+		UserId:       ctxdata.GetUid(l.ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var res types.GetAttachmentResp
+	copier.Copy(&res, data)
+	return &res, nil
+}
@@ -0,0 +1,52 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/media/storage"
+	"easy-chat/pkg/xerr"
+	"github.com/pkg/errors"
+	"io"
+	"net/http"
+
+	"easy-chat/apps/media/api/internal/svc"
+	"easy-chat/apps/media/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetObjectLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetObjectLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetObjectLogic {
+	return &GetObjectLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// GetObject 校验预签名地址后将对象写入 w
+func (l *GetObjectLogic) GetObject(req *types.ObjectReq, w io.Writer) error {
+	if err := verifyObject(l.svcCtx, http.MethodGet, req); err != nil {
+		return err
+	}
+
+	r, err := l.svcCtx.Storage.Get(l.ctx, req.Key)
+	switch err {
+	case nil:
+	case storage.ErrObjectNotFound:
+		return errors.WithStack(ErrObjectNotFound)
+	default:
+		return errors.Wrapf(xerr.NewInternalErr(), "get object err %v, key %v", err, req.Key)
+	}
+	defer r.Close()
+
+	if _, err := io.Copy(w, r); err != nil {
+		// 已经开始写入响应，只记录错误
+		l.Errorf("copy object err %v, key %v", err, req.Key)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/media/storage"
+	"easy-chat/pkg/xerr"
+	"github.com/pkg/errors"
+	"io"
+	"net/http"
+
+	"easy-chat/apps/media/api/internal/svc"
+	"easy-chat/apps/media/api/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+var (
+	ErrObjectSign     = xerr.New(xerr.REQUEST_PARAM_ERROR, "签名无效")
+	ErrObjectExpired  = xerr.New(xerr.REQUEST_PARAM_ERROR, "地址已过期")
+	ErrObjectNotFound = xerr.New(xerr.REQUEST_PARAM_ERROR, "对象不存在")
+	ErrObjectUploaded = xerr.New(xerr.REQUEST_PARAM_ERROR, "对象已上传")
+	ErrObjectSize     = xerr.New(xerr.REQUEST_PARAM_ERROR, "上传的大小与申请的不一致")
+)
+
+type PutObjectLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewPutObjectLogic(ctx context.Context, svcCtx *svc.ServiceContext) *PutObjectLogic {
+	return &PutObjectLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// PutObject 校验预签名地址后保存上传的对象，对象只能上传一次，大小必须与申请时的一致
+func (l *PutObjectLogic) PutObject(req *types.ObjectReq, body io.Reader) error {
+	if err := verifyObject(l.svcCtx, http.MethodPut, req); err != nil {
+		return err
+	}
+	if req.Size <= 0 {
+		return errors.WithStack(ErrObjectSign)
+	}
+
+	// 已上传的对象不允许通过未过期的地址覆盖
+	switch _, err := l.svcCtx.Storage.Stat(l.ctx, req.Key); err {
+	case nil:
+		return errors.WithStack(ErrObjectUploaded)
+	case storage.ErrObjectNotFound:
+	default:
+		return errors.Wrapf(xerr.NewInternalErr(), "stat object err %v, key %v", err, req.Key)
+	}
+
+	err := l.svcCtx.Storage.Put(l.ctx, req.Key, &sizedReader{r: body, remain: req.Size})
+	if err == errObjectSize {
+		return errors.WithStack(ErrObjectSize)
+	}
+	if err != nil {
+		return errors.Wrapf(xerr.NewInternalErr(), "put object err %v, key %v", err, req.Key)
+	}
+	return nil
+}
+
+var errObjectSize = errors.New("object size mismatch")
+
+// sizedReader 只允许读出 remain 个字节，多了或少了都返回 errObjectSize，使存储放弃写入
+type sizedReader struct {
+	r      io.Reader
+	remain int64
+}
+
+func (s *sizedReader) Read(p []byte) (int, error) {
+	// 多读一个字节以发现超出的内容
+	if int64(len(p)) > s.remain+1 {
+		p = p[:s.remain+1]
+	}
+	n, err := s.r.Read(p)
+	s.remain -= int64(n)
+	if s.remain < 0 {
+		return 0, errObjectSize
+	}
+	if err == io.EOF && s.remain > 0 {
+		return n, errObjectSize
+	}
+	return n, err
+}
+
+func verifyObject(svcCtx *svc.ServiceContext, method string, req *types.ObjectReq) error {
+	switch err := svcCtx.Storage.Verify(method, req.Key, req.Size, req.Expires, req.Sign); err {
+	case nil:
+		return nil
+	case storage.ErrSignExpired:
+		return errors.WithStack(ErrObjectExpired)
+	default:
+		return errors.WithStack(ErrObjectSign)
+	}
+}
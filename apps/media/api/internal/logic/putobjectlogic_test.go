@@ -0,0 +1,95 @@
+package logic
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"easy-chat/apps/media/api/internal/svc"
+	"easy-chat/apps/media/api/internal/types"
+	"easy-chat/apps/media/storage"
+	"github.com/pkg/errors"
+)
+
+func newTestSvc(t *testing.T) *svc.ServiceContext {
+	return &svc.ServiceContext{
+		Storage: storage.NewLocal(storage.LocalConf{
+			Root:    t.TempDir(),
+			BaseUrl: "http://127.0.0.1:8883/v1/media/object",
+			Secret:  "secret",
+		}),
+	}
+}
+
+func presignPut(t *testing.T, svcCtx *svc.ServiceContext, key string, size int64) *types.ObjectReq {
+	raw, err := svcCtx.Storage.PresignPut(context.Background(), key, size, time.Minute)
+	if err != nil {
+		t.Fatalf("PresignPut() err = %v", err)
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("parse presigned url %q: %v", raw, err)
+	}
+	q := u.Query()
+	req := &types.ObjectReq{Key: q.Get("key"), Sign: q.Get("sign")}
+	req.Size, _ = strconv.ParseInt(q.Get("size"), 10, 64)
+	req.Expires, _ = strconv.ParseInt(q.Get("expires"), 10, 64)
+	return req
+}
+
+func TestPutObjectLogic_PutObject(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		size     int64 // 签名时申请的大小
+		reqSize  int64 // 请求中携带的大小，为 0 时与签名一致
+		uploaded bool
+		wantErr  error
+	}{
+		{name: "ok", body: "hello", size: 5},
+		{name: "larger", body: "hello world", size: 5, wantErr: ErrObjectSize},
+		{name: "smaller", body: "hel", size: 5, wantErr: ErrObjectSize},
+		{name: "size tampered", body: "hello world", size: 5, reqSize: 11, wantErr: ErrObjectSign},
+		{name: "uploaded", body: "hello", size: 5, uploaded: true, wantErr: ErrObjectUploaded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			svcCtx := newTestSvc(t)
+			req := presignPut(t, svcCtx, "u1/a1", tt.size)
+			if tt.reqSize > 0 {
+				req.Size = tt.reqSize
+			}
+			if tt.uploaded {
+				if err := svcCtx.Storage.Put(ctx, req.Key, strings.NewReader("first")); err != nil {
+					t.Fatalf("Put() err = %v", err)
+				}
+			}
+
+			err := NewPutObjectLogic(ctx, svcCtx).PutObject(req, strings.NewReader(tt.body))
+			if errors.Cause(err) != tt.wantErr {
+				t.Fatalf("PutObject() err = %v, want %v", err, tt.wantErr)
+			}
+
+			info, statErr := svcCtx.Storage.Stat(ctx, req.Key)
+			switch {
+			case tt.uploaded:
+				// 已上传的对象保持不变
+				if statErr != nil || info.Size != int64(len("first")) {
+					t.Errorf("Stat() = %v, %v, want the first upload", info, statErr)
+				}
+			case tt.wantErr != nil:
+				if statErr != storage.ErrObjectNotFound {
+					t.Errorf("Stat() err = %v, want ErrObjectNotFound", statErr)
+				}
+			default:
+				if statErr != nil || info.Size != tt.size {
+					t.Errorf("Stat() = %v, %v, want size %d", info, statErr, tt.size)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package svc
+
+import (
+	"easy-chat/apps/media/api/internal/config"
+	"easy-chat/apps/media/rpc/mediaclient"
+	"easy-chat/apps/media/storage"
+	"github.com/zeromicro/go-zero/zrpc"
+)
+
+type ServiceContext struct {
+	Config config.Config
+	mediaclient.Media
+	Storage *storage.Local
+}
+
+func NewServiceContext(c config.Config) *ServiceContext {
+	return &ServiceContext{
+		Config:  c,
+		Media:   mediaclient.NewMedia(zrpc.MustNewClient(c.MediaRpc)),
+		Storage: storage.NewLocal(c.Storage),
+	}
+}
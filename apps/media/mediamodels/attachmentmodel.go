@@ -0,0 +1,29 @@
+package mediamodels
+
+import "github.com/zeromicro/go-zero/core/stores/mon"
+
+var _ AttachmentModel = (*customAttachmentModel)(nil)
+
+type (
+	// AttachmentModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customAttachmentModel.
+	AttachmentModel interface {
+		attachmentModel
+	}
+
+	customAttachmentModel struct {
+		*defaultAttachmentModel
+	}
+)
+
+// NewAttachmentModel returns a model for the mongo.
+func NewAttachmentModel(url, db, collection string) AttachmentModel {
+	conn := mon.MustNewModel(url, db, collection)
+	return &customAttachmentModel{
+		defaultAttachmentModel: newDefaultAttachmentModel(conn),
+	}
+}
+
+func MustAttachmentModel(url, db string) AttachmentModel {
+	return NewAttachmentModel(url, db, "attachment")
+}
@@ -0,0 +1,32 @@
+package mediamodels
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AttachmentStatus 附件状态 0. 等待上传 1. 已上传
+type AttachmentStatus int
+
+const (
+	PendingAttachmentStatus AttachmentStatus = iota
+	UploadedAttachmentStatus
+)
+
+type Attachment struct {
+	ID primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+
+	UserId       string           `bson:"userId"` // 上传者
+	Name         string           `bson:"name"`   // 文件名
+	Mime         string           `bson:"mime"`
+	Size         int64            `bson:"size"`                   // 文件大小：单位为字节
+	Key          string           `bson:"key"`                    // 对象存储中的对象
+	ThumbnailKey string           `bson:"thumbnailKey,omitempty"` // 缩略图的对象，仅图片
+	Width        int32            `bson:"width,omitempty"`        // 图片宽度
+	Height       int32            `bson:"height,omitempty"`       // 图片高度
+	Status       AttachmentStatus `bson:"status"`
+
+	UpdateAt time.Time `bson:"updateAt,omitempty" json:"updateAt,omitempty"`
+	CreateAt time.Time `bson:"createAt,omitempty" json:"createAt,omitempty"`
+}
@@ -0,0 +1,21 @@
+package config
+
+import (
+	"easy-chat/apps/media/storage"
+	"github.com/zeromicro/go-zero/zrpc"
+)
+
+type Config struct {
+	zrpc.RpcServerConf
+	Mongo struct {
+		Url string
+		Db  string
+	}
+	Storage        storage.LocalConf
+	MaxSize        int64 // 附件的最大大小：单位为字节
+	UploadExpire   int64 // 上传地址的有效期：单位为s
+	DownloadExpire int64 // 下载地址的有效期：单位为s
+	ThumbnailSize  int   // 缩略图最长边的像素
+	// 查询群成员，校验附件的访问权限
+	SocialRpc zrpc.RpcClientConf
+}
@@ -0,0 +1,110 @@
+package logic
+
+import (
+	"bytes"
+	"context"
+	"easy-chat/apps/media/mediamodels"
+	"easy-chat/apps/media/storage"
+	"easy-chat/apps/media/thumbnail"
+	"easy-chat/pkg/xerr"
+	"github.com/pkg/errors"
+	"strings"
+
+	"easy-chat/apps/media/rpc/internal/svc"
+	"easy-chat/apps/media/rpc/media"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+var (
+	ErrAttachmentNotFound   = xerr.New(xerr.REQUEST_PARAM_ERROR, "附件不存在")
+	ErrAttachmentNotUpload  = xerr.New(xerr.REQUEST_PARAM_ERROR, "附件还未上传")
+	ErrAttachmentPermission = xerr.New(xerr.NO_PERMISSION_ERROR, "没有权限操作该附件")
+)
+
+type CompleteUploadLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewCompleteUploadLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CompleteUploadLogic {
+	return &CompleteUploadLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// CompleteUpload 确认附件已上传，以存储中的实际大小为准，图片附件生成缩略图
+//
+// 重复调用直接返回附件信息。
+func (l *CompleteUploadLogic) CompleteUpload(in *media.CompleteUploadReq) (*media.CompleteUploadResp, error) {
+	attachment, err := findAttachment(l.ctx, l.svcCtx, in.AttachmentId)
+	if err != nil {
+		return nil, err
+	}
+	if attachment.UserId != in.UserId {
+		return nil, errors.WithStack(ErrAttachmentPermission)
+	}
+	if attachment.Status == mediamodels.UploadedAttachmentStatus {
+		return &media.CompleteUploadResp{Attachment: toAttachment(attachment)}, nil
+	}
+
+	info, err := l.svcCtx.Storage.Stat(l.ctx, attachment.Key)
+	switch err {
+	case nil:
+	case storage.ErrObjectNotFound:
+		return nil, errors.WithStack(ErrAttachmentNotUpload)
+	default:
+		return nil, errors.Wrapf(xerr.NewInternalErr(), "stat object err %v, key %v", err, attachment.Key)
+	}
+	maxSize := DefaultMaxSize
+	if l.svcCtx.Config.MaxSize > 0 {
+		maxSize = l.svcCtx.Config.MaxSize
+	}
+	if info.Size > maxSize {
+		if err := l.svcCtx.Storage.Delete(l.ctx, attachment.Key); err != nil {
+			l.Errorf("delete oversize object err %v, key %v", err, attachment.Key)
+		}
+		return nil, errors.WithStack(ErrAttachmentSize)
+	}
+	attachment.Size = info.Size
+
+	if strings.HasPrefix(attachment.Mime, "image/") {
+		// 缩略图生成失败不影响附件的使用，客户端回退到原图
+		if err := l.thumbnail(attachment); err != nil {
+			l.Errorf("generate thumbnail err %v, key %v", err, attachment.Key)
+		}
+	}
+
+	attachment.Status = mediamodels.UploadedAttachmentStatus
+	if _, err := l.svcCtx.AttachmentModel.Update(l.ctx, attachment); err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "update attachment err %v, req %v", err, in)
+	}
+
+	return &media.CompleteUploadResp{Attachment: toAttachment(attachment)}, nil
+}
+
+// thumbnail 生成缩略图并记录图片尺寸
+func (l *CompleteUploadLogic) thumbnail(attachment *mediamodels.Attachment) error {
+	r, err := l.svcCtx.Storage.Get(l.ctx, attachment.Key)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	res, err := thumbnail.Generate(r, l.svcCtx.Config.ThumbnailSize)
+	if err != nil {
+		return err
+	}
+
+	key := attachment.Key + ".thumb.jpg"
+	if err := l.svcCtx.Storage.Put(l.ctx, key, bytes.NewReader(res.Data)); err != nil {
+		return err
+	}
+	attachment.ThumbnailKey = key
+	attachment.Width = int32(res.Width)
+	attachment.Height = int32(res.Height)
+	return nil
+}
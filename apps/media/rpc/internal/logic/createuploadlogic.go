@@ -0,0 +1,93 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/media/mediamodels"
+	"easy-chat/pkg/xerr"
+	"fmt"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"path"
+	"strings"
+	"time"
+
+	"easy-chat/apps/media/rpc/internal/svc"
+	"easy-chat/apps/media/rpc/media"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+var (
+	// DefaultUploadExpire 未配置时上传地址的有效期
+	DefaultUploadExpire = 15 * time.Minute
+	// DefaultMaxSize 未配置时附件的最大大小
+	DefaultMaxSize int64 = 100 << 20
+)
+
+var (
+	ErrAttachmentName = xerr.New(xerr.REQUEST_PARAM_ERROR, "文件名不能为空")
+	ErrAttachmentSize = xerr.New(xerr.REQUEST_PARAM_ERROR, "文件大小超出限制")
+)
+
+type CreateUploadLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewCreateUploadLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateUploadLogic {
+	return &CreateUploadLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// CreateUpload 记录待上传的附件并签发上传地址，客户端上传完成后需要调用 CompleteUpload
+func (l *CreateUploadLogic) CreateUpload(in *media.CreateUploadReq) (*media.CreateUploadResp, error) {
+	name := strings.TrimSpace(in.Name)
+	if name == "" {
+		return nil, errors.WithStack(ErrAttachmentName)
+	}
+	maxSize := DefaultMaxSize
+	if l.svcCtx.Config.MaxSize > 0 {
+		maxSize = l.svcCtx.Config.MaxSize
+	}
+	if in.Size <= 0 || in.Size > maxSize {
+		return nil, errors.WithStack(ErrAttachmentSize)
+	}
+
+	id := primitive.NewObjectID()
+	attachment := &mediamodels.Attachment{
+		ID:     id,
+		UserId: in.UserId,
+		Name:   name,
+		Mime:   in.Mime,
+		Size:   in.Size,
+		Key:    objectKey(in.UserId, id, name),
+		Status: mediamodels.PendingAttachmentStatus,
+	}
+	if err := l.svcCtx.AttachmentModel.Insert(l.ctx, attachment); err != nil {
+		return nil, errors.Wrapf(xerr.NewDBErr(), "insert attachment err %v, req %v", err, in)
+	}
+
+	expire := DefaultUploadExpire
+	if l.svcCtx.Config.UploadExpire > 0 {
+		expire = time.Duration(l.svcCtx.Config.UploadExpire) * time.Second
+	}
+	uploadUrl, err := l.svcCtx.Storage.PresignPut(l.ctx, attachment.Key, attachment.Size, expire)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewInternalErr(), "presign put err %v, key %v", err, attachment.Key)
+	}
+
+	return &media.CreateUploadResp{
+		AttachmentId: id.Hex(),
+		UploadUrl:    uploadUrl,
+		ExpireAt:     time.Now().Add(expire).Unix(),
+	}, nil
+}
+
+// objectKey 附件在对象存储中的位置：用户/日期/附件ID+扩展名
+func objectKey(uid string, id primitive.ObjectID, name string) string {
+	return fmt.Sprintf("%s/%s/%s%s", uid, time.Now().Format("20060102"), id.Hex(), strings.ToLower(path.Ext(name)))
+}
@@ -0,0 +1,133 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/media/mediamodels"
+	"easy-chat/pkg/xerr"
+	"github.com/pkg/errors"
+	"time"
+
+	"easy-chat/apps/media/rpc/internal/svc"
+	"easy-chat/apps/media/rpc/media"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+var (
+	// DefaultDownloadExpire 未配置时下载地址的有效期
+	DefaultDownloadExpire = time.Hour
+	// MaxAttachmentRefs 校验访问权限时最多查询的引用附件的消息数
+	MaxAttachmentRefs int64 = 20
+)
+
+type GetAttachmentLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewGetAttachmentLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetAttachmentLogic {
+	return &GetAttachmentLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// GetAttachment 获取已上传的附件，并签发原文件与缩略图的下载地址
+//
+// 只有上传者与引用该附件的消息所在会话的成员可以获取。
+func (l *GetAttachmentLogic) GetAttachment(in *media.GetAttachmentReq) (*media.GetAttachmentResp, error) {
+	attachment, err := findAttachment(l.ctx, l.svcCtx, in.AttachmentId)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.checkAccess(attachment, in.UserId); err != nil {
+		return nil, err
+	}
+	if attachment.Status != mediamodels.UploadedAttachmentStatus {
+		return nil, errors.WithStack(ErrAttachmentNotUpload)
+	}
+
+	expire := DefaultDownloadExpire
+	if l.svcCtx.Config.DownloadExpire > 0 {
+		expire = time.Duration(l.svcCtx.Config.DownloadExpire) * time.Second
+	}
+	downloadUrl, err := l.svcCtx.Storage.PresignGet(l.ctx, attachment.Key, expire)
+	if err != nil {
+		return nil, errors.Wrapf(xerr.NewInternalErr(), "presign get err %v, key %v", err, attachment.Key)
+	}
+	var thumbnailUrl string
+	if attachment.ThumbnailKey != "" {
+		thumbnailUrl, err = l.svcCtx.Storage.PresignGet(l.ctx, attachment.ThumbnailKey, expire)
+		if err != nil {
+			return nil, errors.Wrapf(xerr.NewInternalErr(), "presign get err %v, key %v", err, attachment.ThumbnailKey)
+		}
+	}
+
+	return &media.GetAttachmentResp{
+		Attachment:   toAttachment(attachment),
+		DownloadUrl:  downloadUrl,
+		ThumbnailUrl: thumbnailUrl,
+		ExpireAt:     time.Now().Add(expire).Unix(),
+	}, nil
+}
+
+// checkAccess 用户是附件的上传者，或是引用该附件的消息所在会话的成员
+func (l *GetAttachmentLogic) checkAccess(attachment *mediamodels.Attachment, uid string) error {
+	if uid == "" {
+		return errors.WithStack(ErrAttachmentPermission)
+	}
+	if attachment.UserId == uid {
+		return nil
+	}
+
+	chatLogs, err := l.svcCtx.ChatLogModel.ListByAttachmentId(l.ctx, attachment.ID.Hex(), MaxAttachmentRefs)
+	if err != nil && err != immodels.ErrNotFound {
+		return errors.Wrapf(xerr.NewDBErr(), "list chat logs by attachment err %v, id %v", err, attachment.ID.Hex())
+	}
+	// 同一会话中多次引用时只校验一次
+	checked := make(map[string]bool, len(chatLogs))
+	for _, chatLog := range chatLogs {
+		if checked[chatLog.ConversationId] {
+			continue
+		}
+		checked[chatLog.ConversationId] = true
+
+		ok, err := l.svcCtx.Auth.IsChatLogMember(l.ctx, uid, chatLog.ChatType, chatLog.SendId, chatLog.RecvId)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+	}
+	return errors.WithStack(ErrAttachmentPermission)
+}
+
+func findAttachment(ctx context.Context, svcCtx *svc.ServiceContext, id string) (*mediamodels.Attachment, error) {
+	attachment, err := svcCtx.AttachmentModel.FindOne(ctx, id)
+	switch err {
+	case nil:
+		return attachment, nil
+	case mediamodels.ErrNotFound, mediamodels.ErrInvalidObjectId:
+		return nil, errors.WithStack(ErrAttachmentNotFound)
+	default:
+		return nil, errors.Wrapf(xerr.NewDBErr(), "find attachment err %v, id %v", err, id)
+	}
+}
+
+func toAttachment(data *mediamodels.Attachment) *media.Attachment {
+	return &media.Attachment{
+		Id:       data.ID.Hex(),
+		UserId:   data.UserId,
+		Name:     data.Name,
+		Mime:     data.Mime,
+		Size:     data.Size,
+		Width:    data.Width,
+		Height:   data.Height,
+		Status:   int32(data.Status),
+		CreateAt: data.CreateAt.UnixMilli(),
+	}
+}
@@ -0,0 +1,108 @@
+package logic
+
+import (
+	"context"
+	"easy-chat/apps/im/authz"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/media/mediamodels"
+	"easy-chat/apps/media/rpc/internal/svc"
+	"easy-chat/apps/media/rpc/media"
+	"easy-chat/apps/media/storage"
+	"easy-chat/apps/social/rpc/socialclient"
+	"easy-chat/pkg/constants"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+)
+
+type fakeAttachmentModel struct {
+	mediamodels.AttachmentModel
+	attachment *mediamodels.Attachment
+}
+
+func (f *fakeAttachmentModel) FindOne(ctx context.Context, id string) (*mediamodels.Attachment, error) {
+	if f.attachment.ID.Hex() != id {
+		return nil, mediamodels.ErrNotFound
+	}
+	return f.attachment, nil
+}
+
+type fakeStorage struct {
+	storage.Storage
+}
+
+func (f *fakeStorage) PresignGet(ctx context.Context, key string, expire time.Duration) (string, error) {
+	return "http://media/" + key, nil
+}
+
+// fakeChatLogModel 引用附件的消息
+type fakeChatLogModel struct {
+	immodels.ChatLogModel
+	refs []*immodels.ChatLog
+}
+
+func (f *fakeChatLogModel) ListByAttachmentId(ctx context.Context, attachmentId string, limit int64) ([]*immodels.ChatLog, error) {
+	return f.refs, nil
+}
+
+// fakeSocial 群 g1 的成员为 u1、u4
+type fakeSocial struct{}
+
+func (f *fakeSocial) GroupUsers(ctx context.Context, in *socialclient.GroupUsersReq, opts ...grpc.CallOption) (*socialclient.GroupUsersResp, error) {
+	var list []*socialclient.GroupMembers
+	if in.GroupId == "g1" {
+		list = []*socialclient.GroupMembers{{GroupId: "g1", UserId: "u1"}, {GroupId: "g1", UserId: "u4"}}
+	}
+	return &socialclient.GroupUsersResp{List: list}, nil
+}
+
+func (f *fakeSocial) FriendList(ctx context.Context, in *socialclient.FriendListReq, opts ...grpc.CallOption) (*socialclient.FriendListResp, error) {
+	return &socialclient.FriendListResp{}, nil
+}
+
+func TestGetAttachment_Access(t *testing.T) {
+	attachment := &mediamodels.Attachment{
+		ID:     primitive.NewObjectID(),
+		UserId: "u1",
+		Key:    "u1/a.png",
+		Status: mediamodels.UploadedAttachmentStatus,
+	}
+	svcCtx := &svc.ServiceContext{
+		AttachmentModel: &fakeAttachmentModel{attachment: attachment},
+		Storage:         &fakeStorage{},
+		ChatLogModel: &fakeChatLogModel{refs: []*immodels.ChatLog{
+			{ConversationId: "u1_u2", ChatType: constants.SingleChatType, SendId: "u1", RecvId: "u2"},
+			{ConversationId: "g1", ChatType: constants.GroupChatType, SendId: "u1", RecvId: "g1"},
+		}},
+		Auth: authz.NewAuthorizer(&fakeSocial{}),
+	}
+
+	tests := []struct {
+		name    string
+		uid     string
+		wantErr error
+	}{
+		{name: "uploader", uid: "u1"},
+		{name: "single chat member", uid: "u2"},
+		{name: "group member", uid: "u4"},
+		{name: "stranger", uid: "u3", wantErr: ErrAttachmentPermission},
+		{name: "anonymous", wantErr: ErrAttachmentPermission},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := NewGetAttachmentLogic(context.Background(), svcCtx).GetAttachment(&media.GetAttachmentReq{
+				AttachmentId: attachment.ID.Hex(),
+				UserId:       tt.uid,
+			})
+			if errors.Cause(err) != tt.wantErr {
+				t.Fatalf("GetAttachment() err = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && resp.DownloadUrl == "" {
+				t.Errorf("GetAttachment() download url is empty")
+			}
+		})
+	}
+}
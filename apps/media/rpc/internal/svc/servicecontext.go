@@ -0,0 +1,30 @@
+package svc
+
+import (
+	"easy-chat/apps/im/authz"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/media/mediamodels"
+	"easy-chat/apps/media/rpc/internal/config"
+	"easy-chat/apps/media/storage"
+	"easy-chat/apps/social/rpc/socialclient"
+	"github.com/zeromicro/go-zero/zrpc"
+)
+
+type ServiceContext struct {
+	Config config.Config
+	mediamodels.AttachmentModel
+	Storage storage.Storage
+	// 查询引用附件的消息，附件所在会话的成员可以获取附件
+	ChatLogModel immodels.ChatLogModel
+	Auth         *authz.Authorizer
+}
+
+func NewServiceContext(c config.Config) *ServiceContext {
+	return &ServiceContext{
+		Config:          c,
+		AttachmentModel: mediamodels.MustAttachmentModel(c.Mongo.Url, c.Mongo.Db),
+		Storage:         storage.NewLocal(c.Storage),
+		ChatLogModel:    immodels.MustChatLogModel(c.Mongo.Url, c.Mongo.Db),
+		Auth:            authz.NewAuthorizer(socialclient.NewSocial(zrpc.MustNewClient(c.SocialRpc))),
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"easy-chat/pkg/intercepter/rpcserver"
+	"flag"
+	"fmt"
+
+	"easy-chat/apps/media/rpc/internal/config"
+	"easy-chat/apps/media/rpc/internal/server"
+	"easy-chat/apps/media/rpc/internal/svc"
+	"easy-chat/apps/media/rpc/media"
+
+	"github.com/zeromicro/go-zero/core/conf"
+	"github.com/zeromicro/go-zero/core/service"
+	"github.com/zeromicro/go-zero/zrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+var configFile = flag.String("f", "etc/dev/media.yaml", "the config file")
+
+func main() {
+	flag.Parse()
+
+	var c config.Config
+	conf.MustLoad(*configFile, &c)
+	ctx := svc.NewServiceContext(c)
+
+	s := zrpc.MustNewServer(c.RpcServerConf, func(grpcServer *grpc.Server) {
+		media.RegisterMediaServer(grpcServer, server.NewMediaServer(ctx))
+
+		if c.Mode == service.DevMode || c.Mode == service.TestMode {
+			reflection.Register(grpcServer)
+		}
+	})
+	//增加拦截器
+	s.AddUnaryInterceptors(rpcserver.LogInterceptor)
+	defer s.Stop()
+
+	fmt.Printf("Starting rpc server at %s...\n", c.ListenOn)
+	s.Start()
+}
@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestLocal(t *testing.T) *Local {
+	return NewLocal(LocalConf{
+		Root:    t.TempDir(),
+		BaseUrl: "http://127.0.0.1:8883/v1/media/object",
+		Secret:  "secret",
+	})
+}
+
+func TestLocal_PutGetStatDelete(t *testing.T) {
+	var (
+		ctx = context.Background()
+		l   = newTestLocal(t)
+		key = "u1/a1"
+	)
+
+	if _, err := l.Stat(ctx, key); err != ErrObjectNotFound {
+		t.Fatalf("Stat() before put err = %v, want ErrObjectNotFound", err)
+	}
+	if err := l.Put(ctx, key, strings.NewReader("hello")); err != nil {
+		t.Fatalf("Put() err = %v", err)
+	}
+
+	info, err := l.Stat(ctx, key)
+	if err != nil || info.Size != 5 {
+		t.Fatalf("Stat() = %v, %v, want size 5", info, err)
+	}
+	r, err := l.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get() err = %v", err)
+	}
+	data, _ := io.ReadAll(r)
+	r.Close()
+	if string(data) != "hello" {
+		t.Errorf("Get() = %q, want hello", data)
+	}
+
+	if err := l.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete() err = %v", err)
+	}
+	if _, err := l.Get(ctx, key); err != ErrObjectNotFound {
+		t.Errorf("Get() after delete err = %v, want ErrObjectNotFound", err)
+	}
+}
+
+func TestLocal_InvalidKey(t *testing.T) {
+	var (
+		ctx = context.Background()
+		l   = newTestLocal(t)
+	)
+	for _, key := range []string{"", "/etc/passwd", "../a", "a/../../b", "a//b", "a\\b", "."} {
+		if err := l.Put(ctx, key, strings.NewReader("x")); err != ErrInvalidKey {
+			t.Errorf("Put(%q) err = %v, want ErrInvalidKey", key, err)
+		}
+		if _, err := l.PresignGet(ctx, key, time.Minute); err != ErrInvalidKey {
+			t.Errorf("PresignGet(%q) err = %v, want ErrInvalidKey", key, err)
+		}
+	}
+}
+
+func parsePresigned(t *testing.T, raw string) (key string, size, expires int64, sign string) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("parse presigned url %q: %v", raw, err)
+	}
+	q := u.Query()
+	size, _ = strconv.ParseInt(q.Get("size"), 10, 64)
+	expires, _ = strconv.ParseInt(q.Get("expires"), 10, 64)
+	return q.Get("key"), size, expires, q.Get("sign")
+}
+
+func TestLocal_Presign(t *testing.T) {
+	var (
+		ctx = context.Background()
+		l   = newTestLocal(t)
+		now = time.Unix(1700000000, 0)
+	)
+	l.now = func() time.Time { return now }
+
+	raw, err := l.PresignPut(ctx, "u1/a1", 1024, time.Minute)
+	if err != nil {
+		t.Fatalf("PresignPut() err = %v", err)
+	}
+	if !strings.HasPrefix(raw, "http://127.0.0.1:8883/v1/media/object?") {
+		t.Errorf("PresignPut() = %q", raw)
+	}
+	key, size, expires, sign := parsePresigned(t, raw)
+	if size != 1024 {
+		t.Errorf("PresignPut() size = %d, want 1024", size)
+	}
+
+	if err := l.Verify(http.MethodPut, key, size, expires, sign); err != nil {
+		t.Errorf("Verify() err = %v", err)
+	}
+	// 签名与方法、对象、大小、过期时间绑定
+	if err := l.Verify(http.MethodGet, key, size, expires, sign); err != ErrInvalidSign {
+		t.Errorf("Verify() with other method err = %v, want ErrInvalidSign", err)
+	}
+	if err := l.Verify(http.MethodPut, "u1/a2", size, expires, sign); err != ErrInvalidSign {
+		t.Errorf("Verify() with other key err = %v, want ErrInvalidSign", err)
+	}
+	if err := l.Verify(http.MethodPut, key, size+1, expires, sign); err != ErrInvalidSign {
+		t.Errorf("Verify() with other size err = %v, want ErrInvalidSign", err)
+	}
+	if err := l.Verify(http.MethodPut, key, size, expires+60, sign); err != ErrInvalidSign {
+		t.Errorf("Verify() with other expires err = %v, want ErrInvalidSign", err)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if err := l.Verify(http.MethodPut, key, size, expires, sign); err != ErrSignExpired {
+		t.Errorf("Verify() after expire err = %v, want ErrSignExpired", err)
+	}
+}
@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+var (
+	ErrObjectNotFound = errors.New("object not found")
+	ErrInvalidKey     = errors.New("invalid object key")
+	ErrInvalidSign    = errors.New("invalid signature")
+	ErrSignExpired    = errors.New("signature expired")
+)
+
+// ObjectInfo 对象的元数据
+type ObjectInfo struct {
+	Key  string
+	Size int64
+}
+
+// Storage 附件的对象存储
+//
+// 客户端通过预签名的地址直接上传与下载对象，服务端只负责签发地址与读取对象生成缩略图。
+type Storage interface {
+	// PresignPut 签发上传对象的地址，客户端以 PUT 请求上传，上传的大小必须为 size
+	PresignPut(ctx context.Context, key string, size int64, expire time.Duration) (string, error)
+	// PresignGet 签发下载对象的地址，客户端以 GET 请求下载
+	PresignGet(ctx context.Context, key string, expire time.Duration) (string, error)
+
+	Put(ctx context.Context, key string, r io.Reader) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Stat(ctx context.Context, key string) (*ObjectInfo, error)
+	Delete(ctx context.Context, key string) error
+}
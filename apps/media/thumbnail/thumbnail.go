@@ -0,0 +1,91 @@
+// 为图片附件生成缩略图
+
+package thumbnail
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+)
+
+// DefaultMaxSide 缩略图最长边的默认像素
+const DefaultMaxSide = 240
+
+// MaxPixels 可以生成缩略图的原图最大像素数，解码时每个像素约占 4 字节内存
+var MaxPixels int64 = 40 * 1000 * 1000
+
+var ErrTooLarge = errors.New("thumbnail: image too large")
+
+// Result 缩略图与原图的尺寸
+type Result struct {
+	Data   []byte // JPEG 编码的缩略图
+	Width  int    // 原图宽度
+	Height int    // 原图高度
+}
+
+// Generate 按比例缩小图片，使最长边不超过 maxSide，原图更小时保持原尺寸
+//
+// 解码之前先读取图片头中的尺寸，超过 MaxPixels 时返回 ErrTooLarge，避免解码超大图片耗尽内存。
+func Generate(r io.Reader, maxSide int) (*Result, error) {
+	if maxSide <= 0 {
+		maxSide = DefaultMaxSide
+	}
+
+	var head bytes.Buffer
+	config, _, err := image.DecodeConfig(io.TeeReader(r, &head))
+	if err != nil {
+		return nil, err
+	}
+	if config.Width <= 0 || config.Height <= 0 || int64(config.Width)*int64(config.Height) > MaxPixels {
+		return nil, ErrTooLarge
+	}
+
+	src, _, err := image.Decode(io.MultiReader(&head, r))
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		bounds = src.Bounds()
+		w, h   = bounds.Dx(), bounds.Dy()
+		tw, th = scale(w, h, maxSide)
+		dst    = image.NewRGBA(image.Rect(0, 0, tw, th))
+	)
+	if tw == w && th == h {
+		draw.Draw(dst, dst.Bounds(), src, bounds.Min, draw.Src)
+	} else {
+		// 最近邻采样，缩略图对画质要求不高
+		for y := 0; y < th; y++ {
+			sy := bounds.Min.Y + y*h/th
+			for x := 0; x < tw; x++ {
+				dst.Set(x, y, src.At(bounds.Min.X+x*w/tw, sy))
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 80}); err != nil {
+		return nil, err
+	}
+	return &Result{
+		Data:   buf.Bytes(),
+		Width:  w,
+		Height: h,
+	}, nil
+}
+
+// scale 计算缩略图的尺寸
+func scale(w, h, maxSide int) (int, int) {
+	if w <= maxSide && h <= maxSide {
+		return w, h
+	}
+	if w >= h {
+		return maxSide, max(1, h*maxSide/w)
+	}
+	return max(1, w*maxSide/h), maxSide
+}
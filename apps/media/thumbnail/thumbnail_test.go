@@ -0,0 +1,76 @@
+package thumbnail
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func pngImage(t *testing.T, w, h int) *bytes.Buffer {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return &buf
+}
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name         string
+		w, h         int
+		maxSide      int
+		wantW, wantH int
+	}{
+		{"landscape", 800, 400, 200, 200, 100},
+		{"portrait", 300, 900, 90, 30, 90},
+		{"small", 50, 40, 200, 50, 40},
+		{"thin", 1000, 2, 100, 100, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := Generate(pngImage(t, tt.w, tt.h), tt.maxSide)
+			if err != nil {
+				t.Fatalf("Generate() err = %v", err)
+			}
+			if res.Width != tt.w || res.Height != tt.h {
+				t.Errorf("source size = %dx%d, want %dx%d", res.Width, res.Height, tt.w, tt.h)
+			}
+
+			thumb, err := jpeg.Decode(bytes.NewReader(res.Data))
+			if err != nil {
+				t.Fatalf("decode thumbnail err = %v", err)
+			}
+			if b := thumb.Bounds(); b.Dx() != tt.wantW || b.Dy() != tt.wantH {
+				t.Errorf("thumbnail size = %dx%d, want %dx%d", b.Dx(), b.Dy(), tt.wantW, tt.wantH)
+			}
+		})
+	}
+}
+
+func TestGenerate_NotImage(t *testing.T) {
+	if _, err := Generate(strings.NewReader("not an image"), 0); err == nil {
+		t.Error("Generate() on non-image returned nil error")
+	}
+}
+
+func TestGenerate_TooLarge(t *testing.T) {
+	defer func(n int64) { MaxPixels = n }(MaxPixels)
+	MaxPixels = 100 * 100
+
+	if _, err := Generate(pngImage(t, 101, 100), 0); err != ErrTooLarge {
+		t.Errorf("Generate() err = %v, want %v", err, ErrTooLarge)
+	}
+	if _, err := Generate(pngImage(t, 100, 100), 0); err != nil {
+		t.Errorf("Generate() at the limit err = %v", err)
+	}
+}
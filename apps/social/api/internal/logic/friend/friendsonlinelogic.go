@@ -3,7 +3,6 @@ package friend
 import (
 	"context"
 	"easy-chat/apps/social/rpc/social"
-	"easy-chat/pkg/constants"
 	"easy-chat/pkg/ctxdata"
 
 	"easy-chat/apps/social/api/internal/svc"
@@ -31,8 +30,8 @@ func NewFriendsOnlineLogic(ctx context.Context, svcCtx *svc.ServiceContext) *Fri
 // 功能描述:
 //   - 从上下文中获取当前用户ID
 //   - 查询当前用户的所有好友列表
-//   - 查询缓存中在线的用户
-//   - 比较好友列表与缓存中的在线用户列表，返回每个好友的在线状态
+//   - 批量查询好友的在线状态
+//   - 返回每个好友是否在线以及最后在线的时间
 //
 // 参数:
 //   - req: `*types.FriendsOnlineReq` 类型，包含请求参数（当前未使用）
@@ -61,31 +60,27 @@ func (l *FriendsOnlineLogic) FriendsOnline(req *types.FriendsOnlineReq) (resp *t
 	// 提取好友ID列表
 	uids := make([]string, 0, len(friendList.List))
 	for _, friend := range friendList.List {
-		uids = append(uids, friend.UserId)
+		uids = append(uids, friend.FriendUid)
 	}
 
-	// 查询Redis缓存中的在线用户
-	onlines, err := l.svcCtx.Redis.Hgetall(constants.RedisOnlineUser)
+	// 批量查询好友的在线状态
+	statuses, err := l.svcCtx.Presence.Lookup(l.ctx, uids)
 	if err != nil {
-		// 如果查询Redis缓存失败，返回错误信息
+		// 如果查询在线状态失败，返回错误信息
 		return nil, err
 	}
 
-	// 构建在线状态的映射表
+	// 构建在线状态与最后在线时间的映射表
 	resOnlineList := make(map[string]bool, len(uids))
-	for _, uid := range uids {
-		// 检查每个好友ID是否在在线用户列表中
-		if _, ok := onlines[uid]; ok {
-			// 如果好友在在线用户列表中，则标记为在线（即未找到，说明是离线状态）
-			resOnlineList[uid] = true
-		} else {
-			// 如果好友不在在线用户列表中，则标记为离线
-			resOnlineList[uid] = false
-		}
+	resLastSeenList := make(map[string]int64, len(uids))
+	for _, status := range statuses {
+		resOnlineList[status.UserId] = status.Online
+		resLastSeenList[status.UserId] = status.LastSeen
 	}
 
 	// 返回好友在线状态的响应
 	return &types.FriendsOnlineResp{
-		OnlineList: resOnlineList,
+		OnlineList:   resOnlineList,
+		LastSeenList: resLastSeenList,
 	}, nil
 }
@@ -7,6 +7,7 @@ import (
 	"easy-chat/apps/social/rpc/socialclient"
 	"easy-chat/apps/user/rpc/userclient"
 	interceptor "easy-chat/pkg/intercepter"
+	"easy-chat/pkg/presence"
 	"github.com/zeromicro/go-zero/core/stores/redis"
 	"github.com/zeromicro/go-zero/rest"
 	"github.com/zeromicro/go-zero/zrpc"
@@ -34,10 +35,10 @@ type ServiceContext struct {
 	Config                config.Config
 	LimitMiddleware       rest.Middleware
 	IdempotenceMiddleware rest.Middleware
-	socialclient.Social   // 社交服务客户端
-	userclient.User       // 用户服务客户端
-	imclient.Im           // 即时通讯服务客户端
-	*redis.Redis          // Redis 客户端
+	socialclient.Social                   // 社交服务客户端
+	userclient.User                       // 用户服务客户端
+	imclient.Im                           // 即时通讯服务客户端
+	Presence              *presence.Store // 用户的在线状态
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
@@ -49,7 +50,8 @@ func NewServiceContext(c config.Config) *ServiceContext {
 			zrpc.WithDialOption(grpc.WithDefaultServiceConfig(retryPolicy)),
 			zrpc.WithUnaryClientInterceptor(interceptor.DefaultIdempotentClient),
 		)),
-		User: userclient.NewUser(zrpc.MustNewClient(c.UserRpc)),
-		Im:   imclient.NewIm(zrpc.MustNewClient(c.ImRpc)),
+		User:     userclient.NewUser(zrpc.MustNewClient(c.UserRpc)),
+		Im:       imclient.NewIm(zrpc.MustNewClient(c.ImRpc)),
+		Presence: presence.NewStore(redis.MustNewRedis(c.Redisx), presence.DefaultTTL),
 	}
 }
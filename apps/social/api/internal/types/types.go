@@ -139,7 +139,8 @@ type FriendsOnlineReq struct {
 }
 
 type FriendsOnlineResp struct {
-	OnlineList map[string]bool `json:"onLineList"`
+	OnlineList   map[string]bool  `json:"onLineList"`
+	LastSeenList map[string]int64 `json:"lastSeenList"`
 }
 
 type GroupUserOnlineReq struct {
@@ -147,5 +148,6 @@ type GroupUserOnlineReq struct {
 }
 
 type GroupUserOnlineResp struct {
-	OnlineList map[string]bool `json:"onLineList"`
+	OnlineList   map[string]bool  `json:"onLineList"`
+	LastSeenList map[string]int64 `json:"lastSeenList"`
 }
@@ -9,16 +9,29 @@ import (
 
 type Config struct {
 	service.ServiceConf
-	ListenOn        string
-	MsgChatTransfer kq.KqConf
-	SocialRpc       zrpc.RpcClientConf
-	MsgReadTransfer kq.KqConf
-	Redisx          redis.RedisConf
-	MsgReadHandler  struct {
+	ListenOn         string
+	MsgChatTransfer  kq.KqConf
+	SocialRpc        zrpc.RpcClientConf
+	MsgReadTransfer  kq.KqConf
+	MsgEventTransfer kq.KqConf
+	Redisx           redis.RedisConf
+	MsgChatHandler   struct {
+		BatchSize       int
+		BatchLingerTime int64
+	}
+	MsgReadHandler struct {
 		GroupMsgReadHandler          int
 		GroupMsgReadRecordDelayTime  int64
 		GroupMsgReadRecordDelayCount int
 	}
+	ScheduledMsgHandler struct {
+		Interval     int64
+		ClaimTimeout int64
+	}
+	Migrate struct {
+		Conversations bool
+		ReadRecords   bool
+	}
 	Mongo struct {
 		Url string
 		Db  string
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"easy-chat/apps/task/mq/internal/handler/msgTransfer"
+	"easy-chat/apps/task/mq/internal/handler/scheduledMsg"
 	"easy-chat/apps/task/mq/internal/svc"
 	"github.com/zeromicro/go-queue/kq"
 	"github.com/zeromicro/go-zero/core/service"
@@ -21,6 +22,10 @@ func (l *Listen) Services() []service.Service {
 	return []service.Service{
 		//todo: 此处可以加载多个消费者
 		kq.MustNewQueue(l.svc.Config.MsgReadTransfer, msgTransfer.NewMsgReadTransfer(l.svc)),
-		kq.MustNewQueue(l.svc.Config.MsgChatTransfer, msgTransfer.NewMsgChatTransfer(l.svc)),
+		//聊天消息按读取顺序批量写入，写入之后再提交 offset
+		msgTransfer.NewMsgChatTransfer(l.svc, msgTransfer.NewMsgChatReader(l.svc.Config.MsgChatTransfer)),
+		kq.MustNewQueue(l.svc.Config.MsgEventTransfer, msgTransfer.NewMsgEventTransfer(l.svc)),
+		//定时消息到期后投递到 msgChatTransfer
+		scheduledMsg.NewSender(l.svc),
 	}
 }
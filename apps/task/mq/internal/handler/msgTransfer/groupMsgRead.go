@@ -2,149 +2,164 @@ package msgTransfer
 
 import (
 	"easy-chat/apps/im/ws/ws"
-	"easy-chat/pkg/constants"
 	"github.com/zeromicro/go-zero/core/logx"
+	"strconv"
 	"sync"
 	"time"
 )
 
+// clock 抽象计时器的创建，便于在测试中替换为可控的时钟
+type clock interface {
+	AfterFunc(d time.Duration, f func()) timer
+}
+
+type timer interface {
+	Stop() bool
+}
+
+type realClock struct{}
+
+func (realClock) AfterFunc(d time.Duration, f func()) timer {
+	return time.AfterFunc(d, f)
+}
+
+// groupMsgRead 合并一个群在一段时间窗口内的已读回执
+//
+// 生命周期：
+//   - 收到群的第一条已读回执时创建，并开启一个 GroupMsgReadRecordDelayTime 的窗口
+//   - 窗口内的回执合并到同一条推送中，达到 GroupMsgReadRecordDelayCount 时立即推送并开启新窗口
+//   - 窗口到期时推送已合并的数据并开启新窗口；若整个窗口内没有新的回执则关闭并通知释放
+//
+// 等待期间不占用协程，只持有一个计时器，因此可以同时存在大量活跃的群。
 type groupMsgRead struct {
 	mu sync.Mutex
 
 	conversationId string // 会话ID
 
-	push     *ws.Push      // 用于记录消息
-	pushChan chan *ws.Push // 推送消息
+	push  *ws.Push // 用于记录消息
+	count int      // 计数
 
-	count    int       // 计数
-	pushTime time.Time // 上次推送时间
+	clock  clock
+	timer  timer
+	gen    int  // 计时器的代数，用于忽略过期的回调
+	closed bool // 是否已关闭
 
-	done chan struct{}
+	emit   func(push *ws.Push)       // 推送消息
+	onIdle func(group *groupMsgRead) // 空闲关闭后的通知
 }
 
-func newGroupMsgRead(push *ws.Push, pushChan chan *ws.Push) *groupMsgRead {
-	m := &groupMsgRead{
+func newGroupMsgRead(push *ws.Push, clock clock, emit func(push *ws.Push), onIdle func(group *groupMsgRead)) *groupMsgRead {
+	g := &groupMsgRead{
 		conversationId: push.ConversationId,
 		push:           push,
-		pushChan:       pushChan,
 		count:          1,
-		pushTime:       time.Now(),
-		done:           make(chan struct{}),
+		clock:          clock,
+		emit:           emit,
+		onIdle:         onIdle,
 	}
 
-	go m.transfer()
-	return m
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.schedule()
+	return g
 }
 
 // mergePush 合并消息
-func (g *groupMsgRead) mergePush(push *ws.Push) {
+//
+// 返回值 ok 为 false 表示该聚合器已经关闭，调用方需要重新创建；
+// 返回的 push 不为空表示达到了最大计数，需要由调用方立即推送。
+func (g *groupMsgRead) mergePush(push *ws.Push) (full *ws.Push, ok bool) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
-	// 说明已经被清理，重新设置
+
+	if g.closed {
+		return nil, false
+	}
+
+	// 说明已经推送过，重新设置
 	if g.push == nil {
 		g.push = push
+	} else {
+		mergeReadSeqs(g.push.ReadRecords, push.ReadRecords)
 	}
-
 	g.count++
-	for msgId, read := range push.ReadRecords {
-		g.push.ReadRecords[msgId] = read
+
+	if g.count < GroupMsgReadRecordDelayCount {
+		return nil, true
 	}
+
+	// 达标，推送并开启新的窗口
+	full = g.take()
+	g.schedule()
+	return full, true
 }
 
-func (g *groupMsgRead) transfer() {
-	// 超时发送
-	timer := time.NewTimer(GroupMsgReadRecordDelayTime / 2)
-	defer timer.Stop()
-	for {
-		select {
-		case <-g.done:
-			return
-		case <-timer.C:
-			g.mu.Lock()
-			// 获取上一次推送时间
-			lastPushTime := g.pushTime
-			// *2，防止在推送时刻由于阻塞未推送
-			val := GroupMsgReadRecordDelayTime*2 - time.Since(lastPushTime)
-			// 得到待推送的数据
-			push := g.push
-			// 当前没有超时且没有超过最大计数，或数据为空
-			if val > 0 && g.count < GroupMsgReadRecordDelayCount || push == nil {
-				// 重置定时器
-				if val > 0 {
-					timer.Reset(val)
-				}
-				// 未达标
-				g.mu.Unlock()
-				continue
-			}
-			// 达标
-			g.pushTime = time.Now()
-			g.push = nil
-			g.count = 0
-			timer.Reset(GroupMsgReadRecordDelayTime / 2)
-			g.mu.Unlock()
-			// 推送
-			logx.Infof("merge push delay time condition reached, push: %v ", push)
-			g.pushChan <- push
-		default:
-			g.mu.Lock()
-			if g.count >= GroupMsgReadRecordDelayCount {
-				// 达标，推送
-				// 得到待推送的数据
-				push := g.push
-				g.push = nil
-				g.count = 0
-				g.mu.Unlock()
-				// 推送
-				logx.Infof("merge push max delay count condition reached, push: %v ", push)
-				g.pushChan <- push
-				continue
-			}
-			if g.isIdle() {
-				g.mu.Unlock()
-				// 使得 msgReadTransfer 释放
-				g.pushChan <- &ws.Push{
-					ChatType:       constants.GroupChatType,
-					ConversationId: g.conversationId,
-				}
-				continue
-			}
-			g.mu.Unlock()
-			// 睡眠等待一段时间，最大为一秒钟
-			tempDelay := GroupMsgReadRecordDelayTime / 4
-			if tempDelay > time.Second {
-				tempDelay = time.Second
-			}
-			time.Sleep(tempDelay)
+// mergeReadSeqs 合并已读水位，同一用户保留较大的序号
+func mergeReadSeqs(dst, src map[string]string) {
+	for uid, seq := range src {
+		if old, ok := dst[uid]; ok && parseSeq(old) >= parseSeq(seq) {
+			continue
 		}
+		dst[uid] = seq
 	}
 }
 
-// IsIdle 判断是否为活跃状态
-func (g *groupMsgRead) IsIdle() bool {
+func parseSeq(s string) int64 {
+	seq, _ := strconv.ParseInt(s, 10, 64)
+	return seq
+}
+
+// onTimer 窗口到期
+func (g *groupMsgRead) onTimer(gen int) {
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	if g.closed || gen != g.gen {
+		g.mu.Unlock()
+		return
+	}
+
+	push := g.take()
+	if push == nil {
+		// 整个窗口内没有新的回执，关闭
+		g.closed = true
+		g.mu.Unlock()
+		g.onIdle(g)
+		return
+	}
+	g.schedule()
+	g.mu.Unlock()
 
-	return g.isIdle()
+	logx.Infof("merge push delay time condition reached, push: %v ", push)
+	g.emit(push)
 }
 
-func (g *groupMsgRead) isIdle() bool {
-	// 获取上一次推送时间
-	lastPushTime := g.pushTime
-	// *2，防止在推送时刻由于阻塞未推送
-	val := GroupMsgReadRecordDelayTime*2 - time.Since(lastPushTime)
-	if val <= 0 && g.push == nil && g.count == 0 {
-		return true
-	}
-	return false
+// take 取出待推送的数据，调用方需持有锁
+func (g *groupMsgRead) take() *ws.Push {
+	push := g.push
+	g.push = nil
+	g.count = 0
+	return push
 }
 
-func (m *groupMsgRead) Clear() {
-	select {
-	case <-m.done:
-	default:
-		close(m.done)
+// schedule 开启新的窗口，调用方需持有锁
+func (g *groupMsgRead) schedule() {
+	if g.timer != nil {
+		g.timer.Stop()
 	}
+	g.gen++
+	gen := g.gen
+	g.timer = g.clock.AfterFunc(GroupMsgReadRecordDelayTime, func() {
+		g.onTimer(gen)
+	})
+}
+
+// Clear 关闭聚合器，丢弃未推送的数据
+func (g *groupMsgRead) Clear() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
 
-	m.push = nil
+	g.closed = true
+	g.push = nil
+	if g.timer != nil {
+		g.timer.Stop()
+	}
 }
@@ -0,0 +1,305 @@
+package msgTransfer
+
+import (
+	"context"
+	"easy-chat/apps/im/ws/ws"
+	"easy-chat/pkg/constants"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// fakeClock 手动推进的时钟，到期的回调在 Advance 中同步执行
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Duration
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	c       *fakeClock
+	at      time.Duration
+	f       func()
+	stopped bool
+}
+
+func (c *fakeClock) AfterFunc(d time.Duration, f func()) timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &fakeTimer{c: c, at: c.now + d, f: f}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.c.mu.Lock()
+	defer t.c.mu.Unlock()
+
+	active := !t.stopped
+	t.stopped = true
+	return active
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now += d
+	var (
+		due     []*fakeTimer
+		pending []*fakeTimer
+	)
+	for _, t := range c.timers {
+		switch {
+		case t.stopped:
+		case t.at <= c.now:
+			t.stopped = true
+			due = append(due, t)
+		default:
+			pending = append(pending, t)
+		}
+	}
+	c.timers = pending
+	c.mu.Unlock()
+
+	sort.SliceStable(due, func(i, j int) bool { return due[i].at < due[j].at })
+	for _, t := range due {
+		t.f()
+	}
+}
+
+// recorder 记录推送与释放
+type recorder struct {
+	mu     sync.Mutex
+	pushes []*ws.Push
+	idles  []*groupMsgRead
+}
+
+func (r *recorder) emit(push *ws.Push) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pushes = append(r.pushes, push)
+}
+
+func (r *recorder) onIdle(g *groupMsgRead) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.idles = append(r.idles, g)
+}
+
+func withReadConfig(t *testing.T, delay time.Duration, count int) {
+	oldDelay, oldCount := GroupMsgReadRecordDelayTime, GroupMsgReadRecordDelayCount
+	GroupMsgReadRecordDelayTime, GroupMsgReadRecordDelayCount = delay, count
+	t.Cleanup(func() {
+		GroupMsgReadRecordDelayTime, GroupMsgReadRecordDelayCount = oldDelay, oldCount
+	})
+}
+
+func readPush(conversationId string, uids ...string) *ws.Push {
+	records := make(map[string]string, len(uids))
+	for _, uid := range uids {
+		records[uid] = "1"
+	}
+	return &ws.Push{
+		ConversationId: conversationId,
+		ChatType:       constants.GroupChatType,
+		RecvId:         conversationId,
+		ContentType:    constants.ContentMakeRead,
+		ReadRecords:    records,
+	}
+}
+
+func TestGroupMsgRead_MergeUntilDelay(t *testing.T) {
+	withReadConfig(t, time.Second, 10)
+	var (
+		c   = new(fakeClock)
+		rec = new(recorder)
+		g   = newGroupMsgRead(readPush("g1", "m1"), c, rec.emit, rec.onIdle)
+	)
+
+	if full, ok := g.mergePush(readPush("g1", "m2")); !ok || full != nil {
+		t.Fatalf("mergePush() = %v, %v, want nil, true", full, ok)
+	}
+	c.Advance(500 * time.Millisecond)
+	if len(rec.pushes) != 0 {
+		t.Fatalf("pushed before delay: %v", rec.pushes)
+	}
+
+	c.Advance(500 * time.Millisecond)
+	if len(rec.pushes) != 1 {
+		t.Fatalf("pushes = %d, want 1", len(rec.pushes))
+	}
+	if got := rec.pushes[0].ReadRecords; len(got) != 2 || got["m1"] == "" || got["m2"] == "" {
+		t.Errorf("merged read records = %v", got)
+	}
+	if len(rec.idles) != 0 {
+		t.Errorf("released after a busy window")
+	}
+}
+
+func TestGroupMsgRead_MergeKeepsMaxSeq(t *testing.T) {
+	withReadConfig(t, time.Second, 10)
+	var (
+		c     = new(fakeClock)
+		rec   = new(recorder)
+		first = readPush("g1")
+	)
+	first.ReadRecords["u1"] = "5"
+	g := newGroupMsgRead(first, c, rec.emit, rec.onIdle)
+
+	older := readPush("g1")
+	older.ReadRecords["u1"] = "3"
+	g.mergePush(older)
+	newer := readPush("g1")
+	newer.ReadRecords["u1"] = "12"
+	newer.ReadRecords["u2"] = "7"
+	g.mergePush(newer)
+
+	c.Advance(time.Second)
+	if len(rec.pushes) != 1 {
+		t.Fatalf("pushes = %d, want 1", len(rec.pushes))
+	}
+	if got := rec.pushes[0].ReadRecords; got["u1"] != "12" || got["u2"] != "7" {
+		t.Errorf("merged read seqs = %v", got)
+	}
+}
+
+func TestGroupMsgRead_FlushOnCount(t *testing.T) {
+	withReadConfig(t, time.Second, 3)
+	var (
+		c   = new(fakeClock)
+		rec = new(recorder)
+		g   = newGroupMsgRead(readPush("g1", "m1"), c, rec.emit, rec.onIdle)
+	)
+
+	g.mergePush(readPush("g1", "m2"))
+	full, ok := g.mergePush(readPush("g1", "m3"))
+	if !ok || full == nil {
+		t.Fatalf("mergePush() = %v, %v, want full push", full, ok)
+	}
+	if len(full.ReadRecords) != 3 {
+		t.Errorf("full read records = %v", full.ReadRecords)
+	}
+
+	// 推送后开启新的窗口，窗口内没有回执则不会重复推送
+	c.Advance(time.Second)
+	if len(rec.pushes) != 0 {
+		t.Errorf("pushes after count flush = %v", rec.pushes)
+	}
+	if len(rec.idles) != 1 {
+		t.Errorf("idles = %d, want 1", len(rec.idles))
+	}
+}
+
+func TestGroupMsgRead_IdleLifecycle(t *testing.T) {
+	withReadConfig(t, time.Second, 10)
+	var (
+		c   = new(fakeClock)
+		rec = new(recorder)
+		g   = newGroupMsgRead(readPush("g1", "m1"), c, rec.emit, rec.onIdle)
+	)
+
+	c.Advance(time.Second)
+	if len(rec.pushes) != 1 || len(rec.idles) != 0 {
+		t.Fatalf("first window: pushes %d idles %d", len(rec.pushes), len(rec.idles))
+	}
+
+	c.Advance(time.Second)
+	if len(rec.idles) != 1 || rec.idles[0] != g {
+		t.Fatalf("empty window: idles %d", len(rec.idles))
+	}
+	if _, ok := g.mergePush(readPush("g1", "m2")); ok {
+		t.Errorf("mergePush() on closed group returned ok")
+	}
+
+	// 关闭后不再有计时器
+	c.Advance(time.Hour)
+	if len(rec.pushes) != 1 || len(rec.idles) != 1 {
+		t.Errorf("closed group still active: pushes %d idles %d", len(rec.pushes), len(rec.idles))
+	}
+}
+
+func TestGroupMsgRead_Clear(t *testing.T) {
+	withReadConfig(t, time.Second, 10)
+	var (
+		c   = new(fakeClock)
+		rec = new(recorder)
+		g   = newGroupMsgRead(readPush("g1", "m1"), c, rec.emit, rec.onIdle)
+	)
+
+	g.Clear()
+	c.Advance(time.Second)
+	if len(rec.pushes) != 0 || len(rec.idles) != 0 {
+		t.Errorf("cleared group still active: pushes %d idles %d", len(rec.pushes), len(rec.idles))
+	}
+}
+
+func newTestMsgReadTransfer(c clock, size int) *MsgReadTransfer {
+	return &MsgReadTransfer{
+		baseMsgTransfer: &baseMsgTransfer{Logger: logx.WithContext(context.Background())},
+		groupMsgs:       make(map[string]*groupMsgRead),
+		push:            make(chan *ws.Push, size),
+		clock:           c,
+	}
+}
+
+func TestMsgReadTransfer_mergeGroupPush(t *testing.T) {
+	withReadConfig(t, time.Second, 10)
+	var (
+		c = new(fakeClock)
+		m = newTestMsgReadTransfer(c, 10)
+	)
+
+	m.mergeGroupPush(readPush("g1", "m1"))
+	m.mergeGroupPush(readPush("g1", "m2"))
+	if len(m.groupMsgs) != 1 {
+		t.Fatalf("groupMsgs = %d, want 1", len(m.groupMsgs))
+	}
+
+	c.Advance(time.Second)
+	if len(m.push) != 1 {
+		t.Fatalf("pushes = %d, want 1", len(m.push))
+	}
+	<-m.push
+
+	// 空闲后释放，新的回执重新创建聚合器
+	c.Advance(time.Second)
+	if len(m.groupMsgs) != 0 {
+		t.Fatalf("groupMsgs = %d after idle, want 0", len(m.groupMsgs))
+	}
+	m.mergeGroupPush(readPush("g1", "m3"))
+	c.Advance(time.Second)
+	if len(m.push) != 1 {
+		t.Fatalf("pushes = %d after recreate, want 1", len(m.push))
+	}
+	if got := (<-m.push).ReadRecords; len(got) != 1 || got["m3"] == "" {
+		t.Errorf("recreated read records = %v", got)
+	}
+}
+
+func TestMsgReadTransfer_ManyGroups(t *testing.T) {
+	withReadConfig(t, time.Second, 10)
+	const groups = 10000
+	var (
+		c = new(fakeClock)
+		m = newTestMsgReadTransfer(c, groups)
+	)
+
+	for i := 0; i < groups; i++ {
+		id := fmt.Sprintf("g%d", i)
+		m.mergeGroupPush(readPush(id, "m1"))
+		m.mergeGroupPush(readPush(id, "m2"))
+	}
+
+	c.Advance(time.Second)
+	if len(m.push) != groups {
+		t.Fatalf("pushes = %d, want %d", len(m.push), groups)
+	}
+	c.Advance(time.Second)
+	if len(m.groupMsgs) != 0 {
+		t.Errorf("groupMsgs = %d after idle, want 0", len(m.groupMsgs))
+	}
+}
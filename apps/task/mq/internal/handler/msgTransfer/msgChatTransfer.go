@@ -6,56 +6,520 @@ import (
 	"easy-chat/apps/im/ws/ws"
 	"easy-chat/apps/task/mq/internal/svc"
 	"easy-chat/apps/task/mq/mq"
-	"easy-chat/pkg/bitmap"
+	"easy-chat/pkg/constants"
 	"encoding/json"
-	"fmt"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/zeromicro/go-queue/kq"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+var (
+	MsgChatBatchSize       = 100
+	MsgChatBatchLingerTime = 100 * time.Millisecond
+	// 写入聊天记录失败后重试的间隔，重试使用已经分配的序号，直到写入成功或服务停止
+	MsgChatPersistRetryInterval = 100 * time.Millisecond
+)
+
+// errTransferStopped 服务停止时放弃重试，批次不提交 offset，重启后由 kafka 重新投递
+var errTransferStopped = errors.New("msg chat transfer stopped")
+
+// Reader 按分区顺序读取 kafka 消息，由 kafka.Reader 实现
+type Reader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// MsgChatTransfer 批量写入并推送聊天消息
+//
+// 不使用 kq 的消费者：kq 的多个 Processors 并发处理消息，同一会话的消息可能乱序进入批次，
+// 而且处理失败的消息会被之后提交的 offset 覆盖。这里只用一个协程按读取顺序组成批次，
+// 序号的分配与 kafka 分区内的顺序一致；批次写入成功后才提交其中的 offset，
+// 写入失败时一直重试并阻塞后续的批次，服务停止时未写入的批次由 kafka 重新投递。
 type MsgChatTransfer struct {
 	*baseMsgTransfer
+	reader    Reader
+	msgs      chan kafka.Message
+	batchSize int
+	linger    time.Duration
+
+	done     chan struct{}
+	stopOnce sync.Once
 }
 
-func NewMsgChatTransfer(svc *svc.ServiceContext) *MsgChatTransfer {
+func NewMsgChatTransfer(svc *svc.ServiceContext, reader Reader) *MsgChatTransfer {
+	// 批量大小
+	if svc.Config.MsgChatHandler.BatchSize > 0 {
+		MsgChatBatchSize = svc.Config.MsgChatHandler.BatchSize
+	}
+	// 批量等待时间
+	if svc.Config.MsgChatHandler.BatchLingerTime > 0 {
+		MsgChatBatchLingerTime = time.Duration(svc.Config.MsgChatHandler.BatchLingerTime) * time.Millisecond
+	}
+
 	return &MsgChatTransfer{
-		NewBaseMsgTransfer(svc),
+		baseMsgTransfer: NewBaseMsgTransfer(svc),
+		reader:          reader,
+		msgs:            make(chan kafka.Message, MsgChatBatchSize),
+		batchSize:       MsgChatBatchSize,
+		linger:          MsgChatBatchLingerTime,
+		done:            make(chan struct{}),
 	}
 }
 
-//实现kafka消费者
+// NewMsgChatReader 按 kq 的配置创建消费组的 kafka 读取者，offset 只在写入之后由 MsgChatTransfer 提交
+func NewMsgChatReader(c kq.KqConf) *kafka.Reader {
+	offset := kafka.LastOffset
+	if c.Offset == "first" {
+		offset = kafka.FirstOffset
+	}
+	return kafka.NewReader(kafka.ReaderConfig{
+		Brokers:        c.Brokers,
+		GroupID:        c.Group,
+		Topic:          c.Topic,
+		StartOffset:    offset,
+		MinBytes:       c.MinBytes,
+		MaxBytes:       c.MaxBytes,
+		CommitInterval: time.Second,
+	})
+}
 
-func (m *MsgChatTransfer) Consume(ctx context.Context, key, value string) error {
-	fmt.Println("key: ", key, "value: ", value)
+// Start 读取并处理消息，直到服务停止或读取者关闭
+func (m *MsgChatTransfer) Start() {
+	go m.fetch()
+	m.transfer()
+}
+
+// Stop 停止读取，正在重试的批次放弃写入
+func (m *MsgChatTransfer) Stop() {
+	m.stopOnce.Do(func() {
+		close(m.done)
+		if err := m.reader.Close(); err != nil {
+			m.Errorf("msg chat reader close err %v", err)
+		}
+	})
+}
+
+// stopped 等待 d 之后返回服务是否已经停止，等待期间停止时立即返回
+func (m *MsgChatTransfer) stopped(d time.Duration) bool {
+	select {
+	case <-m.done:
+		return true
+	default:
+	}
+
+	select {
+	case <-m.done:
+		return true
+	case <-time.After(d):
+		return false
+	}
+}
+
+// fetch 按读取顺序把消息交给 transfer，读取者关闭后结束
+func (m *MsgChatTransfer) fetch() {
+	defer close(m.msgs)
+	for {
+		msg, err := m.reader.FetchMessage(context.Background())
+		if err == io.EOF || errors.Is(err, io.ErrClosedPipe) {
+			return
+		}
+		if err != nil {
+			m.Errorf("msg chat fetch err %v", err)
+			continue
+		}
+		m.msgs <- msg
+	}
+}
+
+// transfer 聚合消息，达到批量大小或等待超时后统一写入并推送
+//
+// 只有一个协程按读取顺序处理批次，保证同一会话内的消息顺序。
+func (m *MsgChatTransfer) transfer() {
 	var (
-		data  mq.MsgChatTransfer
-		msgId = primitive.NewObjectID()
+		batch  = make([]kafka.Message, 0, m.batchSize)
+		linger <-chan time.Time
 	)
-	if err := json.Unmarshal([]byte(value), &data); err != nil {
-		return err
+	for {
+		select {
+		case msg, ok := <-m.msgs:
+			if !ok {
+				if len(batch) > 0 {
+					m.flush(context.Background(), batch)
+				}
+				return
+			}
+			batch = append(batch, msg)
+			if len(batch) == 1 {
+				linger = time.After(m.linger)
+			}
+			if len(batch) < m.batchSize {
+				continue
+			}
+		case <-linger:
+		}
+
+		m.flush(context.Background(), batch)
+		batch = make([]kafka.Message, 0, m.batchSize)
+		linger = nil
 	}
+}
 
-	//记录数据
-	if err := m.addChatLog(ctx, msgId, &data); err != nil {
-		return err
+// flush 批量记录消息，写入成功后提交 offset，再更新会话并推送
+func (m *MsgChatTransfer) flush(ctx context.Context, batch []kafka.Message) {
+	msgs := make([]*mq.MsgChatTransfer, 0, len(batch))
+	for _, msg := range batch {
+		var data mq.MsgChatTransfer
+		if err := json.Unmarshal(msg.Value, &data); err != nil {
+			// 无法解析的消息重试也不会成功，直接跳过
+			m.Errorf("msg chat unmarshal err %v, offset %v", err, msg.Offset)
+			continue
+		}
+		msgs = append(msgs, &data)
 	}
 
-	return m.Transfer(ctx, &ws.Push{
-		ConversationId: data.ConversationId,
-		ChatType:       data.ChatType,
-		SendId:         data.SendId,
-		RecvId:         data.RecvId,
-		RecvIds:        data.RecvIds,
-		SendTime:       data.SendTime,
-		MType:          data.MType,
-		MsgId:          data.MsgId,
-		Content:        data.Content,
-	})
+	msgs, chatLogs, err := m.persist(ctx, msgs)
+	if err != nil {
+		m.Errorf("chatLog persist err %v, count %v", err, len(batch))
+		return
+	}
+	if err := m.reader.CommitMessages(ctx, batch...); err != nil {
+		m.Errorf("msg chat commit err %v, count %v", err, len(batch))
+	}
+	if len(chatLogs) == 0 {
+		return
+	}
+
+	//更新会话，话题中的回复不作为会话的最后一条消息
+	mainLogs := make([]*immodels.ChatLog, 0, len(chatLogs))
+	for _, chatLog := range chatLogs {
+		if chatLog.ThreadId == "" {
+			mainLogs = append(mainLogs, chatLog)
+		}
+	}
+	if err := m.svcCtx.ConversationModel.UpdateMsgs(ctx, mainLogs); err != nil {
+		m.Errorf("conversation update msgs err %v, count %v", err, len(mainLogs))
+	}
+	m.updateLastMsgTimes(ctx, mainLogs)
+	//其他用户的未读数增加，话题中的回复不计入未读数；发送者读到自己发送的消息，需要在增加未读数之后重新统计发送者的未读数
+	m.incrUnreads(ctx, mainLogs)
+	m.updateSenderReadSeqs(ctx, chatLogs)
+	//发送消息后清除发送者之前保存的草稿
+	drafts := m.clearDrafts(ctx, chatLogs)
+	//记录被提及的成员
+	m.updateMentionSeqs(ctx, chatLogs)
+
+	pushes := make([]*ws.Push, 0, len(msgs))
+	for i, data := range msgs {
+		pushes = append(pushes, &ws.Push{
+			ConversationId: data.ConversationId,
+			ChatType:       data.ChatType,
+			SendId:         data.SendId,
+			RecvId:         data.RecvId,
+			RecvIds:        data.RecvIds,
+			SendTime:       data.SendTime,
+			MType:          data.MType,
+			MsgId:          chatLogs[i].ID.Hex(),
+			ClientMsgId:    data.ClientMsgId,
+			Seq:            chatLogs[i].Seq,
+			ContentType:    constants.ContentChatMsg,
+			Content:        data.Content,
+			Body:           data.Body,
+			Quote:          data.Quote,
+			Mention:        data.Mention,
+			ThreadId:       data.ThreadId,
+		})
+	}
+	//更新话题的统计，并通知群成员
+	pushes = append(pushes, m.updateThreads(ctx, chatLogs)...)
+	//推送会话中用户的未读数
+	pushes = append(pushes, m.badges(ctx, chatLogs)...)
+	pushes = append(pushes, drafts...)
+	if err := m.TransferBatch(ctx, pushes); err != nil {
+		m.Errorf("transfer batch err %v, count %v", err, len(pushes))
+	}
+}
+
+// persist 分配序号并写入聊天记录，返回本批中新写入的消息与对应的聊天记录
+//
+// 失败时以已经分配的序号重试，已经写入的记录不会重复写入，避免重试在会话的序号中留下空洞；
+// 一直重试到写入成功，只有服务停止时返回错误。
+func (m *MsgChatTransfer) persist(ctx context.Context, batch []*mq.MsgChatTransfer) ([]*mq.MsgChatTransfer, []*immodels.ChatLog, error) {
+	var (
+		chatLogs []*immodels.ChatLog
+		err      error
+	)
+	for i := 0; ; i++ {
+		if i > 0 {
+			m.Errorf("chatLog persist err %v, retry %v", err, i)
+			if m.stopped(MsgChatPersistRetryInterval) {
+				return nil, nil, errTransferStopped
+			}
+		}
+
+		if chatLogs == nil {
+			//过滤重复投递的消息，如写入后提交 offset 之前中断、定时消息在发送任务中断后再次投递
+			var msgs []*mq.MsgChatTransfer
+			if msgs, err = m.dedupe(ctx, batch); err != nil {
+				continue
+			}
+			batch = msgs
+			chatLogs = make([]*immodels.ChatLog, 0, len(batch))
+			for _, data := range batch {
+				chatLogs = append(chatLogs, newChatLog(data))
+			}
+		}
+		//分配会话内的消息序号
+		if err = m.allocSeqs(ctx, chatLogs); err != nil {
+			continue
+		}
+		//记录数据
+		if err = m.svcCtx.ChatLogModel.InsertMany(ctx, chatLogs); err != nil {
+			continue
+		}
+		return batch, chatLogs, nil
+	}
+}
+
+// dedupe 按预先分配的聊天记录ID过滤已经写入的消息
+func (m *MsgChatTransfer) dedupe(ctx context.Context, batch []*mq.MsgChatTransfer) ([]*mq.MsgChatTransfer, error) {
+	ids := make([]string, 0, len(batch))
+	for _, data := range batch {
+		if data.ChatLogId != "" {
+			ids = append(ids, data.ChatLogId)
+		}
+	}
+	if len(ids) == 0 {
+		return batch, nil
+	}
+
+	chatLogs, err := m.svcCtx.ChatLogModel.ListByMsgIds(ctx, ids)
+	if err != nil && err != immodels.ErrNotFound {
+		return batch, err
+	}
+	written := make(map[string]bool, len(chatLogs))
+	for _, chatLog := range chatLogs {
+		written[chatLog.ID.Hex()] = true
+	}
+	return uniqueMsgs(batch, written), nil
+}
+
+// uniqueMsgs 去除已经写入以及在同一批中重复的消息，没有预先分配聊天记录ID的消息不去重
+func uniqueMsgs(batch []*mq.MsgChatTransfer, written map[string]bool) []*mq.MsgChatTransfer {
+	res := make([]*mq.MsgChatTransfer, 0, len(batch))
+	for _, data := range batch {
+		if data.ChatLogId != "" {
+			if written[data.ChatLogId] {
+				continue
+			}
+			written[data.ChatLogId] = true
+		}
+		res = append(res, data)
+	}
+	return res
+}
+
+// allocSeqs 按会话批量分配消息序号
+//
+// 同一会话在一批消息中只申请一次，序号按消息在批次中的顺序连续递增；已经分配序号的消息不再分配，
+// 部分会话申请失败后重试时只为其余的会话申请。
+func (m *MsgChatTransfer) allocSeqs(ctx context.Context, chatLogs []*immodels.ChatLog) error {
+	var (
+		conversationIds []string
+		groups          = make(map[string][]*immodels.ChatLog)
+	)
+	for _, chatLog := range chatLogs {
+		if chatLog.Seq > 0 {
+			continue
+		}
+		if _, ok := groups[chatLog.ConversationId]; !ok {
+			conversationIds = append(conversationIds, chatLog.ConversationId)
+		}
+		groups[chatLog.ConversationId] = append(groups[chatLog.ConversationId], chatLog)
+	}
+
+	for _, conversationId := range conversationIds {
+		logs := groups[conversationId]
+		n := int64(len(logs))
+		last, err := m.svcCtx.ConversationModel.IncrSeq(ctx, conversationId, logs[0].ChatType, n)
+		if err != nil {
+			return err
+		}
+		for i, chatLog := range logs {
+			chatLog.Seq = last - n + 1 + int64(i)
+		}
+	}
+	return nil
+}
+
+// updateSenderReadSeqs 将发送者的已读水位推进到其发送的最后一条消息
+func (m *MsgChatTransfer) updateSenderReadSeqs(ctx context.Context, chatLogs []*immodels.ChatLog) {
+	type key struct{ uid, conversationId string }
+
+	seqs := make(map[key]int64)
+	for _, chatLog := range chatLogs {
+		k := key{chatLog.SendId, chatLog.ConversationId}
+		if chatLog.Seq > seqs[k] {
+			seqs[k] = chatLog.Seq
+		}
+	}
+
+	for k, seq := range seqs {
+		readSeq, err := m.svcCtx.UserConversationModel.UpdateReadSeq(ctx, k.uid, k.conversationId, seq)
+		if err != nil {
+			if err != immodels.ErrNotFound {
+				m.Errorf("conversations update read seq err %v, uid %v, conversationId %v", err, k.uid, k.conversationId)
+			}
+			continue
+		}
+		if _, err := m.resetUnread(ctx, k.uid, k.conversationId, readSeq); err != nil && err != immodels.ErrNotFound {
+			m.Errorf("conversations reset unread err %v, uid %v, conversationId %v", err, k.uid, k.conversationId)
+		}
+	}
+}
+
+// updateLastMsgTimes 按会话更新用户的会话列表中最后一条消息的时间
+func (m *MsgChatTransfer) updateLastMsgTimes(ctx context.Context, chatLogs []*immodels.ChatLog) {
+	times := make(map[string]int64)
+	for _, chatLog := range chatLogs {
+		if chatLog.SendTime > times[chatLog.ConversationId] {
+			times[chatLog.ConversationId] = chatLog.SendTime
+		}
+	}
+
+	for conversationId, sendTime := range times {
+		if err := m.svcCtx.UserConversationModel.UpdateLastMsgTime(ctx, conversationId, sendTime); err != nil {
+			m.Errorf("conversations update last msg time err %v, conversationId %v", err, conversationId)
+		}
+	}
+}
+
+// clearDrafts 清除发送者在发送消息之前保存的草稿，返回同步给发送者其他设备的推送
+func (m *MsgChatTransfer) clearDrafts(ctx context.Context, chatLogs []*immodels.ChatLog) []*ws.Push {
+	type key struct{ uid, conversationId string }
+
+	var (
+		pushes []*ws.Push
+		sends  = make(map[key]*immodels.ChatLog)
+	)
+	for _, chatLog := range chatLogs {
+		k := key{chatLog.SendId, chatLog.ConversationId}
+		if last, ok := sends[k]; !ok || chatLog.SendTime > last.SendTime {
+			sends[k] = chatLog
+		}
+	}
+
+	for k, chatLog := range sends {
+		cleared, err := m.svcCtx.UserConversationModel.ClearDraft(ctx, k.uid, k.conversationId, chatLog.SendTime)
+		if err != nil {
+			m.Errorf("conversations clear draft err %v, uid %v, conversationId %v", err, k.uid, k.conversationId)
+			continue
+		}
+		if cleared {
+			pushes = append(pushes, newDraftPush(k.uid, k.conversationId, chatLog.ChatType, "", chatLog.SendTime))
+		}
+	}
+	return pushes
+}
+
+// incrUnreads 按会话与发送者增加其他用户的未读数
+func (m *MsgChatTransfer) incrUnreads(ctx context.Context, chatLogs []*immodels.ChatLog) {
+	type key struct{ conversationId, sendId string }
+
+	counts := make(map[key]int64)
+	for _, chatLog := range chatLogs {
+		counts[key{chatLog.ConversationId, chatLog.SendId}]++
+	}
+
+	for k, n := range counts {
+		if err := m.svcCtx.UserConversationModel.IncrUnread(ctx, k.conversationId, k.sendId, n); err != nil {
+			m.Errorf("conversations incr unread err %v, conversationId %v, sendId %v", err, k.conversationId, k.sendId)
+		}
+	}
 }
 
-func (m *MsgChatTransfer) addChatLog(ctx context.Context, msgId primitive.ObjectID, data *mq.MsgChatTransfer) error {
+// badges 返回推送给会话中每个用户的未读数，同一会话在一批消息中只推送一次
+func (m *MsgChatTransfer) badges(ctx context.Context, chatLogs []*immodels.ChatLog) []*ws.Push {
+	var (
+		pushes []*ws.Push
+		seen   = make(map[string]bool)
+	)
+	for _, chatLog := range chatLogs {
+		if seen[chatLog.ConversationId] {
+			continue
+		}
+		seen[chatLog.ConversationId] = true
+
+		conversations, err := m.svcCtx.UserConversationModel.ListByConversationId(ctx, chatLog.ConversationId)
+		if err != nil {
+			m.Errorf("conversations list by conversationId err %v, conversationId %v", err, chatLog.ConversationId)
+			continue
+		}
+		for _, conversation := range conversations {
+			pushes = append(pushes, newBadgePush(conversation))
+		}
+	}
+	return pushes
+}
+
+// updateThreads 更新话题的回复数与最后一条回复，返回推送给群成员的话题更新事件
+func (m *MsgChatTransfer) updateThreads(ctx context.Context, chatLogs []*immodels.ChatLog) []*ws.Push {
+	var pushes []*ws.Push
+	for _, chatLog := range chatLogs {
+		if chatLog.ThreadId == "" {
+			continue
+		}
+
+		root, err := m.svcCtx.ChatLogModel.UpdateThread(ctx, chatLog)
+		if err != nil {
+			m.Errorf("chatLog update thread err %v, threadId %v", err, chatLog.ThreadId)
+			continue
+		}
+		pushes = append(pushes, &ws.Push{
+			ConversationId: root.ConversationId,
+			ChatType:       root.ChatType,
+			SendId:         chatLog.SendId,
+			RecvId:         root.RecvId,
+			SendTime:       chatLog.SendTime,
+			MsgId:          chatLog.ThreadId,
+			Seq:            root.Seq,
+			ContentType:    constants.ContentThread,
+			Thread:         root.Thread,
+		})
+	}
+	return pushes
+}
+
+// updateMentionSeqs 将被提及成员的提及序号推进到提及他们的消息
+func (m *MsgChatTransfer) updateMentionSeqs(ctx context.Context, chatLogs []*immodels.ChatLog) {
+	for _, chatLog := range chatLogs {
+		if chatLog.Mention.IsEmpty() {
+			continue
+		}
+
+		// 提及所有人时更新会话的所有成员
+		var uids []string
+		if !chatLog.Mention.All {
+			uids = chatLog.Mention.UserIds
+		}
+		err := m.svcCtx.UserConversationModel.UpdateMentionSeq(ctx, chatLog.ConversationId, uids, chatLog.Seq)
+		if err != nil {
+			m.Errorf("conversations update mention seq err %v, conversationId %v, seq %v", err, chatLog.ConversationId, chatLog.Seq)
+		}
+	}
+}
+
+func newChatLog(data *mq.MsgChatTransfer) *immodels.ChatLog {
+	//预先分配的聊天记录ID，未分配时写入时生成
+	id, _ := primitive.ObjectIDFromHex(data.ChatLogId)
 	//记录消息
-	chatLog := immodels.ChatLog{
-		ID:             msgId,
+	return &immodels.ChatLog{
+		ID:             id,
 		ConversationId: data.ConversationId,
 		SendId:         data.SendId,
 		RecvId:         data.RecvId,
@@ -63,15 +527,10 @@ func (m *MsgChatTransfer) addChatLog(ctx context.Context, msgId primitive.Object
 		MsgType:        data.MType,
 		ChatType:       data.ChatType,
 		MsgContent:     data.Content,
+		Body:           data.Body,
+		ReplyTo:        data.Quote,
+		Mention:        data.Mention,
+		ThreadId:       data.ThreadId,
 		SendTime:       data.SendTime,
 	}
-	readRecords := bitmap.NewBitmap(0)
-	readRecords.Set(chatLog.SendId)
-	chatLog.ReadRecords = readRecords.Export()
-	//更新会话
-	err := m.svcCtx.ChatLogModel.Insert(ctx, &chatLog)
-	if err != nil {
-		return err
-	}
-	return m.svcCtx.ConversationModel.UpdateMsg(ctx, &chatLog)
 }
@@ -0,0 +1,527 @@
+package msgTransfer
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/im/ws/websocket"
+	"easy-chat/apps/im/ws/ws"
+	"easy-chat/apps/social/rpc/socialclient"
+	"easy-chat/apps/task/mq/internal/svc"
+	"easy-chat/apps/task/mq/mq"
+	"easy-chat/pkg/constants"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+)
+
+// fakeChatLogModel 记录每次批量写入的聊天记录，insertErrs 依次作为写入的结果，threads 为话题的第一条消息
+type fakeChatLogModel struct {
+	immodels.ChatLogModel
+	inserts    [][]*immodels.ChatLog
+	insertErrs []error
+	threads    map[string]*immodels.ChatLog
+}
+
+func (f *fakeChatLogModel) ListByMsgIds(ctx context.Context, msgIds []string) ([]*immodels.ChatLog, error) {
+	return nil, immodels.ErrNotFound
+}
+
+func (f *fakeChatLogModel) InsertMany(ctx context.Context, data []*immodels.ChatLog) error {
+	if len(f.insertErrs) > 0 {
+		err := f.insertErrs[0]
+		f.insertErrs = f.insertErrs[1:]
+		if err != nil {
+			return err
+		}
+	}
+	f.inserts = append(f.inserts, data)
+	return nil
+}
+
+func (f *fakeChatLogModel) UpdateThread(ctx context.Context, reply *immodels.ChatLog) (*immodels.ChatLog, error) {
+	root, ok := f.threads[reply.ThreadId]
+	if !ok {
+		return nil, immodels.ErrNotFound
+	}
+	if root.Thread == nil {
+		root.Thread = &immodels.Thread{}
+	}
+	root.Thread.ReplyCount++
+	root.Thread.LastReply = immodels.NewQuote(reply)
+	root.Thread.LastReplyTime = reply.SendTime
+	// 返回更新后的副本，与数据库中查询到的记录一致
+	res := *root
+	thread := *root.Thread
+	res.Thread = &thread
+	return &res, nil
+}
+
+func (f *fakeChatLogModel) CountUnread(ctx context.Context, conversationId, uid string, readSeq int64) (int64, error) {
+	return 0, nil
+}
+
+// fakeConversationModel 按会话递增的消息序号，记录每次申请的数量
+type fakeConversationModel struct {
+	immodels.ConversationModel
+	seqs  map[string]int64
+	incrs []seqIncr
+}
+
+type seqIncr struct {
+	conversationId string
+	n              int64
+}
+
+func (f *fakeConversationModel) IncrSeq(ctx context.Context, conversationId string, chatType constants.ChatType, n int64) (int64, error) {
+	f.seqs[conversationId] += n
+	f.incrs = append(f.incrs, seqIncr{conversationId, n})
+	return f.seqs[conversationId], nil
+}
+
+func (f *fakeConversationModel) UpdateMsgs(ctx context.Context, chatLogs []*immodels.ChatLog) error {
+	return nil
+}
+
+// fakeUserConversationModel 记录按会话与发送者增加的未读数，以及按会话推进的提及序号
+type fakeUserConversationModel struct {
+	immodels.UserConversationModel
+	unreads  map[string]int64
+	mentions []string
+}
+
+func (f *fakeUserConversationModel) UpdateLastMsgTime(ctx context.Context, conversationId string, sendTime int64) error {
+	return nil
+}
+
+func (f *fakeUserConversationModel) IncrUnread(ctx context.Context, conversationId, sendId string, n int64) error {
+	f.unreads[conversationId+":"+sendId] += n
+	return nil
+}
+
+func (f *fakeUserConversationModel) UpdateReadSeq(ctx context.Context, uid, conversationId string, readSeq int64) (int64, error) {
+	return 0, immodels.ErrNotFound
+}
+
+func (f *fakeUserConversationModel) ClearDraft(ctx context.Context, uid, conversationId string, before int64) (bool, error) {
+	return false, nil
+}
+
+func (f *fakeUserConversationModel) UpdateMentionSeq(ctx context.Context, conversationId string, uids []string, seq int64) error {
+	f.mentions = append(f.mentions, fmt.Sprintf("%v:%v:%v", conversationId, uids, seq))
+	return nil
+}
+
+func (f *fakeUserConversationModel) ListByConversationId(ctx context.Context, conversationId string) ([]*immodels.UserConversation, error) {
+	return nil, nil
+}
+
+// fakeSocial 群 g1 的成员为 u1、u2、u3
+type fakeSocial struct {
+	socialclient.Social
+}
+
+func (f *fakeSocial) GroupUsers(ctx context.Context, in *socialclient.GroupUsersReq, opts ...grpc.CallOption) (*socialclient.GroupUsersResp, error) {
+	var list []*socialclient.GroupMembers
+	for _, uid := range []string{"u1", "u2", "u3"} {
+		list = append(list, &socialclient.GroupMembers{GroupId: in.GroupId, UserId: uid})
+	}
+	return &socialclient.GroupUsersResp{List: list}, nil
+}
+
+// fakeWsClient 记录发送给 websocket 服务的帧
+type fakeWsClient struct {
+	websocket.Client
+	mu     sync.Mutex
+	frames []websocket.Message
+}
+
+func (f *fakeWsClient) Send(v any) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.frames = append(f.frames, v.(websocket.Message))
+	return nil
+}
+
+// fakeReader 依次返回 msgs，读完之后 block 为 true 时阻塞到关闭，否则视为已经关闭；记录提交的消息
+type fakeReader struct {
+	mu        sync.Mutex
+	msgs      []kafka.Message
+	block     bool
+	closed    chan struct{}
+	commits   []kafka.Message
+	committed chan struct{}
+}
+
+func newFakeReader(block bool, msgs ...*mq.MsgChatTransfer) *fakeReader {
+	r := &fakeReader{
+		block:     block,
+		closed:    make(chan struct{}),
+		committed: make(chan struct{}, 1),
+	}
+	r.msgs = newKafkaMsgs(msgs...)
+	return r
+}
+
+func (r *fakeReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	r.mu.Lock()
+	if len(r.msgs) > 0 {
+		msg := r.msgs[0]
+		r.msgs = r.msgs[1:]
+		r.mu.Unlock()
+		return msg, nil
+	}
+	r.mu.Unlock()
+
+	if r.block {
+		<-r.closed
+	}
+	return kafka.Message{}, io.EOF
+}
+
+func (r *fakeReader) CommitMessages(ctx context.Context, msgs ...kafka.Message) error {
+	r.mu.Lock()
+	r.commits = append(r.commits, msgs...)
+	r.mu.Unlock()
+
+	select {
+	case r.committed <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (r *fakeReader) Close() error {
+	close(r.closed)
+	return nil
+}
+
+// offsets 已经提交的消息的 offset
+func (r *fakeReader) offsets() []int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var res []int64
+	for _, msg := range r.commits {
+		res = append(res, msg.Offset)
+	}
+	return res
+}
+
+type testTransfer struct {
+	*MsgChatTransfer
+	reader        *fakeReader
+	chatLogs      *fakeChatLogModel
+	conversations *fakeConversationModel
+	users         *fakeUserConversationModel
+	ws            *fakeWsClient
+}
+
+func newTestTransfer(batchSize int, linger time.Duration, reader *fakeReader) *testTransfer {
+	MsgChatBatchSize = batchSize
+	MsgChatBatchLingerTime = linger
+	MsgChatPersistRetryInterval = 0
+
+	if reader == nil {
+		reader = newFakeReader(false)
+	}
+	t := &testTransfer{
+		reader:        reader,
+		chatLogs:      &fakeChatLogModel{},
+		conversations: &fakeConversationModel{seqs: make(map[string]int64)},
+		users:         &fakeUserConversationModel{unreads: make(map[string]int64)},
+		ws:            &fakeWsClient{},
+	}
+	t.MsgChatTransfer = NewMsgChatTransfer(&svc.ServiceContext{
+		WsClient:              t.ws,
+		Social:                &fakeSocial{},
+		ChatLogModel:          t.chatLogs,
+		ConversationModel:     t.conversations,
+		UserConversationModel: t.users,
+	}, reader)
+	return t
+}
+
+func newChatMsg(conversationId, content string) *mq.MsgChatTransfer {
+	return &mq.MsgChatTransfer{
+		ConversationId: conversationId,
+		ChatType:       constants.SingleChatType,
+		SendId:         "u1",
+		RecvId:         "u2",
+		Content:        content,
+		ChatLogId:      primitive.NewObjectID().Hex(),
+	}
+}
+
+// newKafkaMsgs 按顺序编排 offset 的 kafka 消息
+func newKafkaMsgs(msgs ...*mq.MsgChatTransfer) []kafka.Message {
+	res := make([]kafka.Message, 0, len(msgs))
+	for i, msg := range msgs {
+		value, _ := json.Marshal(msg)
+		res = append(res, kafka.Message{Offset: int64(i), Key: []byte(msg.ConversationId), Value: value})
+	}
+	return res
+}
+
+// seqs 按写入顺序列出聊天记录的会话、内容与序号
+func (t *testTransfer) seqs() []string {
+	var res []string
+	for _, chatLogs := range t.chatLogs.inserts {
+		for _, chatLog := range chatLogs {
+			res = append(res, fmt.Sprintf("%v:%v:%v", chatLog.ConversationId, chatLog.MsgContent, chatLog.Seq))
+		}
+	}
+	return res
+}
+
+func TestMsgChatTransfer_BatchSize(t *testing.T) {
+	reader := newFakeReader(false, newChatMsg("c1", "1"), newChatMsg("c1", "2"), newChatMsg("c2", "3"))
+	tt := newTestTransfer(2, time.Hour, reader)
+
+	// 凑满一批后立即写入，读取者关闭时写入剩余的消息
+	tt.Start()
+	if len(tt.chatLogs.inserts) != 2 || len(tt.chatLogs.inserts[0]) != 2 || len(tt.chatLogs.inserts[1]) != 1 {
+		t.Fatalf("inserts = %v, want batches of 2 and 1", tt.chatLogs.inserts)
+	}
+	if got := reader.offsets(); !reflect.DeepEqual(got, []int64{0, 1, 2}) {
+		t.Errorf("committed offsets = %v, want [0 1 2]", got)
+	}
+}
+
+func TestMsgChatTransfer_BatchLinger(t *testing.T) {
+	reader := newFakeReader(true, newChatMsg("c1", "1"), newChatMsg("c1", "2"))
+	tt := newTestTransfer(100, 10*time.Millisecond, reader)
+
+	// 没有凑满一批时在等待超时后写入并提交
+	stopped := make(chan struct{})
+	go func() {
+		tt.Start()
+		close(stopped)
+	}()
+	select {
+	case <-reader.committed:
+	case <-time.After(time.Second):
+		t.Fatal("batch not committed after linger")
+	}
+	tt.Stop()
+	<-stopped
+
+	if got := tt.seqs(); len(got) != 2 {
+		t.Fatalf("inserted %v, want 2 chat logs", got)
+	}
+}
+
+func TestMsgChatTransfer_Order(t *testing.T) {
+	reader := newFakeReader(false,
+		newChatMsg("c1", "1"), newChatMsg("c2", "1"), newChatMsg("c1", "2"),
+		newChatMsg("c2", "2"), newChatMsg("c1", "3"), newChatMsg("c2", "3"),
+	)
+	tt := newTestTransfer(4, time.Hour, reader)
+
+	// 交错到达的同一会话的消息，跨批次也按读取顺序分配序号
+	tt.Start()
+	want := []string{"c1:1:1", "c2:1:1", "c1:2:2", "c2:2:2", "c1:3:3", "c2:3:3"}
+	if got := tt.seqs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("chat logs = %v, want %v", got, want)
+	}
+	if got := reader.offsets(); !reflect.DeepEqual(got, []int64{0, 1, 2, 3, 4, 5}) {
+		t.Errorf("committed offsets = %v, want in order", got)
+	}
+}
+
+func TestMsgChatTransfer_AllocSeqs(t *testing.T) {
+	tt := newTestTransfer(100, time.Hour, nil)
+	tt.conversations.seqs["c1"] = 10
+
+	tt.flush(context.Background(), newKafkaMsgs(newChatMsg("c1", "1"), newChatMsg("c2", "2"), newChatMsg("c1", "3"), newChatMsg("c1", "4")))
+
+	// 同一会话在一批中只申请一次序号
+	wantIncrs := []seqIncr{{"c1", 3}, {"c2", 1}}
+	if !reflect.DeepEqual(tt.conversations.incrs, wantIncrs) {
+		t.Errorf("incrs = %v, want %v", tt.conversations.incrs, wantIncrs)
+	}
+	// 会话内的序号按消息在批次中的顺序递增
+	want := []string{"c1:1:11", "c2:2:1", "c1:3:12", "c1:4:13"}
+	if got := tt.seqs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("chat logs = %v, want %v", got, want)
+	}
+}
+
+func TestMsgChatTransfer_PushBatch(t *testing.T) {
+	tt := newTestTransfer(100, time.Hour, nil)
+
+	group := newChatMsg("g1", "2")
+	group.ChatType = constants.GroupChatType
+	group.RecvId = "g1"
+	group.ClientMsgId = "frame-2"
+	single := newChatMsg("c1", "1")
+	single.ClientMsgId = "frame-1"
+	tt.flush(context.Background(), newKafkaMsgs(single, group))
+
+	// 一批消息只向 websocket 服务发送一帧，群消息推送给除发送者以外的成员
+	if len(tt.ws.frames) != 1 || tt.ws.frames[0].Method != "push.batch" {
+		t.Fatalf("frames = %+v, want one push.batch", tt.ws.frames)
+	}
+	pushes := tt.ws.frames[0].Data.(*ws.PushBatch).List
+	if len(pushes) != 2 {
+		t.Fatalf("pushes = %d, want 2", len(pushes))
+	}
+	if pushes[0].Content != "1" || pushes[0].RecvId != "u2" || pushes[0].Seq != 1 {
+		t.Errorf("single push = %+v, want content 1 to u2 with seq 1", pushes[0])
+	}
+	// 推送的消息ID为聊天记录ID，发送者的帧ID单独带回
+	for i, chatLog := range tt.chatLogs.inserts[0] {
+		if pushes[i].MsgId != chatLog.ID.Hex() || pushes[i].ClientMsgId != fmt.Sprintf("frame-%d", i+1) {
+			t.Errorf("push %d msgId = %v clientMsgId = %v, want %v and frame-%d",
+				i, pushes[i].MsgId, pushes[i].ClientMsgId, chatLog.ID.Hex(), i+1)
+		}
+	}
+	if pushes[1].Content != "2" || !reflect.DeepEqual(pushes[1].RecvIds, []string{"u2", "u3"}) {
+		t.Errorf("group push = %+v, want content 2 to u2 and u3", pushes[1])
+	}
+}
+
+func TestMsgChatTransfer_IncrUnreads(t *testing.T) {
+	msg := func(conversationId, sendId string, reply bool) *mq.MsgChatTransfer {
+		m := newChatMsg(conversationId, "")
+		m.SendId = sendId
+		if reply {
+			m.ThreadId = primitive.NewObjectID().Hex()
+		}
+		return m
+	}
+	tests := []struct {
+		name string
+		msgs []*mq.MsgChatTransfer
+		want map[string]int64
+	}{
+		{"by conversation and sender", []*mq.MsgChatTransfer{
+			msg("c1", "u1", false), msg("c1", "u2", false), msg("c1", "u1", false), msg("c2", "u1", false),
+		}, map[string]int64{"c1:u1": 2, "c1:u2": 1, "c2:u1": 1}},
+		// 话题中的回复不计入未读数
+		{"thread replies", []*mq.MsgChatTransfer{
+			msg("c1", "u1", false), msg("c1", "u1", true), msg("c1", "u1", false),
+		}, map[string]int64{"c1:u1": 2}},
+		{"only thread replies", []*mq.MsgChatTransfer{msg("c1", "u1", true), msg("c1", "u2", true)}, map[string]int64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := newTestTransfer(100, time.Hour, nil)
+			tr.flush(context.Background(), newKafkaMsgs(tt.msgs...))
+			if !reflect.DeepEqual(tr.users.unreads, tt.want) {
+				t.Errorf("unreads = %v, want %v", tr.users.unreads, tt.want)
+			}
+		})
+	}
+}
+
+func TestMsgChatTransfer_UpdateThreads(t *testing.T) {
+	tt := newTestTransfer(100, time.Hour, nil)
+	root := &immodels.ChatLog{ID: primitive.NewObjectID(), ConversationId: "g1", ChatType: constants.GroupChatType, RecvId: "g1", Seq: 7}
+	tt.chatLogs.threads = map[string]*immodels.ChatLog{root.ID.Hex(): root}
+
+	var msgs []*mq.MsgChatTransfer
+	for i, threadId := range []string{root.ID.Hex(), "", root.ID.Hex(), primitive.NewObjectID().Hex()} {
+		msg := newChatMsg("g1", fmt.Sprint(i+1))
+		msg.ChatType, msg.RecvId, msg.ThreadId = constants.GroupChatType, "g1", threadId
+		msgs = append(msgs, msg)
+	}
+	tt.flush(context.Background(), newKafkaMsgs(msgs...))
+
+	// 每条回复增加一次回复数，话题不存在的回复不推送话题更新
+	var got []int64
+	for _, push := range tt.ws.frames[0].Data.(*ws.PushBatch).List {
+		if push.ContentType != constants.ContentThread {
+			continue
+		}
+		if push.MsgId != root.ID.Hex() || push.Seq != root.Seq || !reflect.DeepEqual(push.RecvIds, []string{"u2", "u3"}) {
+			t.Errorf("thread push = %+v, want root %v to u2 and u3", push, root.ID.Hex())
+		}
+		got = append(got, push.Thread.ReplyCount)
+	}
+	if !reflect.DeepEqual(got, []int64{1, 2}) {
+		t.Errorf("reply counts = %v, want [1 2]", got)
+	}
+	if root.Thread.LastReply.Snippet != "3" {
+		t.Errorf("last reply = %+v, want 3", root.Thread.LastReply)
+	}
+}
+
+func TestMsgChatTransfer_MentionSeqs(t *testing.T) {
+	tt := newTestTransfer(100, time.Hour, nil)
+
+	var msgs []*mq.MsgChatTransfer
+	for i, mention := range []*immodels.Mention{{UserIds: []string{"u2", "u3"}}, nil, {All: true}} {
+		msg := newChatMsg("g1", fmt.Sprint(i+1))
+		msg.ChatType, msg.RecvId, msg.Mention = constants.GroupChatType, "g1", mention
+		msgs = append(msgs, msg)
+	}
+	tt.flush(context.Background(), newKafkaMsgs(msgs...))
+
+	// 只推进被提及成员的提及序号，提及所有人时不限定成员
+	want := []string{"g1:[u2 u3]:1", "g1:[]:3"}
+	if !reflect.DeepEqual(tt.users.mentions, want) {
+		t.Errorf("mentions = %v, want %v", tt.users.mentions, want)
+	}
+}
+
+func TestMsgChatTransfer_PersistRetry(t *testing.T) {
+	tt := newTestTransfer(100, time.Hour, nil)
+	insertErr := errors.New("timeout")
+	tt.chatLogs.insertErrs = []error{insertErr, insertErr, insertErr, insertErr}
+
+	tt.flush(context.Background(), newKafkaMsgs(newChatMsg("c1", "1"), newChatMsg("c1", "2")))
+
+	// 一直重试到写入成功，重试使用已经分配的序号，不会再次申请
+	if len(tt.conversations.incrs) != 1 {
+		t.Errorf("incrs = %v, want one", tt.conversations.incrs)
+	}
+	if got := tt.seqs(); !reflect.DeepEqual(got, []string{"c1:1:1", "c1:2:2"}) {
+		t.Errorf("chat logs = %v, want seqs 1 and 2 written once", got)
+	}
+	if got := tt.reader.offsets(); !reflect.DeepEqual(got, []int64{0, 1}) {
+		t.Errorf("committed offsets = %v, want [0 1]", got)
+	}
+}
+
+func TestMsgChatTransfer_PersistStopped(t *testing.T) {
+	tt := newTestTransfer(100, time.Hour, nil)
+	tt.chatLogs.insertErrs = []error{errors.New("timeout")}
+
+	// 服务停止时放弃重试，不提交 offset 也不推送，重启后由 kafka 重新投递
+	tt.Stop()
+	tt.flush(context.Background(), newKafkaMsgs(newChatMsg("c1", "1"), newChatMsg("c1", "2")))
+	if len(tt.chatLogs.inserts) != 0 || len(tt.ws.frames) != 0 || len(tt.reader.commits) != 0 {
+		t.Errorf("inserts = %v, frames = %v, commits = %v, want none", tt.chatLogs.inserts, tt.ws.frames, tt.reader.commits)
+	}
+}
+
+func TestUniqueMsgs(t *testing.T) {
+	batch := []*mq.MsgChatTransfer{
+		{ChatLogId: "a", Content: "1"},
+		{ChatLogId: "b", Content: "2"},
+		{Content: "3"},
+		{ChatLogId: "b", Content: "4"},
+		{Content: "5"},
+		{ChatLogId: "c", Content: "6"},
+	}
+
+	got := uniqueMsgs(batch, map[string]bool{"a": true})
+	var contents string
+	for _, data := range got {
+		contents += data.Content
+	}
+	// a 已经写入，重复的 b 只保留第一条，没有聊天记录ID的消息全部保留
+	if contents != "2356" {
+		t.Errorf("uniqueMsgs() contents = %q, want %q", contents, "2356")
+	}
+}
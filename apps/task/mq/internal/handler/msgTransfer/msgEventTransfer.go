@@ -0,0 +1,104 @@
+//转发消息事件
+
+package msgTransfer
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/im/ws/ws"
+	"easy-chat/apps/task/mq/internal/svc"
+	"easy-chat/apps/task/mq/mq"
+	"easy-chat/pkg/constants"
+	"encoding/json"
+)
+
+type MsgEventTransfer struct {
+	*baseMsgTransfer
+}
+
+func NewMsgEventTransfer(svc *svc.ServiceContext) *MsgEventTransfer {
+	return &MsgEventTransfer{
+		baseMsgTransfer: NewBaseMsgTransfer(svc),
+	}
+}
+
+func (m *MsgEventTransfer) Consume(ctx context.Context, key, value string) error {
+	m.Info("MsgEventTransfer ", value)
+	var (
+		data mq.MsgEventTransfer
+	)
+	if err := json.Unmarshal([]byte(value), &data); err != nil {
+		return err
+	}
+
+	push := &ws.Push{
+		ConversationId: data.ConversationId,
+		ChatType:       data.ChatType,
+		SendId:         data.SendId,
+		RecvId:         data.RecvId,
+		SendTime:       data.SendTime,
+		MsgId:          data.MsgId,
+		Seq:            data.Seq,
+		Version:        data.Version,
+		ContentType:    data.ContentType,
+		Content:        data.Content,
+	}
+	switch data.ContentType {
+	case constants.ContentRecall:
+		push.SyncSend = true
+	case constants.ContentReaction:
+		push.Reaction = &ws.ReactionPayload{
+			MsgId:   data.MsgId,
+			Seq:     data.Seq,
+			UserId:  data.SendId,
+			Emoji:   data.Emoji,
+			Removed: data.Removed,
+			Count:   data.Count,
+		}
+	case constants.ContentPin:
+		push.SyncSend = true
+		push.Pin = &ws.PinPayload{
+			MsgId:    data.MsgId,
+			Seq:      data.Seq,
+			UserId:   data.SendId,
+			Unpinned: data.Removed,
+		}
+	case constants.ContentDraft:
+		push.Draft = &ws.DraftPayload{
+			Content:   data.Content,
+			DraftTime: data.SendTime,
+		}
+	}
+	if err := m.Transfer(ctx, push); err != nil {
+		return err
+	}
+
+	if data.ContentType == constants.ContentRecall {
+		return m.recallUnreads(ctx, &data)
+	}
+	return nil
+}
+
+// recallUnreads 撤回的消息不再计入未读，重新统计还没有读到该消息的成员的未读数并推送角标
+func (m *MsgEventTransfer) recallUnreads(ctx context.Context, data *mq.MsgEventTransfer) error {
+	conversations, err := m.svcCtx.UserConversationModel.ListByConversationId(ctx, data.ConversationId)
+	if err != nil && err != immodels.ErrNotFound {
+		return err
+	}
+
+	var pushes []*ws.Push
+	for _, conversation := range conversations {
+		if conversation.ReadSeq >= data.Seq {
+			continue
+		}
+		conversation, err := m.resetUnread(ctx, conversation.UserId, data.ConversationId, conversation.ReadSeq)
+		switch err {
+		case nil:
+			pushes = append(pushes, newBadgePush(conversation))
+		case immodels.ErrNotFound:
+		default:
+			return err
+		}
+	}
+	return m.TransferBatch(ctx, pushes)
+}
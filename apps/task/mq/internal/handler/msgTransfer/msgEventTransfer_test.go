@@ -0,0 +1,95 @@
+package msgTransfer
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/im/ws/ws"
+	"easy-chat/apps/task/mq/internal/svc"
+	"easy-chat/apps/task/mq/mq"
+	"easy-chat/pkg/constants"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// countChatLogModel 按用户返回重新统计的未读数
+type countChatLogModel struct {
+	fakeChatLogModel
+	unreads map[string]int64
+}
+
+func (f *countChatLogModel) CountUnread(ctx context.Context, conversationId, uid string, readSeq int64) (int64, error) {
+	return f.unreads[uid], nil
+}
+
+// memberUserConversationModel 保存会话中全部成员的会话
+type memberUserConversationModel struct {
+	fakeUserConversationModel
+	conversations []*immodels.UserConversation
+}
+
+func (f *memberUserConversationModel) ListByConversationId(ctx context.Context, conversationId string) ([]*immodels.UserConversation, error) {
+	return f.conversations, nil
+}
+
+func (f *memberUserConversationModel) SetUnread(ctx context.Context, uid, conversationId string, unread int64) (*immodels.UserConversation, error) {
+	for _, conversation := range f.conversations {
+		if conversation.UserId == uid {
+			conversation.Unread = unread
+			return conversation, nil
+		}
+	}
+	return nil, immodels.ErrNotFound
+}
+
+func TestMsgEventTransfer_Recall(t *testing.T) {
+	wsClient := &fakeWsClient{}
+	users := &memberUserConversationModel{}
+	for _, v := range []struct {
+		uid             string
+		readSeq, unread int64
+	}{
+		{"u1", 5, 0}, // 发送者已经读到撤回的消息
+		{"u2", 3, 2},
+		{"u3", 4, 1},
+	} {
+		users.conversations = append(users.conversations, &immodels.UserConversation{
+			UserId: v.uid, ConversationId: "g1", ChatType: constants.GroupChatType, ReadSeq: v.readSeq, Unread: v.unread,
+		})
+	}
+	m := NewMsgEventTransfer(&svc.ServiceContext{
+		WsClient:              wsClient,
+		Social:                &fakeSocial{},
+		ChatLogModel:          &countChatLogModel{unreads: map[string]int64{"u1": 0, "u2": 1, "u3": 0}},
+		UserConversationModel: users,
+	})
+
+	value, _ := json.Marshal(&mq.MsgEventTransfer{
+		ConversationId: "g1", ChatType: constants.GroupChatType, SendId: "u1", RecvId: "g1",
+		MsgId: "m1", Seq: 5, ContentType: constants.ContentRecall,
+	})
+	if err := m.Consume(context.Background(), "", string(value)); err != nil {
+		t.Fatalf("Consume() err = %v", err)
+	}
+
+	// 撤回推送给其他成员并同步到发送者的其他设备，之后推送重新统计的角标
+	if len(wsClient.frames) != 2 || wsClient.frames[0].Method != "push" || wsClient.frames[1].Method != "push.batch" {
+		t.Fatalf("frames = %+v, want push and push.batch", wsClient.frames)
+	}
+	recall := wsClient.frames[0].Data.(*ws.Push)
+	if !recall.SyncSend || !reflect.DeepEqual(recall.RecvIds, []string{"u2", "u3"}) {
+		t.Errorf("recall push = %+v, want synced to the sender and pushed to u2 and u3", recall)
+	}
+
+	got := make(map[string]int64)
+	for _, push := range wsClient.frames[1].Data.(*ws.PushBatch).List {
+		if push.ContentType != constants.ContentBadge {
+			t.Fatalf("push = %+v, want badge", push)
+		}
+		got[push.RecvId] = push.Badge.Unread
+	}
+	want := map[string]int64{"u2": 1, "u3": 0}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("badges = %v, want %v", got, want)
+	}
+}
@@ -4,15 +4,15 @@ package msgTransfer
 
 import (
 	"context"
+	"easy-chat/apps/im/immodels"
 	"easy-chat/apps/im/ws/ws"
 	"easy-chat/apps/task/mq/internal/svc"
 	"easy-chat/apps/task/mq/mq"
-	"easy-chat/pkg/bitmap"
 	"easy-chat/pkg/constants"
-	"encoding/base64"
 	"encoding/json"
 	"github.com/zeromicro/go-queue/kq"
 	"github.com/zeromicro/go-zero/core/stores/cache"
+	"strconv"
 	"sync"
 	"time"
 )
@@ -23,6 +23,7 @@ type MsgReadTransfer struct {
 	mu        sync.Mutex
 	groupMsgs map[string]*groupMsgRead
 	push      chan *ws.Push
+	clock     clock
 }
 
 var (
@@ -40,6 +41,7 @@ func NewMsgReadTransfer(svc *svc.ServiceContext) kq.ConsumeHandler {
 		baseMsgTransfer: NewBaseMsgTransfer(svc),
 		groupMsgs:       make(map[string]*groupMsgRead, 1),
 		push:            make(chan *ws.Push, 1),
+		clock:           realClock{},
 	}
 	// 如果开启
 	if svc.Config.MsgReadHandler.GroupMsgReadHandler != GroupMsgReadHandlerAtTransfer {
@@ -65,24 +67,30 @@ func (m *MsgReadTransfer) Consume(ctx context.Context, key, value string) error
 		return err
 	}
 
-	//业务处理---更新用户对消息的已读未读
-	//map[string]:已读记录
-	readRecords, err := m.UpdateChatLogRead(ctx, &data)
+	//业务处理---推进用户在会话中的已读水位
+	readSeq, err := m.UpdateReadSeq(ctx, &data)
 	if err != nil {
 		return err
 	}
+	if readSeq == 0 {
+		return nil
+	}
 	push := &ws.Push{
 		ConversationId: data.ConversationId,
 		ChatType:       data.ChatType,
 		SendId:         data.SendId,
 		RecvId:         data.RecvId,
 		ContentType:    constants.ContentMakeRead,
-		ReadRecords:    readRecords,
+		ReadRecords:    map[string]string{data.SendId: strconv.FormatInt(readSeq, 10)},
 		//RecvIds:        data.RecvIds,
 		//SendTime:       data.SendTime,
 		//MType:          data.MType,
 		//Content:        data.Content,
 	}
+	//推送用户新的未读数，同步到用户的其他设备
+	if badge := m.resetBadge(ctx, &data, readSeq); badge != nil {
+		m.push <- badge
+	}
 	switch data.ChatType {
 	case constants.SingleChatType:
 		//直接推送
@@ -91,66 +99,99 @@ func (m *MsgReadTransfer) Consume(ctx context.Context, key, value string) error
 		//判断是否开启合并消息处理
 		if m.svcCtx.Config.MsgReadHandler.GroupMsgReadHandler == GroupMsgReadHandlerAtTransfer {
 			m.push <- push
+			return nil
 		}
-		m.mu.Lock()
-		defer m.mu.Unlock()
 		push.SendId = ""
-		if _, ok := m.groupMsgs[push.ConversationId]; ok {
-			//和并请求
-			m.Infof("merge push %v ", push.ConversationId)
-			m.groupMsgs[push.ConversationId].mergePush(push)
-		} else {
-			//创建新消息
-			m.Infof("newGroupMsgRead push %v ", push.ConversationId)
-			m.groupMsgs[push.ConversationId] = newGroupMsgRead(push, m.push)
+		if full := m.mergeGroupPush(push); full != nil {
+			// 达到最大计数，推送
+			m.Infof("merge push max delay count condition reached, push: %v ", full)
+			m.push <- full
 		}
 	}
 	return nil
 }
-func (m *MsgReadTransfer) UpdateChatLogRead(ctx context.Context, data *mq.MsgMarkRead) (map[string]string, error) {
-	res := make(map[string]string)
-	chatLogs, err := m.svcCtx.ChatLogModel.ListByMsgIds(ctx, data.MsgIds)
-	if err != nil {
-		return nil, err
-	}
-	//处理已读
-	for _, chatLog := range chatLogs {
-		switch chatLog.ChatType {
-		case constants.SingleChatType:
-			chatLog.ReadRecords = []byte{1}
-		case constants.GroupChatType:
-			readRecords := bitmap.Load(chatLog.ReadRecords)
-			readRecords.Set(data.SendId)
-			chatLog.ReadRecords = readRecords.Export()
+
+// mergeGroupPush 将群的已读回执合并到对应的聚合器中，聚合器不存在或已关闭时重新创建
+func (m *MsgReadTransfer) mergeGroupPush(push *ws.Push) *ws.Push {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if g, ok := m.groupMsgs[push.ConversationId]; ok {
+		//和并请求
+		m.Infof("merge push %v ", push.ConversationId)
+		if full, ok := g.mergePush(push); ok {
+			return full
 		}
-		res[chatLog.ID.Hex()] = base64.StdEncoding.EncodeToString(chatLog.ReadRecords)
+	}
+	//创建新消息
+	m.Infof("newGroupMsgRead push %v ", push.ConversationId)
+	m.groupMsgs[push.ConversationId] = newGroupMsgRead(push, m.clock, func(push *ws.Push) {
+		m.push <- push
+	}, m.releaseGroupMsgRead)
+	return nil
+}
+
+// releaseGroupMsgRead 释放空闲的聚合器
+func (m *MsgReadTransfer) releaseGroupMsgRead(g *groupMsgRead) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.groupMsgs[g.conversationId] == g {
+		delete(m.groupMsgs, g.conversationId)
+	}
+}
 
-		err = m.svcCtx.ChatLogModel.UpdateMarkRead(ctx, chatLog.ID, chatLog.ReadRecords)
-		if err != nil {
-			return nil, err
+// UpdateReadSeq 推进用户的已读水位，返回更新后的水位
+//
+// 水位不会超过会话当前的最新序号，为 0 表示会话中还没有消息或用户没有该会话。
+func (m *MsgReadTransfer) UpdateReadSeq(ctx context.Context, data *mq.MsgMarkRead) (int64, error) {
+	conversation, err := m.svcCtx.ConversationModel.FindByConversationId(ctx, data.ConversationId)
+	switch err {
+	case nil:
+	case immodels.ErrNotFound:
+		return 0, nil
+	default:
+		return 0, err
+	}
+
+	readSeq := data.ReadSeq
+	if readSeq <= 0 || readSeq > conversation.Seq {
+		readSeq = conversation.Seq
+	}
+	if readSeq == 0 {
+		return 0, nil
+	}
+
+	readSeq, err = m.svcCtx.UserConversationModel.UpdateReadSeq(ctx, data.SendId, data.ConversationId, readSeq)
+	switch err {
+	case nil:
+		return readSeq, nil
+	case immodels.ErrNotFound:
+		return 0, nil
+	default:
+		return 0, err
+	}
+}
+
+// resetBadge 按新的已读水位重新统计用户的未读数，返回推送给用户的角标，失败时只记录日志
+func (m *MsgReadTransfer) resetBadge(ctx context.Context, data *mq.MsgMarkRead, readSeq int64) *ws.Push {
+	conversation, err := m.resetUnread(ctx, data.SendId, data.ConversationId, readSeq)
+	if err != nil {
+		if err != immodels.ErrNotFound {
+			m.Errorf("conversations reset unread err %v, uid %v, conversationId %v", err, data.SendId, data.ConversationId)
 		}
+		return nil
 	}
-	return res, nil
+	return newBadgePush(conversation)
 }
 
 func (m *MsgReadTransfer) transfer() {
 	for push := range m.push {
-		if push.RecvId != "" || len(push.RecvIds) > 0 {
-			if err := m.Transfer(context.Background(), push); err != nil {
-				m.Errorf("m transfer err %v push %v", err, push)
-			}
-		}
-		if push.ChatType == constants.SingleChatType {
-			continue
-		}
-		if m.svcCtx.Config.MsgReadHandler.GroupMsgReadHandler == GroupMsgReadHandlerAtTransfer {
+		if push.RecvId == "" && len(push.RecvIds) == 0 {
 			continue
 		}
-		//清空数据
-		m.mu.Lock()
-		if _, ok := m.groupMsgs[push.ConversationId]; ok && m.groupMsgs[push.ConversationId].IsIdle() {
-			m.groupMsgs[push.ConversationId].Clear()
-			delete(m.groupMsgs, push.ConversationId)
+		if err := m.Transfer(context.Background(), push); err != nil {
+			m.Errorf("m transfer err %v push %v", err, push)
 		}
 	}
 }
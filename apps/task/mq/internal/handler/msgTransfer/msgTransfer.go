@@ -2,12 +2,14 @@ package msgTransfer
 
 import (
 	"context"
+	"easy-chat/apps/im/immodels"
 	"easy-chat/apps/im/ws/websocket"
 	"easy-chat/apps/im/ws/ws"
 	"easy-chat/apps/social/rpc/socialclient"
 	"easy-chat/apps/task/mq/internal/svc"
 	"easy-chat/pkg/constants"
 	"github.com/zeromicro/go-zero/core/logx"
+	"time"
 )
 
 type baseMsgTransfer struct {
@@ -26,15 +28,48 @@ func NewBaseMsgTransfer(svc *svc.ServiceContext) *baseMsgTransfer {
 
 func (m *baseMsgTransfer) Transfer(ctx context.Context, data *ws.Push) error {
 	var err error
-	switch data.ChatType {
-	case constants.SingleChatType:
+	switch {
+	case data.ContentType.Private(), data.ChatType == constants.SingleChatType:
 		err = m.single(ctx, data)
-	case constants.GroupChatType:
+	case data.ChatType == constants.GroupChatType:
 		err = m.group(ctx, data)
 	}
 	return err
 }
 
+// TransferBatch 批量转发消息
+//
+// 同一个群在一批消息中只查询一次群成员，全部消息以一个 push.batch 帧发送给 websocket 服务。
+func (m *baseMsgTransfer) TransferBatch(ctx context.Context, data []*ws.Push) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	groupUsers := make(map[string][]string)
+	for _, push := range data {
+		if push.ChatType != constants.GroupChatType || push.ContentType.Private() {
+			continue
+		}
+		uids, ok := groupUsers[push.RecvId]
+		if !ok {
+			var err error
+			uids, err = m.groupUserIds(ctx, push.RecvId)
+			if err != nil {
+				return err
+			}
+			groupUsers[push.RecvId] = uids
+		}
+		push.RecvIds = excludeUserId(uids, push.SendId)
+	}
+
+	return m.svcCtx.WsClient.Send(websocket.Message{
+		FrameType: websocket.FrameData,
+		Method:    "push.batch",
+		FormId:    constants.SYSTEM_ROOT_UID,
+		Data:      &ws.PushBatch{List: data},
+	})
+}
+
 func (m *baseMsgTransfer) single(ctx context.Context, data *ws.Push) error {
 	//推送消息
 	return m.svcCtx.WsClient.Send(websocket.Message{
@@ -47,19 +82,11 @@ func (m *baseMsgTransfer) single(ctx context.Context, data *ws.Push) error {
 
 func (m *baseMsgTransfer) group(ctx context.Context, data *ws.Push) error {
 	//查询群的用户再推送给群成员
-	users, err := m.svcCtx.Social.GroupUsers(ctx, &socialclient.GroupUsersReq{
-		GroupId: data.RecvId,
-	})
+	uids, err := m.groupUserIds(ctx, data.RecvId)
 	if err != nil {
 		return err
 	}
-	data.RecvIds = make([]string, 0, len(users.List))
-	for _, members := range users.List {
-		if members.UserId == data.SendId {
-			continue
-		}
-		data.RecvIds = append(data.RecvIds, members.UserId)
-	}
+	data.RecvIds = excludeUserId(uids, data.SendId)
 	return m.svcCtx.WsClient.Send(websocket.Message{
 		FrameType: websocket.FrameData,
 		Method:    "push",
@@ -67,3 +94,74 @@ func (m *baseMsgTransfer) group(ctx context.Context, data *ws.Push) error {
 		Data:      data,
 	})
 }
+
+// resetUnread 已读水位推进到 readSeq 后重新统计用户在会话中的未读数，返回更新后的会话
+func (m *baseMsgTransfer) resetUnread(ctx context.Context, uid, conversationId string, readSeq int64) (*immodels.UserConversation, error) {
+	unread, err := m.svcCtx.ChatLogModel.CountUnread(ctx, conversationId, uid, readSeq)
+	if err != nil {
+		return nil, err
+	}
+	return m.svcCtx.UserConversationModel.SetUnread(ctx, uid, conversationId, unread)
+}
+
+// newBadgePush 推送用户在会话中的未读数
+func newBadgePush(conversation *immodels.UserConversation) *ws.Push {
+	return &ws.Push{
+		ConversationId: conversation.ConversationId,
+		ChatType:       conversation.ChatType,
+		SendId:         constants.SYSTEM_ROOT_UID,
+		RecvId:         conversation.UserId,
+		SendTime:       time.Now().UnixMilli(),
+		ContentType:    constants.ContentBadge,
+		Badge: &ws.BadgePayload{
+			Unread:    conversation.Unread,
+			ReadSeq:   conversation.ReadSeq,
+			Mentioned: conversation.Mentioned(),
+			Muted:     conversation.Muted(time.Now()),
+			Archived:  conversation.Archived,
+		},
+	}
+}
+
+// newDraftPush 推送用户在会话中的草稿，draft 为空表示草稿已清除
+func newDraftPush(uid, conversationId string, chatType constants.ChatType, draft string, draftTime int64) *ws.Push {
+	return &ws.Push{
+		ConversationId: conversationId,
+		ChatType:       chatType,
+		SendId:         uid,
+		RecvId:         uid,
+		SendTime:       draftTime,
+		ContentType:    constants.ContentDraft,
+		Draft: &ws.DraftPayload{
+			Content:   draft,
+			DraftTime: draftTime,
+		},
+	}
+}
+
+// groupUserIds 查询群成员的用户id
+func (m *baseMsgTransfer) groupUserIds(ctx context.Context, groupId string) ([]string, error) {
+	users, err := m.svcCtx.Social.GroupUsers(ctx, &socialclient.GroupUsersReq{
+		GroupId: groupId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	uids := make([]string, 0, len(users.List))
+	for _, members := range users.List {
+		uids = append(uids, members.UserId)
+	}
+	return uids, nil
+}
+
+// excludeUserId 过滤掉发送者自己
+func excludeUserId(uids []string, uid string) []string {
+	res := make([]string, 0, len(uids))
+	for _, id := range uids {
+		if id == uid {
+			continue
+		}
+		res = append(res, id)
+	}
+	return res
+}
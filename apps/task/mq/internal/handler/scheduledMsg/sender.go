@@ -0,0 +1,133 @@
+package scheduledMsg
+
+import (
+	"context"
+	"easy-chat/apps/im/authz"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/task/mq/internal/svc"
+	"easy-chat/apps/task/mq/mq"
+	"easy-chat/pkg/constants"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/zeromicro/go-zero/core/logx"
+	zerr "github.com/zeromicro/x/errors"
+)
+
+var (
+	ScheduledMsgInterval     = time.Second
+	ScheduledMsgClaimTimeout = time.Minute
+)
+
+// Sender 定时发送到期的消息
+//
+// 到期的消息经过原子的领取后，重新校验好友关系与群成员，再以用户的身份投递到 msgChatTransfer，
+// 与用户直接发送的消息一起处理。发送中断时（如服务重启）消息在领取超时后被重新领取并投递，
+// 投递的消息带有创建时预先分配的聊天记录ID，消费者据此去重，保证每条定时消息只发送一次。
+//
+// 投递之前先记录开始投递，已经开始投递的消息可能已经发出，重新领取时不再校验权限，直接重新投递，
+// 只会变为已发送。
+type Sender struct {
+	logx.Logger
+	svcCtx *svc.ServiceContext
+	done   chan struct{}
+}
+
+func NewSender(svc *svc.ServiceContext) *Sender {
+	// 检查到期消息的间隔
+	if svc.Config.ScheduledMsgHandler.Interval > 0 {
+		ScheduledMsgInterval = time.Duration(svc.Config.ScheduledMsgHandler.Interval) * time.Millisecond
+	}
+	// 领取超时的时间
+	if svc.Config.ScheduledMsgHandler.ClaimTimeout > 0 {
+		ScheduledMsgClaimTimeout = time.Duration(svc.Config.ScheduledMsgHandler.ClaimTimeout) * time.Second
+	}
+
+	return &Sender{
+		Logger: logx.WithContext(context.Background()),
+		svcCtx: svc,
+		done:   make(chan struct{}),
+	}
+}
+
+func (s *Sender) Start() {
+	ticker := time.NewTicker(ScheduledMsgInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			s.sendDue(context.Background())
+		}
+	}
+}
+
+func (s *Sender) Stop() {
+	close(s.done)
+}
+
+// sendDue 依次领取并发送所有到期的消息
+func (s *Sender) sendDue(ctx context.Context) {
+	for {
+		select {
+		case <-s.done:
+			return
+		default:
+		}
+
+		now := time.Now().UnixMilli()
+		msg, err := s.svcCtx.ScheduledMsgModel.Claim(ctx, now, now-ScheduledMsgClaimTimeout.Milliseconds())
+		switch err {
+		case nil:
+		case immodels.ErrNotFound:
+			return
+		default:
+			s.Errorf("scheduled msg claim err %v", err)
+			return
+		}
+		s.send(ctx, msg)
+	}
+}
+
+// send 发送领取的消息，不再有权限发送时标记为发送失败；其他错误保留为发送中，领取超时后重试
+func (s *Sender) send(ctx context.Context, msg *immodels.ScheduledMsg) {
+	if msg.PushTime == 0 {
+		if err := s.svcCtx.Auth.CheckSend(ctx, msg.SendId, msg.ChatType, msg.RecvId); err != nil {
+			cause := errors.Cause(err)
+			if cause != authz.ErrNotFriend && cause != authz.ErrNotMember {
+				s.Errorf("scheduled msg %v check send err %v", msg.ID.Hex(), err)
+				return
+			}
+			if err := s.svcCtx.ScheduledMsgModel.Finish(ctx, msg.ID, constants.FailedScheduledMsgStatus, cause.(*zerr.CodeMsg).Msg); err != nil {
+				s.Errorf("scheduled msg %v finish failed err %v", msg.ID.Hex(), err)
+			}
+			return
+		}
+		if err := s.svcCtx.ScheduledMsgModel.MarkPushing(ctx, msg.ID, time.Now().UnixMilli()); err != nil {
+			s.Errorf("scheduled msg %v mark pushing err %v", msg.ID.Hex(), err)
+			return
+		}
+	}
+
+	err := s.svcCtx.MsgChatTransferClient.Push(&mq.MsgChatTransfer{
+		ClientMsgId:    msg.ID.Hex(),
+		ConversationId: msg.ConversationId,
+		ChatType:       msg.ChatType,
+		SendId:         msg.SendId,
+		RecvId:         msg.RecvId,
+		SendTime:       time.Now().UnixMilli(),
+		MType:          msg.MsgType,
+		Content:        msg.MsgContent,
+		Body:           msg.Body,
+		ChatLogId:      msg.ChatLogId,
+	})
+	if err != nil {
+		s.Errorf("scheduled msg %v push err %v", msg.ID.Hex(), err)
+		return
+	}
+	if err := s.svcCtx.ScheduledMsgModel.Finish(ctx, msg.ID, constants.SentScheduledMsgStatus, ""); err != nil {
+		s.Errorf("scheduled msg %v finish sent err %v", msg.ID.Hex(), err)
+	}
+}
@@ -0,0 +1,173 @@
+package scheduledMsg
+
+import (
+	"context"
+	"easy-chat/apps/im/authz"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/social/rpc/socialclient"
+	"easy-chat/apps/task/mq/internal/svc"
+	"easy-chat/apps/task/mq/mq"
+	"easy-chat/pkg/constants"
+	"errors"
+	"testing"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+)
+
+// fakeScheduledMsgModel 按顺序领取到期的消息，记录发送的结果
+type fakeScheduledMsgModel struct {
+	immodels.ScheduledMsgModel
+	due      []*immodels.ScheduledMsg
+	finished map[primitive.ObjectID]constants.ScheduledMsgStatus
+	reasons  map[primitive.ObjectID]string
+	pushing  map[primitive.ObjectID]bool
+}
+
+func (f *fakeScheduledMsgModel) Claim(ctx context.Context, now, staleBefore int64) (*immodels.ScheduledMsg, error) {
+	if len(f.due) == 0 {
+		return nil, immodels.ErrNotFound
+	}
+	msg := f.due[0]
+	f.due = f.due[1:]
+	msg.Status = constants.SendingScheduledMsgStatus
+	return msg, nil
+}
+
+func (f *fakeScheduledMsgModel) MarkPushing(ctx context.Context, id primitive.ObjectID, now int64) error {
+	f.pushing[id] = true
+	return nil
+}
+
+func (f *fakeScheduledMsgModel) Finish(ctx context.Context, id primitive.ObjectID, status constants.ScheduledMsgStatus, reason string) error {
+	f.finished[id] = status
+	f.reasons[id] = reason
+	return nil
+}
+
+type fakeMsgChatTransferClient struct {
+	pushed []*mq.MsgChatTransfer
+}
+
+func (f *fakeMsgChatTransferClient) Push(msg *mq.MsgChatTransfer) error {
+	f.pushed = append(f.pushed, msg)
+	return nil
+}
+
+// fakeSocial u1 的好友只有 u2，群 g1 的成员为 u1；err 不为空时模拟 social rpc 不可用
+type fakeSocial struct {
+	err error
+}
+
+func (f *fakeSocial) GroupUsers(ctx context.Context, in *socialclient.GroupUsersReq, opts ...grpc.CallOption) (*socialclient.GroupUsersResp, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &socialclient.GroupUsersResp{List: []*socialclient.GroupMembers{{GroupId: "g1", UserId: "u1"}}}, nil
+}
+
+func (f *fakeSocial) FriendList(ctx context.Context, in *socialclient.FriendListReq, opts ...grpc.CallOption) (*socialclient.FriendListResp, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	var list []*socialclient.Friends
+	if in.UserId == "u1" {
+		list = append(list, &socialclient.Friends{UserId: "u1", FriendUid: "u2"})
+	}
+	return &socialclient.FriendListResp{List: list}, nil
+}
+
+func newTestSender(social *fakeSocial, due ...*immodels.ScheduledMsg) (*Sender, *fakeScheduledMsgModel, *fakeMsgChatTransferClient) {
+	model := &fakeScheduledMsgModel{
+		due:      due,
+		finished: make(map[primitive.ObjectID]constants.ScheduledMsgStatus),
+		reasons:  make(map[primitive.ObjectID]string),
+		pushing:  make(map[primitive.ObjectID]bool),
+	}
+	client := &fakeMsgChatTransferClient{}
+	return &Sender{
+		Logger: logx.WithContext(context.Background()),
+		svcCtx: &svc.ServiceContext{
+			ScheduledMsgModel:     model,
+			MsgChatTransferClient: client,
+			Auth:                  authz.NewAuthorizer(social),
+		},
+		done: make(chan struct{}),
+	}, model, client
+}
+
+func newScheduledMsg(sendId, recvId string, chatType constants.ChatType) *immodels.ScheduledMsg {
+	return &immodels.ScheduledMsg{
+		ID:         primitive.NewObjectID(),
+		SendId:     sendId,
+		RecvId:     recvId,
+		ChatType:   chatType,
+		MsgContent: "hello",
+		ChatLogId:  primitive.NewObjectID().Hex(),
+		Status:     constants.PendingScheduledMsgStatus,
+	}
+}
+
+func TestSender_SendDue(t *testing.T) {
+	var (
+		toFriend   = newScheduledMsg("u1", "u2", constants.SingleChatType)
+		toGroup    = newScheduledMsg("u1", "g1", constants.GroupChatType)
+		toStranger = newScheduledMsg("u1", "u3", constants.SingleChatType)
+		notMember  = newScheduledMsg("u2", "g1", constants.GroupChatType)
+	)
+	sender, model, client := newTestSender(&fakeSocial{}, toFriend, toGroup, toStranger, notMember)
+
+	sender.sendDue(context.Background())
+
+	if len(client.pushed) != 2 {
+		t.Fatalf("pushed %d msgs, want 2", len(client.pushed))
+	}
+	for i, msg := range []*immodels.ScheduledMsg{toFriend, toGroup} {
+		pushed := client.pushed[i]
+		if pushed.ChatLogId != msg.ChatLogId || pushed.SendId != msg.SendId || pushed.RecvId != msg.RecvId {
+			t.Errorf("pushed %+v, want msg %v as sent by %v", pushed, msg.ID.Hex(), msg.SendId)
+		}
+		if model.finished[msg.ID] != constants.SentScheduledMsgStatus || !model.pushing[msg.ID] {
+			t.Errorf("msg to %v status = %v pushing %v, want marked pushing and sent", msg.RecvId, model.finished[msg.ID], model.pushing[msg.ID])
+		}
+	}
+	for _, msg := range []*immodels.ScheduledMsg{toStranger, notMember} {
+		if model.finished[msg.ID] != constants.FailedScheduledMsgStatus || model.reasons[msg.ID] == "" {
+			t.Errorf("msg from %v to %v status = %v reason %q, want failed with reason",
+				msg.SendId, msg.RecvId, model.finished[msg.ID], model.reasons[msg.ID])
+		}
+	}
+}
+
+func TestSender_SocialUnavailable(t *testing.T) {
+	msg := newScheduledMsg("u1", "u2", constants.SingleChatType)
+	sender, model, client := newTestSender(&fakeSocial{err: errors.New("unavailable")}, msg)
+
+	sender.sendDue(context.Background())
+
+	// 校验失败不是因为权限时保留为发送中，领取超时后重新发送
+	if len(client.pushed) != 0 {
+		t.Errorf("pushed %d msgs, want 0", len(client.pushed))
+	}
+	if _, ok := model.finished[msg.ID]; ok {
+		t.Errorf("msg finished as %v, want left sending", model.finished[msg.ID])
+	}
+}
+
+func TestSender_Reclaimed(t *testing.T) {
+	// 上次领取后已经开始投递，之后发送者与接收者不再是好友
+	msg := newScheduledMsg("u1", "u3", constants.SingleChatType)
+	msg.PushTime = 1
+	sender, model, client := newTestSender(&fakeSocial{}, msg)
+
+	sender.sendDue(context.Background())
+
+	// 可能已经发出的消息不再校验权限，以相同的聊天记录ID重新投递并标记为已发送
+	if len(client.pushed) != 1 || client.pushed[0].ChatLogId != msg.ChatLogId {
+		t.Fatalf("pushed %+v, want msg pushed again with chat log %v", client.pushed, msg.ChatLogId)
+	}
+	if model.finished[msg.ID] != constants.SentScheduledMsgStatus {
+		t.Errorf("msg status = %v, want sent", model.finished[msg.ID])
+	}
+}
@@ -0,0 +1,123 @@
+// 将旧版本的用户会话列表迁移为每个用户与会话一条的记录
+
+package migrate
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/task/mq/internal/svc"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// conversationsBatchSize 每次读取的旧版本会话列表数量
+const conversationsBatchSize = 100
+
+// Conversations 迁移旧版本的用户会话列表
+//
+// 旧版本中一个用户的所有会话保存在 conversations 集合的一条记录中。迁移按 ID 顺序遍历该集合，
+// 把列表中的每个会话合并到 user_conversation 集合：
+//   - 不存在的会话按旧数据建立
+//   - 已存在的会话只以 $max 合并已读水位、提及序号与最后一条消息的时间，不覆盖迁移之后的修改
+//   - 按合并后的已读水位重新统计未读数
+//
+// 迁移可以重复执行，也可以与新版本的服务同时运行；旧集合保留不动，确认无误后再手动删除。
+type Conversations struct {
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewConversations(svcCtx *svc.ServiceContext) *Conversations {
+	return &Conversations{
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(context.Background()),
+	}
+}
+
+// Run 迁移所有用户的会话列表，单个用户失败不影响其他用户
+func (m *Conversations) Run(ctx context.Context) error {
+	var (
+		afterId string
+		users   int
+	)
+	for {
+		list, err := m.svcCtx.LegacyConversationsModel.ListAfterId(ctx, afterId, conversationsBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, conversations := range list {
+			if err := m.user(ctx, conversations); err != nil {
+				m.Errorf("migrate conversations err %v, userId %v", err, conversations.UserId)
+			}
+		}
+		users += len(list)
+		if len(list) < conversationsBatchSize {
+			break
+		}
+		afterId = list[len(list)-1].ID.Hex()
+	}
+
+	m.Infof("migrate conversations, users %v", users)
+	return nil
+}
+
+func (m *Conversations) user(ctx context.Context, conversations *immodels.Conversations) error {
+	for id, conversation := range conversations.ConversationList {
+		if conversation == nil {
+			continue
+		}
+		conversationId := conversation.ConversationId
+		if conversationId == "" {
+			conversationId = id
+		}
+
+		lastMsgTime, err := m.lastMsgTime(ctx, conversationId)
+		if err != nil {
+			return err
+		}
+		err = m.svcCtx.UserConversationModel.Merge(ctx, &immodels.UserConversation{
+			UserId:         conversations.UserId,
+			ConversationId: conversationId,
+			ChatType:       conversation.ChatType,
+			IsShow:         conversation.IsShow,
+			Total:          conversation.Total,
+			ReadSeq:        conversation.ReadSeq,
+			MentionSeq:     conversation.MentionSeq,
+			LastMsgTime:    lastMsgTime,
+		})
+		if err != nil {
+			return err
+		}
+		if err := m.resetUnread(ctx, conversations.UserId, conversationId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lastMsgTime 会话最后一条消息的发送时间，会话不存在或还没有消息时为 0
+func (m *Conversations) lastMsgTime(ctx context.Context, conversationId string) (int64, error) {
+	conversation, err := m.svcCtx.ConversationModel.FindByConversationId(ctx, conversationId)
+	switch {
+	case err == immodels.ErrNotFound:
+		return 0, nil
+	case err != nil:
+		return 0, err
+	case conversation.Msg == nil:
+		return 0, nil
+	default:
+		return conversation.Msg.SendTime, nil
+	}
+}
+
+func (m *Conversations) resetUnread(ctx context.Context, uid, conversationId string) error {
+	conversation, err := m.svcCtx.UserConversationModel.FindOne(ctx, uid, conversationId)
+	if err != nil {
+		return err
+	}
+	unread, err := m.svcCtx.ChatLogModel.CountUnread(ctx, conversationId, uid, conversation.ReadSeq)
+	if err != nil {
+		return err
+	}
+	_, err = m.svcCtx.UserConversationModel.SetUnread(ctx, uid, conversationId, unread)
+	return err
+}
@@ -0,0 +1,164 @@
+package migrate
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/pkg/constants"
+	"fmt"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type memLegacyConversationsModel struct {
+	immodels.ConversationsModel
+	s *memStore
+}
+
+func (m *memLegacyConversationsModel) ListAfterId(ctx context.Context, afterId string, limit int64) ([]*immodels.Conversations, error) {
+	var res []*immodels.Conversations
+	for _, conversations := range m.s.legacy {
+		if afterId != "" && conversations.ID.Hex() <= afterId {
+			continue
+		}
+		if int64(len(res)) == limit {
+			break
+		}
+		res = append(res, conversations)
+	}
+	return res, nil
+}
+
+// Merge 与数据库中的一样，已存在时只以 $max 合并水位与最后一条消息的时间
+func (m *memUserConversationModel) Merge(ctx context.Context, data *immodels.UserConversation) error {
+	if err := m.s.write("Merge"); err != nil {
+		return err
+	}
+	key := [2]string{data.UserId, data.ConversationId}
+	conversation, ok := m.s.userConversations[key]
+	if !ok {
+		c := *data
+		m.s.userConversations[key] = &c
+		return nil
+	}
+	conversation.ReadSeq = max(conversation.ReadSeq, data.ReadSeq)
+	conversation.MentionSeq = max(conversation.MentionSeq, data.MentionSeq)
+	conversation.LastMsgTime = max(conversation.LastMsgTime, data.LastMsgTime)
+	return nil
+}
+
+// newLegacyStore u1 的旧会话列表中有私聊 u1_u2 与只以键记录会话ID的群 g1，u2 迁移之后在新版本中读到了 1 并置顶了会话，
+// 未读数还没有更新
+func newLegacyStore() *memStore {
+	s := newMemStore()
+	for _, v := range []struct {
+		conversationId string
+		chatType       constants.ChatType
+		sendId, recvId string
+		seq            int64
+	}{
+		{"u1_u2", constants.SingleChatType, "u1", "u2", 1},
+		{"u1_u2", constants.SingleChatType, "u2", "u1", 2},
+		{"u1_u2", constants.SingleChatType, "u2", "u1", 3},
+		{"g1", constants.GroupChatType, "u3", "g1", 1},
+		{"g1", constants.GroupChatType, "u3", "g1", 2},
+		{"g1", constants.GroupChatType, "u1", "g1", 3},
+	} {
+		s.chatLogs = append(s.chatLogs, &immodels.ChatLog{
+			ID: primitive.NewObjectID(), ConversationId: v.conversationId, ChatType: v.chatType,
+			SendId: v.sendId, RecvId: v.recvId, Seq: v.seq, SendTime: v.seq * 10,
+		})
+	}
+	s.conversations["g1"] = &immodels.Conversation{ConversationId: "g1", ChatType: constants.GroupChatType, Seq: 3,
+		Msg: copyChatLog(s.chatLogs[len(s.chatLogs)-1])}
+
+	s.legacy = []*immodels.Conversations{
+		{ID: primitive.NewObjectID(), UserId: "u1", ConversationList: map[string]*immodels.Conversation{
+			"u1_u2": {ConversationId: "u1_u2", ChatType: constants.SingleChatType, IsShow: true, Total: 2, ReadSeq: 1},
+			"g1":    {ChatType: constants.GroupChatType, IsShow: true, ReadSeq: 1, MentionSeq: 2},
+			"x":     nil,
+		}},
+		{ID: primitive.NewObjectID(), UserId: "u2", ConversationList: map[string]*immodels.Conversation{
+			"u1_u2": {ConversationId: "u1_u2", ChatType: constants.SingleChatType, IsShow: true},
+		}},
+	}
+	s.userConversations[[2]string{"u2", "u1_u2"}] = &immodels.UserConversation{
+		UserId: "u2", ConversationId: "u1_u2", ChatType: constants.SingleChatType, IsShow: true, ReadSeq: 1, PinTime: 5, Unread: 9,
+	}
+	return s
+}
+
+func checkConversationsMigrated(t *testing.T, s *memStore) {
+	t.Helper()
+	for _, want := range []immodels.UserConversation{
+		{UserId: "u1", ConversationId: "u1_u2", ChatType: constants.SingleChatType, IsShow: true, Total: 2, ReadSeq: 1, Unread: 2},
+		{UserId: "u1", ConversationId: "g1", ChatType: constants.GroupChatType, IsShow: true, ReadSeq: 1, MentionSeq: 2, Unread: 1, LastMsgTime: 30},
+		// 迁移之后的修改不被旧数据覆盖，未读数按合并后的水位重新统计
+		{UserId: "u2", ConversationId: "u1_u2", ChatType: constants.SingleChatType, IsShow: true, ReadSeq: 1, PinTime: 5},
+	} {
+		got := s.userConversation(want.UserId, want.ConversationId)
+		if got == nil {
+			t.Errorf("%v %v not migrated", want.UserId, want.ConversationId)
+			continue
+		}
+		if *got != want {
+			t.Errorf("%v %v = %+v, want %+v", want.UserId, want.ConversationId, *got, want)
+		}
+	}
+	if n := len(s.userConversations); n != 3 {
+		t.Errorf("user conversations = %d, want 3", n)
+	}
+}
+
+func TestConversations_Run(t *testing.T) {
+	s := newLegacyStore()
+	m := NewConversations(s.serviceContext())
+	if err := m.Run(context.Background()); err != nil {
+		t.Fatalf("Run() err = %v", err)
+	}
+	checkConversationsMigrated(t, s)
+
+	// 重复执行的结果不变
+	if err := m.Run(context.Background()); err != nil {
+		t.Fatalf("Run() again err = %v", err)
+	}
+	checkConversationsMigrated(t, s)
+}
+
+func TestConversations_RunFailed(t *testing.T) {
+	// 单个用户失败时继续迁移其他用户，重新执行后补齐
+	for _, method := range []string{"Merge", "SetUnread"} {
+		t.Run(method, func(t *testing.T) {
+			s := newLegacyStore()
+			s.fail = method
+			m := NewConversations(s.serviceContext())
+			if err := m.Run(context.Background()); err != nil {
+				t.Fatalf("Run() err = %v", err)
+			}
+			if s.userConversation("u2", "u1_u2").Unread != 0 {
+				t.Errorf("u2 not migrated after u1 failed")
+			}
+			if err := m.Run(context.Background()); err != nil {
+				t.Fatalf("Run() again err = %v", err)
+			}
+			checkConversationsMigrated(t, s)
+		})
+	}
+}
+
+func TestConversations_RunBatches(t *testing.T) {
+	s := newMemStore()
+	n := conversationsBatchSize*2 + 1
+	for i := 0; i < n; i++ {
+		s.legacy = append(s.legacy, &immodels.Conversations{ID: primitive.NewObjectID(), UserId: fmt.Sprint("u", i),
+			ConversationList: map[string]*immodels.Conversation{"g1": {ConversationId: "g1", ChatType: constants.GroupChatType}}})
+	}
+
+	// 分批遍历全部用户
+	if err := NewConversations(s.serviceContext()).Run(context.Background()); err != nil {
+		t.Fatalf("Run() err = %v", err)
+	}
+	if len(s.userConversations) != n {
+		t.Errorf("user conversations = %d, want %d", len(s.userConversations), n)
+	}
+}
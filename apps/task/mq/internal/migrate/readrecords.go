@@ -0,0 +1,282 @@
+// 将旧版本聊天记录上的已读位图迁移为用户的已读水位
+
+package migrate
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/social/rpc/socialclient"
+	"easy-chat/apps/task/mq/internal/svc"
+	"easy-chat/pkg/bitmap"
+	"easy-chat/pkg/constants"
+
+	"github.com/pkg/errors"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// ReadRecords 迁移旧版本的已读记录
+//
+// 旧版本的消息没有序号，已读信息以哈希位图的形式保存在每条消息上。迁移按会话进行：
+//   - 记录旧消息的数量并为其预留序号，旧消息按发送顺序获得最小的一段序号
+//   - 已分配序号的新消息与用户的水位整体后移
+//   - 用位图推算每个成员已读的最后一条旧消息，以 $max 合并到用户的已读水位
+//   - 写入旧消息与会话最后一条消息的序号，清除消息上的位图
+//   - 旧消息有了序号之后，按水位重新统计所有成员的未读数
+//
+// 迁移进度记录在会话上，每一步都可以重复执行，中断后重新执行会从未完成的步骤继续；
+// 旧消息写入序号之后中断的会话按迁移进度找回。已经完成的会话不再有旧消息，重新执行时不做任何修改。
+//
+// 位图存在哈希冲突，推算出的水位只能尽量接近原有数据；迁移之后的已读状态完全由水位决定。
+// 迁移期间需要停止消息的写入，否则新分配的序号会与迁移冲突。
+type ReadRecords struct {
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewReadRecords(svcCtx *svc.ServiceContext) *ReadRecords {
+	return &ReadRecords{
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(context.Background()),
+	}
+}
+
+// Run 迁移所有存在旧消息或迁移未完成的会话，单个会话失败不影响其他会话
+func (m *ReadRecords) Run(ctx context.Context) error {
+	ids, err := m.svcCtx.ChatLogModel.ListLegacyConversationIds(ctx)
+	if err != nil {
+		return err
+	}
+	migrating, err := m.svcCtx.ConversationModel.ListSeqMigrating(ctx)
+	if err != nil {
+		return err
+	}
+	ids = mergeIds(ids, migrating)
+
+	m.Infof("migrate read records, conversations %v", len(ids))
+	for _, id := range ids {
+		if err := m.conversation(ctx, id); err != nil {
+			m.Errorf("migrate read records err %v, conversationId %v", err, id)
+		}
+	}
+	return nil
+}
+
+func (m *ReadRecords) conversation(ctx context.Context, conversationId string) error {
+	conversation, err := m.plan(ctx, conversationId)
+	if err != nil || conversation == nil {
+		return err
+	}
+	migration := conversation.SeqMigration
+
+	// 已有的序号后移，先取反再恢复，后移中断后重新执行不会重复后移
+	if migration.Shift > 0 && migration.Step < immodels.NegatedSeqMigration {
+		if err := m.svcCtx.ChatLogModel.NegateSeqs(ctx, conversationId); err != nil {
+			return err
+		}
+		if err := m.svcCtx.ConversationModel.SetSeqMigrationStep(ctx, conversationId, immodels.NegatedSeqMigration); err != nil {
+			return err
+		}
+	}
+	if migration.Shift > 0 && migration.Step < immodels.ShiftedSeqMigration {
+		if err := m.svcCtx.ChatLogModel.ShiftNegatedSeqs(ctx, conversationId, migration.Shift); err != nil {
+			return err
+		}
+		if err := m.svcCtx.UserConversationModel.ShiftReadSeqs(ctx, conversationId, migration.Shift); err != nil {
+			return err
+		}
+		if err := m.svcCtx.ConversationModel.SetSeqMigrationStep(ctx, conversationId, immodels.ShiftedSeqMigration); err != nil {
+			return err
+		}
+	}
+
+	// 旧消息占用 1..Legacy 的序号，已经写入序号的旧消息同样查出，保证每次得到相同的序号
+	legacy, err := m.svcCtx.ChatLogModel.ListLegacyByConversationId(ctx, conversationId, migration.Legacy)
+	if err != nil {
+		return err
+	}
+	if int64(len(legacy)) != migration.Legacy {
+		return errors.Errorf("legacy chat logs %v, want %v", len(legacy), migration.Legacy)
+	}
+	for i, chatLog := range legacy {
+		chatLog.Seq = int64(i) + 1
+	}
+
+	// 先合并已读水位再清除位图，清除中断时已读信息已经合并
+	if err := m.mergeReadSeqs(ctx, conversationId, legacy); err != nil {
+		return err
+	}
+	if err := m.msgSeq(ctx, conversation, legacy); err != nil {
+		return err
+	}
+	if err := m.svcCtx.ChatLogModel.MigrateSeqs(ctx, legacy); err != nil {
+		return err
+	}
+	// 旧消息写入序号之后才能被统计为未读
+	if err := m.resetUnreads(ctx, conversationId); err != nil {
+		return err
+	}
+	if err := m.svcCtx.ConversationModel.SetSeqMigrationStep(ctx, conversationId, immodels.DoneSeqMigration); err != nil {
+		return err
+	}
+
+	m.Infof("migrate read records conversationId %v, legacy %v, shift %v", conversationId, migration.Legacy, migration.Shift)
+	return nil
+}
+
+// plan 返回记录了迁移进度的会话，第一次迁移时记录旧消息的数量并为其预留序号
+func (m *ReadRecords) plan(ctx context.Context, conversationId string) (*immodels.Conversation, error) {
+	conversation, err := m.svcCtx.ConversationModel.FindByConversationId(ctx, conversationId)
+	switch err {
+	case nil:
+		if conversation.SeqMigration != nil {
+			return conversation, nil
+		}
+	case immodels.ErrNotFound:
+	default:
+		return nil, err
+	}
+
+	legacy, err := m.svcCtx.ChatLogModel.ListLegacyByConversationId(ctx, conversationId, 0)
+	if err != nil || len(legacy) == 0 {
+		return nil, err
+	}
+	if conversation == nil {
+		// 旧版本的会话可能没有记录，先建立序号为 0 的会话
+		if _, err := m.svcCtx.ConversationModel.IncrSeq(ctx, conversationId, legacy[0].ChatType, 0); err != nil {
+			return nil, err
+		}
+		conversation = &immodels.Conversation{ConversationId: conversationId}
+	}
+
+	// 会话中已经有分配了序号的消息时，已有的序号后移 n 位
+	n := int64(len(legacy))
+	migration := &immodels.SeqMigration{
+		Legacy: n,
+		Step:   immodels.PlannedSeqMigration,
+	}
+	if conversation.Seq > 0 {
+		migration.Shift = n
+	}
+	ok, err := m.svcCtx.ConversationModel.StartSeqMigration(ctx, conversationId, conversation.Seq, migration)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("conversation seq changed during migration")
+	}
+
+	conversation.Seq += n
+	conversation.SeqMigration = migration
+	return conversation, nil
+}
+
+// mergeReadSeqs 将推算出的已读水位合并到用户的会话
+func (m *ReadRecords) mergeReadSeqs(ctx context.Context, conversationId string, legacy []*immodels.ChatLog) error {
+	readSeqs, err := m.readSeqs(ctx, legacy)
+	if err != nil {
+		return err
+	}
+	for uid, readSeq := range readSeqs {
+		_, err := m.svcCtx.UserConversationModel.UpdateReadSeq(ctx, uid, conversationId, readSeq)
+		if err != nil && err != immodels.ErrNotFound {
+			return err
+		}
+	}
+	return nil
+}
+
+// resetUnreads 按已读水位重新统计会话中所有成员的未读数
+func (m *ReadRecords) resetUnreads(ctx context.Context, conversationId string) error {
+	conversations, err := m.svcCtx.UserConversationModel.ListByConversationId(ctx, conversationId)
+	if err != nil && err != immodels.ErrNotFound {
+		return err
+	}
+	for _, conversation := range conversations {
+		unread, err := m.svcCtx.ChatLogModel.CountUnread(ctx, conversationId, conversation.UserId, conversation.ReadSeq)
+		if err != nil {
+			return err
+		}
+		if _, err := m.svcCtx.UserConversationModel.SetUnread(ctx, conversation.UserId, conversationId, unread); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeIds 合并两组会话ID并去除重复
+func mergeIds(a, b []string) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	res := make([]string, 0, len(a)+len(b))
+	for _, ids := range [][]string{a, b} {
+		for _, id := range ids {
+			if !seen[id] {
+				seen[id] = true
+				res = append(res, id)
+			}
+		}
+	}
+	return res
+}
+
+// msgSeq 为会话中保存的最后一条消息写入迁移后的序号
+func (m *ReadRecords) msgSeq(ctx context.Context, conversation *immodels.Conversation, legacy []*immodels.ChatLog) error {
+	if conversation.Msg == nil || conversation.Msg.ID.IsZero() {
+		return nil
+	}
+
+	for _, chatLog := range legacy {
+		if chatLog.ID == conversation.Msg.ID {
+			return m.svcCtx.ConversationModel.SetMsgSeq(ctx, conversation.ConversationId, chatLog.ID, chatLog.Seq)
+		}
+	}
+	// 不是旧消息时以后移之后的序号为准
+	chatLog, err := m.svcCtx.ChatLogModel.FindOne(ctx, conversation.Msg.ID.Hex())
+	switch err {
+	case nil:
+		return m.svcCtx.ConversationModel.SetMsgSeq(ctx, conversation.ConversationId, chatLog.ID, chatLog.Seq)
+	case immodels.ErrNotFound:
+		return nil
+	default:
+		return err
+	}
+}
+
+// readSeqs 根据旧消息上的已读信息推算成员的已读水位
+//
+// 成员的水位取其发送或已读的最后一条消息的序号。
+func (m *ReadRecords) readSeqs(ctx context.Context, legacy []*immodels.ChatLog) (map[string]int64, error) {
+	res := make(map[string]int64)
+	read := func(uid string, seq int64) {
+		if seq > res[uid] {
+			res[uid] = seq
+		}
+	}
+
+	switch legacy[0].ChatType {
+	case constants.SingleChatType:
+		for _, chatLog := range legacy {
+			read(chatLog.SendId, chatLog.Seq)
+			if len(chatLog.ReadRecords) > 0 && chatLog.ReadRecords[0] != 0 {
+				read(chatLog.RecvId, chatLog.Seq)
+			}
+		}
+	case constants.GroupChatType:
+		groupUsers, err := m.svcCtx.Social.GroupUsers(ctx, &socialclient.GroupUsersReq{
+			GroupId: legacy[0].RecvId,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, chatLog := range legacy {
+			read(chatLog.SendId, chatLog.Seq)
+			records := bitmap.Load(chatLog.ReadRecords)
+			for _, member := range groupUsers.List {
+				if records.IsSet(member.UserId) {
+					read(member.UserId, chatLog.Seq)
+				}
+			}
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,511 @@
+package migrate
+
+import (
+	"context"
+	"easy-chat/apps/im/immodels"
+	"easy-chat/apps/social/rpc/socialclient"
+	"easy-chat/apps/task/mq/internal/svc"
+	"easy-chat/pkg/bitmap"
+	"easy-chat/pkg/constants"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+)
+
+var errFake = errors.New("fake error")
+
+// memStore 迁移涉及的集合，查询返回副本，与从数据库读出的数据一样不会被调用方修改
+type memStore struct {
+	chatLogs          []*immodels.ChatLog
+	conversations     map[string]*immodels.Conversation
+	userConversations map[[2]string]*immodels.UserConversation
+	shifted           map[[2]string]bool // 用户会话的水位已经后移
+	groups            map[string][]string
+	legacy            []*immodels.Conversations // 旧版本的用户会话列表，按ID升序
+
+	fail   string // 下一次调用该方法时返回错误
+	writes int
+}
+
+func newMemStore() *memStore {
+	return &memStore{
+		conversations:     make(map[string]*immodels.Conversation),
+		userConversations: make(map[[2]string]*immodels.UserConversation),
+		shifted:           make(map[[2]string]bool),
+		groups:            make(map[string][]string),
+	}
+}
+
+func (s *memStore) write(method string) error {
+	if s.fail == method {
+		s.fail = ""
+		return errFake
+	}
+	s.writes++
+	return nil
+}
+
+func (s *memStore) userConversation(uid, conversationId string) *immodels.UserConversation {
+	return s.userConversations[[2]string{uid, conversationId}]
+}
+
+func (s *memStore) serviceContext() *svc.ServiceContext {
+	return &svc.ServiceContext{
+		Social:                   &memSocial{s: s},
+		ChatLogModel:             &memChatLogModel{s: s},
+		ConversationModel:        &memConversationModel{s: s},
+		UserConversationModel:    &memUserConversationModel{s: s},
+		LegacyConversationsModel: &memLegacyConversationsModel{s: s},
+	}
+}
+
+func copyChatLog(chatLog *immodels.ChatLog) *immodels.ChatLog {
+	c := *chatLog
+	return &c
+}
+
+type memChatLogModel struct {
+	immodels.ChatLogModel
+	s *memStore
+}
+
+func (m *memChatLogModel) ListLegacyConversationIds(ctx context.Context) ([]string, error) {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, chatLog := range m.s.chatLogs {
+		if chatLog.Seq == 0 && !seen[chatLog.ConversationId] {
+			seen[chatLog.ConversationId] = true
+			ids = append(ids, chatLog.ConversationId)
+		}
+	}
+	return ids, nil
+}
+
+func (m *memChatLogModel) ListLegacyByConversationId(ctx context.Context, conversationId string, legacySeq int64) ([]*immodels.ChatLog, error) {
+	var res []*immodels.ChatLog
+	for _, chatLog := range m.s.chatLogs {
+		if chatLog.ConversationId != conversationId {
+			continue
+		}
+		if chatLog.Seq == 0 || (legacySeq > 0 && chatLog.Seq > 0 && chatLog.Seq <= legacySeq) {
+			res = append(res, copyChatLog(chatLog))
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].SendTime != res[j].SendTime {
+			return res[i].SendTime < res[j].SendTime
+		}
+		return res[i].ID.Hex() < res[j].ID.Hex()
+	})
+	return res, nil
+}
+
+func (m *memChatLogModel) NegateSeqs(ctx context.Context, conversationId string) error {
+	if err := m.s.write("NegateSeqs"); err != nil {
+		return err
+	}
+	for _, chatLog := range m.s.chatLogs {
+		if chatLog.ConversationId == conversationId && chatLog.Seq > 0 {
+			chatLog.Seq = -chatLog.Seq
+		}
+	}
+	return nil
+}
+
+func (m *memChatLogModel) ShiftNegatedSeqs(ctx context.Context, conversationId string, shift int64) error {
+	if err := m.s.write("ShiftNegatedSeqs"); err != nil {
+		return err
+	}
+	for _, chatLog := range m.s.chatLogs {
+		if chatLog.ConversationId == conversationId && chatLog.Seq < 0 {
+			chatLog.Seq = shift - chatLog.Seq
+		}
+	}
+	return nil
+}
+
+func (m *memChatLogModel) MigrateSeqs(ctx context.Context, legacy []*immodels.ChatLog) error {
+	if err := m.s.write("MigrateSeqs"); err != nil {
+		return err
+	}
+	for _, v := range legacy {
+		for _, chatLog := range m.s.chatLogs {
+			if chatLog.ID == v.ID {
+				chatLog.Seq, chatLog.ReadRecords = v.Seq, nil
+			}
+		}
+	}
+	return nil
+}
+
+func (m *memChatLogModel) CountUnread(ctx context.Context, conversationId, uid string, readSeq int64) (int64, error) {
+	var n int64
+	for _, chatLog := range m.s.chatLogs {
+		if chatLog.ConversationId == conversationId && chatLog.Seq > readSeq && chatLog.SendId != uid &&
+			chatLog.ThreadId == "" && chatLog.Status != constants.RecallMsgStatus {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (m *memChatLogModel) FindOne(ctx context.Context, id string) (*immodels.ChatLog, error) {
+	for _, chatLog := range m.s.chatLogs {
+		if chatLog.ID.Hex() == id {
+			return copyChatLog(chatLog), nil
+		}
+	}
+	return nil, immodels.ErrNotFound
+}
+
+type memConversationModel struct {
+	immodels.ConversationModel
+	s *memStore
+}
+
+func (m *memConversationModel) FindByConversationId(ctx context.Context, conversationId string) (*immodels.Conversation, error) {
+	conversation, ok := m.s.conversations[conversationId]
+	if !ok {
+		return nil, immodels.ErrNotFound
+	}
+	c := *conversation
+	if c.Msg != nil {
+		c.Msg = copyChatLog(c.Msg)
+	}
+	if c.SeqMigration != nil {
+		migration := *c.SeqMigration
+		c.SeqMigration = &migration
+	}
+	return &c, nil
+}
+
+func (m *memConversationModel) IncrSeq(ctx context.Context, conversationId string, chatType constants.ChatType, n int64) (int64, error) {
+	if err := m.s.write("IncrSeq"); err != nil {
+		return 0, err
+	}
+	conversation, ok := m.s.conversations[conversationId]
+	if !ok {
+		conversation = &immodels.Conversation{ConversationId: conversationId, ChatType: chatType}
+		m.s.conversations[conversationId] = conversation
+	}
+	conversation.Seq += n
+	return conversation.Seq, nil
+}
+
+func (m *memConversationModel) StartSeqMigration(ctx context.Context, conversationId string, seq int64, migration *immodels.SeqMigration) (bool, error) {
+	if err := m.s.write("StartSeqMigration"); err != nil {
+		return false, err
+	}
+	conversation, ok := m.s.conversations[conversationId]
+	if !ok || conversation.SeqMigration != nil || conversation.Seq != seq {
+		return false, nil
+	}
+	c := *migration
+	conversation.Seq += migration.Legacy
+	conversation.SeqMigration = &c
+	return true, nil
+}
+
+func (m *memConversationModel) SetSeqMigrationStep(ctx context.Context, conversationId string, step immodels.SeqMigrationStep) error {
+	if err := m.s.write("SetSeqMigrationStep"); err != nil {
+		return err
+	}
+	if conversation, ok := m.s.conversations[conversationId]; ok && conversation.SeqMigration != nil {
+		conversation.SeqMigration.Step = step
+	}
+	return nil
+}
+
+func (m *memConversationModel) ListSeqMigrating(ctx context.Context) ([]string, error) {
+	var ids []string
+	for id, conversation := range m.s.conversations {
+		if conversation.SeqMigration != nil && conversation.SeqMigration.Step < immodels.DoneSeqMigration {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (m *memConversationModel) SetMsgSeq(ctx context.Context, conversationId string, msgId primitive.ObjectID, seq int64) error {
+	if err := m.s.write("SetMsgSeq"); err != nil {
+		return err
+	}
+	if conversation, ok := m.s.conversations[conversationId]; ok && conversation.Msg != nil && conversation.Msg.ID == msgId {
+		conversation.Msg.Seq = seq
+	}
+	return nil
+}
+
+type memUserConversationModel struct {
+	immodels.UserConversationModel
+	s *memStore
+}
+
+func (m *memUserConversationModel) FindOne(ctx context.Context, uid, conversationId string) (*immodels.UserConversation, error) {
+	conversation := m.s.userConversation(uid, conversationId)
+	if conversation == nil {
+		return nil, immodels.ErrNotFound
+	}
+	c := *conversation
+	return &c, nil
+}
+
+func (m *memUserConversationModel) ListByConversationId(ctx context.Context, conversationId string) ([]*immodels.UserConversation, error) {
+	var res []*immodels.UserConversation
+	for key, conversation := range m.s.userConversations {
+		if key[1] == conversationId {
+			c := *conversation
+			res = append(res, &c)
+		}
+	}
+	return res, nil
+}
+
+func (m *memUserConversationModel) ShiftReadSeqs(ctx context.Context, conversationId string, shift int64) error {
+	if err := m.s.write("ShiftReadSeqs"); err != nil {
+		return err
+	}
+	for key, conversation := range m.s.userConversations {
+		if key[1] != conversationId || m.s.shifted[key] {
+			continue
+		}
+		for _, seq := range []*int64{&conversation.ReadSeq, &conversation.MentionSeq, &conversation.ClearSeq} {
+			if *seq > 0 {
+				*seq += shift
+			}
+		}
+		m.s.shifted[key] = true
+	}
+	return nil
+}
+
+func (m *memUserConversationModel) UpdateReadSeq(ctx context.Context, uid, conversationId string, readSeq int64) (int64, error) {
+	if err := m.s.write("UpdateReadSeq"); err != nil {
+		return 0, err
+	}
+	conversation := m.s.userConversation(uid, conversationId)
+	if conversation == nil {
+		return 0, immodels.ErrNotFound
+	}
+	if readSeq > conversation.ReadSeq {
+		conversation.ReadSeq = readSeq
+	}
+	return conversation.ReadSeq, nil
+}
+
+func (m *memUserConversationModel) SetUnread(ctx context.Context, uid, conversationId string, unread int64) (*immodels.UserConversation, error) {
+	if err := m.s.write("SetUnread"); err != nil {
+		return nil, err
+	}
+	conversation := m.s.userConversation(uid, conversationId)
+	if conversation == nil {
+		return nil, immodels.ErrNotFound
+	}
+	conversation.Unread = unread
+	c := *conversation
+	return &c, nil
+}
+
+type memSocial struct {
+	socialclient.Social
+	s *memStore
+}
+
+func (f *memSocial) GroupUsers(ctx context.Context, in *socialclient.GroupUsersReq, opts ...grpc.CallOption) (*socialclient.GroupUsersResp, error) {
+	var list []*socialclient.GroupMembers
+	for _, uid := range f.s.groups[in.GroupId] {
+		list = append(list, &socialclient.GroupMembers{GroupId: in.GroupId, UserId: uid})
+	}
+	return &socialclient.GroupUsersResp{List: list}, nil
+}
+
+// readBitmap 旧版本群消息上的已读位图
+func readBitmap(uids ...string) []byte {
+	bm := bitmap.NewBitmap(0)
+	for _, uid := range uids {
+		bm.Set(uid)
+	}
+	return bm.Export()
+}
+
+// newSingleStore 没有会话记录的旧版本私聊：u1 发送的 l1 已读、l2 未读，u2 发送 l3
+func newSingleStore() *memStore {
+	s := newMemStore()
+	// 按发送时间而不是写入的顺序分配序号
+	for _, v := range []struct {
+		content, sendId, recvId string
+		sendTime                int64
+		readRecords             []byte
+	}{
+		{"l3", "u2", "u1", 3, nil},
+		{"l1", "u1", "u2", 1, []byte{1}},
+		{"l2", "u1", "u2", 2, []byte{0}},
+	} {
+		s.chatLogs = append(s.chatLogs, &immodels.ChatLog{
+			ID: primitive.NewObjectID(), ConversationId: "u1_u2", ChatType: constants.SingleChatType,
+			SendId: v.sendId, RecvId: v.recvId, MsgContent: v.content, SendTime: v.sendTime, ReadRecords: v.readRecords,
+		})
+	}
+	for _, uid := range []string{"u1", "u2"} {
+		s.userConversations[[2]string{uid, "u1_u2"}] = &immodels.UserConversation{UserId: uid, ConversationId: "u1_u2", ChatType: constants.SingleChatType}
+	}
+	return s
+}
+
+// newGroupStore 群 g1 在旧消息 l1、l2 之后已经有了分配序号的新消息 n1、n2
+//
+// u2 读过 l1，u1 读到 n1，u3 读到 n2，会话的最后一条消息是 n2。
+func newGroupStore() *memStore {
+	s := newMemStore()
+	s.groups["g1"] = []string{"u1", "u2", "u3"}
+	for _, v := range []struct {
+		content, sendId string
+		sendTime, seq   int64
+		readRecords     []byte
+	}{
+		{"l1", "u1", 1, 0, readBitmap("u2")},
+		{"l2", "u2", 2, 0, readBitmap()},
+		{"n1", "u1", 3, 1, nil},
+		{"n2", "u3", 4, 2, nil},
+	} {
+		s.chatLogs = append(s.chatLogs, &immodels.ChatLog{
+			ID: primitive.NewObjectID(), ConversationId: "g1", ChatType: constants.GroupChatType,
+			SendId: v.sendId, RecvId: "g1", MsgContent: v.content, SendTime: v.sendTime, Seq: v.seq, ReadRecords: v.readRecords,
+		})
+	}
+	last := s.chatLogs[len(s.chatLogs)-1]
+	s.conversations["g1"] = &immodels.Conversation{ConversationId: "g1", ChatType: constants.GroupChatType, Seq: 2, Msg: copyChatLog(last)}
+	for uid, readSeq := range map[string]int64{"u1": 1, "u2": 0, "u3": 2} {
+		s.userConversations[[2]string{uid, "g1"}] = &immodels.UserConversation{UserId: uid, ConversationId: "g1", ChatType: constants.GroupChatType, ReadSeq: readSeq}
+	}
+	return s
+}
+
+type wantMigration struct {
+	conversationId string
+	seqs           map[string]int64 // 按消息内容
+	seq            int64            // 会话的序号
+	msgSeq         int64            // 会话最后一条消息的序号，没有时为 0
+	readSeqs       map[string]int64
+	unreads        map[string]int64
+}
+
+func checkMigrated(t *testing.T, s *memStore, want wantMigration) {
+	t.Helper()
+	for _, chatLog := range s.chatLogs {
+		if chatLog.ConversationId != want.conversationId {
+			continue
+		}
+		if chatLog.Seq != want.seqs[chatLog.MsgContent] {
+			t.Errorf("%v seq = %v, want %v", chatLog.MsgContent, chatLog.Seq, want.seqs[chatLog.MsgContent])
+		}
+		if chatLog.ReadRecords != nil {
+			t.Errorf("%v read records not cleared", chatLog.MsgContent)
+		}
+	}
+
+	conversation := s.conversations[want.conversationId]
+	if conversation == nil || conversation.Seq != want.seq {
+		t.Fatalf("conversation = %+v, want seq %v", conversation, want.seq)
+	}
+	if conversation.SeqMigration == nil || conversation.SeqMigration.Step != immodels.DoneSeqMigration {
+		t.Errorf("conversation migration = %+v, want done", conversation.SeqMigration)
+	}
+	if conversation.Msg != nil && conversation.Msg.Seq != want.msgSeq {
+		t.Errorf("conversation msg seq = %v, want %v", conversation.Msg.Seq, want.msgSeq)
+	}
+
+	for uid, readSeq := range want.readSeqs {
+		conversation := s.userConversation(uid, want.conversationId)
+		if conversation.ReadSeq != readSeq || conversation.Unread != want.unreads[uid] {
+			t.Errorf("%v read seq %v unread %v, want %v, %v", uid, conversation.ReadSeq, conversation.Unread, readSeq, want.unreads[uid])
+		}
+	}
+}
+
+var (
+	wantSingleMigration = wantMigration{
+		conversationId: "u1_u2",
+		seqs:           map[string]int64{"l1": 1, "l2": 2, "l3": 3},
+		seq:            3,
+		readSeqs:       map[string]int64{"u1": 2, "u2": 3},
+		unreads:        map[string]int64{"u1": 1, "u2": 0},
+	}
+	// 新消息后移到旧消息之后，u1 的水位随之后移，u2 的水位由位图推算
+	wantGroupMigration = wantMigration{
+		conversationId: "g1",
+		seqs:           map[string]int64{"l1": 1, "l2": 2, "n1": 3, "n2": 4},
+		seq:            4,
+		msgSeq:         4,
+		readSeqs:       map[string]int64{"u1": 3, "u2": 2, "u3": 4},
+		unreads:        map[string]int64{"u1": 1, "u2": 2, "u3": 0},
+	}
+)
+
+func TestReadRecords_Run(t *testing.T) {
+	tests := []struct {
+		name  string
+		store func() *memStore
+		want  wantMigration
+	}{
+		{"fresh", newSingleStore, wantSingleMigration},
+		{"assigned seqs", newGroupStore, wantGroupMigration},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.store()
+			m := NewReadRecords(s.serviceContext())
+			if err := m.Run(context.Background()); err != nil {
+				t.Fatalf("Run() err = %v", err)
+			}
+			checkMigrated(t, s, tt.want)
+
+			// 已经完成的会话不再有旧消息，重新执行不做任何修改
+			before := s.writes
+			if err := m.Run(context.Background()); err != nil {
+				t.Fatalf("Run() again err = %v", err)
+			}
+			if s.writes != before {
+				t.Errorf("Run() again wrote %d times, want 0", s.writes-before)
+			}
+			checkMigrated(t, s, tt.want)
+		})
+	}
+}
+
+func TestReadRecords_RunInterrupted(t *testing.T) {
+	// 迁移在每一步中断后重新执行，结果与一次完成的迁移相同；SetUnread 在旧消息写入序号之后失败
+	for _, method := range []string{
+		"StartSeqMigration", "SetSeqMigrationStep", "NegateSeqs", "ShiftNegatedSeqs", "ShiftReadSeqs",
+		"UpdateReadSeq", "SetUnread", "SetMsgSeq", "MigrateSeqs",
+	} {
+		t.Run(method, func(t *testing.T) {
+			s := newGroupStore()
+			s.fail = method
+			m := NewReadRecords(s.serviceContext())
+
+			// 单个会话的失败不作为整体的错误
+			if err := m.Run(context.Background()); err != nil {
+				t.Fatalf("Run() err = %v", err)
+			}
+			if s.fail != "" {
+				t.Fatalf("%v not called", method)
+			}
+			if migration := s.conversations["g1"].SeqMigration; migration != nil && migration.Step == immodels.DoneSeqMigration {
+				t.Fatalf("conversation migrated after %v failed", method)
+			}
+
+			if err := m.Run(context.Background()); err != nil {
+				t.Fatalf("Run() again err = %v", err)
+			}
+			checkMigrated(t, s, wantGroupMigration)
+			if !reflect.DeepEqual(s.conversations["g1"].SeqMigration, &immodels.SeqMigration{Legacy: 2, Shift: 2, Step: immodels.DoneSeqMigration}) {
+				t.Errorf("conversation migration = %+v", s.conversations["g1"].SeqMigration)
+			}
+		})
+	}
+}
@@ -1,10 +1,12 @@
 package svc
 
 import (
+	"easy-chat/apps/im/authz"
 	"easy-chat/apps/im/immodels"
 	"easy-chat/apps/im/ws/websocket"
 	"easy-chat/apps/social/rpc/socialclient"
 	"easy-chat/apps/task/mq/internal/config"
+	"easy-chat/apps/task/mq/mqclient"
 	"easy-chat/pkg/constants"
 	"github.com/zeromicro/go-zero/core/stores/redis"
 	"github.com/zeromicro/go-zero/zrpc"
@@ -18,16 +20,28 @@ type ServiceContext struct {
 	socialclient.Social
 	immodels.ChatLogModel
 	immodels.ConversationModel
+	immodels.UserConversationModel
+	immodels.ScheduledMsgModel
+	// 定时消息到期后投递到消息队列，与用户发送的消息一起处理
+	MsgChatTransferClient mqclient.MsgChatTransferClient
+	Auth                  *authz.Authorizer
+	// 旧版本的用户会话列表，仅用于迁移
+	LegacyConversationsModel immodels.ConversationsModel
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
 	svc := &ServiceContext{
-		Config:            c,
-		Redis:             redis.MustNewRedis(c.Redisx),
-		Social:            socialclient.NewSocial(zrpc.MustNewClient(c.SocialRpc)),
-		ChatLogModel:      immodels.MustChatLogModel(c.Mongo.Url, c.Mongo.Db),
-		ConversationModel: immodels.MustConversationModel(c.Mongo.Url, c.Mongo.Db),
+		Config:                   c,
+		Redis:                    redis.MustNewRedis(c.Redisx),
+		Social:                   socialclient.NewSocial(zrpc.MustNewClient(c.SocialRpc)),
+		ChatLogModel:             immodels.MustChatLogModel(c.Mongo.Url, c.Mongo.Db),
+		ConversationModel:        immodels.MustConversationModel(c.Mongo.Url, c.Mongo.Db),
+		UserConversationModel:    immodels.MustUserConversationModel(c.Mongo.Url, c.Mongo.Db),
+		ScheduledMsgModel:        immodels.MustScheduledMsgModel(c.Mongo.Url, c.Mongo.Db),
+		MsgChatTransferClient:    mqclient.NewMsgChatTransferClient(c.MsgChatTransfer.Brokers, c.MsgChatTransfer.Topic),
+		LegacyConversationsModel: immodels.MustConversationsModel(c.Mongo.Url, c.Mongo.Db),
 	}
+	svc.Auth = authz.NewAuthorizer(svc.Social)
 	token, err := svc.GetSystemToken()
 	if err != nil {
 		panic(err)
@@ -1,10 +1,13 @@
 package mq
 
-import "easy-chat/pkg/constants"
+import (
+	"easy-chat/apps/im/immodels"
+	"easy-chat/pkg/constants"
+)
 
 // MsgChatTransfer kafka消息格式
 type MsgChatTransfer struct {
-	MsgId              string            `mapstructure:"msgId"`
+	ClientMsgId        string            `json:"clientMsgId"`    // 发送者客户端的消息标识，如 websocket 帧的ID，推送时原样带回
 	ConversationId     string            `json:"conversationId"` // 聊天会话的唯一标识符
 	constants.ChatType `json:"chatType"` // 聊天的类型，定义在 constants 中
 	SendId             string            `json:"sendId"` // 发送者的唯一标识符
@@ -12,14 +15,36 @@ type MsgChatTransfer struct {
 	RecvIds            []string          `json:"recvIds"`
 	SendTime           int64             `json:"sendTime"` // 消息发送的时间戳
 	constants.MType    `json:"mType"`    // 消息的类型，定义在 constants 中
-	Content            string            `json:"content"` // 消息的实际内容
+	Content            string            `json:"content"`   // 消息的实际内容
+	Body               *immodels.MsgBody `json:"body"`      // 非文本消息的结构化内容
+	Quote              *immodels.Quote   `json:"quote"`     // 回复时引用的消息
+	Mention            *immodels.Mention `json:"mention"`   // 群消息中提及的成员
+	ThreadId           string            `json:"threadId"`  // 所属话题的第一条消息ID
+	ChatLogId          string            `json:"chatLogId"` // 聊天记录ID，由生产者预先分配，消费者据此关联同一条消息
 }
 
 // MsgMarkRead 处理已读消息
 type MsgMarkRead struct {
 	constants.ChatType `json:"chatType"`
-	ConversationId     string   `json:"conversationId"`
-	SendId             string   `json:"sendId"`
-	RecvId             string   `json:"recvId"`
-	MsgIds             []string `json:"msgIds"`
+	ConversationId     string `json:"conversationId"`
+	SendId             string `json:"sendId"`
+	RecvId             string `json:"recvId"`
+	ReadSeq            int64  `json:"readSeq"` // 已读到的消息序号，为 0 表示读到会话的最新消息
+}
+
+// MsgEventTransfer 已经处理完成、只需推送给会话成员的消息事件，如撤回、编辑等
+type MsgEventTransfer struct {
+	constants.ContentType `json:"contentType"` // 事件类型
+	ConversationId        string               `json:"conversationId"`
+	constants.ChatType    `json:"chatType"`
+	SendId                string `json:"sendId"` // 事件的发起者
+	RecvId                string `json:"recvId"`
+	SendTime              int64  `json:"sendTime"`
+	MsgId                 string `json:"msgId"` // 事件对应的消息
+	Seq                   int64  `json:"seq"`
+	Version               int    `json:"version"` // 消息的编辑版本
+	Content               string `json:"content"`
+	Emoji                 string `json:"emoji"`   // 回应的表情
+	Removed               bool   `json:"removed"` // 是否为取消回应或取消置顶
+	Count                 int64  `json:"count"`   // 表情当前的回应数
 }
@@ -53,3 +53,26 @@ func NewMsgReadTransferClient(addr []string, topic string, opts ...kq.PushOption
 		pusher: kq.NewPusher(addr, topic),
 	}
 }
+
+//消息事件的客户端
+
+type MsgEventTransferClient interface {
+	Push(msg *mq.MsgEventTransfer) error
+}
+type msgEventTransferClient struct {
+	pusher *kq.Pusher
+}
+
+func (m *msgEventTransferClient) Push(msg *mq.MsgEventTransfer) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return m.pusher.Push(context.Background(), string(body))
+}
+
+func NewMsgEventTransferClient(addr []string, topic string, opts ...kq.PushOption) MsgEventTransferClient {
+	return &msgEventTransferClient{
+		pusher: kq.NewPusher(addr, topic),
+	}
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"easy-chat/apps/task/mq/internal/config"
 	"easy-chat/apps/task/mq/internal/handler"
+	"easy-chat/apps/task/mq/internal/migrate"
 	"easy-chat/apps/task/mq/internal/svc"
 	"flag"
 	"fmt"
@@ -21,6 +23,17 @@ func main() {
 	}
 	// 创建服务上下文。
 	ctx := svc.NewServiceContext(c)
+	// 迁移旧版本的数据，完成后再开始消费；已读记录迁移到用户的会话上，需要先迁移会话列表
+	if c.Migrate.Conversations {
+		if err := migrate.NewConversations(ctx).Run(context.Background()); err != nil {
+			panic(err)
+		}
+	}
+	if c.Migrate.ReadRecords {
+		if err := migrate.NewReadRecords(ctx).Run(context.Background()); err != nil {
+			panic(err)
+		}
+	}
 	// 初始化监听器，用于处理服务请求
 	listen := handler.NewListen(ctx)
 	// 创建服务组，用于统一管理和启动服务。
@@ -3,7 +3,6 @@ package user
 import (
 	"context"
 	"easy-chat/apps/user/rpc/user"
-	"easy-chat/pkg/constants"
 	"github.com/jinzhu/copier"
 
 	"easy-chat/apps/user/api/internal/svc"
@@ -37,7 +36,5 @@ func (l *LoginLogic) Login(req *types.LoginReq) (resp *types.LoginResp, err erro
 	var res types.LoginResp
 	copier.Copy(&res, LoginResp)
 
-	//处理登录的业务
-	l.svcCtx.Redis.HsetCtx(l.ctx, constants.RedisOnlineUser, LoginResp.Id, "1")
 	return &res, nil
 }
@@ -1,6 +1,9 @@
 package bitmap
 
 // Bitmap 是一个位图结构，用于高效地表示和操作大量的布尔值。
+//
+// 不同的 id 可能映射到同一位，只适合允许误判的场景；消息的已读状态已改为按已读水位计算，
+// 这里仅用于读取旧版本消息上的已读记录。
 type Bitmap struct {
 	bits []byte // 存储位图的字节数组
 	size int    // 位图的总位数
@@ -4,6 +4,11 @@ type MType int
 
 const (
 	TextMtype MType = iota
+	ImageMtype
+	FileMtype
+	VoiceMtype
+	LocationMtype
+	CardMtype
 )
 
 type ChatType int
@@ -18,4 +23,58 @@ type ContentType int
 const (
 	ContentChatMsg ContentType = iota
 	ContentMakeRead
+	ContentRecall
+	ContentSystem
+	ContentEdit
+	ContentReaction
+	ContentThread
+	ContentPin
+	// 会话的未读数变化，只推送给该用户
+	ContentBadge
+	// 会话的草稿变化，只推送给该用户
+	ContentDraft
+	// 正在输入，由 websocket 服务直接转发
+	ContentTyping
+	// 好友的在线状态变化，只推送给订阅了该好友的连接
+	ContentPresence
+)
+
+// Private 是否只推送给 RecvId 对应的用户，而不是会话的所有成员
+func (t ContentType) Private() bool {
+	return t == ContentBadge || t == ContentDraft
+}
+
+// PageDirection 聊天记录的翻页方向 0. 更早的消息 1. 更新的消息
+type PageDirection int
+
+const (
+	OlderPageDirection PageDirection = iota
+	NewerPageDirection
+)
+
+// MsgStatus 消息状态 0. 正常 1. 已撤回
+type MsgStatus int
+
+const (
+	NormalMsgStatus MsgStatus = iota
+	RecallMsgStatus
+)
+
+// ScheduledMsgStatus 定时消息状态 0. 等待发送 1. 发送中 2. 已发送 3. 已取消 4. 发送失败
+type ScheduledMsgStatus int
+
+const (
+	PendingScheduledMsgStatus ScheduledMsgStatus = iota
+	SendingScheduledMsgStatus
+	SentScheduledMsgStatus
+	CanceledScheduledMsgStatus
+	FailedScheduledMsgStatus
+)
+
+// CardType 名片类型 1. 用户 2. 群
+type CardType int
+
+const (
+	UserCardType CardType = iota + 1
+	GroupCardType
 )
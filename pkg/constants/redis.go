@@ -2,5 +2,14 @@ package constants
 
 const (
 	REDIS_SYSTEM_ROOT_TOKEN string = "system:root:token"
-	RedisOnlineUser         string = "online:user"
+	// 用户各个设备的在线状态，hash 的字段为设备，值为过期的毫秒时间戳，%s 为用户ID
+	//
+	// 同一用户的键使用相同的哈希标签，在集群中位于同一个槽，可以在一个脚本中同时修改
+	RedisPresenceDevices string = "presence:{%s}:devices"
+	// 用户最后在线的毫秒时间戳，%s 为用户ID
+	RedisPresenceLastSeen string = "presence:{%s}:lastseen"
+	// 有设备在线的用户，有序集合的分数为用户设备中最晚的过期时间，用于清理没有正常离线的设备
+	RedisPresenceExpiry string = "presence:expiry"
+	// 用户在线状态变化的发布订阅频道，在 websocket 服务之间广播
+	RedisPresenceChannel string = "presence:notify"
 )
@@ -0,0 +1,72 @@
+package presence
+
+import (
+	"context"
+	"crypto/tls"
+	"easy-chat/pkg/constants"
+	"encoding/json"
+	"strings"
+
+	red "github.com/redis/go-redis/v9"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+)
+
+// Notifier 通过 redis 的发布订阅在 websocket 服务之间广播在线状态的变化
+//
+// 用户的设备分布在不同的服务上，上线或离线的服务发布变化，所有服务（包括发布者自己）收到后
+// 通知本服务上订阅了该用户的连接。go-zero 的 redis 不支持订阅，这里直接使用 go-redis 的客户端。
+type Notifier struct {
+	cli red.UniversalClient
+}
+
+// NewNotifier 使用与 Store 相同的 redis 配置
+func NewNotifier(c redis.RedisConf) *Notifier {
+	opts := &red.UniversalOptions{
+		Addrs:    strings.Split(c.Host, ","),
+		Password: c.Pass,
+	}
+	if c.Tls {
+		opts.TLSConfig = &tls.Config{}
+	}
+	if c.Type == redis.ClusterType {
+		return &Notifier{cli: red.NewClusterClient(opts.Cluster())}
+	}
+	return &Notifier{cli: red.NewClient(opts.Simple())}
+}
+
+// Publish 发布用户在线状态的变化
+func (n *Notifier) Publish(ctx context.Context, status *Status) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	return n.cli.Publish(ctx, constants.RedisPresenceChannel, data).Err()
+}
+
+// Subscribe 订阅在线状态的变化，订阅成功后在后台依次调用 fn，直到 ctx 结束
+//
+// 与 redis 的连接断开后自动重新订阅，断开期间发布的变化会丢失，订阅者可以再次查询在线状态。
+func (n *Notifier) Subscribe(ctx context.Context, fn func(status *Status)) error {
+	pubsub := n.cli.Subscribe(ctx, constants.RedisPresenceChannel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return err
+	}
+
+	go func() {
+		<-ctx.Done()
+		pubsub.Close()
+	}()
+	go func() {
+		for msg := range pubsub.Channel() {
+			var status Status
+			if err := json.Unmarshal([]byte(msg.Payload), &status); err != nil {
+				logx.Errorf("presence notify unmarshal %v err %v", msg.Payload, err)
+				continue
+			}
+			fn(&status)
+		}
+	}()
+	return nil
+}
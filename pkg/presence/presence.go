@@ -0,0 +1,284 @@
+// 用户的在线状态，由 websocket 服务在连接建立与关闭时维护，其他服务按用户批量查询
+
+package presence
+
+import (
+	"context"
+	"easy-chat/pkg/constants"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	red "github.com/redis/go-redis/v9"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+)
+
+// DefaultTTL 设备没有心跳后仍视为在线的时间，websocket 服务异常退出时设备在此之后自动离线
+const DefaultTTL = 60 * time.Second
+
+// Status 用户的在线状态
+type Status struct {
+	UserId   string
+	Online   bool     // 至少有一个设备在线
+	Devices  []string // 在线的设备
+	LastSeen int64    // 最后在线的毫秒时间戳，在线时为最近一次心跳的时间，从未上线时为 0
+}
+
+// Device 用户在线的一个设备
+type Device struct {
+	UserId string
+	Device string
+}
+
+// connectScript 记录设备上线，返回上线之前在线的设备数
+//
+// KEYS[1] 用户的设备，KEYS[2] 最后在线的时间；ARGV 依次为设备、当前时间、TTL 与设备记录的保留时间，单位为毫秒
+var connectScript = redis.NewScript(`
+local now = tonumber(ARGV[2])
+local live = 0
+local devices = redis.call('HGETALL', KEYS[1])
+for i = 1, #devices, 2 do
+	if tonumber(devices[i + 1]) > now then
+		live = live + 1
+	else
+		redis.call('HDEL', KEYS[1], devices[i])
+	end
+end
+redis.call('HSET', KEYS[1], ARGV[1], now + tonumber(ARGV[3]))
+redis.call('PEXPIRE', KEYS[1], ARGV[4])
+redis.call('SET', KEYS[2], ARGV[2])
+return live
+`)
+
+// disconnectScript 记录设备离线，返回离线之后仍在线的设备数
+//
+// KEYS 与 ARGV 同 connectScript，不使用 TTL 与保留时间
+var disconnectScript = redis.NewScript(`
+local now = tonumber(ARGV[2])
+redis.call('HDEL', KEYS[1], ARGV[1])
+local live = 0
+local devices = redis.call('HGETALL', KEYS[1])
+for i = 1, #devices, 2 do
+	if tonumber(devices[i + 1]) > now then
+		live = live + 1
+	else
+		redis.call('HDEL', KEYS[1], devices[i])
+	end
+end
+redis.call('SET', KEYS[2], ARGV[2])
+return live
+`)
+
+// expireScript 清理用户已过期的设备，返回用户是否因此离线以及最后在线的时间
+//
+// KEYS 同 connectScript；ARGV[1] 为当前时间。设备已经由离线或上线清理过时不算作离线，
+// 多个服务同时清理时只有一个会得到离线的结果。
+var expireScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local live, expired = 0, 0
+local devices = redis.call('HGETALL', KEYS[1])
+for i = 1, #devices, 2 do
+	if tonumber(devices[i + 1]) > now then
+		live = live + 1
+	else
+		redis.call('HDEL', KEYS[1], devices[i])
+		expired = expired + 1
+	end
+end
+if live > 0 or expired == 0 then
+	return {0, 0}
+end
+return {1, tonumber(redis.call('GET', KEYS[2]) or '0')}
+`)
+
+// untrackScript 用户的设备全部过期后不再需要清理，期间重新上线续期的用户保留
+//
+// KEYS[1] 有设备在线的用户；ARGV 依次为用户ID与当前时间
+var untrackScript = redis.NewScript(`
+local expireAt = redis.call('ZSCORE', KEYS[1], ARGV[1])
+if expireAt and tonumber(expireAt) <= tonumber(ARGV[2]) then
+	redis.call('ZREM', KEYS[1], ARGV[1])
+end
+return 0
+`)
+
+// keepTimes 设备记录保留的时间为 TTL 的倍数，留给清理过期设备的时间，所有服务都退出后由 redis 删除
+const keepTimes = 10
+
+// SweepLimit 每次最多清理的用户数
+var SweepLimit = 1000
+
+// Store 基于 redis 的在线状态存储
+//
+// 每个用户的设备记录在一个 hash 中，值为设备的过期时间，在线的连接需要在过期之前心跳续期；
+// 最后在线的时间在上线、心跳与离线时更新。服务异常退出时设备没有离线，由 Sweep 在过期后清理。
+type Store struct {
+	rds *redis.Redis
+	ttl time.Duration
+}
+
+// NewStore ttl 不大于 0 时使用 DefaultTTL
+func NewStore(rds *redis.Redis, ttl time.Duration) *Store {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Store{rds: rds, ttl: ttl}
+}
+
+// TTL 设备没有心跳后仍视为在线的时间，心跳的间隔需小于该时间
+func (s *Store) TTL() time.Duration {
+	return s.ttl
+}
+
+// Connect 设备上线，返回用户是否由离线变为在线
+func (s *Store) Connect(ctx context.Context, uid, device string) (bool, error) {
+	now := time.Now().UnixMilli()
+	// 先记录过期时间，上线之后服务异常退出时设备仍会被清理
+	err := s.rds.PipelinedCtx(ctx, func(p redis.Pipeliner) error {
+		p.ZAddGT(ctx, constants.RedisPresenceExpiry, red.Z{Score: float64(now + s.ttl.Milliseconds()), Member: uid})
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	res, err := s.rds.ScriptRunCtx(ctx, connectScript, []string{devicesKey(uid), lastSeenKey(uid)},
+		device, now, s.ttl.Milliseconds(), (keepTimes * s.ttl).Milliseconds())
+	if err != nil {
+		return false, err
+	}
+	live, _ := res.(int64)
+	return live == 0, nil
+}
+
+// Disconnect 设备离线，返回用户是否由在线变为离线以及最后在线的时间
+func (s *Store) Disconnect(ctx context.Context, uid, device string) (bool, int64, error) {
+	now := time.Now().UnixMilli()
+	res, err := s.rds.ScriptRunCtx(ctx, disconnectScript, []string{devicesKey(uid), lastSeenKey(uid)},
+		device, now)
+	if err != nil {
+		return false, 0, err
+	}
+	live, _ := res.(int64)
+	return live == 0, now, nil
+}
+
+// Heartbeat 为在线的设备续期
+func (s *Store) Heartbeat(ctx context.Context, devices []Device) error {
+	if len(devices) == 0 {
+		return nil
+	}
+	now := time.Now().UnixMilli()
+	expireAt := now + s.ttl.Milliseconds()
+	return s.rds.PipelinedCtx(ctx, func(p redis.Pipeliner) error {
+		for _, d := range devices {
+			key := devicesKey(d.UserId)
+			p.HSet(ctx, key, d.Device, expireAt)
+			p.PExpire(ctx, key, keepTimes*s.ttl)
+			p.Set(ctx, lastSeenKey(d.UserId), now, 0)
+			p.ZAddGT(ctx, constants.RedisPresenceExpiry, red.Z{Score: float64(expireAt), Member: d.UserId})
+		}
+		return nil
+	})
+}
+
+// Sweep 清理已过期的设备，返回因此离线的用户的状态
+//
+// 服务异常退出时设备没有离线，也就没有广播变化，各个服务定时调用 Sweep 补发离线。
+func (s *Store) Sweep(ctx context.Context) ([]*Status, error) {
+	now := time.Now().UnixMilli()
+	pairs, err := s.rds.ZrangebyscoreWithScoresAndLimitCtx(ctx, constants.RedisPresenceExpiry, 0, now, 0, SweepLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []*Status
+	for _, pair := range pairs {
+		uid := pair.Key
+		v, err := s.rds.ScriptRunCtx(ctx, expireScript, []string{devicesKey(uid), lastSeenKey(uid)}, now)
+		if err != nil {
+			return res, err
+		}
+		if offline, lastSeen := parseExpire(v); offline {
+			res = append(res, &Status{UserId: uid, LastSeen: lastSeen})
+		}
+		if _, err := s.rds.ScriptRunCtx(ctx, untrackScript, []string{constants.RedisPresenceExpiry}, uid, now); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+// Lookup 批量查询用户的在线状态，每个用户都有对应的状态
+func (s *Store) Lookup(ctx context.Context, uids []string) (map[string]*Status, error) {
+	if len(uids) == 0 {
+		return map[string]*Status{}, nil
+	}
+
+	var (
+		devices  = make([]func() (map[string]string, error), len(uids))
+		lastSeen = make([]func() (string, error), len(uids))
+	)
+	// 从未上线的用户没有最后在线的时间，查询结果为 redis.Nil
+	err := s.rds.PipelinedCtx(ctx, func(p redis.Pipeliner) error {
+		for i, uid := range uids {
+			devices[i] = p.HGetAll(ctx, devicesKey(uid)).Result
+			lastSeen[i] = p.Get(ctx, lastSeenKey(uid)).Result
+		}
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	res := make(map[string]*Status, len(uids))
+	for i, uid := range uids {
+		d, err := devices[i]()
+		if err != nil {
+			return nil, err
+		}
+		v, err := lastSeen[i]()
+		if err != nil && err != redis.Nil {
+			return nil, err
+		}
+		res[uid] = parseStatus(uid, d, v, now)
+	}
+	return res, nil
+}
+
+// parseExpire 解析 expireScript 的结果
+func parseExpire(v any) (bool, int64) {
+	res, ok := v.([]any)
+	if !ok || len(res) != 2 {
+		return false, 0
+	}
+	offline, _ := res[0].(int64)
+	lastSeen, _ := res[1].(int64)
+	return offline == 1, lastSeen
+}
+
+// parseStatus 根据设备的过期时间计算在线状态，已过期的设备视为离线
+func parseStatus(uid string, devices map[string]string, lastSeen string, now int64) *Status {
+	status := &Status{UserId: uid}
+	status.LastSeen, _ = strconv.ParseInt(lastSeen, 10, 64)
+	for device, v := range devices {
+		expireAt, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || expireAt <= now {
+			continue
+		}
+		status.Devices = append(status.Devices, device)
+	}
+	sort.Strings(status.Devices)
+	status.Online = len(status.Devices) > 0
+	return status
+}
+
+func devicesKey(uid string) string {
+	return fmt.Sprintf(constants.RedisPresenceDevices, uid)
+}
+
+func lastSeenKey(uid string) string {
+	return fmt.Sprintf(constants.RedisPresenceLastSeen, uid)
+}
@@ -0,0 +1,92 @@
+package presence
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		devices  map[string]string
+		lastSeen string
+		want     *Status
+	}{
+		{
+			name: "never online",
+			want: &Status{UserId: "u1"},
+		},
+		{
+			name:     "online devices",
+			devices:  map[string]string{"web": "2000", "ios": "1500"},
+			lastSeen: "900",
+			want:     &Status{UserId: "u1", Online: true, Devices: []string{"ios", "web"}, LastSeen: 900},
+		},
+		{
+			name:     "expired devices",
+			devices:  map[string]string{"web": "1000", "ios": "999", "bad": "x"},
+			lastSeen: "800",
+			want:     &Status{UserId: "u1", LastSeen: 800},
+		},
+		{
+			name:     "partly expired",
+			devices:  map[string]string{"web": "1001", "ios": "999"},
+			lastSeen: "950",
+			want:     &Status{UserId: "u1", Online: true, Devices: []string{"web"}, LastSeen: 950},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseStatus("u1", tt.devices, tt.lastSeen, 1000); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseStatus() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// hashTag 集群按键中第一个 {} 内的内容计算槽
+func hashTag(key string) string {
+	start := strings.Index(key, "{")
+	if start < 0 {
+		return key
+	}
+	end := strings.Index(key[start+1:], "}")
+	if end <= 0 {
+		return key
+	}
+	return key[start+1 : start+1+end]
+}
+
+func TestKeys(t *testing.T) {
+	// 脚本同时修改的键需要位于同一个槽
+	for _, uid := range []string{"u1", "1234567890"} {
+		if got := hashTag(devicesKey(uid)); got != uid {
+			t.Errorf("devicesKey(%v) hash tag = %v, want %v", uid, got, uid)
+		}
+		if got := hashTag(lastSeenKey(uid)); got != uid {
+			t.Errorf("lastSeenKey(%v) hash tag = %v, want %v", uid, got, uid)
+		}
+	}
+}
+
+func TestParseExpire(t *testing.T) {
+	tests := []struct {
+		name         string
+		v            any
+		wantOffline  bool
+		wantLastSeen int64
+	}{
+		{"offline", []any{int64(1), int64(900)}, true, 900},
+		{"still online", []any{int64(0), int64(0)}, false, 0},
+		{"unexpected", int64(1), false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offline, lastSeen := parseExpire(tt.v)
+			if offline != tt.wantOffline || lastSeen != tt.wantLastSeen {
+				t.Errorf("parseExpire() = %v, %v, want %v, %v", offline, lastSeen, tt.wantOffline, tt.wantLastSeen)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/edwingeng/wuid/mysql/wuid"
 	"sort"
 	"strconv"
+	"strings"
 )
 
 // w 是全局的 wuid.WUID 对象，用于生成唯一标识符（UID）。
@@ -74,3 +75,12 @@ func CombineId(aid, bid string) string {
 	})
 	return fmt.Sprintf("%s_%s", ids[0], ids[1])
 }
+
+// SplitId 拆分 CombineId 组合的标识符，格式不正确时返回 false
+func SplitId(id string) (string, string, bool) {
+	aid, bid, ok := strings.Cut(id, "_")
+	if !ok || aid == "" || bid == "" {
+		return "", "", false
+	}
+	return aid, bid, true
+}
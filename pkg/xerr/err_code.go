@@ -4,4 +4,5 @@ const (
 	SERVER_COMMON_ERROE = 100001
 	REQUEST_PARAM_ERROR = 100002
 	DB_ERROR            = 10003
+	NO_PERMISSION_ERROR = 100003
 )
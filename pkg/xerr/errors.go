@@ -12,6 +12,9 @@ func NewMsg(msg string) error {
 func NewDBErr() error {
 	return errors.New(DB_ERROR, ErrMsg(DB_ERROR))
 }
+func NewPermissionErr() error {
+	return errors.New(NO_PERMISSION_ERROR, ErrMsg(NO_PERMISSION_ERROR))
+}
 func NewInternalErr() error {
 	return errors.New(SERVER_COMMON_ERROE, ErrMsg(SERVER_COMMON_ERROE))
 }